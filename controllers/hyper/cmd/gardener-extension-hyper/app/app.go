@@ -7,6 +7,7 @@ import (
 	coreosalicloud "github.com/gardener/gardener-extensions/controllers/os-coreos-alicloud/cmd/gardener-extension-os-coreos-alicloud/app"
 	coreos "github.com/gardener/gardener-extensions/controllers/os-coreos/cmd/gardener-extension-os-coreos/app"
 	jeos "github.com/gardener/gardener-extensions/controllers/os-suse-jeos/cmd/gardener-extension-os-suse-jeos/app"
+	ubuntu "github.com/gardener/gardener-extensions/controllers/os-ubuntu/cmd/gardener-extension-os-ubuntu/app"
 	provideralicloud "github.com/gardener/gardener-extensions/controllers/provider-alicloud/cmd/gardener-extension-provider-alicloud/app"
 	provideraws "github.com/gardener/gardener-extensions/controllers/provider-aws/cmd/gardener-extension-provider-aws/app"
 	providerazure "github.com/gardener/gardener-extensions/controllers/provider-azure/cmd/gardener-extension-provider-azure/app"
@@ -26,6 +27,7 @@ func NewHyperCommand(ctx context.Context) *cobra.Command {
 		coreos.NewControllerCommand(ctx),
 		coreosalicloud.NewControllerCommand(ctx),
 		jeos.NewControllerCommand(ctx),
+		ubuntu.NewControllerCommand(ctx),
 		provideraws.NewControllerManagerCommand(ctx),
 		providerazure.NewControllerManagerCommand(ctx),
 		providergcp.NewControllerManagerCommand(ctx),
@@ -18,9 +18,11 @@ import (
 	"context"
 	"os"
 
-	"github.com/gardener/gardener-extensions/controllers/os-coreos-alicloud/pkg/coreos-alicloud"
+	"github.com/gardener/gardener-extensions/controllers/os-coreos-alicloud/pkg/generator"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon"
+	oscommoncmd "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cmd"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	"github.com/spf13/cobra"
@@ -28,11 +30,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-// Name is the name of the CoreOS Alicloud controller.
-const Name = "os-coreos-alicloud"
+const (
+	// Name is the name of the CoreOS Alicloud controller.
+	Name = "os-coreos-alicloud"
+	// Type is the type of OperatingSystemConfigs the CoreOS Alicloud controller is responsible for.
+	Type = "coreos-alicloud"
+)
 
 // NewControllerCommand creates a new command for running a CoreOS Alicloud controller.
 func NewControllerCommand(ctx context.Context) *cobra.Command {
+	g, err := generator.NewCloudInitGenerator()
+	if err != nil {
+		controllercmd.LogErrAndExit(err, "Could not create Generator")
+	}
+
 	var (
 		restOpts = &controllercmd.RESTOptions{}
 		mgrOpts  = &controllercmd.ManagerOptions{
@@ -43,13 +54,17 @@ func NewControllerCommand(ctx context.Context) *cobra.Command {
 		ctrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		userDataOpts = &oscommoncmd.UserDataOptions{
+			Provider: "alicloud",
+		}
 
-		controllerSwitches = coreos.ControllerSwitchOptions()
+		controllerSwitches = oscommoncmd.SwitchOptions(Type, g)
 
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
 			ctrlOpts,
+			userDataOpts,
 			controllerSwitches,
 		)
 	)
@@ -77,7 +92,8 @@ func NewControllerCommand(ctx context.Context) *cobra.Command {
 				controllercmd.LogErrAndExit(err, "Could not update manager scheme")
 			}
 
-			ctrlOpts.Completed().Apply(&coreos.DefaultAddOptions.Controller)
+			ctrlOpts.Completed().Apply(&oscommon.DefaultAddOptions.Controller)
+			userDataOpts.Completed().Apply(&oscommon.DefaultAddOptions.UserDataSizeBudget)
 
 			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
 				controllercmd.LogErrAndExit(err, "Could not add controller to manager")
@@ -0,0 +1,77 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	template_gen "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/template"
+
+	"github.com/gobuffalo/packr/v2"
+)
+
+var cmd = "/usr/bin/env bash %s"
+
+var hooks = template_gen.Hooks{
+	PreBootstrap: []string{
+		`#Disable upgrade related services
+systemctl disable locksmithd
+systemctl stop locksmithd
+systemctl disable update-engine
+systemctl stop update-engine`,
+		`#Fix mis-configuration of dockerd
+mkdir -p /etc/docker
+echo '{ "storage-driver": "devicemapper" }' > /etc/docker/daemon.json
+sed -i '/Environment=DOCKER_SELINUX=--selinux-enabled=true/s/^/#/g' /run/systemd/system/docker.service`,
+	},
+	PostBootstrap: []string{
+		`META_EP=http://100.100.100.200/latest/meta-data
+PROVIDER_ID=` + "`curl -s $META_EP/region-id`.`curl -s $META_EP/instance-id`" + `
+echo PROVIDER_ID=$PROVIDER_ID > $DOWNLOAD_MAIN_PATH/provider-id
+echo PROVIDER_ID=$PROVIDER_ID >> /etc/environment`,
+	},
+}
+
+//go:generate packr2
+
+// NewCloudInitGenerator creates a new Generator using the template file for coreos-alicloud.
+func NewCloudInitGenerator() (generator.Generator, error) {
+	box := packr.New("coreos-alicloud-templates", "./templates")
+	cloudInitGenerator, err := template_gen.NewCloudInitGeneratorFromBox(box, template_gen.DefaultUnitsPath, cmd, hooks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sctpBlacklistingGenerator{cloudInitGenerator}, nil
+}
+
+// sctpBlacklistingGenerator blacklists the sctp kernel module on running machines before it
+// delegates to the wrapped Generator.
+type sctpBlacklistingGenerator struct {
+	generator.Generator
+}
+
+// Generate implements generator.Generator.
+func (g *sctpBlacklistingGenerator) Generate(config *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	if !config.Bootstrap {
+		withSCTPBlacklist := *config
+		withSCTPBlacklist.Files = append(append([]*generator.File{}, config.Files...), &generator.File{
+			Path:    "/etc/modprobe.d/sctp.conf",
+			Content: []byte("install sctp /bin/true"),
+		})
+		config = &withSCTPBlacklist
+	}
+
+	return g.Generator.Generate(config)
+}
@@ -12,14 +12,16 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package coreos
+package generator
 
 import (
-	"context"
+	"testing"
 
-	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 )
 
-func (a *actuator) delete(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) error {
-	return nil
+func TestGenerator(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CoreOS Alicloud Generator Suite")
 }
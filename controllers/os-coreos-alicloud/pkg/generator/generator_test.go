@@ -0,0 +1,68 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator/test"
+
+	"github.com/gobuffalo/packr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("CoreOS Alicloud Generator Test", func() {
+	var box = packr.NewBox("./testfiles")
+	g, err := NewCloudInitGenerator()
+
+	It("should not fail creating generator", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Conformance Tests", test.DescribeTest(g, box))
+
+	Describe("Generator Conformance", test.DescribeConformance(test.GeneratorRenderFunc(g), test.FormatShellScript, filepath.Join("testfiles", "conformance")))
+
+	It("should blacklist the sctp kernel module and skip the bootstrap steps on reconcile", func() {
+		expectedCloudInit, err := box.Find("cloud-init-reconcile")
+		Expect(err).NotTo(HaveOccurred())
+
+		path := "/var/lib/cloud-config-downloader/downloads/cloud_config"
+		config := &generator.OperatingSystemConfig{
+			Files: []*generator.File{
+				{
+					Path:    "/foo",
+					Content: []byte("bar"),
+				},
+			},
+			Units: []*generator.Unit{
+				{
+					Name:    "docker.service",
+					Content: []byte("unit"),
+				},
+			},
+			Path: &path,
+		}
+
+		cloudInit, cmd, err := g.Generate(config)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cloudInit).To(Equal(expectedCloudInit))
+		Expect(cmd).To(PointTo(Equal("/usr/bin/env bash " + path)))
+		Expect(config.Files).To(HaveLen(1))
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"github.com/gardener/gardener-extensions/controllers/os-coreos/pkg/coreos"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	oscommoncmd "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cmd"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	"github.com/spf13/cobra"
@@ -43,12 +44,14 @@ func NewControllerCommand(ctx context.Context) *cobra.Command {
 		ctrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		userDataOpts       = &oscommoncmd.UserDataOptions{}
 		controllerSwitches = coreos.ControllerSwitchOptions()
 
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
 			ctrlOpts,
+			userDataOpts,
 			controllerSwitches,
 		)
 	)
@@ -77,6 +80,7 @@ func NewControllerCommand(ctx context.Context) *cobra.Command {
 			}
 
 			ctrlOpts.Completed().Apply(&coreos.DefaultAddOptions.Controller)
+			userDataOpts.Completed().Apply(&coreos.DefaultAddOptions.UserDataSizeBudget)
 
 			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
 				controllercmd.LogErrAndExit(err, "Could not add controller to manager")
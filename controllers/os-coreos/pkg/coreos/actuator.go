@@ -18,6 +18,8 @@ import (
 	"context"
 
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	oscommonactuator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
@@ -33,11 +35,17 @@ type actuator struct {
 	client client.Client
 	scheme *runtime.Scheme
 	logger logr.Logger
+
+	userDataSizeBudget int
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled OperatingSystemConfigs.
-func NewActuator() operatingsystemconfig.Actuator {
-	return &actuator{logger: log.Log.WithName("coreos-operatingsystemconfig-actuator")}
+// A positive userDataSizeBudget limits the size of the generated user data in bytes.
+func NewActuator(userDataSizeBudget int) operatingsystemconfig.Actuator {
+	return &actuator{
+		logger:             log.Log.WithName("coreos-operatingsystemconfig-actuator"),
+		userDataSizeBudget: userDataSizeBudget,
+	}
 }
 
 func (c *actuator) InjectScheme(scheme *runtime.Scheme) error {
@@ -54,6 +62,21 @@ func (c *actuator) Reconcile(ctx context.Context, config *extensionsv1alpha1.Ope
 	return c.reconcile(ctx, config)
 }
 
+// UnitChecksums implements operatingsystemconfig.UnitChecksummer.
+func (c *actuator) UnitChecksums(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) (map[string]string, error) {
+	providerConfig, err := ProviderConfigFromOperatingSystemConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	osc, err := oscommonactuator.OperatingSystemConfigContent(ctx, c.client, config, providerConfig.Hardening, providerConfig.ContainerRuntime)
+	if err != nil {
+		return nil, err
+	}
+
+	return commonosgenerator.UnitChecksums(osc), nil
+}
+
 func (c *actuator) Delete(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) error {
 	return c.delete(ctx, config)
 }
@@ -20,31 +20,66 @@ import (
 	"fmt"
 	"strconv"
 
-	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
-
-	corev1 "k8s.io/api/core/v1"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/hardening"
 
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
 
-var coreOSCloudInitCommand = fmt.Sprintf("/usr/bin/coreos-cloudinit --from-file=")
-
 func (c *actuator) reconcile(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, []string, error) {
-	cloudConfig, units, err := c.cloudConfigFromOperatingSystemConfig(ctx, config)
+	providerConfig, err := ProviderConfigFromOperatingSystemConfig(config)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("could not generate cloud config: %v", err)
+		return nil, nil, nil, err
+	}
+
+	if errs := operatingsystemconfig.ValidateOperatingSystemConfig(config); len(errs) > 0 {
+		return nil, nil, nil, fmt.Errorf("invalid operating system config: %v", errs.ToAggregate())
+	}
+
+	var (
+		data          string
+		units         []string
+		reloadCommand string
+	)
+
+	switch providerConfig.Format {
+	case FormatIgnition:
+		data, units, err = c.ignitionFromOperatingSystemConfig(ctx, config, providerConfig)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not generate ignition config: %v", err)
+		}
+		if err := ValidateIgnitionConfig([]byte(data)); err != nil {
+			return nil, nil, nil, fmt.Errorf("generated ignition config is invalid: %v", err)
+		}
+		reloadCommand = IgnitionReloadCommand
+	default:
+		data, units, err = c.cloudConfigFromOperatingSystemConfig(ctx, config, providerConfig)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not generate cloud config: %v", err)
+		}
+		if err := ValidateCloudConfig([]byte(data)); err != nil {
+			return nil, nil, nil, fmt.Errorf("generated cloud config is invalid: %v", err)
+		}
+		reloadCommand = CloudInitReloadCommand
+	}
+
+	if err := content.CheckUserDataSize(config, []byte(data), c.userDataSizeBudget); err != nil {
+		return nil, nil, nil, err
 	}
 
 	var command *string
 	if path := config.Spec.ReloadConfigFilePath; path != nil {
-		cmd := coreOSCloudInitCommand + *path
+		cmd := reloadCommand + *path
 		command = &cmd
 	}
 
-	return []byte(cloudConfig), command, units, nil
+	return []byte(data), command, units, nil
 }
 
-func (c *actuator) cloudConfigFromOperatingSystemConfig(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) (string, []string, error) {
+func (c *actuator) cloudConfigFromOperatingSystemConfig(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig, providerConfig *ProviderConfig) (string, []string, error) {
 	cloudConfig := &CloudConfig{
 		CoreOS: Config{
 			Update: Update{
@@ -89,7 +124,11 @@ func (c *actuator) cloudConfigFromOperatingSystemConfig(ctx context.Context, con
 			u.Enable = *unit.Enable
 		}
 		if unit.Content != nil {
-			u.Content = *unit.Content
+			content, err := containerruntime.UnitContent(unit.Name, *unit.Content, providerConfig.ContainerRuntime)
+			if err != nil {
+				return "", nil, err
+			}
+			u.Content = content
 		}
 
 		for _, dropIn := range unit.DropIns {
@@ -102,44 +141,242 @@ func (c *actuator) cloudConfigFromOperatingSystemConfig(ctx context.Context, con
 		cloudConfig.CoreOS.Units = append(cloudConfig.CoreOS.Units, u)
 	}
 
-	for _, file := range config.Spec.Files {
-		f := File{
-			Path: file.Path,
+	files, err := content.NewResolver(c.client).Resolve(ctx, config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, file := range files {
+		if file.Remote != nil {
+			continue
 		}
 
 		permissions := extensionsv1alpha1.OperatingSystemConfigDefaultFilePermission
 		if p := file.Permissions; p != nil {
 			permissions = *p
 		}
-		f.RawFilePermissions = strconv.FormatInt(int64(permissions), 8)
 
-		if file.Content.Inline != nil {
-			f.Encoding = file.Content.Inline.Encoding
-			f.Content = file.Content.Inline.Data
+		cloudConfig.WriteFiles = append(cloudConfig.WriteFiles, File{
+			Encoding:           "b64",
+			Content:            base64.StdEncoding.EncodeToString(file.Content),
+			Path:               file.Path,
+			RawFilePermissions: strconv.FormatInt(int64(permissions), 8),
+		})
+	}
+
+	if providerConfig.ContainerRuntime == containerruntime.Containerd {
+		cloudConfig.WriteFiles = append(cloudConfig.WriteFiles, File{
+			Encoding:           "b64",
+			Content:            base64.StdEncoding.EncodeToString([]byte(containerruntime.ContainerdConfig)),
+			Path:               containerruntime.ContainerdConfigPath,
+			RawFilePermissions: "0644",
+		})
+
+		dropIn := UnitDropIn{Name: containerruntime.ContainerdDropInName, Content: containerruntime.ContainerdDropIn}
+		if i := cloudConfigUnitIndex(cloudConfig.CoreOS.Units, containerruntime.ContainerdUnitName); i >= 0 {
+			cloudConfig.CoreOS.Units[i].DropIns = append(cloudConfig.CoreOS.Units[i].DropIns, dropIn)
+		} else {
+			cloudConfig.CoreOS.Units = append(cloudConfig.CoreOS.Units, Unit{
+				Name:    containerruntime.ContainerdUnitName,
+				Enable:  true,
+				Command: "restart",
+				DropIns: []UnitDropIn{dropIn},
+			})
+		}
+	}
+
+	additionalFiles, additionalUnits := additionalFilesAndUnits(config, providerConfig, files)
+	for _, file := range additionalFiles {
+		cloudConfig.WriteFiles = append(cloudConfig.WriteFiles, File{
+			Encoding:           "b64",
+			Content:            base64.StdEncoding.EncodeToString(file.Content),
+			Path:               file.Path,
+			RawFilePermissions: strconv.FormatInt(int64(*file.Permissions), 8),
+		})
+	}
+	for _, unit := range additionalUnits {
+		cloudConfig.CoreOS.Units = append(cloudConfig.CoreOS.Units, Unit{
+			Name:    unit.Name,
+			Enable:  true,
+			Command: "restart",
+			Content: string(unit.Content),
+		})
+	}
+
+	data, err := cloudConfig.String()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return data, unitNames, nil
+}
+
+func (c *actuator) ignitionFromOperatingSystemConfig(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig, providerConfig *ProviderConfig) (string, []string, error) {
+	version := providerConfig.IgnitionVersion
+
+	ignition := &IgnitionConfig{
+		Ignition: IgnitionMetadata{
+			Version: version,
+		},
+		Systemd: IgnitionSystemd{
+			Units: []IgnitionUnit{
+				{
+					Name: "update-engine.service",
+					Mask: true,
+				},
+				{
+					Name: "locksmithd.service",
+					Mask: true,
+				},
+			},
+		},
+	}
+
+	addFile := func(path string, permissions int32, data []byte) {
+		mode := int(permissions)
+		f := IgnitionFile{
+			Path:     path,
+			Mode:     &mode,
+			Contents: IgnitionFileContents{Source: IgnitionDataURL(data)},
+		}
+
+		if version == IgnitionVersion22 {
+			f.Filesystem = "root"
+		} else {
+			overwrite := true
+			f.Overwrite = &overwrite
 		}
 
-		if file.Content.SecretRef != nil {
-			var secret corev1.Secret
-			if err := c.client.Get(ctx, client.ObjectKey{Name: file.Content.SecretRef.Name, Namespace: config.Namespace}, &secret); err != nil {
+		ignition.Storage.Files = append(ignition.Storage.Files, f)
+	}
+
+	addFile(IgnitionReloadScriptPath, 0755, []byte(ignitionReloadScript))
+
+	// blacklist sctp kernel module
+	if config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeReconcile {
+		addFile("/etc/modprobe.d/sctp.conf", 0644, []byte("install sctp /bin/true"))
+	}
+
+	unitNames := make([]string, 0, len(config.Spec.Units))
+	for _, unit := range config.Spec.Units {
+		unitNames = append(unitNames, unit.Name)
+
+		u := IgnitionUnit{
+			Name:    unit.Name,
+			Enabled: unit.Enable,
+		}
+
+		if unit.Content != nil {
+			content, err := containerruntime.UnitContent(unit.Name, *unit.Content, providerConfig.ContainerRuntime)
+			if err != nil {
 				return "", nil, err
 			}
+			u.Contents = content
+		}
 
-			data, ok := secret.Data[file.Content.SecretRef.DataKey]
-			if !ok {
-				return "", nil, fmt.Errorf("could not find key %q in data of secret %q", file.Content.SecretRef.DataKey, file.Content.SecretRef.Name)
-			}
+		for _, dropIn := range unit.DropIns {
+			u.Dropins = append(u.Dropins, IgnitionUnitDropIn{
+				Name:     dropIn.Name,
+				Contents: dropIn.Content,
+			})
+		}
+
+		ignition.Systemd.Units = append(ignition.Systemd.Units, u)
+	}
+
+	files, err := content.NewResolver(c.client).Resolve(ctx, config)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, file := range files {
+		if file.Remote != nil {
+			continue
+		}
 
-			f.Encoding = "b64"
-			f.Content = base64.StdEncoding.EncodeToString(data)
+		permissions := extensionsv1alpha1.OperatingSystemConfigDefaultFilePermission
+		if p := file.Permissions; p != nil {
+			permissions = *p
 		}
 
-		cloudConfig.WriteFiles = append(cloudConfig.WriteFiles, f)
+		addFile(file.Path, permissions, file.Content)
 	}
 
-	data, err := cloudConfig.String()
+	if providerConfig.ContainerRuntime == containerruntime.Containerd {
+		addFile(containerruntime.ContainerdConfigPath, 0644, []byte(containerruntime.ContainerdConfig))
+
+		dropIn := IgnitionUnitDropIn{Name: containerruntime.ContainerdDropInName, Contents: containerruntime.ContainerdDropIn}
+		if i := ignitionUnitIndex(ignition.Systemd.Units, containerruntime.ContainerdUnitName); i >= 0 {
+			ignition.Systemd.Units[i].Dropins = append(ignition.Systemd.Units[i].Dropins, dropIn)
+		} else {
+			enabled := true
+			ignition.Systemd.Units = append(ignition.Systemd.Units, IgnitionUnit{
+				Name:    containerruntime.ContainerdUnitName,
+				Enabled: &enabled,
+				Dropins: []IgnitionUnitDropIn{dropIn},
+			})
+		}
+	}
+
+	additionalFiles, additionalUnits := additionalFilesAndUnits(config, providerConfig, files)
+	for _, file := range additionalFiles {
+		addFile(file.Path, *file.Permissions, file.Content)
+	}
+	for _, unit := range additionalUnits {
+		enabled := true
+		ignition.Systemd.Units = append(ignition.Systemd.Units, IgnitionUnit{
+			Name:     unit.Name,
+			Enabled:  &enabled,
+			Contents: string(unit.Content),
+		})
+	}
+
+	data, err := ignition.String()
 	if err != nil {
 		return "", nil, err
 	}
 
 	return data, unitNames, nil
 }
+
+// additionalFilesAndUnits returns the files and units that are added to the ones of the given
+// OperatingSystemConfig independent of the format: the settings of the hardening profile and the
+// download of the remote <files>. The units are enabled and (re)started.
+func additionalFilesAndUnits(config *extensionsv1alpha1.OperatingSystemConfig, providerConfig *ProviderConfig, files []*generator.File) ([]*generator.File, []*generator.Unit) {
+	var (
+		additionalFiles []*generator.File
+		additionalUnits []*generator.Unit
+	)
+
+	if providerConfig.Hardening != nil && config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeReconcile {
+		hardeningFiles, hardeningUnit := hardening.Render(providerConfig.Hardening)
+		additionalFiles = append(additionalFiles, hardeningFiles...)
+		additionalUnits = append(additionalUnits, hardeningUnit)
+	}
+
+	if content.HasRemoteFiles(files) {
+		permissions := int32(0755)
+		additionalFiles = append(additionalFiles, &generator.File{Path: DownloadScriptPath, Content: content.DownloadScript(files), Permissions: &permissions})
+		additionalUnits = append(additionalUnits, &generator.Unit{Name: DownloadUnitName, Content: []byte(downloadUnit)})
+	}
+
+	return additionalFiles, additionalUnits
+}
+
+func cloudConfigUnitIndex(units []Unit, name string) int {
+	for i, unit := range units {
+		if unit.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func ignitionUnitIndex(units []IgnitionUnit, name string) int {
+	for i, unit := range units {
+		if unit.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -15,9 +15,27 @@
 package coreos_test
 
 import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+
 	"github.com/gardener/gardener-extensions/controllers/os-coreos/pkg/coreos"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	oscommonactuator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/hardening"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
 var _ = Describe("CloudConfig", func() {
@@ -45,3 +63,223 @@ coreos:
 		})
 	})
 })
+
+var _ = Describe("Actuator", func() {
+	var (
+		ctx      = context.TODO()
+		actuator operatingsystemconfig.Actuator
+		config   *extensionsv1alpha1.OperatingSystemConfig
+	)
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "kubelet", Namespace: "shoot--foo--bar"},
+			Data:       map[string][]byte{"ca.crt": []byte("certificate")},
+		}
+
+		actuator = coreos.NewActuator(0)
+		Expect(actuator.(inject.Client).InjectClient(fake.NewFakeClient(secret))).To(Succeed())
+
+		var (
+			command     = "start"
+			enable      = true
+			content     = "[Unit]\nDescription=docker\n"
+			permissions = int32(0600)
+			reloadPath  = "/var/lib/cloud-config"
+		)
+
+		config = &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "shoot--foo--bar"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				DefaultSpec:          extensionsv1alpha1.DefaultSpec{Type: coreos.Type},
+				Purpose:              extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+				ReloadConfigFilePath: &reloadPath,
+				Units: []extensionsv1alpha1.Unit{
+					{
+						Name:    "docker.service",
+						Command: &command,
+						Enable:  &enable,
+						Content: &content,
+						DropIns: []extensionsv1alpha1.DropIn{
+							{Name: "10-docker-opts.conf", Content: "[Service]\nEnvironment=DOCKER_OPTS=--debug\n"},
+						},
+					},
+				},
+				Files: []extensionsv1alpha1.File{
+					{
+						Path:        "/foo",
+						Permissions: &permissions,
+						Content: extensionsv1alpha1.FileContent{
+							Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: "YmFy"},
+						},
+					},
+					{
+						Path: "/var/lib/kubelet/ca.crt",
+						Content: extensionsv1alpha1.FileContent{
+							SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "kubelet", DataKey: "ca.crt"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#Reconcile", func() {
+		DescribeTable("should render the configured format",
+			func(providerConfig, goldenFile, expectedCommand string, matcher func(interface{}) types.GomegaMatcher) {
+				if providerConfig != "" {
+					config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+				}
+
+				data, command, units, err := actuator.Reconcile(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected, err := ioutil.ReadFile(filepath.Join("testdata", goldenFile))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(string(data)).To(matcher(string(expected)))
+				Expect(command).To(gstruct.PointTo(Equal(expectedCommand)))
+				Expect(units).To(Equal([]string{"docker.service"}))
+			},
+			Entry("cloud-init by default", "", "cloud-config", "/usr/bin/coreos-cloudinit --from-file=/var/lib/cloud-config", equal),
+			Entry("cloud-init", `{"format":"cloud-init"}`, "cloud-config", "/usr/bin/coreos-cloudinit --from-file=/var/lib/cloud-config", equal),
+			Entry("ignition v2 by default", `{"format":"ignition"}`, "ignition-v2.json", "/opt/bin/reload-ignition-config /var/lib/cloud-config", MatchJSON),
+			Entry("ignition v3", `{"format":"ignition","ignitionVersion":"3.0.0"}`, "ignition-v3.json", "/opt/bin/reload-ignition-config /var/lib/cloud-config", MatchJSON),
+		)
+
+		DescribeTable("should fail for an invalid provider config",
+			func(providerConfig string) {
+				config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+
+				_, _, _, err := actuator.Reconcile(ctx, config)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("malformed", `{`),
+			Entry("unknown format", `{"format":"foo"}`),
+			Entry("unknown ignition version", `{"format":"ignition","ignitionVersion":"1.0.0"}`),
+			Entry("invalid hardening profile", `{"hardening":{"sshd":{"permitRootLogin":"maybe"}}}`),
+		)
+
+		DescribeTable("should fail for an invalid operating system config",
+			func(providerConfig string, mutate func()) {
+				config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+				mutate()
+
+				_, _, _, err := actuator.Reconcile(ctx, config)
+				Expect(err).To(MatchError(ContainSubstring("invalid operating system config")))
+			},
+			Entry("unparseable drop-in", `{"format":"cloud-init"}`, func() {
+				config.Spec.Units[0].DropIns[0].Content = "override"
+			}),
+			Entry("duplicate file path", `{"format":"ignition"}`, func() {
+				config.Spec.Files[1].Path = config.Spec.Files[0].Path
+			}),
+		)
+
+		DescribeTable("should download files with a URL source",
+			func(providerConfig string) {
+				config.Annotations = map[string]string{
+					oscommonactuator.AnnotationProviderConfig: providerConfig,
+					content.AnnotationFileSources:             `[{"path":"/opt/bin/tool","url":{"url":"https://example.com/tool","sha256":"fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9"}}]`,
+				}
+
+				data, _, units, err := actuator.Reconcile(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring(coreos.DownloadUnitName))
+				Expect(string(data)).To(ContainSubstring(coreos.DownloadScriptPath))
+				Expect(string(data)).NotTo(ContainSubstring("example.com"))
+				Expect(units).To(Equal([]string{"docker.service"}))
+			},
+			Entry("cloud-init", `{"format":"cloud-init"}`),
+			Entry("ignition", `{"format":"ignition"}`),
+		)
+
+		DescribeTable("should harden reconciled machines",
+			func(providerConfig string, purpose extensionsv1alpha1.OperatingSystemConfigPurpose, matcher types.GomegaMatcher) {
+				config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+				config.Spec.Purpose = purpose
+
+				data, _, units, err := actuator.Reconcile(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(matcher)
+				Expect(units).To(Equal([]string{"docker.service"}))
+			},
+			Entry("cloud-init", `{"format":"cloud-init","hardening":{}}`, extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+				And(ContainSubstring(hardening.UnitName), ContainSubstring(hardening.SysctlPath), ContainSubstring(hardening.SSHDPath))),
+			Entry("ignition", `{"format":"ignition","hardening":{}}`, extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+				And(ContainSubstring(hardening.UnitName), ContainSubstring(hardening.SysctlPath), ContainSubstring(hardening.SSHDPath))),
+			Entry("not on provision", `{"format":"cloud-init","hardening":{}}`, extensionsv1alpha1.OperatingSystemConfigPurposeProvision,
+				Not(ContainSubstring(hardening.UnitName))),
+			Entry("not without profile", `{"format":"cloud-init"}`, extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+				Not(ContainSubstring(hardening.UnitName))),
+		)
+
+		DescribeTable("should configure containerd",
+			func(providerConfig string) {
+				config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+
+				data, _, units, err := actuator.Reconcile(ctx, config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(ContainSubstring(containerruntime.ContainerdUnitName))
+				Expect(string(data)).To(ContainSubstring(containerruntime.ContainerdDropInName))
+				Expect(string(data)).To(ContainSubstring(containerruntime.ContainerdConfigPath))
+				Expect(units).To(Equal([]string{"docker.service"}))
+			},
+			Entry("cloud-init", `{"format":"cloud-init","containerRuntime":"containerd"}`),
+			Entry("ignition", `{"format":"ignition","containerRuntime":"containerd"}`),
+		)
+
+		It("should fail for an unsupported container runtime", func() {
+			config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: `{"containerRuntime":"rkt"}`}
+
+			_, _, _, err := actuator.Reconcile(ctx, config)
+			Expect(err).To(MatchError(ContainSubstring("containerRuntime")))
+		})
+
+		It("should fail if the user data exceeds the budget", func() {
+			actuator = coreos.NewActuator(64)
+			Expect(actuator.(inject.Client).InjectClient(fake.NewFakeClient())).To(Succeed())
+			config.Spec.Purpose = extensionsv1alpha1.OperatingSystemConfigPurposeProvision
+			config.Spec.Files = config.Spec.Files[:1]
+
+			_, _, _, err := actuator.Reconcile(ctx, config)
+			Expect(err).To(MatchError(ContainSubstring("exceeds the user data size budget of 64 bytes")))
+		})
+
+		It("should fail if the referenced secret key does not exist", func() {
+			config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: `{"format":"ignition"}`}
+			config.Spec.Files[1].Content.SecretRef.DataKey = "foo"
+
+			_, _, _, err := actuator.Reconcile(ctx, config)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#UnitChecksums", func() {
+		It("should cover the files, units and drop-ins added by the actuator", func() {
+			checksummer := actuator.(operatingsystemconfig.UnitChecksummer)
+
+			old, err := checksummer.UnitChecksums(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(old).To(HaveLen(1))
+			Expect(old).To(HaveKey("docker.service"))
+
+			config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: `{"containerRuntime":"containerd","hardening":{}}`}
+			checksums, err := checksummer.UnitChecksums(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksums).To(HaveKey(hardening.UnitName))
+			Expect(checksums).To(HaveKey(containerruntime.ContainerdUnitName))
+			Expect(checksums["docker.service"]).To(Equal(old["docker.service"]))
+
+			config.Annotations[oscommonactuator.AnnotationProviderConfig] = `{"containerRuntime":"containerd","hardening":{"sshd":{"maxAuthTries":3}}}`
+			changed, err := checksummer.UnitChecksums(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed[hardening.UnitName]).NotTo(Equal(checksums[hardening.UnitName]))
+			Expect(changed[containerruntime.ContainerdUnitName]).To(Equal(checksums[containerruntime.ContainerdUnitName]))
+		})
+	})
+})
+
+func equal(expected interface{}) types.GomegaMatcher {
+	return Equal(expected)
+}
@@ -33,13 +33,16 @@ var (
 type AddOptions struct {
 	// Controller are the controller related options.
 	Controller controller.Options
+	// UserDataSizeBudget is the maximum size of the generated user data in bytes. Zero disables
+	// the check.
+	UserDataSizeBudget int
 }
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) error {
 	return operatingsystemconfig.Add(mgr, operatingsystemconfig.AddArgs{
-		Actuator:          NewActuator(),
+		Actuator:          NewActuator(opts.UserDataSizeBudget),
 		ControllerOptions: opts.Controller,
 		Predicates:        operatingsystemconfig.DefaultPredicates(Type),
 	})
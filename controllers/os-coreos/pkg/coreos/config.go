@@ -0,0 +1,86 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos
+
+import (
+	"fmt"
+
+	oscommonactuator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// Format is the format of the generated operating system configuration.
+type Format string
+
+const (
+	// FormatCloudInit is the coreos-cloudinit `#cloud-config` format.
+	FormatCloudInit Format = "cloud-init"
+	// FormatIgnition is the Ignition JSON format.
+	FormatIgnition Format = "ignition"
+)
+
+const (
+	// IgnitionVersion22 is the Ignition v2 spec version.
+	IgnitionVersion22 = "2.2.0"
+	// IgnitionVersion30 is the Ignition v3 spec version.
+	IgnitionVersion30 = "3.0.0"
+)
+
+// ProviderConfig is the CoreOS specific configuration of an OperatingSystemConfig. It is read from
+// the same annotation as the provider config of the operating systems based on oscommon and
+// extends it with the CoreOS specific settings.
+type ProviderConfig struct {
+	oscommonactuator.ProviderConfig `json:",inline"`
+
+	// Format is the format of the generated configuration. Defaults to `cloud-init`.
+	Format Format `json:"format,omitempty"`
+	// IgnitionVersion is the Ignition spec version used if the format is `ignition`.
+	// Defaults to `2.2.0`.
+	IgnitionVersion string `json:"ignitionVersion,omitempty"`
+}
+
+// ProviderConfigFromOperatingSystemConfig reads the provider config from the annotations of the
+// given OperatingSystemConfig, validates it and sets the defaults for unset fields.
+func ProviderConfigFromOperatingSystemConfig(config *extensionsv1alpha1.OperatingSystemConfig) (*ProviderConfig, error) {
+	providerConfig := &ProviderConfig{}
+	if err := oscommonactuator.DecodeProviderConfig(config, providerConfig); err != nil {
+		return nil, err
+	}
+
+	oscommonactuator.SetDefaultsProviderConfig(&providerConfig.ProviderConfig)
+	if providerConfig.Format == "" {
+		providerConfig.Format = FormatCloudInit
+	}
+	if providerConfig.Format == FormatIgnition && providerConfig.IgnitionVersion == "" {
+		providerConfig.IgnitionVersion = IgnitionVersion22
+	}
+
+	switch providerConfig.Format {
+	case FormatCloudInit:
+	case FormatIgnition:
+		if providerConfig.IgnitionVersion != IgnitionVersion22 && providerConfig.IgnitionVersion != IgnitionVersion30 {
+			return nil, fmt.Errorf("unsupported ignition version %q, supported versions are %q and %q", providerConfig.IgnitionVersion, IgnitionVersion22, IgnitionVersion30)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q, supported formats are %q and %q", providerConfig.Format, FormatCloudInit, FormatIgnition)
+	}
+
+	if errs := oscommonactuator.ValidateProviderConfig(&providerConfig.ProviderConfig, nil); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid provider config: %v", errs.ToAggregate())
+	}
+
+	return providerConfig, nil
+}
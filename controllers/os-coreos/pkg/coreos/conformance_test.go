@@ -0,0 +1,44 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos_test
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/gardener/gardener-extensions/controllers/os-coreos/pkg/coreos"
+	oscommonactuator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator/test"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// renderWithProviderConfig renders OperatingSystemConfigs with the given provider config annotation.
+func renderWithProviderConfig(providerConfig string) test.RenderFunc {
+	render := test.ActuatorRenderFunc(coreos.NewActuator(0))
+
+	return func(ctx context.Context, c client.Client, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, error) {
+		config.Annotations = map[string]string{oscommonactuator.AnnotationProviderConfig: providerConfig}
+		return render(ctx, c, config)
+	}
+}
+
+var _ = Describe("Conformance", func() {
+	Describe("cloud-init", test.DescribeConformance(renderWithProviderConfig(`{"format":"cloud-init"}`), test.FormatContainerLinuxCloudConfig, filepath.Join("testdata", "conformance", "cloud-init")))
+	Describe("ignition v2", test.DescribeConformance(renderWithProviderConfig(`{"format":"ignition","ignitionVersion":"2.2.0"}`), test.FormatIgnition, filepath.Join("testdata", "conformance", "ignition-v2")))
+	Describe("ignition v3", test.DescribeConformance(renderWithProviderConfig(`{"format":"ignition","ignitionVersion":"3.0.0"}`), test.FormatIgnition, filepath.Join("testdata", "conformance", "ignition-v3")))
+})
@@ -0,0 +1,42 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos
+
+const (
+	// DownloadScriptPath is the path of the script that downloads and verifies the files with a
+	// URL source. It is only part of generated configs that contain such files.
+	DownloadScriptPath = "/opt/bin/download-files"
+	// DownloadUnitName is the name of the unit that runs the download script.
+	DownloadUnitName = "download-files.service"
+)
+
+// Neither coreos-cloudinit nor Ignition v2.2 support SHA256 checksums for remote files, hence they
+// are downloaded by a oneshot unit that runs before the other units are started. The kubelet requires
+// the unit so that it does not start if a download fails.
+const downloadUnit = `[Unit]
+Description=Download the remote files of the operating system config
+Wants=network-online.target
+After=network-online.target
+Before=docker.service kubelet.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=` + DownloadScriptPath + `
+
+[Install]
+WantedBy=multi-user.target
+RequiredBy=kubelet.service
+`
@@ -0,0 +1,106 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// IgnitionConfig is a structure containing the relevant fields of an Ignition config. It covers
+// both the v2 and the v3 spec, the fields that only exist in one of them are omitted if unset.
+// It can be marshalled to JSON.
+type IgnitionConfig struct {
+	// Ignition contains the metadata of the config.
+	Ignition IgnitionMetadata `json:"ignition"`
+	// Storage describes the files that will be written onto the disk of the machine.
+	Storage IgnitionStorage `json:"storage,omitempty"`
+	// Systemd describes the systemd units of the machine.
+	Systemd IgnitionSystemd `json:"systemd,omitempty"`
+}
+
+// IgnitionMetadata contains the metadata of an Ignition config.
+type IgnitionMetadata struct {
+	// Version is the spec version of the Ignition config.
+	Version string `json:"version"`
+}
+
+// IgnitionStorage describes the files that will be written onto the disk of the machine.
+type IgnitionStorage struct {
+	// Files is a list of files.
+	Files []IgnitionFile `json:"files,omitempty"`
+}
+
+// IgnitionFile is a file that gets written onto the disk of the machine.
+type IgnitionFile struct {
+	// Filesystem is the name of the filesystem the file is written to (v2 only).
+	Filesystem string `json:"filesystem,omitempty"`
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+	// Overwrite defines whether an existing file is overwritten (v3 only).
+	Overwrite *bool `json:"overwrite,omitempty"`
+	// Mode is the file's permission mode as decimal number.
+	Mode *int `json:"mode,omitempty"`
+	// Contents are the contents of the file.
+	Contents IgnitionFileContents `json:"contents"`
+}
+
+// IgnitionFileContents are the contents of an Ignition file.
+type IgnitionFileContents struct {
+	// Source is the URL of the contents, usually a data URL.
+	Source string `json:"source"`
+}
+
+// IgnitionSystemd describes the systemd units of the machine.
+type IgnitionSystemd struct {
+	// Units is a list of units.
+	Units []IgnitionUnit `json:"units,omitempty"`
+}
+
+// IgnitionUnit is a systemd unit.
+type IgnitionUnit struct {
+	// Name is the name of the unit.
+	Name string `json:"name"`
+	// Enabled defines whether the unit is enabled or not.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Mask defines whether the unit is masked or not.
+	Mask bool `json:"mask,omitempty"`
+	// Contents is the actual systemd specific content of the unit.
+	Contents string `json:"contents,omitempty"`
+	// Dropins is a list of drop-ins of the unit.
+	Dropins []IgnitionUnitDropIn `json:"dropins,omitempty"`
+}
+
+// IgnitionUnitDropIn is a drop-in of a systemd unit.
+type IgnitionUnitDropIn struct {
+	// Name is the name of the drop-in.
+	Name string `json:"name"`
+	// Contents is the content of the drop-in.
+	Contents string `json:"contents,omitempty"`
+}
+
+// IgnitionDataURL returns a base64 encoded data URL for the given data.
+func IgnitionDataURL(data []byte) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// String returns the string representation of the IgnitionConfig structure.
+func (c IgnitionConfig) String() (string, error) {
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos
+
+const (
+	// CloudInitReloadCommand is the command that applies a `#cloud-config` on a running machine.
+	CloudInitReloadCommand = "/usr/bin/coreos-cloudinit --from-file="
+
+	// IgnitionReloadScriptPath is the path of the script that applies an Ignition config on a
+	// running machine. It is part of every generated Ignition config.
+	IgnitionReloadScriptPath = "/opt/bin/reload-ignition-config"
+	// IgnitionReloadCommand is the command that applies an Ignition config on a running machine.
+	IgnitionReloadCommand = IgnitionReloadScriptPath + " "
+)
+
+// Ignition only runs once in the initramfs of the first boot. Changes to the configuration of an
+// already running machine are applied by this script: it writes the files, units and drop-ins of
+// the given config, masks the masked units and (re)starts the enabled ones.
+const ignitionReloadScript = `#!/bin/bash -eu
+
+CONFIG="$1"
+UNITS_DIR=/etc/systemd/system
+
+jq -r '.storage.files[]? | [.path, (.mode // 420 | tostring), .contents.source] | @tsv' "$CONFIG" | while IFS=$'\t' read -r path mode source; do
+  mkdir -p "$(dirname "$path")"
+  echo "${source#data:;base64,}" | base64 -d > "$path"
+  chmod "$(printf '%o' "$mode")" "$path"
+done
+
+jq -r '.systemd.units[]? | select(.contents != null) | [.name, (.contents | @base64)] | @tsv' "$CONFIG" | while IFS=$'\t' read -r name contents; do
+  echo "$contents" | base64 -d > "$UNITS_DIR/$name"
+done
+
+jq -r '.systemd.units[]? | .name as $unit | .dropins[]? | [$unit, .name, (.contents | @base64)] | @tsv' "$CONFIG" | while IFS=$'\t' read -r unit name contents; do
+  mkdir -p "$UNITS_DIR/$unit.d"
+  echo "$contents" | base64 -d > "$UNITS_DIR/$unit.d/$name"
+done
+
+systemctl daemon-reload
+
+for unit in $(jq -r '.systemd.units[]? | select(.mask == true) | .name' "$CONFIG"); do
+  systemctl mask --now "$unit"
+done
+
+for unit in $(jq -r '.systemd.units[]? | select(.enabled == true) | .name' "$CONFIG"); do
+  systemctl enable "$unit" && systemctl restart "$unit"
+done
+`
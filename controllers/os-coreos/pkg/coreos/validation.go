@@ -0,0 +1,134 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const cloudConfigHeader = "#cloud-config\n"
+
+// ValidateCloudConfig validates the given rendered cloud config against the schema of the
+// Container Linux cloud config.
+func ValidateCloudConfig(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(cloudConfigHeader)) {
+		return fmt.Errorf("cloud config must start with %q", strings.TrimSpace(cloudConfigHeader))
+	}
+
+	cloudConfig := &CloudConfig{}
+	if err := yaml.UnmarshalStrict(data, cloudConfig); err != nil {
+		return fmt.Errorf("could not decode cloud config: %v", err)
+	}
+
+	allErrs := field.ErrorList{}
+
+	for i, unit := range cloudConfig.CoreOS.Units {
+		allErrs = append(allErrs, validateUnitName(field.NewPath("coreos", "units").Index(i).Child("name"), unit.Name)...)
+	}
+
+	for i, file := range cloudConfig.WriteFiles {
+		idxPath := field.NewPath("write_files").Index(i)
+
+		allErrs = append(allErrs, validateFilePath(idxPath.Child("path"), file.Path)...)
+		if _, err := strconv.ParseUint(file.RawFilePermissions, 8, 32); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("permissions"), file.RawFilePermissions, "permissions must be an octal number"))
+		}
+	}
+
+	return allErrs.ToAggregate()
+}
+
+// ValidateIgnitionConfig validates the given rendered Ignition config against the schema of the
+// supported Ignition spec versions.
+func ValidateIgnitionConfig(data []byte) error {
+	ignition := &IgnitionConfig{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(ignition); err != nil {
+		return fmt.Errorf("could not decode ignition config: %v", err)
+	}
+
+	var (
+		allErrs = field.ErrorList{}
+		version = ignition.Ignition.Version
+	)
+
+	if version != IgnitionVersion22 && version != IgnitionVersion30 {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("ignition", "version"), version, []string{IgnitionVersion22, IgnitionVersion30}))
+	}
+
+	for i, file := range ignition.Storage.Files {
+		idxPath := field.NewPath("storage", "files").Index(i)
+
+		allErrs = append(allErrs, validateFilePath(idxPath.Child("path"), file.Path)...)
+		if version == IgnitionVersion22 && len(file.Filesystem) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("filesystem"), "filesystem is required for ignition version "+IgnitionVersion22))
+		}
+		if version == IgnitionVersion30 && len(file.Filesystem) > 0 {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("filesystem"), "filesystem is not supported for ignition version "+IgnitionVersion30))
+		}
+		if file.Mode != nil && (*file.Mode < 0 || *file.Mode > 07777) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("mode"), *file.Mode, "mode must be between 0 and 07777"))
+		}
+		if !strings.HasPrefix(file.Contents.Source, "data:") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("contents", "source"), file.Contents.Source, "source must be a data URL"))
+		}
+	}
+
+	for i, unit := range ignition.Systemd.Units {
+		idxPath := field.NewPath("systemd", "units").Index(i)
+
+		allErrs = append(allErrs, validateUnitName(idxPath.Child("name"), unit.Name)...)
+		for j, dropIn := range unit.Dropins {
+			if !strings.HasSuffix(dropIn.Name, ".conf") {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("dropins").Index(j).Child("name"), dropIn.Name, "drop-in name must end with .conf"))
+			}
+		}
+	}
+
+	return allErrs.ToAggregate()
+}
+
+func validateUnitName(fldPath *field.Path, name string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "unit name must not be empty"))
+	} else if strings.Contains(name, "/") || len(path.Ext(name)) <= 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "unit name must not contain '/' and must have a unit type suffix"))
+	}
+
+	return allErrs
+}
+
+func validateFilePath(fldPath *field.Path, p string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !path.IsAbs(p) {
+		allErrs = append(allErrs, field.Invalid(fldPath, p, "path must be absolute"))
+	}
+
+	return allErrs
+}
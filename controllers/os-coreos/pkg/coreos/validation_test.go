@@ -0,0 +1,76 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coreos_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/gardener/gardener-extensions/controllers/os-coreos/pkg/coreos"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation", func() {
+	Describe("#ValidateCloudConfig", func() {
+		It("should accept the generated cloud config", func() {
+			data, err := ioutil.ReadFile(filepath.Join("testdata", "cloud-config"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(coreos.ValidateCloudConfig(data)).To(Succeed())
+		})
+
+		DescribeTable("should reject invalid cloud configs",
+			func(data string) {
+				Expect(coreos.ValidateCloudConfig([]byte(data))).NotTo(Succeed())
+			},
+			Entry("missing header", "coreos: {}\n"),
+			Entry("unknown field", "#cloud-config\ncoreos:\n  foo: bar\n"),
+			Entry("unit without type suffix", "#cloud-config\ncoreos:\n  units:\n  - name: docker\n"),
+			Entry("relative file path", "#cloud-config\nwrite_files:\n- path: foo\n  permissions: \"0644\"\n"),
+			Entry("invalid permissions", "#cloud-config\nwrite_files:\n- path: /foo\n  permissions: rw\n"),
+		)
+	})
+
+	Describe("#ValidateIgnitionConfig", func() {
+		DescribeTable("should accept the generated ignition configs",
+			func(goldenFile string) {
+				data, err := ioutil.ReadFile(filepath.Join("testdata", goldenFile))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(coreos.ValidateIgnitionConfig(data)).To(Succeed())
+			},
+			Entry("v2", "ignition-v2.json"),
+			Entry("v3", "ignition-v3.json"),
+		)
+
+		DescribeTable("should reject invalid ignition configs",
+			func(data string) {
+				Expect(coreos.ValidateIgnitionConfig([]byte(data))).NotTo(Succeed())
+			},
+			Entry("malformed", `{`),
+			Entry("unknown field", `{"ignition":{"version":"3.0.0"},"foo":{}}`),
+			Entry("unsupported version", `{"ignition":{"version":"1.0.0"}}`),
+			Entry("v2 file without filesystem", `{"ignition":{"version":"2.2.0"},"storage":{"files":[{"path":"/foo","contents":{"source":"data:,bar"}}]}}`),
+			Entry("v3 file with filesystem", `{"ignition":{"version":"3.0.0"},"storage":{"files":[{"filesystem":"root","path":"/foo","contents":{"source":"data:,bar"}}]}}`),
+			Entry("relative file path", `{"ignition":{"version":"3.0.0"},"storage":{"files":[{"path":"foo","contents":{"source":"data:,bar"}}]}}`),
+			Entry("non-data source", `{"ignition":{"version":"3.0.0"},"storage":{"files":[{"path":"/foo","contents":{"source":"http://example.com/foo"}}]}}`),
+			Entry("unit without name", `{"ignition":{"version":"3.0.0"},"systemd":{"units":[{"name":""}]}}`),
+			Entry("drop-in without conf suffix", `{"ignition":{"version":"3.0.0"},"systemd":{"units":[{"name":"docker.service","dropins":[{"name":"opts"}]}]}}`),
+		)
+	})
+})
@@ -16,26 +16,14 @@ package generator
 
 import (
 	template_gen "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/template"
+
 	"github.com/gobuffalo/packr/v2"
-	"text/template"
 )
 
-var cmd = "/usr/bin/cloud-init clean && /usr/bin/cloud-init --file %s init"
-
 //go:generate packr2
 
 // NewCloudInitGenerator creates a new Generator using the template file for suse-jeos
 func NewCloudInitGenerator() (*template_gen.CloudInitGenerator, error) {
 	box := packr.New("templates", "./templates")
-	cloudInitTemplateString, err := box.FindString("cloud-init.template")
-	if err != nil {
-		return nil, err
-	}
-
-	cloudInitTemplate, err := template.New("cloud-init").Parse(cloudInitTemplateString)
-	if err != nil {
-		return nil, err
-	}
-	generator := template_gen.NewCloudInitGenerator(cloudInitTemplate, template_gen.DefaultUnitsPath, cmd)
-	return generator, nil
+	return template_gen.NewCloudInitGeneratorFromBox(box, template_gen.DefaultUnitsPath, template_gen.CloudInitCmd, template_gen.Hooks{})
 }
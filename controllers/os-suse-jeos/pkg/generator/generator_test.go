@@ -15,6 +15,10 @@
 package generator
 
 import (
+	"path/filepath"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator/test"
 	"github.com/gobuffalo/packr"
 	. "github.com/onsi/ginkgo"
@@ -30,4 +34,14 @@ var _ = Describe("JeOS Generator Test", func() {
 	})
 
 	Describe("Conformance Tests", test.DescribeTest(generator, box))
+
+	Describe("Generator Conformance", test.DescribeConformance(test.GeneratorRenderFunc(generator), test.FormatCloudConfig, filepath.Join("testfiles", "conformance")))
+
+	It("should bootstrap containerd instead of docker", func() {
+		data, _, err := generator.Generate(&commonosgenerator.OperatingSystemConfig{Bootstrap: true, ContainerRuntime: containerruntime.Containerd})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("- systemctl enable containerd && systemctl start containerd\n"))
+		Expect(string(data)).To(ContainSubstring(`- '[ -e /bin/ctr ] || ln -s "$(command -v ctr)" /bin/ctr'` + "\n"))
+		Expect(string(data)).NotTo(ContainSubstring("docker"))
+	})
 })
@@ -12,31 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package internal
+package generator
 
-// File is a file to be stored during the cloud init script.
-type File struct {
-	Path        string
-	Content     []byte
-	Permissions *int32
-}
+import (
+	template_gen "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/template"
 
-// Unit is a unit to be created during the cloud init script.
-type Unit struct {
-	Name    string
-	Content []byte
-	DropIns []*DropIn
-}
+	"github.com/gobuffalo/packr/v2"
+)
 
-// DropIn is a drop in of a Unit.
-type DropIn struct {
-	Name    string
-	Content []byte
-}
+//go:generate packr2
 
-// OperatingSystemConfig is the data required to create a cloud init script.
-type OperatingSystemConfig struct {
-	Files     []*File
-	Units     []*Unit
-	Bootstrap bool
+// NewCloudInitGenerator creates a new Generator using the template file for Ubuntu
+func NewCloudInitGenerator() (*template_gen.CloudInitGenerator, error) {
+	box := packr.New("ubuntu-templates", "./templates")
+	return template_gen.NewCloudInitGeneratorFromBox(box, template_gen.DefaultUnitsPath, template_gen.CloudInitCmd, template_gen.Hooks{})
 }
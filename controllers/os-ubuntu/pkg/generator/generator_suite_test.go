@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package internal
+package generator
 
 import (
 	"testing"
@@ -23,5 +23,5 @@ import (
 
 func TestInternal(t *testing.T) {
 	RegisterFailHandler(Fail)
-	RunSpecs(t, "CoreOS Alicloud Internal Suite")
+	RunSpecs(t, "Ubuntu Generator Suite")
 }
@@ -0,0 +1,47 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"path/filepath"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator/test"
+	"github.com/gobuffalo/packr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Ubuntu Generator Test", func() {
+	var box = packr.NewBox("./testfiles")
+	generator, err := NewCloudInitGenerator()
+
+	It("should not fail creating generator", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Conformance Tests", test.DescribeTest(generator, box))
+
+	Describe("Generator Conformance", test.DescribeConformance(test.GeneratorRenderFunc(generator), test.FormatCloudConfig, filepath.Join("testfiles", "conformance")))
+
+	It("should bootstrap containerd instead of docker", func() {
+		data, _, err := generator.Generate(&commonosgenerator.OperatingSystemConfig{Bootstrap: true, ContainerRuntime: containerruntime.Containerd})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("- containerd\n"))
+		Expect(string(data)).To(ContainSubstring(`- '[ -e /bin/ctr ] || ln -s /usr/bin/ctr /bin/ctr'` + "\n"))
+		Expect(string(data)).NotTo(ContainSubstring("docker"))
+	})
+})
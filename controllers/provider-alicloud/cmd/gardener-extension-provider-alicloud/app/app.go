@@ -25,10 +25,12 @@ import (
 	alicloudcontrolplane "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/controller/controlplane"
 	alicloudinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/controller/infrastructure"
 	alicloudworker "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/controller/worker"
+	alicloudcontrolplanewebhook "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/webhook/controlplane"
 	alicloudcontrolplanebackup "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/webhook/controlplanebackup"
 	alicloudcontrolplaneexposure "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/webhook/controlplaneexposure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -53,6 +55,18 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		controlPlaneCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		controlPlaneReconcileOpts = &controlplane.ReconcilerOptions{
+			ResyncInterval: controlplane.DefaultResyncInterval,
+		}
+		controlPlaneSecretsRotationOpts = &controlplane.SecretsRotationOptions{
+			CertificateRenewalWindow: util.DefaultCertificateRenewalWindow,
+			CABundleDuration:         util.DefaultCABundleDuration,
+		}
+		controlPlaneDeletionOpts = &controlplane.DeletionOptions{
+			ManagedResourceDeletionTimeout: controlplane.DefaultManagedResourceDeletionTimeout,
+			OrphanPolicy:                   string(controlplane.OrphanPolicyNever),
+		}
+		controlPlaneCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(controlPlaneCtrlOpts, controlPlaneReconcileOpts, controlPlaneSecretsRotationOpts, controlPlaneDeletionOpts)
 
 		// options for the infrastructure controller
 		infraCtrlOpts = &controllercmd.ControllerOptions{
@@ -68,7 +82,8 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			MaxConcurrentReconciles: 5,
 		}
 		workerReconcileOpts = &worker.Options{
-			DeployCRDs: true,
+			DeployCRDs:     true,
+			RolloutTimeout: worker.DefaultRolloutTimeout,
 		}
 		workerCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(workerCtrlOpts, workerReconcileOpts)
 
@@ -88,7 +103,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
-			controllercmd.PrefixOption("controlplane-", controlPlaneCtrlOpts),
+			controllercmd.PrefixOption("controlplane-", &controlPlaneCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("infrastructure-", &infraCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("worker-", &workerCtrlOptsUnprefixed),
 			configFileOpts,
@@ -129,17 +144,26 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyMachineImages(&alicloudworker.DefaultAddOptions.MachineImages)
 			configFileOpts.Completed().ApplyETCDStorage(&alicloudcontrolplaneexposure.DefaultAddOptions.ETCDStorage)
 			configFileOpts.Completed().ApplyETCDBackup(&alicloudcontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyClusterAutoscaler(&alicloudcontrolplanewebhook.DefaultAddOptions.ClusterAutoscaler)
+			configFileOpts.Completed().ApplyMachineControllerManager(&alicloudworker.DefaultAddOptions.MachineControllerManager)
 			controlPlaneCtrlOpts.Completed().Apply(&alicloudcontrolplane.Options)
+			controlPlaneReconcileOpts.Completed().ApplyResyncInterval(&alicloudcontrolplane.ResyncInterval)
+			controlPlaneSecretsRotationOpts.Completed().Apply(alicloudcontrolplane.RotatingSecrets)
+			controlPlaneDeletionOpts.Completed().ApplyManagedResourceDeletionTimeout(&alicloudcontrolplane.ManagedResourceDeletionTimeout)
+			controlPlaneDeletionOpts.Completed().ApplyOrphanPolicy(&alicloudcontrolplane.OrphanPolicy)
 			infraCtrlOpts.Completed().Apply(&alicloudinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&alicloudinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
 			workerCtrlOpts.Completed().Apply(&alicloudworker.DefaultAddOptions.Controller)
+			workerReconcileOpts.Completed().ApplyRolloutTimeout(&alicloudworker.DefaultAddOptions.RolloutTimeout)
 
-			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
+			shootWebhookConfig, err := webhookOptions.Completed().AddToManager(mgr)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
 			}
+			alicloudcontrolplane.ShootWebhookConfig = shootWebhookConfig
 
-			if err := webhookOptions.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
+			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
 			}
 
 			if err := mgr.Start(ctx.Done()); err != nil {
@@ -43,3 +43,17 @@ func FindSecurityGroupByPurpose(securityGroups []alicloud.SecurityGroup, purpose
 	}
 	return nil, fmt.Errorf("cannot find security group with purpose %q", purpose)
 }
+
+// FindImageFromCloudProfile takes a CloudProfileConfig, and the desired image name and version. It tries to find the
+// image with the given name and version. If it cannot be found then an error is returned.
+func FindImageFromCloudProfile(cloudProfileConfig *alicloud.CloudProfileConfig, imageName, imageVersion string) (string, error) {
+	if cloudProfileConfig != nil {
+		for _, machineImage := range cloudProfileConfig.MachineImages {
+			if machineImage.Name == imageName && machineImage.Version == imageVersion {
+				return machineImage.ID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find an image for name %q in version %q in the cloud profile", imageName, imageVersion)
+}
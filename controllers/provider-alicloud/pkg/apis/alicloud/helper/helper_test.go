@@ -53,6 +53,25 @@ var _ = Describe("Helper", func() {
 		Entry("entry not found", []alicloud.SecurityGroup{{ID: "bar", Purpose: purposeWrong}}, purpose, nil, true),
 		Entry("entry exists", []alicloud.SecurityGroup{{ID: "bar", Purpose: purpose}}, purpose, &alicloud.SecurityGroup{ID: "bar", Purpose: purpose}, false),
 	)
+
+	DescribeTable("#FindImageFromCloudProfile",
+		func(cloudProfileConfig *alicloud.CloudProfileConfig, imageName, imageVersion, expectedImage string) {
+			image, err := FindImageFromCloudProfile(cloudProfileConfig, imageName, imageVersion)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", ""),
+		Entry("no machine images", &alicloud.CloudProfileConfig{}, "ubuntu", "1", ""),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "coreos", "1", ""),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "2", ""),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "1", "image-1234"),
+	)
 })
 
 func expectResults(result, expected interface{}, err error, expectErr bool) {
@@ -64,3 +83,15 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func makeCloudProfileConfig(name, version, image string) *alicloud.CloudProfileConfig {
+	return &alicloud.CloudProfileConfig{
+		MachineImages: []alicloud.MachineImage{
+			{
+				Name:    name,
+				Version: version,
+				ID:      image,
+			},
+		},
+	}
+}
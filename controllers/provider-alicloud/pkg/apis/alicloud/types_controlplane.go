@@ -35,4 +35,18 @@ type ControlPlaneConfig struct {
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	gardenv1beta1.KubernetesConfig
+
+	// LoadBalancer contains configuration settings for the Server Load Balancers (SLBs) of services of type
+	// LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig
+}
+
+// LoadBalancerConfig contains configuration settings for the Server Load Balancers (SLBs) of services of type
+// LoadBalancer.
+type LoadBalancerConfig struct {
+	// DisablePublicSLB forbids internet-facing SLBs, i.e., services of type LoadBalancer must request intranet SLBs
+	// with the `service.beta.kubernetes.io/alicloud-loadbalancer-address-type: intranet` annotation.
+	// +optional
+	DisablePublicSLB bool
 }
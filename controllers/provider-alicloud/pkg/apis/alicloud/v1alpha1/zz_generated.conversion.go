@@ -46,6 +46,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*alicloud.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_alicloud_CloudProfileConfig(a.(*CloudProfileConfig), b.(*alicloud.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*alicloud.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_alicloud_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*alicloud.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*alicloud.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_alicloud_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*alicloud.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -76,6 +86,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*alicloud.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_alicloud_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*alicloud.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*alicloud.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_alicloud_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*alicloud.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*alicloud.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_alicloud_MachineImage(a.(*MachineImage), b.(*alicloud.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*alicloud.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_alicloud_MachineImage_To_v1alpha1_MachineImage(a.(*alicloud.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Networks)(nil), (*alicloud.Networks)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Networks_To_alicloud_Networks(a.(*Networks), b.(*alicloud.Networks), scope)
 	}); err != nil {
@@ -141,6 +171,7 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_alicloud_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *alicloud.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*alicloud.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
 	return nil
 }
 
@@ -151,6 +182,7 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_alicloud_CloudControllerMa
 
 func autoConvert_alicloud_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *alicloud.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
 	return nil
 }
 
@@ -159,6 +191,26 @@ func Convert_alicloud_CloudControllerManagerConfig_To_v1alpha1_CloudControllerMa
 	return autoConvert_alicloud_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_alicloud_CloudProfileConfig(in *CloudProfileConfig, out *alicloud.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]alicloud.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_alicloud_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_alicloud_CloudProfileConfig(in *CloudProfileConfig, out *alicloud.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_alicloud_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_alicloud_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *alicloud.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_alicloud_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_alicloud_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *alicloud.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_alicloud_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_alicloud_ControlPlaneConfig(in *ControlPlaneConfig, out *alicloud.ControlPlaneConfig, s conversion.Scope) error {
 	out.Zone = in.Zone
 	out.CloudControllerManager = (*alicloud.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
@@ -231,6 +283,50 @@ func Convert_alicloud_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *
 	return autoConvert_alicloud_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_LoadBalancerConfig_To_alicloud_LoadBalancerConfig(in *LoadBalancerConfig, out *alicloud.LoadBalancerConfig, s conversion.Scope) error {
+	out.DisablePublicSLB = in.DisablePublicSLB
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_alicloud_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_alicloud_LoadBalancerConfig(in *LoadBalancerConfig, out *alicloud.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_alicloud_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_alicloud_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *alicloud.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.DisablePublicSLB = in.DisablePublicSLB
+	return nil
+}
+
+// Convert_alicloud_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_alicloud_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *alicloud.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_alicloud_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_alicloud_MachineImage(in *MachineImage, out *alicloud.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_alicloud_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_alicloud_MachineImage(in *MachineImage, out *alicloud.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_alicloud_MachineImage(in, out, s)
+}
+
+func autoConvert_alicloud_MachineImage_To_v1alpha1_MachineImage(in *alicloud.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_alicloud_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_alicloud_MachineImage_To_v1alpha1_MachineImage(in *alicloud.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_alicloud_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
+
 func autoConvert_v1alpha1_Networks_To_alicloud_Networks(in *Networks, out *alicloud.Networks, s conversion.Scope) error {
 	if err := Convert_v1alpha1_VPC_To_alicloud_VPC(&in.VPC, &out.VPC, s); err != nil {
 		return err
@@ -15,6 +15,8 @@
 package config
 
 import (
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -34,6 +36,10 @@ type ControllerConfiguration struct {
 	MachineImages []MachineImage
 	// ETCD is the etcd configuration.
 	ETCD ETCD
+	// ClusterAutoscaler is the cluster-autoscaler configuration.
+	ClusterAutoscaler extensionsconfig.ClusterAutoscaler
+	// MachineControllerManager is the machine-controller-manager configuration.
+	MachineControllerManager extensionsconfig.MachineControllerManager
 }
 
 // MachineImage is a mapping from logical names and versions to Alicloud-specific identifiers.
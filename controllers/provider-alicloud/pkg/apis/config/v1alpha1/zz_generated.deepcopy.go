@@ -21,10 +21,47 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscaler) DeepCopyInto(out *ClusterAutoscaler) {
+	*out = *in
+	if in.Expander != nil {
+		in, out := &in.Expander, &out.Expander
+		*out = new(string)
+		**out = **in
+	}
+	if in.BalanceSimilarNodeGroups != nil {
+		in, out := &in.BalanceSimilarNodeGroups, &out.BalanceSimilarNodeGroups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScaleDownDelayAfterAdd != nil {
+		in, out := &in.ScaleDownDelayAfterAdd, &out.ScaleDownDelayAfterAdd
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownUnneededTime != nil {
+		in, out := &in.ScaleDownUnneededTime, &out.ScaleDownUnneededTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscaler.
+func (in *ClusterAutoscaler) DeepCopy() *ClusterAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -40,6 +77,8 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		copy(*out, *in)
 	}
 	in.ETCD.DeepCopyInto(&out.ETCD)
+	in.ClusterAutoscaler.DeepCopyInto(&out.ClusterAutoscaler)
+	in.MachineControllerManager.DeepCopyInto(&out.MachineControllerManager)
 	return
 }
 
@@ -126,6 +165,37 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineControllerManager) DeepCopyInto(out *MachineControllerManager) {
+	*out = *in
+	if in.MachineCreationTimeout != nil {
+		in, out := &in.MachineCreationTimeout, &out.MachineCreationTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MachineHealthTimeout != nil {
+		in, out := &in.MachineHealthTimeout, &out.MachineHealthTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MachineDrainTimeout != nil {
+		in, out := &in.MachineDrainTimeout, &out.MachineDrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineControllerManager.
+func (in *MachineControllerManager) DeepCopy() *MachineControllerManager {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineControllerManager)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineImage) DeepCopyInto(out *MachineImage) {
 	*out = *in
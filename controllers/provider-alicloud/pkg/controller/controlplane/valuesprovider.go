@@ -112,7 +112,7 @@ var controlPlaneSecrets = &secrets.Secrets{
 	},
 }
 
-var configChart = &chart.Chart{
+var configChart = util.NewChart(&chart.Chart{
 	Name: "cloud-provider-config",
 	Path: filepath.Join(alicloud.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
@@ -121,9 +121,9 @@ var configChart = &chart.Chart{
 			Name: alicloud.CloudProviderConfigName,
 		},
 	},
-}
+})
 
-var controlPlaneChart = &chart.Chart{
+var controlPlaneChart = util.NewChart(&chart.Chart{
 	Name: "seed-controlplane",
 	Path: filepath.Join(alicloud.InternalChartsPath, "seed-controlplane"),
 	SubCharts: []*chart.Chart{
@@ -144,9 +144,9 @@ var controlPlaneChart = &chart.Chart{
 			},
 		},
 	},
-}
+})
 
-var controlPlaneShootChart = &chart.Chart{
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
 	Name: "shoot-system-components",
 	Path: filepath.Join(alicloud.InternalChartsPath, "shoot-system-components"),
 	SubCharts: []*chart.Chart{
@@ -181,7 +181,7 @@ var controlPlaneShootChart = &chart.Chart{
 			},
 		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
@@ -297,6 +297,7 @@ type cloudConfig struct {
 		Region               string `json:"region"`
 		ZoneID               string `json:"zoneid"`
 		VswitchID            string `json:"vswitchid"`
+		DisablePublicSLB     bool   `json:"disablePublicSLB,omitempty"`
 
 		AccessKeyID     string `json:"accessKeyID"`
 		AccessKeySecret string `json:"accessKeySecret"`
@@ -325,6 +326,9 @@ func getConfigChartValues(
 	cfg.Global.AccessKeyID = base64.StdEncoding.EncodeToString([]byte(credentials.AccessKeyID))
 	cfg.Global.AccessKeySecret = base64.StdEncoding.EncodeToString([]byte(credentials.AccessKeySecret))
 	cfg.Global.Region = cp.Spec.Region
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		cfg.Global.DisablePublicSLB = ccm.LoadBalancer.DisablePublicSLB
+	}
 
 	cfgJSON, err := json.Marshal(cfg)
 	if err != nil {
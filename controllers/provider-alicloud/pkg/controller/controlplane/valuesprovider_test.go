@@ -70,6 +70,9 @@ var _ = Describe("ValuesProvider", func() {
 									"CustomResourceValidation": true,
 								},
 							},
+							LoadBalancer: &apisalicloud.LoadBalancerConfig{
+								DisablePublicSLB: true,
+							},
 						},
 					}),
 				},
@@ -133,7 +136,7 @@ var _ = Describe("ValuesProvider", func() {
 		}
 
 		configChartValues = map[string]interface{}{
-			"cloudConfig": `{"Global":{"KubernetesClusterTag":"test","uid":"","vpcid":"vpc-1234","region":"eu-central-1","zoneid":"eu-central-1a","vswitchid":"vswitch-acbd1234","accessKeyID":"Zm9v","accessKeySecret":"YmFy"}}`,
+			"cloudConfig": `{"Global":{"KubernetesClusterTag":"test","uid":"","vpcid":"vpc-1234","region":"eu-central-1","zoneid":"eu-central-1a","vswitchid":"vswitch-acbd1234","disablePublicSLB":true,"accessKeyID":"Zm9v","accessKeySecret":"YmFy"}}`,
 		}
 
 		controlPlaneChartValues = map[string]interface{}{
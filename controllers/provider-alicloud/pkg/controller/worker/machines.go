@@ -22,7 +22,6 @@ import (
 	"github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/alicloud"
 	alicloudapi "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/apis/alicloud"
 	alicloudapihelper "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/apis/alicloud/helper"
-	confighelper "github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/apis/config/helper"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -104,10 +103,15 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	findMachineImage, err := newMachineImageLookup(w.decoder, w.machineImages, w.cluster)
+	if err != nil {
+		return err
+	}
+
 	for _, pool := range w.worker.Spec.Pools {
 		zoneLen := len(pool.Zones)
 
-		machineImage, err := confighelper.FindImage(w.machineImages, pool.MachineImage.Name, pool.MachineImage.Version)
+		machineImage, err := findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
 		}
@@ -157,6 +161,8 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 			machineDeployments = append(machineDeployments, worker.MachineDeployment{
 				Name:           deploymentName,
+				PoolName:       pool.Name,
+				Zone:           zone,
 				ClassName:      className,
 				SecretName:     className,
 				Minimum:        worker.DistributeOverZones(zoneIndex, pool.Minimum, zoneLen),
@@ -359,6 +359,8 @@ var _ = Describe("Machines", func() {
 				machineDeployments := worker.MachineDeployments{
 					{
 						Name:           machineClassNamePool1Zone1,
+						PoolName:       namePool1,
+						Zone:           zone1,
 						ClassName:      machineClassWithHashPool1Zone1,
 						SecretName:     machineClassWithHashPool1Zone1,
 						Minimum:        worker.DistributeOverZones(0, minPool1, 2),
@@ -368,6 +370,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool1Zone2,
+						PoolName:       namePool1,
+						Zone:           zone2,
 						ClassName:      machineClassWithHashPool1Zone2,
 						SecretName:     machineClassWithHashPool1Zone2,
 						Minimum:        worker.DistributeOverZones(1, minPool1, 2),
@@ -377,6 +381,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool2Zone1,
+						PoolName:       namePool2,
+						Zone:           zone1,
 						ClassName:      machineClassWithHashPool2Zone1,
 						SecretName:     machineClassWithHashPool2Zone1,
 						Minimum:        worker.DistributeOverZones(0, minPool2, 2),
@@ -386,6 +392,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool2Zone2,
+						PoolName:       namePool2,
+						Zone:           zone2,
 						ClassName:      machineClassWithHashPool2Zone2,
 						SecretName:     machineClassWithHashPool2Zone2,
 						Minimum:        worker.DistributeOverZones(1, minPool2, 2),
@@ -410,6 +418,15 @@ var _ = Describe("Machines", func() {
 				Expect(result).To(BeNil())
 			})
 
+			It("should fail because the provider config of the cloud profile is invalid", func() {
+				expectGetSecretCallToWork(c, alicloudAccessKeyID, alicloudAccessKeySecret)
+				cluster.CloudProfileConfig = []byte(`{"apiVersion":"alicloud.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","machineImages":[{"name":""}]}`)
+				workerDelegate = NewWorkerDelegate(c, decoder, machineImages, chartApplier, "", w, cluster)
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
 			It("should fail because the version is invalid", func() {
 				expectGetSecretCallToWork(c, alicloudAccessKeyID, alicloudAccessKeySecret)
 
@@ -16,6 +16,7 @@ package controlplane
 
 import (
 	"github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/alicloud"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
@@ -28,17 +29,33 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the Alicloud controlplane webhook to the manager.
+type AddOptions struct {
+	// ClusterAutoscaler is the cluster-autoscaler configuration.
+	ClusterAutoscaler extensionsconfig.ClusterAutoscaler
+}
+
 var logger = log.Log.WithName("alicloud-controlplane-webhook")
 
-// AddToManager creates a webhook and adds it to the manager.
-func AddToManager(mgr manager.Manager) (webhook.Webhook, error) {
+// AddToManagerWithOptions creates a webhook with the given options and adds it to the manager.
+func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) (webhook.Webhook, error) {
 	logger.Info("Adding webhook to manager")
 	fciCodec := controlplane.NewFileContentInlineCodec()
 	return controlplane.Add(mgr, controlplane.AddArgs{
 		Kind:     extensionswebhook.ShootKind,
 		Provider: alicloud.Type,
 		Types:    []runtime.Object{&appsv1.Deployment{}, &extensionsv1alpha1.OperatingSystemConfig{}},
-		Mutator: genericmutator.NewMutator(NewEnsurer(logger), controlplane.NewUnitSerializer(),
-			controlplane.NewKubeletConfigCodec(fciCodec), fciCodec, logger),
+		Mutator: genericmutator.NewMutator(NewEnsurer(&opts.ClusterAutoscaler, logger),
+			controlplane.NewUnitSerializer(), controlplane.NewKubeletConfigCodec(fciCodec), fciCodec, logger),
 	})
 }
+
+// AddToManager creates a webhook with the default options and adds it to the manager.
+func AddToManager(mgr manager.Manager) (webhook.Webhook, error) {
+	return AddToManagerWithOptions(mgr, DefaultAddOptions)
+}
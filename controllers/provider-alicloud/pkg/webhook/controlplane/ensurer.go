@@ -17,6 +17,7 @@ package controlplane
 import (
 	"context"
 
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
 
@@ -28,15 +29,17 @@ import (
 )
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(clusterAutoscaler *extensionsconfig.ClusterAutoscaler, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		logger: logger.WithName("alicloud-controlplane-ensurer"),
+		clusterAutoscaler: clusterAutoscaler,
+		logger:            logger.WithName("alicloud-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	logger logr.Logger
+	clusterAutoscaler *extensionsconfig.ClusterAutoscaler
+	logger            logr.Logger
 }
 
 // EnsureKubeAPIServerDeployment ensures that the kube-apiserver deployment conforms to the provider requirements.
@@ -87,6 +90,12 @@ func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container) {
 	c.Command = controlplane.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "external")
 }
 
+// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+func (e *ensurer) EnsureClusterAutoscalerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	controlplane.EnsureClusterAutoscalerCommandLineArgs(dep, e.clusterAutoscaler)
+	return nil
+}
+
 // EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 func (e *ensurer) EnsureKubeletServiceUnitOptions(ctx context.Context, opts []*unit.UnitOption) ([]*unit.UnitOption, error) {
 	if opt := controlplane.UnitOptionWithSectionAndName(opts, "Service", "ExecStart"); opt != nil {
@@ -104,8 +113,9 @@ func ensureKubeletCommandLineArgs(command []string) []string {
 	return command
 }
 
-// EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) error {
+// EnsureKubeletConfiguration ensures that the kubelet configuration of the shoot in the given namespace conforms to
+// the provider requirements.
+func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, namespace string) error {
 	// Ensure CSI-related feature gates
 	if kubeletConfig.FeatureGates == nil {
 		kubeletConfig.FeatureGates = make(map[string]bool)
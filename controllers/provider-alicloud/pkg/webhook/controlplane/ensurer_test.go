@@ -17,11 +17,15 @@ package controlplane
 import (
 	"context"
 	"testing"
+	"time"
 
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/test"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -40,6 +44,13 @@ func TestController(t *testing.T) {
 var _ = Describe("Ensurer", func() {
 	var (
 		ctrl *gomock.Controller
+
+		clusterAutoscaler = &extensionsconfig.ClusterAutoscaler{
+			Expander:                 util.StringPtr("least-waste"),
+			BalanceSimilarNodeGroups: util.BoolPtr(true),
+			ScaleDownDelayAfterAdd:   &metav1.Duration{Duration: 30 * time.Minute},
+			ScaleDownUnneededTime:    &metav1.Duration{Duration: 20 * time.Minute},
+		}
 	)
 
 	BeforeEach(func() {
@@ -70,7 +81,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeAPIServerDeployment method and check the result
 			err := ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dep)
@@ -102,7 +113,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeAPIServerDeployment method and check the result
 			err := ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dep)
@@ -131,7 +142,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeControllerManagerDeployment method and check the result
 			err := ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), dep)
@@ -161,7 +172,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeControllerManagerDeployment method and check the result
 			err := ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), dep)
@@ -170,6 +181,46 @@ var _ = Describe("Ensurer", func() {
 		})
 	})
 
+	Describe("#EnsureClusterAutoscalerDeployment", func() {
+		It("should add or modify the configured flags of the cluster-autoscaler deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "cluster-autoscaler",
+										Command: []string{
+											"./cluster-autoscaler",
+											"--expander=random",
+											"--scale-down-unneeded-time=30m",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+
+			// Call EnsureClusterAutoscalerDeployment method and check the result
+			err := ensurer.EnsureClusterAutoscalerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--expander=least-waste",
+				"--scale-down-unneeded-time=20m0s",
+				"--balance-similar-node-groups=true",
+				"--scale-down-delay-after-add=30m0s",
+			}))
+		})
+	})
+
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
 		It("should modify existing elements of kubelet.service unit options", func() {
 			var (
@@ -195,7 +246,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeletServiceUnitOptions method and check the result
 			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), oldUnitOptions)
@@ -224,11 +275,11 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig)
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig, "test")
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
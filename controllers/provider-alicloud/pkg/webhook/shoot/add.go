@@ -0,0 +1,39 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"github.com/gardener/gardener-extensions/controllers/provider-alicloud/pkg/alicloud"
+	"github.com/gardener/gardener-extensions/pkg/webhook/shoot"
+	"github.com/gardener/gardener-extensions/pkg/webhook/shoot/genericmutator"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var logger = log.Log.WithName("alicloud-shoot-webhook")
+
+// AddToManager creates a webhook and adds it to the manager.
+func AddToManager(mgr manager.Manager) (webhook.Webhook, error) {
+	logger.Info("Adding webhook to manager")
+	return shoot.Add(mgr, shoot.AddArgs{
+		Provider: alicloud.Type,
+		Types:    []runtime.Object{&appsv1.DaemonSet{}, &appsv1.Deployment{}},
+		Mutator:  genericmutator.NewMutator(NewEnsurer(logger), logger),
+	})
+}
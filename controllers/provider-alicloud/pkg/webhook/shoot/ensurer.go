@@ -0,0 +1,63 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/webhook/shoot/genericmutator"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nameservers are the addresses of the internal DNS resolvers of Alibaba Cloud. They are reachable from all VPCs.
+var nameservers = []string{"100.100.2.136", "100.100.2.138"}
+
+// NewEnsurer creates a new shoot ensurer.
+func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+	return &ensurer{
+		logger: logger.WithName("alicloud-shoot-ensurer"),
+	}
+}
+
+type ensurer struct {
+	genericmutator.NoopEnsurer
+	logger logr.Logger
+}
+
+// EnsureCoreDNSDeployment ensures that the coredns deployment conforms to the provider requirements.
+// CoreDNS forwards queries for external names to the nameservers in its /etc/resolv.conf. These are set to the
+// Alibaba Cloud resolvers, so that the forwarding doesn't depend on the resolver configuration of the nodes.
+func (e *ensurer) EnsureCoreDNSDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	ps := &dep.Spec.Template.Spec
+	ps.DNSPolicy = corev1.DNSNone
+	if ps.DNSConfig == nil {
+		ps.DNSConfig = &corev1.PodDNSConfig{}
+	}
+	ps.DNSConfig.Nameservers = ensureStrings(ps.DNSConfig.Nameservers, nameservers...)
+	return nil
+}
+
+func ensureStrings(items []string, values ...string) []string {
+	for _, value := range values {
+		if controlplane.StringIndex(items, value) < 0 {
+			items = append(items, value)
+		}
+	}
+	return items
+}
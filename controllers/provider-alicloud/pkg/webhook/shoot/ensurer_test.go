@@ -0,0 +1,92 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/gardener/pkg/operation/common"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestController(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Alicloud Shoot Webhook Suite")
+}
+
+var _ = Describe("Ensurer", func() {
+	Describe("#EnsureCoreDNSDeployment", func() {
+		It("should set the Alibaba Cloud nameservers in the coredns deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: common.CoreDNSDeploymentName, Namespace: metav1.NamespaceSystem},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								DNSPolicy: corev1.DNSDefault,
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(logger)
+
+			// Call EnsureCoreDNSDeployment method and check the result
+			err := ensurer.EnsureCoreDNSDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkCoreDNSDeployment(dep, []string{"100.100.2.136", "100.100.2.138"})
+		})
+
+		It("should keep existing nameservers in the coredns deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: common.CoreDNSDeploymentName, Namespace: metav1.NamespaceSystem},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								DNSPolicy: corev1.DNSNone,
+								DNSConfig: &corev1.PodDNSConfig{
+									Nameservers: []string{"100.100.2.138", "10.0.0.2"},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(logger)
+
+			// Call EnsureCoreDNSDeployment method and check the result
+			err := ensurer.EnsureCoreDNSDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkCoreDNSDeployment(dep, []string{"100.100.2.138", "10.0.0.2", "100.100.2.136"})
+		})
+	})
+})
+
+func checkCoreDNSDeployment(dep *appsv1.Deployment, nameservers []string) {
+	ps := &dep.Spec.Template.Spec
+	Expect(ps.DNSPolicy).To(Equal(corev1.DNSNone))
+	Expect(ps.DNSConfig).NotTo(BeNil())
+	Expect(ps.DNSConfig.Nameservers).To(Equal(nameservers))
+}
@@ -27,10 +27,12 @@ import (
 	awscontrolplane "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/controller/controlplane"
 	awsinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/controller/infrastructure"
 	awsworker "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/controller/worker"
+	awscontrolplanewebhook "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/webhook/controlplane"
 	awscontrolplanebackup "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/webhook/controlplanebackup"
 	awscontrolplaneexposure "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/webhook/controlplaneexposure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -65,6 +67,18 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		controlPlaneCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		controlPlaneReconcileOpts = &controlplane.ReconcilerOptions{
+			ResyncInterval: controlplane.DefaultResyncInterval,
+		}
+		controlPlaneSecretsRotationOpts = &controlplane.SecretsRotationOptions{
+			CertificateRenewalWindow: util.DefaultCertificateRenewalWindow,
+			CABundleDuration:         util.DefaultCABundleDuration,
+		}
+		controlPlaneDeletionOpts = &controlplane.DeletionOptions{
+			ManagedResourceDeletionTimeout: controlplane.DefaultManagedResourceDeletionTimeout,
+			OrphanPolicy:                   string(controlplane.OrphanPolicyNever),
+		}
+		controlPlaneCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(controlPlaneCtrlOpts, controlPlaneReconcileOpts, controlPlaneSecretsRotationOpts, controlPlaneDeletionOpts)
 
 		// options for the infrastructure controller
 		infraCtrlOpts = &controllercmd.ControllerOptions{
@@ -80,7 +94,8 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			MaxConcurrentReconciles: 5,
 		}
 		workerReconcileOpts = &worker.Options{
-			DeployCRDs: true,
+			DeployCRDs:     true,
+			RolloutTimeout: worker.DefaultRolloutTimeout,
 		}
 		workerCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(workerCtrlOpts, workerReconcileOpts)
 
@@ -102,7 +117,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			mgrOpts,
 			controllercmd.PrefixOption("backupbucket-", backupBucketCtrlOpts),
 			controllercmd.PrefixOption("backupentry-", backupEntryCtrlOpts),
-			controllercmd.PrefixOption("controlplane-", controlPlaneCtrlOpts),
+			controllercmd.PrefixOption("controlplane-", &controlPlaneCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("infrastructure-", &infraCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("worker-", &workerCtrlOptsUnprefixed),
 			configFileOpts,
@@ -143,19 +158,28 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyMachineImages(&awsworker.DefaultAddOptions.MachineImagesToAMIMapping)
 			configFileOpts.Completed().ApplyETCDStorage(&awscontrolplaneexposure.DefaultAddOptions.ETCDStorage)
 			configFileOpts.Completed().ApplyETCDBackup(&awscontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyClusterAutoscaler(&awscontrolplanewebhook.DefaultAddOptions.ClusterAutoscaler)
+			configFileOpts.Completed().ApplyMachineControllerManager(&awsworker.DefaultAddOptions.MachineControllerManager)
 			backupBucketCtrlOpts.Completed().Apply(&awsbackupbucket.DefaultAddOptions)
 			backupEntryCtrlOpts.Completed().Apply(&awsbackupentry.DefaultAddOptions)
 			controlPlaneCtrlOpts.Completed().Apply(&awscontrolplane.Options)
+			controlPlaneReconcileOpts.Completed().ApplyResyncInterval(&awscontrolplane.ResyncInterval)
+			controlPlaneSecretsRotationOpts.Completed().Apply(awscontrolplane.RotatingSecrets)
+			controlPlaneDeletionOpts.Completed().ApplyManagedResourceDeletionTimeout(&awscontrolplane.ManagedResourceDeletionTimeout)
+			controlPlaneDeletionOpts.Completed().ApplyOrphanPolicy(&awscontrolplane.OrphanPolicy)
 			infraCtrlOpts.Completed().Apply(&awsinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&awsinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
 			workerCtrlOpts.Completed().Apply(&awsworker.DefaultAddOptions.Controller)
+			workerReconcileOpts.Completed().ApplyRolloutTimeout(&awsworker.DefaultAddOptions.RolloutTimeout)
 
-			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
+			shootWebhookConfig, err := webhookOptions.Completed().AddToManager(mgr)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
 			}
+			awscontrolplane.ShootWebhookConfig = shootWebhookConfig
 
-			if err := webhookOptions.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
+			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
 			}
 
 			if err := mgr.Start(ctx.Done()); err != nil {
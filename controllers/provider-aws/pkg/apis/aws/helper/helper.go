@@ -16,6 +16,7 @@ package helper
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
 )
@@ -79,3 +80,34 @@ func FindSubnetForPurposeAndZone(subnets []aws.Subnet, purpose, zone string) (*a
 	}
 	return nil, fmt.Errorf("no subnet with purpose %q in zone %q found", purpose, zone)
 }
+
+// FindAMIForRegionFromCloudProfile takes a CloudProfileConfig, and the desired image name, version, and region. It
+// tries to find the image with the given name and version in the desired region. If it cannot be found then an error
+// is returned.
+func FindAMIForRegionFromCloudProfile(cloudProfileConfig *aws.CloudProfileConfig, imageName, imageVersion, regionName string) (string, error) {
+	if cloudProfileConfig != nil {
+		for _, machineImage := range cloudProfileConfig.MachineImages {
+			if machineImage.Name != imageName || machineImage.Version != imageVersion {
+				continue
+			}
+
+			for _, region := range machineImage.Regions {
+				if region.Name == regionName {
+					return region.AMI, nil
+				}
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find an AMI for region %q and machine image %q in version %q in the cloud profile", regionName, imageName, imageVersion)
+}
+
+// KMSKeyRegion returns the region of the KMS key with the given ARN, or an error if the ARN is malformed.
+func KMSKeyRegion(keyARN string) (string, error) {
+	// arn:<partition>:kms:<region>:<account>:key/<id>
+	parts := strings.Split(keyARN, ":")
+	if len(parts) != 6 || parts[2] != "kms" || parts[3] == "" {
+		return "", fmt.Errorf("%q is not the ARN of a KMS key", keyARN)
+	}
+	return parts[3], nil
+}
@@ -72,6 +72,44 @@ var _ = Describe("Helper", func() {
 		Entry("entry not found (no zone)", []aws.Subnet{{ID: "bar", Purpose: "baz", Zone: "europe"}}, "foo", "asia", nil, true),
 		Entry("entry exists", []aws.Subnet{{ID: "bar", Purpose: "baz", Zone: "europe"}}, "baz", "europe", &aws.Subnet{ID: "bar", Purpose: "baz", Zone: "europe"}, false),
 	)
+
+	DescribeTable("#FindAMIForRegionFromCloudProfile",
+		func(cloudProfileConfig *aws.CloudProfileConfig, imageName, imageVersion, regionName, expectedAMI string) {
+			ami, err := FindAMIForRegionFromCloudProfile(cloudProfileConfig, imageName, imageVersion, regionName)
+
+			Expect(ami).To(Equal(expectedAMI))
+			if expectedAMI != "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", "europe", ""),
+		Entry("no machine images", &aws.CloudProfileConfig{}, "ubuntu", "1", "europe", ""),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1", "europe", "ami-1234"), "coreos", "1", "europe", ""),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1", "europe", "ami-1234"), "ubuntu", "2", "europe", ""),
+		Entry("entry not found (no region)", makeCloudProfileConfig("ubuntu", "1", "europe", "ami-1234"), "ubuntu", "1", "asia", ""),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1", "europe", "ami-1234"), "ubuntu", "1", "europe", "ami-1234"),
+	)
+
+	DescribeTable("#KMSKeyRegion",
+		func(keyARN, expectedRegion string, expectErr bool) {
+			region, err := KMSKeyRegion(keyARN)
+
+			Expect(region).To(Equal(expectedRegion))
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+
+		Entry("empty ARN", "", "", true),
+		Entry("no KMS ARN", "arn:aws:iam::123456789012:role/foo", "", true),
+		Entry("KMS key ARN", "arn:aws:kms:eu-west-1:123456789012:key/1234abcd", "eu-west-1", false),
+		Entry("KMS key ARN in China", "arn:aws-cn:kms:cn-north-1:123456789012:key/1234abcd", "cn-north-1", false),
+	)
 })
 
 func expectResults(result, expected interface{}, err error, expectErr bool) {
@@ -83,3 +121,15 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func makeCloudProfileConfig(name, version, region, ami string) *aws.CloudProfileConfig {
+	return &aws.CloudProfileConfig{
+		MachineImages: []aws.MachineImage{
+			{
+				Name:    name,
+				Version: version,
+				Regions: []aws.RegionAMIMapping{{Name: region, AMI: ami}},
+			},
+		},
+	}
+}
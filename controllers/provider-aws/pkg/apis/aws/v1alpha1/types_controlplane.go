@@ -28,9 +28,60 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+
+	// CSI contains configuration settings for the CSI driver.
+	// +optional
+	CSI *CSIConfig `json:"csi,omitempty"`
+
+	// KMS contains configuration settings for the encryption of secrets with an AWS KMS key.
+	// +optional
+	KMS *KMSConfig `json:"kms,omitempty"`
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	gardenv1beta1.KubernetesConfig `json:",inline"`
+
+	// LoadBalancer contains configuration settings for the load balancers of services of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig `json:"loadBalancer,omitempty"`
+
+	// Route contains configuration settings for the routes to the pod networks of the nodes.
+	// +optional
+	Route *RouteConfig `json:"route,omitempty"`
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// SecurityGroupID is the ID of an existing security group that is attached to all ELBs instead of creating a
+	// security group per ELB.
+	// +optional
+	SecurityGroupID *string `json:"securityGroupID,omitempty"`
+}
+
+// RouteConfig contains configuration settings for the routes to the pod networks of the nodes.
+type RouteConfig struct {
+	// RouteTableID is the ID of the route table in which the cloud-controller-manager creates a route to the pod
+	// network of each node. The cloud-controller-manager only creates routes if it is set.
+	RouteTableID string `json:"routeTableID"`
+}
+
+// CSIConfig contains configuration settings for the CSI driver.
+type CSIConfig struct {
+	// Enabled deploys the CSI driver and the `default-csi` StorageClass. It is only deployed for shoots with
+	// Kubernetes version 1.14 or higher. Existing persistent volumes keep using the in-tree volume plugin unless
+	// MigrateVolumes is set.
+	Enabled bool `json:"enabled"`
+	// MigrateVolumes enables the `CSIMigration` and `CSIMigrationAWS` feature gates of the kube-controller-manager and the
+	// kubelets, so that the existing persistent volumes of the in-tree volume plugin are handled by the CSI driver.
+	// It requires Enabled and is only applied for shoots with Kubernetes version 1.14 or higher.
+	// +optional
+	MigrateVolumes bool `json:"migrateVolumes,omitempty"`
+}
+
+// KMSConfig contains configuration settings for the encryption of secrets with an AWS KMS key.
+type KMSConfig struct {
+	// KeyARN is the ARN of the customer master key that secrets are encrypted with. The key must be in the region
+	// of the shoot, and the credentials of the shoot must be allowed to use it.
+	KeyARN string `json:"keyARN"`
 }
@@ -37,6 +37,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CSIConfig)(nil), (*aws.CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CSIConfig_To_aws_CSIConfig(a.(*CSIConfig), b.(*aws.CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.CSIConfig)(nil), (*CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_CSIConfig_To_v1alpha1_CSIConfig(a.(*aws.CSIConfig), b.(*CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*aws.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CloudControllerManagerConfig_To_aws_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*aws.CloudControllerManagerConfig), scope)
 	}); err != nil {
@@ -47,6 +57,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*aws.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_aws_CloudProfileConfig(a.(*CloudProfileConfig), b.(*aws.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*aws.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*aws.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_aws_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*aws.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -107,6 +127,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMSConfig)(nil), (*aws.KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMSConfig_To_aws_KMSConfig(a.(*KMSConfig), b.(*aws.KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.KMSConfig)(nil), (*KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_KMSConfig_To_v1alpha1_KMSConfig(a.(*aws.KMSConfig), b.(*KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*aws.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_aws_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*aws.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*aws.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*aws.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_aws_MachineImage(a.(*MachineImage), b.(*aws.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_MachineImage_To_v1alpha1_MachineImage(a.(*aws.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Networks)(nil), (*aws.Networks)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Networks_To_aws_Networks(a.(*Networks), b.(*aws.Networks), scope)
 	}); err != nil {
@@ -117,6 +167,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RegionAMIMapping)(nil), (*aws.RegionAMIMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegionAMIMapping_To_aws_RegionAMIMapping(a.(*RegionAMIMapping), b.(*aws.RegionAMIMapping), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.RegionAMIMapping)(nil), (*RegionAMIMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_RegionAMIMapping_To_v1alpha1_RegionAMIMapping(a.(*aws.RegionAMIMapping), b.(*RegionAMIMapping), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Role)(nil), (*aws.Role)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Role_To_aws_Role(a.(*Role), b.(*aws.Role), scope)
 	}); err != nil {
@@ -127,6 +187,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RouteConfig)(nil), (*aws.RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RouteConfig_To_aws_RouteConfig(a.(*RouteConfig), b.(*aws.RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*aws.RouteConfig)(nil), (*RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_aws_RouteConfig_To_v1alpha1_RouteConfig(a.(*aws.RouteConfig), b.(*RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecurityGroup)(nil), (*aws.SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SecurityGroup_To_aws_SecurityGroup(a.(*SecurityGroup), b.(*aws.SecurityGroup), scope)
 	}); err != nil {
@@ -180,8 +250,32 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_CSIConfig_To_aws_CSIConfig(in *CSIConfig, out *aws.CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_v1alpha1_CSIConfig_To_aws_CSIConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CSIConfig_To_aws_CSIConfig(in *CSIConfig, out *aws.CSIConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CSIConfig_To_aws_CSIConfig(in, out, s)
+}
+
+func autoConvert_aws_CSIConfig_To_v1alpha1_CSIConfig(in *aws.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_aws_CSIConfig_To_v1alpha1_CSIConfig is an autogenerated conversion function.
+func Convert_aws_CSIConfig_To_v1alpha1_CSIConfig(in *aws.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	return autoConvert_aws_CSIConfig_To_v1alpha1_CSIConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_aws_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *aws.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*aws.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*aws.RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -192,6 +286,8 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_aws_CloudControllerManager
 
 func autoConvert_aws_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *aws.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -200,8 +296,30 @@ func Convert_aws_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManager
 	return autoConvert_aws_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_aws_CloudProfileConfig(in *CloudProfileConfig, out *aws.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]aws.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_aws_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_aws_CloudProfileConfig(in *CloudProfileConfig, out *aws.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_aws_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_aws_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *aws.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_aws_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_aws_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *aws.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_aws_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_aws_ControlPlaneConfig(in *ControlPlaneConfig, out *aws.ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*aws.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*aws.CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*aws.KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -212,6 +330,8 @@ func Convert_v1alpha1_ControlPlaneConfig_To_aws_ControlPlaneConfig(in *ControlPl
 
 func autoConvert_aws_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *aws.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -344,6 +464,70 @@ func Convert_aws_InstanceProfile_To_v1alpha1_InstanceProfile(in *aws.InstancePro
 	return autoConvert_aws_InstanceProfile_To_v1alpha1_InstanceProfile(in, out, s)
 }
 
+func autoConvert_v1alpha1_KMSConfig_To_aws_KMSConfig(in *KMSConfig, out *aws.KMSConfig, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_v1alpha1_KMSConfig_To_aws_KMSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KMSConfig_To_aws_KMSConfig(in *KMSConfig, out *aws.KMSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMSConfig_To_aws_KMSConfig(in, out, s)
+}
+
+func autoConvert_aws_KMSConfig_To_v1alpha1_KMSConfig(in *aws.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_aws_KMSConfig_To_v1alpha1_KMSConfig is an autogenerated conversion function.
+func Convert_aws_KMSConfig_To_v1alpha1_KMSConfig(in *aws.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	return autoConvert_aws_KMSConfig_To_v1alpha1_KMSConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerConfig_To_aws_LoadBalancerConfig(in *LoadBalancerConfig, out *aws.LoadBalancerConfig, s conversion.Scope) error {
+	out.SecurityGroupID = (*string)(unsafe.Pointer(in.SecurityGroupID))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_aws_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_aws_LoadBalancerConfig(in *LoadBalancerConfig, out *aws.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_aws_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_aws_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *aws.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.SecurityGroupID = (*string)(unsafe.Pointer(in.SecurityGroupID))
+	return nil
+}
+
+// Convert_aws_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_aws_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *aws.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_aws_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_aws_MachineImage(in *MachineImage, out *aws.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Regions = *(*[]aws.RegionAMIMapping)(unsafe.Pointer(&in.Regions))
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_aws_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_aws_MachineImage(in *MachineImage, out *aws.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_aws_MachineImage(in, out, s)
+}
+
+func autoConvert_aws_MachineImage_To_v1alpha1_MachineImage(in *aws.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Regions = *(*[]RegionAMIMapping)(unsafe.Pointer(&in.Regions))
+	return nil
+}
+
+// Convert_aws_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_aws_MachineImage_To_v1alpha1_MachineImage(in *aws.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_aws_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
+
 func autoConvert_v1alpha1_Networks_To_aws_Networks(in *Networks, out *aws.Networks, s conversion.Scope) error {
 	if err := Convert_v1alpha1_VPC_To_aws_VPC(&in.VPC, &out.VPC, s); err != nil {
 		return err
@@ -370,6 +554,28 @@ func Convert_aws_Networks_To_v1alpha1_Networks(in *aws.Networks, out *Networks,
 	return autoConvert_aws_Networks_To_v1alpha1_Networks(in, out, s)
 }
 
+func autoConvert_v1alpha1_RegionAMIMapping_To_aws_RegionAMIMapping(in *RegionAMIMapping, out *aws.RegionAMIMapping, s conversion.Scope) error {
+	out.Name = in.Name
+	out.AMI = in.AMI
+	return nil
+}
+
+// Convert_v1alpha1_RegionAMIMapping_To_aws_RegionAMIMapping is an autogenerated conversion function.
+func Convert_v1alpha1_RegionAMIMapping_To_aws_RegionAMIMapping(in *RegionAMIMapping, out *aws.RegionAMIMapping, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegionAMIMapping_To_aws_RegionAMIMapping(in, out, s)
+}
+
+func autoConvert_aws_RegionAMIMapping_To_v1alpha1_RegionAMIMapping(in *aws.RegionAMIMapping, out *RegionAMIMapping, s conversion.Scope) error {
+	out.Name = in.Name
+	out.AMI = in.AMI
+	return nil
+}
+
+// Convert_aws_RegionAMIMapping_To_v1alpha1_RegionAMIMapping is an autogenerated conversion function.
+func Convert_aws_RegionAMIMapping_To_v1alpha1_RegionAMIMapping(in *aws.RegionAMIMapping, out *RegionAMIMapping, s conversion.Scope) error {
+	return autoConvert_aws_RegionAMIMapping_To_v1alpha1_RegionAMIMapping(in, out, s)
+}
+
 func autoConvert_v1alpha1_Role_To_aws_Role(in *Role, out *aws.Role, s conversion.Scope) error {
 	out.Purpose = in.Purpose
 	out.ARN = in.ARN
@@ -392,6 +598,26 @@ func Convert_aws_Role_To_v1alpha1_Role(in *aws.Role, out *Role, s conversion.Sco
 	return autoConvert_aws_Role_To_v1alpha1_Role(in, out, s)
 }
 
+func autoConvert_v1alpha1_RouteConfig_To_aws_RouteConfig(in *RouteConfig, out *aws.RouteConfig, s conversion.Scope) error {
+	out.RouteTableID = in.RouteTableID
+	return nil
+}
+
+// Convert_v1alpha1_RouteConfig_To_aws_RouteConfig is an autogenerated conversion function.
+func Convert_v1alpha1_RouteConfig_To_aws_RouteConfig(in *RouteConfig, out *aws.RouteConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RouteConfig_To_aws_RouteConfig(in, out, s)
+}
+
+func autoConvert_aws_RouteConfig_To_v1alpha1_RouteConfig(in *aws.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	out.RouteTableID = in.RouteTableID
+	return nil
+}
+
+// Convert_aws_RouteConfig_To_v1alpha1_RouteConfig is an autogenerated conversion function.
+func Convert_aws_RouteConfig_To_v1alpha1_RouteConfig(in *aws.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	return autoConvert_aws_RouteConfig_To_v1alpha1_RouteConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_SecurityGroup_To_aws_SecurityGroup(in *SecurityGroup, out *aws.SecurityGroup, s conversion.Scope) error {
 	out.Purpose = in.Purpose
 	out.ID = in.ID
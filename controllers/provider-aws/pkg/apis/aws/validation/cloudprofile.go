@@ -0,0 +1,66 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateCloudProfileConfig validates a CloudProfileConfig object.
+func ValidateCloudProfileConfig(cloudProfileConfig *apisaws.CloudProfileConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var (
+		machineImagesPath = field.NewPath("machineImages")
+		machineImages     = map[string]bool{}
+	)
+
+	for i, machineImage := range cloudProfileConfig.MachineImages {
+		idxPath := machineImagesPath.Index(i)
+
+		if len(machineImage.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		}
+		if len(machineImage.Version) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("version"), "must provide a version"))
+		}
+
+		key := fmt.Sprintf("%s/%s", machineImage.Name, machineImage.Version)
+		if machineImages[key] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, key))
+		}
+		machineImages[key] = true
+
+		if len(machineImage.Regions) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("regions"), "must provide at least one region"))
+		}
+		for j, region := range machineImage.Regions {
+			regionPath := idxPath.Child("regions").Index(j)
+
+			if len(region.Name) == 0 {
+				allErrs = append(allErrs, field.Required(regionPath.Child("name"), "must provide a region name"))
+			}
+			if len(region.AMI) == 0 {
+				allErrs = append(allErrs, field.Required(regionPath.Child("ami"), "must provide an ami"))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var kmsKeyARNRegex = regexp.MustCompile(`^arn:aws(-cn|-us-gov)?:kms:[a-z0-9-]+:[0-9]{12}:key/[a-zA-Z0-9-]+$`)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisaws.ControlPlaneConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		loadBalancerPath := field.NewPath("cloudControllerManager", "loadBalancer")
+
+		if id := ccm.LoadBalancer.SecurityGroupID; id != nil && !strings.HasPrefix(*id, "sg-") {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPath.Child("securityGroupID"), *id, "must be the ID of a security group"))
+		}
+	}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.Route != nil && !strings.HasPrefix(ccm.Route.RouteTableID, "rtb-") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("cloudControllerManager", "route", "routeTableID"), ccm.Route.RouteTableID, "must be the ID of a route table"))
+	}
+
+	if kms := controlPlaneConfig.KMS; kms != nil && !kmsKeyARNRegex.MatchString(kms.KeyARN) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("kms", "keyARN"), kms.KeyARN, "must be the ARN of a KMS key"))
+	}
+
+	return allErrs
+}
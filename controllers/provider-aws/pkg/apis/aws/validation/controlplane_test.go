@@ -0,0 +1,90 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
+	. "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var controlPlaneConfig *apisaws.ControlPlaneConfig
+
+	BeforeEach(func() {
+		securityGroupID := "sg-1234"
+		controlPlaneConfig = &apisaws.ControlPlaneConfig{
+			CloudControllerManager: &apisaws.CloudControllerManagerConfig{
+				LoadBalancer: &apisaws.LoadBalancerConfig{
+					SecurityGroupID: &securityGroupID,
+				},
+				Route: &apisaws.RouteConfig{
+					RouteTableID: "rtb-1234",
+				},
+			},
+			KMS: &apisaws.KMSConfig{
+				KeyARN: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			},
+		}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(BeEmpty())
+		})
+
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControlPlaneConfig(&apisaws.ControlPlaneConfig{})).To(BeEmpty())
+		})
+
+		It("should forbid invalid security group IDs", func() {
+			securityGroupID := "vpc-1234"
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.SecurityGroupID = &securityGroupID
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.loadBalancer.securityGroupID"),
+				})),
+			))
+		})
+
+		It("should forbid invalid route table IDs", func() {
+			controlPlaneConfig.CloudControllerManager.Route.RouteTableID = "sg-1234"
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.route.routeTableID"),
+				})),
+			))
+		})
+
+		It("should forbid invalid KMS key ARNs", func() {
+			controlPlaneConfig.KMS.KeyARN = "arn:aws:kms:eu-west-1:123456789012:alias/my-key"
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("kms.keyARN"),
+				})),
+			))
+		})
+	})
+})
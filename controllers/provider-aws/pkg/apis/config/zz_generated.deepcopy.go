@@ -42,6 +42,8 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		}
 	}
 	in.ETCD.DeepCopyInto(&out.ETCD)
+	in.ClusterAutoscaler.DeepCopyInto(&out.ClusterAutoscaler)
+	in.MachineControllerManager.DeepCopyInto(&out.MachineControllerManager)
 	return
 }
 
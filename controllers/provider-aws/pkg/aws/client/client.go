@@ -49,6 +49,7 @@ func NewClient(accessKeyID, secretAccessKey, region string) (Interface, error) {
 		ELB: elb.New(s, config),
 		STS: sts.New(s, config),
 		S3:  s3.New(s, config),
+		KMS: NewKMS(s, config),
 	}, nil
 }
 
@@ -61,6 +62,16 @@ func (c *Client) GetAccountID(ctx context.Context) (string, error) {
 	return *getCallerIdentityOutput.Account, nil
 }
 
+// EncryptWithKMSKey encrypts the given <plaintext> with the KMS key with the given ID or ARN <keyID> and returns the
+// ciphertext.
+func (c *Client) EncryptWithKMSKey(ctx context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	encryptOutput, err := c.KMS.EncryptWithContext(ctx, &EncryptInput{KeyId: aws.String(keyID), Plaintext: plaintext})
+	if err != nil {
+		return nil, err
+	}
+	return encryptOutput.CiphertextBlob, nil
+}
+
 // GetInternetGateway returns the ID of the internet gateway attached to the given VPC <vpcID>.
 // If there is no internet gateway attached, the returned string will be empty.
 func (c *Client) GetInternetGateway(ctx context.Context, vpcID string) (string, error) {
@@ -0,0 +1,141 @@
+// Copyright (c) 2018 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+// The vendored AWS SDK does not contain the KMS service, hence the few KMS operations that are needed are
+// implemented here with the JSON 1.1 protocol of the KMS API.
+const (
+	kmsServiceName  = "kms"
+	kmsAPIVersion   = "2014-11-01"
+	kmsJSONVersion  = "1.1"
+	kmsTargetPrefix = "TrentService"
+
+	opEncrypt = "Encrypt"
+)
+
+// KMSAPI is the interface of the KMS operations that are needed.
+type KMSAPI interface {
+	EncryptWithContext(ctx aws.Context, input *EncryptInput, opts ...request.Option) (*EncryptOutput, error)
+}
+
+// KMS is a client for the KMS service.
+type KMS struct {
+	*client.Client
+}
+
+// EncryptInput is the input of the KMS Encrypt operation.
+type EncryptInput struct {
+	// KeyId is the ID, ARN, alias name or alias ARN of the key.
+	KeyId *string `json:"KeyId"`
+	// Plaintext is the data to encrypt.
+	Plaintext []byte `json:"Plaintext"`
+}
+
+// EncryptOutput is the output of the KMS Encrypt operation.
+type EncryptOutput struct {
+	// CiphertextBlob is the encrypted data.
+	CiphertextBlob []byte `json:"CiphertextBlob"`
+	// KeyId is the ARN of the key that encrypted the data.
+	KeyId *string `json:"KeyId"`
+}
+
+// NewKMS creates a new KMS client for the given config provider (e.g., a session) and configs.
+func NewKMS(p client.ConfigProvider, cfgs ...*aws.Config) *KMS {
+	c := p.ClientConfig(kmsServiceName, cfgs...)
+	svc := &KMS{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   kmsServiceName,
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    kmsAPIVersion,
+				JSONVersion:   kmsJSONVersion,
+				TargetPrefix:  kmsTargetPrefix,
+			},
+			c.Handlers,
+		),
+	}
+
+	svc.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	svc.Handlers.Build.PushBackNamed(request.NamedHandler{Name: "kms.BuildHandler", Fn: buildJSON})
+	svc.Handlers.Unmarshal.PushBackNamed(request.NamedHandler{Name: "kms.UnmarshalHandler", Fn: unmarshalJSON})
+	svc.Handlers.UnmarshalMeta.PushBackNamed(request.NamedHandler{Name: "kms.UnmarshalMetaHandler", Fn: unmarshalMeta})
+	svc.Handlers.UnmarshalError.PushBackNamed(request.NamedHandler{Name: "kms.UnmarshalErrorHandler", Fn: unmarshalJSONError})
+
+	return svc
+}
+
+// EncryptWithContext encrypts the given plaintext with the given KMS key.
+func (c *KMS) EncryptWithContext(ctx aws.Context, input *EncryptInput, opts ...request.Option) (*EncryptOutput, error) {
+	output := &EncryptOutput{}
+	req := c.NewRequest(&request.Operation{Name: opEncrypt, HTTPMethod: "POST", HTTPPath: "/"}, input, output)
+	req.SetContext(ctx)
+	req.ApplyOptions(opts...)
+	return output, req.Send()
+}
+
+func buildJSON(r *request.Request) {
+	body, err := json.Marshal(r.Params)
+	if err != nil {
+		r.Error = awserr.New("SerializationError", "failed to encode JSON input", err)
+		return
+	}
+
+	r.SetBufferBody(body)
+	r.HTTPRequest.Header.Set("Content-Type", "application/x-amz-json-"+r.ClientInfo.JSONVersion)
+	r.HTTPRequest.Header.Set("X-Amz-Target", r.ClientInfo.TargetPrefix+"."+r.Operation.Name)
+}
+
+func unmarshalJSON(r *request.Request) {
+	defer r.HTTPResponse.Body.Close()
+	if err := json.NewDecoder(r.HTTPResponse.Body).Decode(r.Data); err != nil {
+		r.Error = awserr.New("SerializationError", "failed to decode JSON output", err)
+	}
+}
+
+func unmarshalMeta(r *request.Request) {
+	r.RequestID = r.HTTPResponse.Header.Get("X-Amzn-Requestid")
+}
+
+func unmarshalJSONError(r *request.Request) {
+	defer r.HTTPResponse.Body.Close()
+
+	var jsonErr struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(r.HTTPResponse.Body).Decode(&jsonErr); err != nil {
+		r.Error = awserr.NewRequestFailure(awserr.New("SerializationError", "failed to decode JSON error", err), r.HTTPResponse.StatusCode, r.RequestID)
+		return
+	}
+
+	// The type may be prefixed with a namespace, e.g. "com.amazonaws.kms#NotFoundException"
+	code := jsonErr.Type[strings.LastIndex(jsonErr.Type, "#")+1:]
+	r.Error = awserr.NewRequestFailure(awserr.New(code, jsonErr.Message, nil), r.HTTPResponse.StatusCode, r.RequestID)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws/client"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const keyARN = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+var _ = Describe("KMS", func() {
+	var (
+		server  *httptest.Server
+		handler http.HandlerFunc
+		c       *Client
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r)
+		}))
+
+		s, err := session.NewSession(&aws.Config{
+			Credentials: credentials.NewStaticCredentials("access-key-id", "secret-access-key", ""),
+			Endpoint:    aws.String(server.URL),
+			Region:      aws.String("eu-west-1"),
+			MaxRetries:  aws.Int(0),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		c = &Client{KMS: NewKMS(s)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#EncryptWithKMSKey", func() {
+		It("should return the ciphertext", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("X-Amz-Target")).To(Equal("TrentService.Encrypt"))
+				Expect(r.Header.Get("Content-Type")).To(Equal("application/x-amz-json-1.1"))
+				Expect(r.Header.Get("Authorization")).To(ContainSubstring("Credential=access-key-id/"))
+
+				input := map[string]string{}
+				Expect(json.NewDecoder(r.Body).Decode(&input)).To(Succeed())
+				Expect(input).To(Equal(map[string]string{"KeyId": keyARN, "Plaintext": "cGxhaW50ZXh0"}))
+
+				w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+				_, _ = w.Write([]byte(`{"CiphertextBlob":"Y2lwaGVydGV4dA==","KeyId":"` + keyARN + `"}`))
+			}
+
+			Expect(c.EncryptWithKMSKey(context.TODO(), keyARN, []byte("plaintext"))).To(Equal([]byte("ciphertext")))
+		})
+
+		It("should return the error of the KMS", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type":"com.amazonaws.kms#AccessDeniedException","message":"not allowed"}`))
+			}
+
+			_, err := c.EncryptWithKMSKey(context.TODO(), keyARN, []byte("plaintext"))
+			Expect(err).To(HaveOccurred())
+			awsErr, ok := err.(awserr.RequestFailure)
+			Expect(ok).To(BeTrue())
+			Expect(awsErr.Code()).To(Equal("AccessDeniedException"))
+			Expect(awsErr.Message()).To(Equal("not allowed"))
+			Expect(awsErr.StatusCode()).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
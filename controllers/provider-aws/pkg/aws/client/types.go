@@ -38,6 +38,7 @@ const (
 type Interface interface {
 	GetAccountID(ctx context.Context) (string, error)
 	GetInternetGateway(ctx context.Context, vpcID string) (string, error)
+	EncryptWithKMSKey(ctx context.Context, keyID string, plaintext []byte) ([]byte, error)
 
 	// S3 wrappers
 	DeleteObjectsWithPrefix(ctx context.Context, bucket, prefix string) error
@@ -56,9 +57,11 @@ type Interface interface {
 // * ELB is the standard client for the ELB service.
 // * STS is the standard client for the STS service.
 // * S3 is the standard client for the S3 service.
+// * KMS is the client for the KMS service.
 type Client struct {
 	EC2 ec2iface.EC2API
 	ELB elbiface.ELBAPI
 	STS stsiface.STSAPI
 	S3  s3iface.S3API
+	KMS KMSAPI
 }
@@ -28,8 +28,18 @@ const (
 	TerraformerImageName = "terraformer"
 	// HyperkubeImageName is the name of the hyperkube image.
 	HyperkubeImageName = "hyperkube"
+	// CSIDriverImageName is the name of the AWS EBS CSI driver image.
+	CSIDriverImageName = "aws-ebs-csi-driver"
+	// CSIAttacherImageName is the name of the CSI attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIProvisionerImageName is the name of the CSI provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSINodeDriverRegistrarImageName is the name of the CSI node driver registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
 	// ETCDBackupRestoreImageName is the name of the etcd backup and restore image.
 	ETCDBackupRestoreImageName = "etcd-backup-restore"
+	// KMSPluginImageName is the name of the AWS encryption provider (KMS plugin) image.
+	KMSPluginImageName = "aws-encryption-provider"
 
 	// AccessKeyID is a constant for the key in a cloud provider secret and backup secret that holds the AWS access key id.
 	AccessKeyID = "accessKeyID"
@@ -68,6 +78,11 @@ const (
 	MachineControllerManagerName = "machine-controller-manager"
 	// BackupSecretName is the name of the secret containing the credentials for storing the backups of Shoot clusters.
 	BackupSecretName = "etcd-backup"
+
+	// CSIMigrationFeatureGate is the feature gate that migrates the volumes of the in-tree AWS EBS volume plugin to the CSI driver.
+	CSIMigrationFeatureGate = "CSIMigrationAWS"
+	// CSIMigrationMinimumKubernetesVersion is the minimum Kubernetes version that supports the CSIMigrationFeatureGate.
+	CSIMigrationMinimumKubernetesVersion = "1.14"
 )
 
 var (
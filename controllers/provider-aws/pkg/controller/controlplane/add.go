@@ -20,6 +20,7 @@ import (
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
 	"github.com/gardener/gardener-extensions/pkg/util"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -29,6 +30,16 @@ import (
 var (
 	// Options are the default controller.Options for AddToManager.
 	Options = controller.Options{}
+	// RotatingSecrets are the controlplane secrets that are renewed before they expire and rotated on demand.
+	RotatingSecrets = util.NewRotatingSecrets(controlPlaneSecrets, util.DefaultCertificateRenewalWindow, util.DefaultCABundleDuration)
+	// ResyncInterval is the interval after which the controlplane charts are applied again even if they have not changed.
+	ResyncInterval = controlplane.DefaultResyncInterval
+	// ManagedResourceDeletionTimeout is the duration to wait for the managed resource of a controlplane to be deleted.
+	ManagedResourceDeletionTimeout = controlplane.DefaultManagedResourceDeletionTimeout
+	// OrphanPolicy determines what happens if the managed resource of a controlplane has not been deleted in time.
+	OrphanPolicy = controlplane.OrphanPolicyNever
+	// ShootWebhookConfig is the configuration of the shoot webhooks that are registered in the shoot clusters.
+	ShootWebhookConfig *extensionswebhook.ShootWebhookConfig
 
 	logger = log.Log.WithName("aws-controlplane-controller")
 )
@@ -37,10 +48,12 @@ var (
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, opts controller.Options) error {
 	return controlplane.Add(mgr, controlplane.AddArgs{
-		Actuator: genericactuator.NewActuator(controlPlaneSecrets, configChart, ccmChart, ccmShootChart,
+		Actuator: genericactuator.NewActuator(RotatingSecrets, configChart, controlPlaneChart, controlPlaneShootChart,
 			NewValuesProvider(logger), genericactuator.ChartRendererFactoryFunc(util.NewChartRendererForShoot),
-			imagevector.ImageVector(), aws.CloudProviderConfigName, logger),
+			imagevector.ImageVector(), aws.CloudProviderConfigName, ResyncInterval,
+			genericactuator.NewLoadBalancerServicesDeletionHook(logger), ManagedResourceDeletionTimeout, OrphanPolicy, ShootWebhookConfig, logger),
 		Type:              aws.Type,
+		ResyncInterval:    ResyncInterval,
 		ControllerOptions: opts,
 	})
 }
@@ -16,11 +16,14 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/helper"
+	awsvalidation "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/validation"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
+	awsclient "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws/client"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
@@ -38,16 +41,22 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // Object names
 const (
 	cloudControllerManagerDeploymentName = "cloud-controller-manager"
 	cloudControllerManagerServerName     = "cloud-controller-manager-server"
+	csiControllerDeploymentName          = "csi-driver-controller"
+	csiAttacherName                      = "csi-attacher"
+	csiProvisionerName                   = "csi-provisioner"
 )
 
 var controlPlaneSecrets = &secrets.Secrets{
@@ -82,11 +91,37 @@ var controlPlaneSecrets = &secrets.Secrets{
 					SigningCA:  cas[gardencorev1alpha1.SecretNameCACluster],
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiAttacherName,
+					CommonName:   "system:csi-attacher",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiProvisionerName,
+					CommonName:   "system:csi-provisioner",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
 		}
 	},
 }
 
-var configChart = &chart.Chart{
+var configChart = util.NewChart(&chart.Chart{
 	Name: "cloud-provider-config",
 	Path: filepath.Join(aws.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
@@ -95,38 +130,70 @@ var configChart = &chart.Chart{
 			Name: aws.CloudProviderConfigName,
 		},
 	},
-}
+})
 
-var ccmChart = &chart.Chart{
-	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(aws.InternalChartsPath, "cloud-controller-manager"),
-	Images: []string{aws.HyperkubeImageName},
-	Objects: []*chart.Object{
-		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
-		{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+var controlPlaneChart = util.NewChart(&chart.Chart{
+	Name: "seed-controlplane",
+	Path: filepath.Join(aws.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{
+		{
+			Name:   "cloud-controller-manager",
+			Images: []string{aws.HyperkubeImageName},
+			Objects: []*chart.Object{
+				{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
+				{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+			},
+		},
+		{
+			Name:   "csi-aws",
+			Images: []string{aws.CSIDriverImageName, aws.CSIAttacherImageName, aws.CSIProvisionerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: csiControllerDeploymentName},
+			},
+		},
 	},
-}
+})
 
-var ccmShootChart = &chart.Chart{
-	Name: "cloud-controller-manager-shoot",
-	Path: filepath.Join(aws.InternalChartsPath, "cloud-controller-manager-shoot"),
-	Objects: []*chart.Object{
-		{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
-		{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
+	Name: "shoot-system-components",
+	Path: filepath.Join(aws.InternalChartsPath, "shoot-system-components"),
+	SubCharts: []*chart.Chart{
+		{
+			Name: "cloud-controller-manager",
+			Objects: []*chart.Object{
+				{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+			},
+		},
+		{
+			Name:   "csi-aws",
+			Images: []string{aws.CSIDriverImageName, aws.CSINodeDriverRegistrarImageName},
+			Objects: []*chart.Object{
+				{Type: &storagev1.StorageClass{}, Name: "default-csi"},
+				{Type: &appsv1.DaemonSet{}, Name: "csi-driver-node"},
+				{Type: &corev1.ServiceAccount{}, Name: "csi-driver-node"},
+				{Type: &policyv1beta1.PodSecurityPolicy{}, Name: "gardener.kube-system.csi-driver-node"},
+				{Type: &rbacv1.ClusterRole{}, Name: "garden.sapcloud.io:psp:kube-system:csi-driver-node"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "garden.sapcloud.io:psp:csi-driver-node"},
+			},
+		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
 	return &valuesProvider{
-		logger: logger.WithName("aws-values-provider"),
+		logger:    logger.WithName("aws-values-provider"),
+		newClient: awsclient.NewClient,
 	}
 }
 
 // valuesProvider is a ValuesProvider that provides AWS-specific values for the 2 charts applied by the generic actuator.
 type valuesProvider struct {
-	decoder runtime.Decoder
-	logger  logr.Logger
+	decoder   runtime.Decoder
+	client    client.Client
+	logger    logr.Logger
+	newClient func(accessKeyID, secretAccessKey, region string) (awsclient.Interface, error)
 }
 
 // InjectScheme injects the given scheme into the valuesProvider.
@@ -135,12 +202,27 @@ func (vp *valuesProvider) InjectScheme(scheme *runtime.Scheme) error {
 	return nil
 }
 
+// InjectClient injects the given client into the valuesProvider.
+func (vp *valuesProvider) InjectClient(client client.Client) error {
+	vp.client = client
+	return nil
+}
+
 // GetConfigChartValues returns the values for the config chart applied by the generic actuator.
 func (vp *valuesProvider) GetConfigChartValues(
 	ctx context.Context,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) (map[string]interface{}, error) {
+	// Decode providerConfig
+	cpConfig := &apisaws.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if errs := awsvalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+
 	// Decode infrastructureProviderStatus
 	infraStatus := &apisaws.InfrastructureStatus{}
 	if _, _, err := vp.decoder.Decode(cp.Spec.InfrastructureProviderStatus.Raw, nil, infraStatus); err != nil {
@@ -148,7 +230,7 @@ func (vp *valuesProvider) GetConfigChartValues(
 	}
 
 	// Get config chart values
-	return getConfigChartValues(infraStatus, cp)
+	return getConfigChartValues(cpConfig, infraStatus, cp)
 }
 
 // GetControlPlaneChartValues returns the values for the control plane chart applied by the generic actuator.
@@ -165,8 +247,8 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// Get control plane chart values
+	return getControlPlaneChartValues(cpConfig, cp, cluster, checksums, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
@@ -175,11 +257,68 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) (map[string]interface{}, error) {
-	return nil, nil
+	// Decode providerConfig
+	cpConfig := &apisaws.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	// Get control plane shoot chart values
+	return getControlPlaneShootChartValues(cpConfig, cluster)
+}
+
+// GetKMSKeyID returns the ARN of the KMS key that the secrets of the shoot are encrypted with, or nil if the shoot
+// doesn't use one. The key must be in the region of the shoot, and the credentials of the shoot must be allowed to
+// encrypt with it, which is checked with a test encryption before the KMS plugin of kube-apiserver uses the key.
+func (vp *valuesProvider) GetKMSKeyID(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (*string, error) {
+	// Decode providerConfig
+	cpConfig := &apisaws.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if errs := awsvalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if cpConfig.KMS == nil {
+		return nil, nil
+	}
+
+	// The KMS plugin uses the credentials of the provider secret against the KMS endpoint of the shoot's region
+	keyARN := cpConfig.KMS.KeyARN
+	region, err := helper.KMSKeyRegion(keyARN)
+	if err != nil {
+		return nil, err
+	}
+	if region != cp.Spec.Region {
+		return nil, fmt.Errorf("KMS key '%s' of controlplane '%s' is not in region '%s'", keyARN, util.ObjectName(cp), cp.Spec.Region)
+	}
+	secret, err := extensionscontroller.GetSecretByReference(ctx, vp.client, &cp.Spec.SecretRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get secret of controlplane '%s'", util.ObjectName(cp))
+	}
+	credentials, err := aws.ReadCredentialsSecret(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read credentials from secret of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	awsClient, err := vp.newClient(string(credentials.AccessKeyID), string(credentials.SecretAccessKey), region)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create AWS client for controlplane '%s'", util.ObjectName(cp))
+	}
+	if _, err := awsClient.EncryptWithKMSKey(ctx, keyARN, []byte(controlplane.KMSKeyCheckPlaintext)); err != nil {
+		return nil, errors.Wrapf(err, "could not encrypt with KMS key '%s' of controlplane '%s'", keyARN, util.ObjectName(cp))
+	}
+
+	return &keyARN, nil
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
 func getConfigChartValues(
+	cpConfig *apisaws.ControlPlaneConfig,
 	infraStatus *apisaws.InfrastructureStatus,
 	cp *extensionsv1alpha1.ControlPlane,
 ) (map[string]interface{}, error) {
@@ -190,11 +329,44 @@ func getConfigChartValues(
 	}
 
 	// Collect config chart values
-	return map[string]interface{}{
+	values := map[string]interface{}{
 		"vpcID":       infraStatus.VPC.ID,
 		"subnetID":    subnet.ID,
 		"clusterName": cp.Namespace,
 		"zone":        subnet.Zone,
+	}
+
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil && ccm.LoadBalancer.SecurityGroupID != nil {
+		values["elbSecurityGroup"] = *ccm.LoadBalancer.SecurityGroupID
+	}
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.Route != nil {
+		values["routeTableID"] = ccm.Route.RouteTableID
+	}
+
+	return values, nil
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *apisaws.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cloud-controller-manager": ccm,
+		"csi-aws":                  csi,
 	}, nil
 }
 
@@ -219,11 +391,65 @@ func getCCMChartValues(
 			"checksum/secret-cloudprovider":            checksums[common.CloudProviderSecretName],
 			"checksum/configmap-cloud-provider-config": checksums[aws.CloudProviderConfigName],
 		},
+		"configureCloudRoutes": false,
 	}
 
 	if cpConfig.CloudControllerManager != nil {
 		values["featureGates"] = cpConfig.CloudControllerManager.FeatureGates
+		values["configureCloudRoutes"] = cpConfig.CloudControllerManager.Route != nil
 	}
 
 	return values, nil
 }
+
+// getCSIChartValues collects and returns the CSI chart values.
+func getCSIChartValues(
+	cpConfig *apisaws.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"enabled":           csiEnabled,
+		"replicas":          extensionscontroller.GetControlPlaneReplicas(cluster.Shoot, scaledDown, 1),
+		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
+		"region":            cp.Spec.Region,
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-csi-attacher":    checksums[csiAttacherName],
+			"checksum/secret-csi-provisioner": checksums[csiProvisionerName],
+			"checksum/secret-cloudprovider":   checksums[common.CloudProviderSecretName],
+		},
+	}, nil
+}
+
+// getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
+func getControlPlaneShootChartValues(
+	cpConfig *apisaws.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"csi-aws": map[string]interface{}{
+			"enabled": csiEnabled,
+		},
+	}, nil
+}
+
+// isCSIEnabled returns true if the shoot opted in for the CSI driver and has a Kubernetes version that supports it.
+func isCSIEnabled(cpConfig *apisaws.ControlPlaneConfig, cluster *extensionscontroller.Cluster) (bool, error) {
+	csiEnabled, err := controlplane.IsCSIEnabled(cpConfig.CSI != nil && cpConfig.CSI.Enabled, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not check whether the CSI driver is enabled for Kubernetes version '%s'", cluster.Shoot.Spec.Kubernetes.Version)
+	}
+	return csiEnabled, nil
+}
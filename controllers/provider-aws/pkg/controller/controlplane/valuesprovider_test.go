@@ -17,10 +17,16 @@ package controlplane
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
+	awsclient "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws/client"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -32,15 +38,18 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
 const (
 	namespace = "test"
+	keyARN    = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
 )
 
 var _ = Describe("ValuesProvider", func() {
@@ -57,6 +66,7 @@ var _ = Describe("ValuesProvider", func() {
 				Namespace: namespace,
 			},
 			Spec: extensionsv1alpha1.ControlPlaneSpec{
+				Region: "eu-west-1",
 				ProviderConfig: &runtime.RawExtension{
 					Raw: encode(&apisaws.ControlPlaneConfig{
 						CloudControllerManager: &apisaws.CloudControllerManagerConfig{
@@ -65,6 +75,12 @@ var _ = Describe("ValuesProvider", func() {
 									"CustomResourceValidation": true,
 								},
 							},
+							LoadBalancer: &apisaws.LoadBalancerConfig{
+								SecurityGroupID: util.StringPtr("sg-1234"),
+							},
+							Route: &apisaws.RouteConfig{
+								RouteTableID: "rtb-1234",
+							},
 						},
 					}),
 				},
@@ -110,13 +126,17 @@ var _ = Describe("ValuesProvider", func() {
 			aws.CloudProviderConfigName:       "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":        "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server": "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			"csi-attacher":                    "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+			"csi-provisioner":                 "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
 		}
 
 		configChartValues = map[string]interface{}{
-			"vpcID":       "vpc-1234",
-			"subnetID":    "subnet-acbd1234",
-			"clusterName": namespace,
-			"zone":        "eu-west-1a",
+			"vpcID":            "vpc-1234",
+			"subnetID":         "subnet-acbd1234",
+			"clusterName":      namespace,
+			"zone":             "eu-west-1a",
+			"elbSecurityGroup": "sg-1234",
+			"routeTableID":     "rtb-1234",
 		}
 
 		ccmChartValues = map[string]interface{}{
@@ -133,6 +153,19 @@ var _ = Describe("ValuesProvider", func() {
 			"featureGates": map[string]bool{
 				"CustomResourceValidation": true,
 			},
+			"configureCloudRoutes": true,
+		}
+
+		csiChartValues = map[string]interface{}{
+			"enabled":           false,
+			"replicas":          1,
+			"kubernetesVersion": "1.13.4",
+			"region":            "eu-west-1",
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-csi-attacher":    "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+				"checksum/secret-csi-provisioner": "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
+				"checksum/secret-cloudprovider":   "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
+			},
 		}
 
 		logger = log.Log.WithName("test")
@@ -158,6 +191,28 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(configChartValues))
 		})
+
+		It("should fail for an invalid providerConfig", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			invalidCP := cp.DeepCopy()
+			invalidCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisaws.ControlPlaneConfig{
+					CloudControllerManager: &apisaws.CloudControllerManagerConfig{
+						LoadBalancer: &apisaws.LoadBalancerConfig{
+							SecurityGroupID: util.StringPtr("vpc-1234"),
+						},
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			_, err = vp.GetConfigChartValues(context.TODO(), invalidCP, cluster)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("#GetControlPlaneChartValues", func() {
@@ -170,7 +225,208 @@ var _ = Describe("ValuesProvider", func() {
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(map[string]interface{}{
+				"cloud-controller-manager": ccmChartValues,
+				"csi-aws":                  csiChartValues,
+			}))
+		})
+	})
+
+	Describe("#GetControlPlaneShootChartValues", func() {
+		var (
+			csiCP      *extensionsv1alpha1.ControlPlane
+			csiCluster *extensionscontroller.Cluster
+		)
+
+		BeforeEach(func() {
+			csiCP = cp.DeepCopy()
+			csiCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisaws.ControlPlaneConfig{
+					CSI: &apisaws.CSIConfig{
+						Enabled: true,
+					},
+				}),
+			}
+
+			csiCluster = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.14.3"
+		})
+
+		It("should return correct control plane shoot chart values", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-aws": map[string]interface{}{
+					"enabled": false,
+				},
+			}))
+		})
+
+		It("should enable the CSI driver if opted in", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), csiCP, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-aws": map[string]interface{}{
+					"enabled": true,
+				},
+			}))
+		})
+
+		It("should not enable the CSI driver for Kubernetes versions below 1.14", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), csiCP, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-aws": map[string]interface{}{
+					"enabled": false,
+				},
+			}))
+		})
+	})
+
+	Describe("#GetKMSKeyID", func() {
+		var (
+			kmsCP *extensionsv1alpha1.ControlPlane
+
+			secretKey = client.ObjectKey{Namespace: namespace, Name: common.CloudProviderSecretName}
+			secret    = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.CloudProviderSecretName},
+				Data: map[string][]byte{
+					aws.AccessKeyID:     []byte("access-key-id"),
+					aws.SecretAccessKey: []byte("secret-access-key"),
+				},
+			}
+		)
+
+		BeforeEach(func() {
+			kmsCP = cp.DeepCopy()
+			kmsCP.Spec.SecretRef = corev1.SecretReference{Namespace: namespace, Name: common.CloudProviderSecretName}
+			kmsCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisaws.ControlPlaneConfig{
+					KMS: &apisaws.KMSConfig{
+						KeyARN: keyARN,
+					},
+				}),
+			}
+		})
+
+		It("should return nil if no KMS key is configured", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			keyID, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyID).To(BeNil())
+		})
+
+		It("should return the ARN of the configured KMS key", func() {
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret) error {
+					*obj = *secret
+					return nil
+				})
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(c)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newClient = newFakeKMSClient(nil)
+
+			// Call GetKMSKeyID method and check the result
+			keyID, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyID).To(Equal(util.StringPtr(keyARN)))
+		})
+
+		It("should fail if the credentials of the shoot are not allowed to encrypt with the KMS key", func() {
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret) error {
+					*obj = *secret
+					return nil
+				})
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(c)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newClient = newFakeKMSClient(fmt.Errorf("AccessDeniedException"))
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(MatchError(ContainSubstring("AccessDeniedException")))
+		})
+
+		It("should fail if the KMS key is not in the region of the shoot", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			kmsCP.Spec.Region = "eu-central-1"
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the provider secret does not contain credentials", func() {
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj *corev1.Secret) error {
+					*obj = corev1.Secret{Data: map[string][]byte{aws.AccessKeyID: []byte("access-key-id")}}
+					return nil
+				})
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(c)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#RotatingSecrets", func() {
+		It("should not have any CA to roll, as the cluster CA is owned by the gardenlet", func() {
+			Expect(RotatingSecrets.RollableCAs()).To(BeEmpty())
+		})
+
+		It("should reject a CA roll", func() {
+			_, _, err := RotatingSecrets.DeployWithRotation(nil, nil, namespace, util.SecretsRotationCA)
+			Expect(err).To(MatchError(ContainSubstring("no CA is generated by the extension")))
 		})
 	})
 })
@@ -179,3 +435,24 @@ func encode(obj runtime.Object) []byte {
 	data, _ := json.Marshal(obj)
 	return data
 }
+
+// fakeKMSClient is an AWS client that only implements EncryptWithKMSKey.
+type fakeKMSClient struct {
+	awsclient.Interface
+	err error
+}
+
+func (c *fakeKMSClient) EncryptWithKMSKey(_ context.Context, keyID string, plaintext []byte) ([]byte, error) {
+	Expect(keyID).To(Equal(keyARN))
+	Expect(plaintext).To(Equal([]byte(controlplane.KMSKeyCheckPlaintext)))
+	return []byte("ciphertext"), c.err
+}
+
+func newFakeKMSClient(err error) func(string, string, string) (awsclient.Interface, error) {
+	return func(accessKeyID, secretAccessKey, region string) (awsclient.Interface, error) {
+		Expect(accessKeyID).To(Equal("access-key-id"))
+		Expect(secretAccessKey).To(Equal("secret-access-key"))
+		Expect(region).To(Equal("eu-west-1"))
+		return &fakeKMSClient{err: err}, nil
+	}
+}
@@ -16,10 +16,12 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/imagevector"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker/genericactuator"
@@ -51,7 +53,7 @@ type delegateFactory struct {
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled WorkerPoolConfigs.
-func NewActuator(machineImageToAMIMapping []config.MachineImage) worker.Actuator {
+func NewActuator(machineImageToAMIMapping []config.MachineImage, rolloutTimeout time.Duration, mcmConfig *extensionsconfig.MachineControllerManager) worker.Actuator {
 	delegateFactory := &delegateFactory{
 		logger:                   log.Log.WithName("worker-actuator"),
 		machineImageToAMIMapping: machineImageToAMIMapping,
@@ -63,6 +65,8 @@ func NewActuator(machineImageToAMIMapping []config.MachineImage) worker.Actuator
 		mcmChart,
 		mcmShootChart,
 		imagevector.ImageVector(),
+		rolloutTimeout,
+		mcmConfig,
 	)
 }
 
@@ -83,6 +87,10 @@ func (d *delegateFactory) InjectClient(client client.Client) error {
 }
 
 func (d *delegateFactory) WorkerDelegate(ctx context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (genericactuator.WorkerDelegate, error) {
+	if err := validateMachineImages(d.decoder, d.machineImageToAMIMapping, worker, cluster); err != nil {
+		return nil, err
+	}
+
 	clientset, err := kubernetes.NewForConfig(d.restConfig)
 	if err != nil {
 		return nil, err
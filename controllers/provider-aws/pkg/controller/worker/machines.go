@@ -21,7 +21,6 @@ import (
 
 	awsapi "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
 	awsapihelper "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/helper"
-	confighelper "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/config/helper"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
@@ -108,10 +107,15 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	findMachineImage, err := newMachineImageLookup(w.decoder, w.machineImageToAMIMapping, w.cluster, w.worker.Spec.Region)
+	if err != nil {
+		return err
+	}
+
 	for _, pool := range w.worker.Spec.Pools {
 		zoneLen := len(pool.Zones)
 
-		ami, err := confighelper.FindAMIForRegion(w.machineImageToAMIMapping, pool.MachineImage.Name, pool.MachineImage.Version, w.worker.Spec.Region)
+		ami, err := findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
 		}
@@ -164,6 +168,8 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 			machineDeployments = append(machineDeployments, worker.MachineDeployment{
 				Name:           deploymentName,
+				PoolName:       pool.Name,
+				Zone:           zone,
 				ClassName:      className,
 				SecretName:     className,
 				Minimum:        worker.DistributeOverZones(zoneIndex, pool.Minimum, zoneLen),
@@ -386,6 +386,8 @@ var _ = Describe("Machines", func() {
 				machineDeployments := worker.MachineDeployments{
 					{
 						Name:           machineClassNamePool1Zone1,
+						PoolName:       namePool1,
+						Zone:           zone1,
 						ClassName:      machineClassWithHashPool1Zone1,
 						SecretName:     machineClassWithHashPool1Zone1,
 						Minimum:        worker.DistributeOverZones(0, minPool1, 2),
@@ -395,6 +397,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool1Zone2,
+						PoolName:       namePool1,
+						Zone:           zone2,
 						ClassName:      machineClassWithHashPool1Zone2,
 						SecretName:     machineClassWithHashPool1Zone2,
 						Minimum:        worker.DistributeOverZones(1, minPool1, 2),
@@ -404,6 +408,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool2Zone1,
+						PoolName:       namePool2,
+						Zone:           zone1,
 						ClassName:      machineClassWithHashPool2Zone1,
 						SecretName:     machineClassWithHashPool2Zone1,
 						Minimum:        worker.DistributeOverZones(0, minPool2, 2),
@@ -413,6 +419,8 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool2Zone2,
+						PoolName:       namePool2,
+						Zone:           zone2,
 						ClassName:      machineClassWithHashPool2Zone2,
 						SecretName:     machineClassWithHashPool2Zone2,
 						Minimum:        worker.DistributeOverZones(1, minPool2, 2),
@@ -437,6 +445,15 @@ var _ = Describe("Machines", func() {
 				Expect(result).To(BeNil())
 			})
 
+			It("should fail because the provider config of the cloud profile is invalid", func() {
+				expectGetSecretCallToWork(c, awsAccessKeyID, awsSecretAccessKey)
+				cluster.CloudProfileConfig = []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","machineImages":[{"name":""}]}`)
+				workerDelegate = NewWorkerDelegate(c, decoder, machineImageToAMIMapping, chartApplier, "", w, cluster)
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
 			It("should fail because the version is invalid", func() {
 				expectGetSecretCallToWork(c, awsAccessKeyID, awsSecretAccessKey)
 
@@ -19,30 +19,45 @@ import (
 	"context"
 	"regexp"
 
+	apisaws "github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws"
+	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/helper"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	extensionscontrolplane "github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
 
 	"github.com/coreos/go-systemd/unit"
 	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(clusterAutoscaler *extensionsconfig.ClusterAutoscaler, imageVector imagevector.ImageVector, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		logger: logger.WithName("aws-controlplane-ensurer"),
+		clusterAutoscaler: clusterAutoscaler,
+		imageVector:       imageVector,
+		logger:            logger.WithName("aws-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	client client.Client
-	logger logr.Logger
+	clusterAutoscaler *extensionsconfig.ClusterAutoscaler
+	imageVector       imagevector.ImageVector
+	client            client.Client
+	decoder           runtime.Decoder
+	logger            logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -51,6 +66,12 @@ func (e *ensurer) InjectClient(client client.Client) error {
 	return nil
 }
 
+// InjectScheme injects the given scheme into the ensurer.
+func (e *ensurer) InjectScheme(scheme *runtime.Scheme) error {
+	e.decoder = serializer.NewCodecFactory(scheme).UniversalDecoder()
+	return nil
+}
+
 // EnsureKubeAPIServerDeployment ensures that the kube-apiserver deployment conforms to the provider requirements.
 func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
 	template := &dep.Spec.Template
@@ -61,15 +82,24 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, dep *appsv1
 		ensureVolumeMounts(c)
 	}
 	ensureVolumes(ps)
+	if err := controlplane.EnsureKMSPlugins(ctx, template, e.client, dep.Namespace, e.getKMSPluginContainer); err != nil {
+		return err
+	}
 	return e.ensureChecksumAnnotations(ctx, &dep.Spec.Template, dep.Namespace)
 }
 
 // EnsureKubeControllerManagerDeployment ensures that the kube-controller-manager deployment conforms to the provider requirements.
 func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	featureGates, err := e.csiMigrationFeatureGates(ctx, dep.Namespace)
+	if err != nil {
+		return err
+	}
+
 	template := &dep.Spec.Template
 	ps := &template.Spec
 	if c := controlplane.ContainerWithName(ps.Containers, "kube-controller-manager"); c != nil {
 		ensureKubeControllerManagerCommandLineArgs(c)
+		c.Command = controlplane.EnsureFeatureGatesFlag(c.Command, featureGates)
 		ensureEnvVars(c)
 		ensureVolumeMounts(c)
 	}
@@ -153,6 +183,32 @@ func ensureVolumes(ps *corev1.PodSpec) {
 	ps.Volumes = controlplane.EnsureVolumeWithName(ps.Volumes, cloudProviderConfigVolume)
 }
 
+func (e *ensurer) getKMSPluginContainer(name, keyARN, socketPath string) (*corev1.Container, error) {
+	// Find aws-encryption-provider image
+	image, err := e.imageVector.FindImage(aws.KMSPluginImageName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find image %s", aws.KMSPluginImageName)
+	}
+
+	// The plugin must talk to the KMS endpoint of the region the key is in
+	region, err := helper.KMSKeyRegion(keyARN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Container{
+		Name:  name,
+		Image: image.String(),
+		Command: []string{
+			"/aws-encryption-provider",
+			"--key=" + keyARN,
+			"--region=" + region,
+			"--listen=" + socketPath,
+		},
+		Env: []corev1.EnvVar{accessKeyIDEnvVar, secretAccessKeyEnvVar},
+	}, nil
+}
+
 func (e *ensurer) ensureChecksumAnnotations(ctx context.Context, template *corev1.PodTemplateSpec, namespace string) error {
 	if err := controlplane.EnsureSecretChecksumAnnotation(ctx, template, e.client, namespace, common.CloudProviderSecretName); err != nil {
 		return err
@@ -160,6 +216,12 @@ func (e *ensurer) ensureChecksumAnnotations(ctx context.Context, template *corev
 	return controlplane.EnsureConfigMapChecksumAnnotation(ctx, template, e.client, namespace, aws.CloudProviderConfigName)
 }
 
+// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+func (e *ensurer) EnsureClusterAutoscalerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	controlplane.EnsureClusterAutoscalerCommandLineArgs(dep, e.clusterAutoscaler)
+	return nil
+}
+
 // EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 func (e *ensurer) EnsureKubeletServiceUnitOptions(ctx context.Context, opts []*unit.UnitOption) ([]*unit.UnitOption, error) {
 	if opt := controlplane.UnitOptionWithSectionAndName(opts, "Service", "ExecStart"); opt != nil {
@@ -175,16 +237,54 @@ func ensureKubeletCommandLineArgs(command []string) []string {
 	return command
 }
 
-// EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) error {
+// EnsureKubeletConfiguration ensures that the kubelet configuration of the shoot in the given namespace conforms to
+// the provider requirements.
+func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, namespace string) error {
 	// Make sure CSI-related feature gates are not enabled
 	// TODO Leaving these enabled shouldn't do any harm, perhaps remove this code when properly tested?
 	delete(kubeletConfig.FeatureGates, "VolumeSnapshotDataSource")
 	delete(kubeletConfig.FeatureGates, "CSINodeInfo")
 	delete(kubeletConfig.FeatureGates, "CSIDriverRegistry")
+
+	// Migrate the volumes of the in-tree volume plugin to the CSI driver if the shoot opted in for it
+	featureGates, err := e.csiMigrationFeatureGates(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	controlplane.EnsureKubeletFeatureGates(kubeletConfig, featureGates)
 	return nil
 }
 
+// csiMigrationFeatureGates returns the feature gates that migrate the volumes of the in-tree volume plugin to the CSI
+// driver if the shoot in the given namespace opted in for it and its Kubernetes version supports it, nil otherwise.
+func (e *ensurer) csiMigrationFeatureGates(ctx context.Context, namespace string) (map[string]bool, error) {
+	cp, err := controlplane.GetControlPlane(ctx, e.client, namespace, aws.Type)
+	if err != nil || cp == nil || cp.Spec.ProviderConfig == nil {
+		return nil, err
+	}
+	cpConfig := &apisaws.ControlPlaneConfig{}
+	if _, _, err := e.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if cpConfig.CSI == nil || !cpConfig.CSI.MigrateVolumes {
+		return nil, nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, e.client, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get cluster for namespace '%s'", namespace)
+	}
+	version := cluster.Shoot.Spec.Kubernetes.Version
+	migrationEnabled, err := extensionscontrolplane.IsCSIMigrationEnabled(cpConfig.CSI.Enabled, cpConfig.CSI.MigrateVolumes, version, aws.CSIMigrationMinimumKubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not check whether the CSI migration is enabled for Kubernetes version '%s'", version)
+	}
+	if !migrationEnabled {
+		return nil, nil
+	}
+	return controlplane.CSIMigrationFeatureGates(aws.CSIMigrationFeatureGate), nil
+}
+
 var regexFindProperty = regexp.MustCompile("net.ipv4.neigh.default.gc_thresh1[[:space:]]*=[[:space:]]*([[:alnum:]]+)")
 
 // EnsureKubernetesGeneralConfiguration ensures that the kubernetes general configuration conforms to the provider requirements.
@@ -17,29 +17,40 @@ package controlplane
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/apis/aws/install"
 	"github.com/gardener/gardener-extensions/controllers/provider-aws/pkg/aws"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	controlplanecontroller "github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/test"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
 const (
 	namespace = "test"
+	keyARN    = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
 )
 
 func TestController(t *testing.T) {
@@ -68,11 +79,36 @@ var _ = Describe("Ensurer", func() {
 			"checksum/configmap-" + aws.CloudProviderConfigName: "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 		}
 
+		kmsSecretKey = client.ObjectKey{Namespace: namespace, Name: controlplanecontroller.KMSEncryptionConfigSecretName}
+		kmsSecret    = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: controlplanecontroller.KMSEncryptionConfigSecretName},
+			Data: map[string][]byte{
+				controlplanecontroller.KMSKeysSecretKey:             []byte(keyARN),
+				controlplanecontroller.KMSEncryptionConfigSecretKey: []byte("foo"),
+			},
+		}
+		kmsNotFound = apierrors.NewNotFound(corev1.Resource("secrets"), controlplanecontroller.KMSEncryptionConfigSecretName)
+
+		imageVector = imagevector.ImageVector{
+			{
+				Name:       aws.KMSPluginImageName,
+				Repository: "test-repository",
+				Tag:        util.StringPtr("test-tag"),
+			},
+		}
+
 		kubeControllerManagerLabels = map[string]string{
 			"networking.gardener.cloud/to-public-networks":  "allowed",
 			"networking.gardener.cloud/to-private-networks": "allowed",
 			"networking.gardener.cloud/to-blocked-cidrs":    "allowed",
 		}
+
+		clusterAutoscaler = &extensionsconfig.ClusterAutoscaler{
+			Expander:                 util.StringPtr("least-waste"),
+			BalanceSimilarNodeGroups: util.BoolPtr(true),
+			ScaleDownDelayAfterAdd:   &metav1.Duration{Duration: 30 * time.Minute},
+			ScaleDownUnneededTime:    &metav1.Duration{Duration: 20 * time.Minute},
+		}
 	)
 
 	BeforeEach(func() {
@@ -104,11 +140,12 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(kmsNotFound)
 			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -154,11 +191,12 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(kmsNotFound)
 			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -167,6 +205,59 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeAPIServerDeployment(dep, annotations)
 		})
+
+		It("should add a KMS plugin for each key to kube-apiserver deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeAPIServerDeploymentName},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+									},
+								},
+							},
+						},
+					},
+				}
+				kmsAnnotations = map[string]string{
+					"checksum/secret-" + controlplanecontroller.KMSEncryptionConfigSecretName: "2673e1f9f19bf1161fe37892a6f6da7247ba95e01c6316cc290707c38185d561",
+				}
+			)
+			for k, v := range annotations {
+				kmsAnnotations[k] = v
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret)).Times(2)
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkKubeAPIServerDeployment(dep, kmsAnnotations)
+
+			name := controlplanecontroller.KMSPluginName(keyARN)
+			c := controlplane.ContainerWithName(dep.Spec.Template.Spec.Containers, name)
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Image).To(Equal("test-repository:test-tag"))
+			Expect(c.Command).To(Equal([]string{
+				"/aws-encryption-provider",
+				"--key=" + keyARN,
+				"--region=eu-west-1",
+				"--listen=" + controlplanecontroller.KMSPluginSocketPath(name),
+			}))
+			Expect(c.Env).To(ConsistOf(accessKeyIDEnvVar, secretAccessKeyEnvVar))
+		})
 	})
 
 	Describe("#EnsureKubeControllerManagerDeployment", func() {
@@ -190,11 +281,12 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -239,11 +331,12 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -252,6 +345,77 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeControllerManagerDeployment(dep, annotations, kubeControllerManagerLabels)
 		})
+
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeControllerManagerDeploymentName},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-controller-manager",
+										Command: []string{"--feature-gates=Foo=true"},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(append(csiMigrationObjects("1.14.1"), secret, cm)...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err := ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			container := controlplane.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(container.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAWS=true,Foo=true"))
+		})
+	})
+	Describe("#EnsureClusterAutoscalerDeployment", func() {
+		It("should add or modify the configured flags of the cluster-autoscaler deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "cluster-autoscaler",
+										Command: []string{
+											"./cluster-autoscaler",
+											"--expander=random",
+											"--scale-down-unneeded-time=30m",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+
+			// Call EnsureClusterAutoscalerDeployment method and check the result
+			err := ensurer.EnsureClusterAutoscalerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--expander=least-waste",
+				"--scale-down-unneeded-time=20m0s",
+				"--balance-similar-node-groups=true",
+				"--scale-down-delay-after-add=30m0s",
+			}))
+		})
 	})
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
@@ -277,7 +441,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 
 			// Call EnsureKubeletServiceUnitOptions method and check the result
 			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), oldUnitOptions)
@@ -303,17 +467,60 @@ var _ = Describe("Ensurer", func() {
 				}
 			)
 
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
+
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig)
+			err = ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig, namespace)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
-	})
 
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
+				FeatureGates: map[string]bool{
+					"Foo": true,
+				},
+			}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.14.1")...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(Equal(map[string]bool{
+				"Foo":             true,
+				"CSIMigration":    true,
+				"CSIMigrationAWS": true,
+			}))
+		})
+
+		It("should not enable the CSI migration feature gates if the Kubernetes version doesn't support it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.13.4")...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(BeEmpty())
+		})
+	})
 	Describe("#EnsureKubernetesGeneralConfiguration", func() {
 		It("should modify existing elements of kubernetes general configuration", func() {
 			var (
@@ -333,7 +540,7 @@ var _ = Describe("Ensurer", func() {
 					"net.ipv4.tcp_slow_start_after_idle = 0"
 			)
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 
 			// Call EnsureKubernetesGeneralConfiguration method and check the result
 			err := ensurer.EnsureKubernetesGeneralConfiguration(context.TODO(), modifiedData)
@@ -351,7 +558,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 
 			// Call EnsureKubernetesGeneralConfiguration method and check the result
 			err := ensurer.EnsureKubernetesGeneralConfiguration(context.TODO(), data)
@@ -414,3 +621,35 @@ func clientGet(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+func newFakeClient(objects ...runtime.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(install.AddToScheme(scheme)).To(Succeed())
+	return fake.NewFakeClientWithScheme(scheme, objects...), scheme
+}
+
+func csiMigrationObjects(kubernetesVersion string) []runtime.Object {
+	return []runtime.Object{
+		&extensionsv1alpha1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "control-plane"},
+			Spec: extensionsv1alpha1.ControlPlaneSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: aws.Type},
+				ProviderConfig: &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","csi":{"enabled":true,"migrateVolumes":true}}`),
+				},
+			},
+		},
+		&extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"CloudProfile"}`)},
+				Seed:         runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Seed"}`)},
+				Shoot: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Shoot","spec":{"kubernetes":{"version":"` + kubernetesVersion + `"}}}`),
+				},
+			},
+		},
+	}
+}
@@ -25,10 +25,12 @@ import (
 	azurecontrolplane "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/controller/controlplane"
 	azureinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/controller/infrastructure"
 	azureworker "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/controller/worker"
+	azurecontrolplanewebhook "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/webhook/controlplane"
 	azurecontrolplanebackup "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/webhook/controlplanebackup"
 	azurecontrolplaneexposure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/webhook/controlplaneexposure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -53,6 +55,18 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		controlPlaneCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		controlPlaneReconcileOpts = &controlplane.ReconcilerOptions{
+			ResyncInterval: controlplane.DefaultResyncInterval,
+		}
+		controlPlaneSecretsRotationOpts = &controlplane.SecretsRotationOptions{
+			CertificateRenewalWindow: util.DefaultCertificateRenewalWindow,
+			CABundleDuration:         util.DefaultCABundleDuration,
+		}
+		controlPlaneDeletionOpts = &controlplane.DeletionOptions{
+			ManagedResourceDeletionTimeout: controlplane.DefaultManagedResourceDeletionTimeout,
+			OrphanPolicy:                   string(controlplane.OrphanPolicyNever),
+		}
+		controlPlaneCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(controlPlaneCtrlOpts, controlPlaneReconcileOpts, controlPlaneSecretsRotationOpts, controlPlaneDeletionOpts)
 
 		// options for the infrastructure controller
 		infraCtrlOpts = &controllercmd.ControllerOptions{
@@ -68,7 +82,8 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			MaxConcurrentReconciles: 5,
 		}
 		workerReconcileOpts = &worker.Options{
-			DeployCRDs: true,
+			DeployCRDs:     true,
+			RolloutTimeout: worker.DefaultRolloutTimeout,
 		}
 		workerCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(workerCtrlOpts, workerReconcileOpts)
 
@@ -88,7 +103,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
-			controllercmd.PrefixOption("controlplane-", controlPlaneCtrlOpts),
+			controllercmd.PrefixOption("controlplane-", &controlPlaneCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("infrastructure-", &infraCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("worker-", &workerCtrlOptsUnprefixed),
 			configFileOpts,
@@ -129,17 +144,26 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyMachineImages(&azureworker.DefaultAddOptions.MachineImages)
 			configFileOpts.Completed().ApplyETCDStorage(&azurecontrolplaneexposure.DefaultAddOptions.ETCDStorage)
 			configFileOpts.Completed().ApplyETCDBackup(&azurecontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyClusterAutoscaler(&azurecontrolplanewebhook.DefaultAddOptions.ClusterAutoscaler)
+			configFileOpts.Completed().ApplyMachineControllerManager(&azureworker.DefaultAddOptions.MachineControllerManager)
 			controlPlaneCtrlOpts.Completed().Apply(&azurecontrolplane.Options)
+			controlPlaneReconcileOpts.Completed().ApplyResyncInterval(&azurecontrolplane.ResyncInterval)
+			controlPlaneSecretsRotationOpts.Completed().Apply(azurecontrolplane.RotatingSecrets)
+			controlPlaneDeletionOpts.Completed().ApplyManagedResourceDeletionTimeout(&azurecontrolplane.ManagedResourceDeletionTimeout)
+			controlPlaneDeletionOpts.Completed().ApplyOrphanPolicy(&azurecontrolplane.OrphanPolicy)
 			infraCtrlOpts.Completed().Apply(&azureinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&azureinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
 			workerCtrlOpts.Completed().Apply(&azureworker.DefaultAddOptions.Controller)
+			workerReconcileOpts.Completed().ApplyRolloutTimeout(&azureworker.DefaultAddOptions.RolloutTimeout)
 
-			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
+			shootWebhookConfig, err := webhookOptions.Completed().AddToManager(mgr)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
 			}
+			azurecontrolplane.ShootWebhookConfig = shootWebhookConfig
 
-			if err := webhookOptions.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
+			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
 			}
 
 			if err := mgr.Start(ctx.Done()); err != nil {
@@ -16,6 +16,7 @@ package helper
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
 )
@@ -67,3 +68,29 @@ func FindAvailabilitySetByPurpose(availabilitySets []azure.AvailabilitySet, purp
 	}
 	return nil, fmt.Errorf("cannot find availability set with purpose %q", purpose)
 }
+
+// FindImageFromCloudProfile takes a CloudProfileConfig, and the desired image name and version. It tries to find the
+// image with the given name and version. If it cannot be found then an error is returned.
+func FindImageFromCloudProfile(cloudProfileConfig *azure.CloudProfileConfig, imageName, imageVersion string) (*azure.MachineImage, error) {
+	if cloudProfileConfig != nil {
+		for _, machineImage := range cloudProfileConfig.MachineImages {
+			if machineImage.Name == imageName && machineImage.Version == imageVersion {
+				return &machineImage, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("could not find an image for name %q in version %q in the cloud profile", imageName, imageVersion)
+}
+
+var keyVaultKeyIDRegex = regexp.MustCompile(`^https://([a-zA-Z0-9-]{3,24})\.vault\.[a-z.]+/keys/([a-zA-Z0-9-]{1,127})/([a-zA-Z0-9]{32})$`)
+
+// ParseKeyVaultKeyID takes the identifier of a Key Vault key and returns the name of its key vault, its name, and its
+// version. If the identifier is malformed then an error will be returned.
+func ParseKeyVaultKeyID(keyID string) (string, string, string, error) {
+	match := keyVaultKeyIDRegex.FindStringSubmatch(keyID)
+	if match == nil {
+		return "", "", "", fmt.Errorf("%q is not the identifier of a Key Vault key with a version", keyID)
+	}
+	return match[1], match[2], match[3], nil
+}
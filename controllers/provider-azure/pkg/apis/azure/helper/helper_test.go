@@ -76,6 +76,45 @@ var _ = Describe("Helper", func() {
 		Entry("entry not found", []azure.AvailabilitySet{{ID: "bar", Purpose: purposeWrong}}, purpose, nil, true),
 		Entry("entry exists", []azure.AvailabilitySet{{ID: "bar", Purpose: purpose}}, purpose, &azure.AvailabilitySet{ID: "bar", Purpose: purpose}, false),
 	)
+
+	DescribeTable("#FindImageFromCloudProfile",
+		func(cloudProfileConfig *azure.CloudProfileConfig, imageName, imageVersion string, expectedImage *azure.MachineImage) {
+			image, err := FindImageFromCloudProfile(cloudProfileConfig, imageName, imageVersion)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", nil),
+		Entry("no machine images", &azure.CloudProfileConfig{}, "ubuntu", "1", nil),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1"), "coreos", "1", nil),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1"), "ubuntu", "2", nil),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1"), "ubuntu", "1", &makeCloudProfileConfig("ubuntu", "1").MachineImages[0]),
+	)
+
+	DescribeTable("#ParseKeyVaultKeyID",
+		func(keyID, expectedVaultName, expectedKeyName, expectedKeyVersion string, expectErr bool) {
+			vaultName, keyName, keyVersion, err := ParseKeyVaultKeyID(keyID)
+
+			Expect(vaultName).To(Equal(expectedVaultName))
+			Expect(keyName).To(Equal(expectedKeyName))
+			Expect(keyVersion).To(Equal(expectedKeyVersion))
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+
+		Entry("empty identifier", "", "", "", "", true),
+		Entry("no version", "https://my-vault.vault.azure.net/keys/my-key", "", "", "", true),
+		Entry("secret identifier", "https://my-vault.vault.azure.net/secrets/my-secret/0123456789abcdef0123456789abcdef", "", "", "", true),
+		Entry("key identifier", "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef0123456789abcdef", "my-vault", "my-key", "0123456789abcdef0123456789abcdef", false),
+	)
 })
 
 func expectResults(result, expected interface{}, err error, expectErr bool) {
@@ -87,3 +126,17 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func makeCloudProfileConfig(name, version string) *azure.CloudProfileConfig {
+	return &azure.CloudProfileConfig{
+		MachineImages: []azure.MachineImage{
+			{
+				Name:      name,
+				Version:   version,
+				Publisher: "Canonical",
+				Offer:     "UbuntuServer",
+				SKU:       "18.04-LTS",
+			},
+		},
+	}
+}
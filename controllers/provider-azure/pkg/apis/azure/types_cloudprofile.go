@@ -0,0 +1,46 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudProfileConfig contains provider-specific configuration that is embedded into Gardener's `CloudProfile`
+// resource.
+type CloudProfileConfig struct {
+	metav1.TypeMeta
+
+	// MachineImages is the list of machine images that are understood by the controller. It maps
+	// logical names and versions to Azure-specific identifiers.
+	// +optional
+	MachineImages []MachineImage
+}
+
+// MachineImage is a mapping from logical names and versions to Azure-specific identifiers.
+type MachineImage struct {
+	// Name is the logical name of the machine image.
+	Name string
+	// Version is the logical version of the machine image.
+	Version string
+	// Publisher is the publisher of the image.
+	Publisher string
+	// Offer is the offering of the image.
+	Offer string
+	// SKU is the stock keeping unit to pull images from.
+	SKU string
+}
@@ -28,9 +28,71 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig
+
+	// CSI contains configuration settings for the CSI driver.
+	// +optional
+	CSI *CSIConfig
+
+	// KMS contains configuration settings for the encryption of secrets with a Key Vault key.
+	// +optional
+	KMS *KMSConfig
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	gardenv1beta1.KubernetesConfig
+
+	// LoadBalancer contains configuration settings for the load balancers of services of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig
+
+	// Route contains configuration settings for the routes to the pod networks of the nodes.
+	// +optional
+	Route *RouteConfig
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// SKU is the SKU of the load balancers, either `basic` or `standard`. Defaults to `basic`.
+	// +optional
+	SKU *LoadBalancerSKU
+}
+
+// LoadBalancerSKU is the SKU of an Azure load balancer.
+type LoadBalancerSKU string
+
+const (
+	// LoadBalancerSKUBasic is the basic SKU of Azure load balancers.
+	LoadBalancerSKUBasic LoadBalancerSKU = "basic"
+	// LoadBalancerSKUStandard is the standard SKU of Azure load balancers.
+	LoadBalancerSKUStandard LoadBalancerSKU = "standard"
+)
+
+// RouteConfig contains configuration settings for the routes to the pod networks of the nodes.
+type RouteConfig struct {
+	// Enabled lets the cloud-controller-manager create a route to the pod network of each node in the route table
+	// of the shoot. Defaults to true.
+	// +optional
+	Enabled *bool
+}
+
+// CSIConfig contains configuration settings for the CSI driver.
+type CSIConfig struct {
+	// Enabled deploys the CSI driver and the `default-csi` StorageClass. It is only deployed for shoots with
+	// Kubernetes version 1.14 or higher. Existing persistent volumes keep using the in-tree volume plugin unless
+	// MigrateVolumes is set.
+	Enabled bool
+	// MigrateVolumes enables the `CSIMigration` and `CSIMigrationAzureDisk` feature gates of the kube-controller-manager and the
+	// kubelets, so that the existing persistent volumes of the in-tree volume plugin are handled by the CSI driver.
+	// It requires Enabled and is only applied for shoots with Kubernetes version 1.15 or higher.
+	// +optional
+	MigrateVolumes bool
+}
+
+// KMSConfig contains configuration settings for the encryption of secrets with a Key Vault key.
+type KMSConfig struct {
+	// KeyID is the identifier of the Key Vault key that secrets are encrypted with, i.e.
+	// https://<key-vault>.vault.azure.net/keys/<key>/<version>. The service principal of the shoot must be allowed to
+	// wrap and unwrap keys with it.
+	KeyID string
 }
@@ -46,6 +46,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CSIConfig)(nil), (*azure.CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CSIConfig_To_azure_CSIConfig(a.(*CSIConfig), b.(*azure.CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CSIConfig)(nil), (*CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CSIConfig_To_v1alpha1_CSIConfig(a.(*azure.CSIConfig), b.(*CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*azure.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*azure.CloudControllerManagerConfig), scope)
 	}); err != nil {
@@ -56,6 +66,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*azure.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig(a.(*CloudProfileConfig), b.(*azure.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*azure.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*azure.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*azure.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -86,6 +106,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMSConfig)(nil), (*azure.KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMSConfig_To_azure_KMSConfig(a.(*KMSConfig), b.(*azure.KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.KMSConfig)(nil), (*KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_KMSConfig_To_v1alpha1_KMSConfig(a.(*azure.KMSConfig), b.(*KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*azure.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*azure.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*azure.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*azure.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_azure_MachineImage(a.(*MachineImage), b.(*azure.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_MachineImage_To_v1alpha1_MachineImage(a.(*azure.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkConfig)(nil), (*azure.NetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NetworkConfig_To_azure_NetworkConfig(a.(*NetworkConfig), b.(*azure.NetworkConfig), scope)
 	}); err != nil {
@@ -116,6 +166,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RouteConfig)(nil), (*azure.RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RouteConfig_To_azure_RouteConfig(a.(*RouteConfig), b.(*azure.RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RouteConfig)(nil), (*RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RouteConfig_To_v1alpha1_RouteConfig(a.(*azure.RouteConfig), b.(*RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RouteTable)(nil), (*azure.RouteTable)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RouteTable_To_azure_RouteTable(a.(*RouteTable), b.(*azure.RouteTable), scope)
 	}); err != nil {
@@ -193,8 +253,32 @@ func Convert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in *azure.Availab
 	return autoConvert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in, out, s)
 }
 
+func autoConvert_v1alpha1_CSIConfig_To_azure_CSIConfig(in *CSIConfig, out *azure.CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_v1alpha1_CSIConfig_To_azure_CSIConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CSIConfig_To_azure_CSIConfig(in *CSIConfig, out *azure.CSIConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CSIConfig_To_azure_CSIConfig(in, out, s)
+}
+
+func autoConvert_azure_CSIConfig_To_v1alpha1_CSIConfig(in *azure.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_azure_CSIConfig_To_v1alpha1_CSIConfig is an autogenerated conversion function.
+func Convert_azure_CSIConfig_To_v1alpha1_CSIConfig(in *azure.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	return autoConvert_azure_CSIConfig_To_v1alpha1_CSIConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *azure.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*azure.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*azure.RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -205,6 +289,8 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManag
 
 func autoConvert_azure_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *azure.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -213,8 +299,30 @@ func Convert_azure_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManag
 	return autoConvert_azure_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig(in *CloudProfileConfig, out *azure.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]azure.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig(in *CloudProfileConfig, out *azure.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *azure.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *azure.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *ControlPlaneConfig, out *azure.ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*azure.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*azure.CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*azure.KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -225,6 +333,8 @@ func Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *Control
 
 func autoConvert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *azure.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -295,6 +405,74 @@ func Convert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *azu
 	return autoConvert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_KMSConfig_To_azure_KMSConfig(in *KMSConfig, out *azure.KMSConfig, s conversion.Scope) error {
+	out.KeyID = in.KeyID
+	return nil
+}
+
+// Convert_v1alpha1_KMSConfig_To_azure_KMSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KMSConfig_To_azure_KMSConfig(in *KMSConfig, out *azure.KMSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMSConfig_To_azure_KMSConfig(in, out, s)
+}
+
+func autoConvert_azure_KMSConfig_To_v1alpha1_KMSConfig(in *azure.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	out.KeyID = in.KeyID
+	return nil
+}
+
+// Convert_azure_KMSConfig_To_v1alpha1_KMSConfig is an autogenerated conversion function.
+func Convert_azure_KMSConfig_To_v1alpha1_KMSConfig(in *azure.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	return autoConvert_azure_KMSConfig_To_v1alpha1_KMSConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in *LoadBalancerConfig, out *azure.LoadBalancerConfig, s conversion.Scope) error {
+	out.SKU = (*azure.LoadBalancerSKU)(unsafe.Pointer(in.SKU))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in *LoadBalancerConfig, out *azure.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *azure.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.SKU = (*LoadBalancerSKU)(unsafe.Pointer(in.SKU))
+	return nil
+}
+
+// Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *azure.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_azure_MachineImage(in *MachineImage, out *azure.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Publisher = in.Publisher
+	out.Offer = in.Offer
+	out.SKU = in.SKU
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_azure_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_azure_MachineImage(in *MachineImage, out *azure.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_azure_MachineImage(in, out, s)
+}
+
+func autoConvert_azure_MachineImage_To_v1alpha1_MachineImage(in *azure.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Publisher = in.Publisher
+	out.Offer = in.Offer
+	out.SKU = in.SKU
+	return nil
+}
+
+// Convert_azure_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_azure_MachineImage_To_v1alpha1_MachineImage(in *azure.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_azure_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
+
 func autoConvert_v1alpha1_NetworkConfig_To_azure_NetworkConfig(in *NetworkConfig, out *azure.NetworkConfig, s conversion.Scope) error {
 	if err := Convert_v1alpha1_VNet_To_azure_VNet(&in.VNet, &out.VNet, s); err != nil {
 		return err
@@ -367,6 +545,26 @@ func Convert_azure_ResourceGroup_To_v1alpha1_ResourceGroup(in *azure.ResourceGro
 	return autoConvert_azure_ResourceGroup_To_v1alpha1_ResourceGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_RouteConfig_To_azure_RouteConfig(in *RouteConfig, out *azure.RouteConfig, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	return nil
+}
+
+// Convert_v1alpha1_RouteConfig_To_azure_RouteConfig is an autogenerated conversion function.
+func Convert_v1alpha1_RouteConfig_To_azure_RouteConfig(in *RouteConfig, out *azure.RouteConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RouteConfig_To_azure_RouteConfig(in, out, s)
+}
+
+func autoConvert_azure_RouteConfig_To_v1alpha1_RouteConfig(in *azure.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	return nil
+}
+
+// Convert_azure_RouteConfig_To_v1alpha1_RouteConfig is an autogenerated conversion function.
+func Convert_azure_RouteConfig_To_v1alpha1_RouteConfig(in *azure.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	return autoConvert_azure_RouteConfig_To_v1alpha1_RouteConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_RouteTable_To_azure_RouteTable(in *RouteTable, out *azure.RouteTable, s conversion.Scope) error {
 	out.Purpose = azure.Purpose(in.Purpose)
 	out.Name = in.Name
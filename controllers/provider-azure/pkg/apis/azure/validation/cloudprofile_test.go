@@ -0,0 +1,97 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisazure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
+	. "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("CloudProfileConfig validation", func() {
+	var cloudProfileConfig *apisazure.CloudProfileConfig
+
+	BeforeEach(func() {
+		cloudProfileConfig = &apisazure.CloudProfileConfig{
+			MachineImages: []apisazure.MachineImage{
+				{
+					Name:      "ubuntu",
+					Version:   "18.04",
+					Publisher: "value",
+					Offer:     "value",
+					SKU:       "value",
+				},
+			},
+		}
+	})
+
+	Describe("#ValidateCloudProfileConfig", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+		})
+
+		It("should forbid machine images without name and version", func() {
+			cloudProfileConfig.MachineImages[0].Name = ""
+			cloudProfileConfig.MachineImages[0].Version = ""
+
+			Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].version"),
+				})),
+			))
+		})
+
+		It("should forbid duplicate machine images", func() {
+			cloudProfileConfig.MachineImages = append(cloudProfileConfig.MachineImages, cloudProfileConfig.MachineImages[0])
+
+			Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineImages[1]"),
+				})),
+			))
+		})
+
+		It("should forbid machine images without provider-specific identifiers", func() {
+			cloudProfileConfig.MachineImages[0].Publisher = ""
+			cloudProfileConfig.MachineImages[0].Offer = ""
+			cloudProfileConfig.MachineImages[0].SKU = ""
+
+			Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].publisher"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].offer"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].sku"),
+				})),
+			))
+		})
+	})
+})
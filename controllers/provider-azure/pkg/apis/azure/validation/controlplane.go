@@ -0,0 +1,43 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	apisazure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/helper"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisazure.ControlPlaneConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		loadBalancerPath := field.NewPath("cloudControllerManager", "loadBalancer")
+
+		if sku := ccm.LoadBalancer.SKU; sku != nil && *sku != apisazure.LoadBalancerSKUBasic && *sku != apisazure.LoadBalancerSKUStandard {
+			allErrs = append(allErrs, field.NotSupported(loadBalancerPath.Child("sku"), *sku, []string{string(apisazure.LoadBalancerSKUBasic), string(apisazure.LoadBalancerSKUStandard)}))
+		}
+	}
+
+	if kms := controlPlaneConfig.KMS; kms != nil {
+		if _, _, _, err := helper.ParseKeyVaultKeyID(kms.KeyID); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("kms", "keyID"), kms.KeyID, "must be the identifier of a Key Vault key with a version"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisazure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
+	. "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var controlPlaneConfig *apisazure.ControlPlaneConfig
+
+	BeforeEach(func() {
+		sku := apisazure.LoadBalancerSKUStandard
+		controlPlaneConfig = &apisazure.ControlPlaneConfig{
+			CloudControllerManager: &apisazure.CloudControllerManagerConfig{
+				LoadBalancer: &apisazure.LoadBalancerConfig{
+					SKU: &sku,
+				},
+			},
+			KMS: &apisazure.KMSConfig{
+				KeyID: "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef0123456789abcdef",
+			},
+		}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(BeEmpty())
+		})
+
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControlPlaneConfig(&apisazure.ControlPlaneConfig{})).To(BeEmpty())
+		})
+
+		It("should forbid unsupported load balancer SKUs", func() {
+			sku := apisazure.LoadBalancerSKU("premium")
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.SKU = &sku
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("cloudControllerManager.loadBalancer.sku"),
+				})),
+			))
+		})
+
+		It("should forbid invalid Key Vault key identifiers", func() {
+			controlPlaneConfig.KMS.KeyID = "https://my-vault.vault.azure.net/keys/my-key"
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("kms.keyID"),
+				})),
+			))
+		})
+	})
+})
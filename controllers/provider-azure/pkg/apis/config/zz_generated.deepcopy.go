@@ -40,6 +40,8 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		copy(*out, *in)
 	}
 	in.ETCD.DeepCopyInto(&out.ETCD)
+	in.ClusterAutoscaler.DeepCopyInto(&out.ClusterAutoscaler)
+	in.MachineControllerManager.DeepCopyInto(&out.MachineControllerManager)
 	return
 }
 
@@ -28,10 +28,20 @@ const (
 	MachineControllerManagerImageName = "machine-controller-manager"
 	// ETCDBackupRestoreImageName is the name of the etcd backup and restore image.
 	ETCDBackupRestoreImageName = "etcd-backup-restore"
+	// KMSPluginImageName is the name of the Key Vault KMS plugin image.
+	KMSPluginImageName = "kubernetes-kms"
 
 	// MachineControllerManagerName is a constant for the name of the machine-controller-manager.
 	MachineControllerManagerName = "machine-controller-manager"
 	HyperkubeImageName           = "hyperkube"
+	// CSIDriverImageName is the name of the Azure Disk CSI driver image.
+	CSIDriverImageName = "azuredisk-csi-plugin"
+	// CSIAttacherImageName is the name of the CSI attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIProvisionerImageName is the name of the CSI provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSINodeDriverRegistrarImageName is the name of the CSI node driver registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
 
 	// SubscriptionIDKey is the key for the subscription ID
 	SubscriptionIDKey = "subscriptionID"
@@ -58,6 +68,11 @@ const (
 	CloudProviderConfigMapKey = "cloudprovider.conf"
 	// BackupSecretName is the name of the secret containing the credentials for storing the backups of Shoot clusters.
 	BackupSecretName = "etcd-backup"
+
+	// CSIMigrationFeatureGate is the feature gate that migrates the volumes of the in-tree Azure Disk volume plugin to the CSI driver.
+	CSIMigrationFeatureGate = "CSIMigrationAzureDisk"
+	// CSIMigrationMinimumKubernetesVersion is the minimum Kubernetes version that supports the CSIMigrationFeatureGate.
+	CSIMigrationMinimumKubernetesVersion = "1.15"
 )
 
 var (
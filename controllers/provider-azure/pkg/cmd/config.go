@@ -19,6 +19,7 @@ import (
 
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/config"
 	configloader "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/config/loader"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 
 	"github.com/spf13/pflag"
 )
@@ -85,6 +86,16 @@ func (c *Config) ApplyETCDBackup(etcdBackup *config.ETCDBackup) {
 	*etcdBackup = c.Config.ETCD.Backup
 }
 
+// ApplyClusterAutoscaler sets the given cluster-autoscaler configuration to that of this Config.
+func (c *Config) ApplyClusterAutoscaler(clusterAutoscaler *extensionsconfig.ClusterAutoscaler) {
+	*clusterAutoscaler = c.Config.ClusterAutoscaler
+}
+
+// ApplyMachineControllerManager sets the given machine-controller-manager configuration to that of this Config.
+func (c *Config) ApplyMachineControllerManager(machineControllerManager *extensionsconfig.MachineControllerManager) {
+	*machineControllerManager = c.Config.MachineControllerManager
+}
+
 // Options initializes empty config.ControllerConfiguration, applies the set values and returns it.
 func (c *Config) Options() config.ControllerConfiguration {
 	var cfg config.ControllerConfiguration
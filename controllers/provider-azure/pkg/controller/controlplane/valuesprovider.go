@@ -21,7 +21,9 @@ import (
 
 	apisazure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
 	azureapihelper "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/helper"
+	azurevalidation "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/validation"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal"
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal/keyvault"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
@@ -39,7 +41,9 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -51,6 +55,9 @@ import (
 const (
 	cloudControllerManagerDeploymentName = "cloud-controller-manager"
 	cloudControllerManagerServerName     = "cloud-controller-manager-server"
+	csiControllerDeploymentName          = "csi-driver-controller"
+	csiAttacherName                      = "csi-attacher"
+	csiProvisionerName                   = "csi-provisioner"
 )
 
 var controlPlaneSecrets = &secrets.Secrets{
@@ -85,11 +92,37 @@ var controlPlaneSecrets = &secrets.Secrets{
 					SigningCA:  cas[gardencorev1alpha1.SecretNameCACluster],
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiAttacherName,
+					CommonName:   "system:csi-attacher",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiProvisionerName,
+					CommonName:   "system:csi-provisioner",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
 		}
 	},
 }
 
-var configChart = &chart.Chart{
+var configChart = util.NewChart(&chart.Chart{
 	Name: "cloud-provider-config",
 	Path: filepath.Join(internal.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
@@ -98,39 +131,70 @@ var configChart = &chart.Chart{
 			Name: azure.CloudProviderConfigName,
 		},
 	},
-}
+})
 
-var ccmChart = &chart.Chart{
-	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(internal.InternalChartsPath, "cloud-controller-manager"),
-	Images: []string{azure.HyperkubeImageName},
-	Objects: []*chart.Object{
-		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
-		{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+var controlPlaneChart = util.NewChart(&chart.Chart{
+	Name: "seed-controlplane",
+	Path: filepath.Join(internal.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{
+		{
+			Name:   "cloud-controller-manager",
+			Images: []string{azure.HyperkubeImageName},
+			Objects: []*chart.Object{
+				{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
+				{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+			},
+		},
+		{
+			Name:   "csi-azure",
+			Images: []string{azure.CSIDriverImageName, azure.CSIAttacherImageName, azure.CSIProvisionerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: csiControllerDeploymentName},
+			},
+		},
 	},
-}
+})
 
-var ccmShootChart = &chart.Chart{
-	Name: "cloud-controller-manager-shoot",
-	Path: filepath.Join(internal.InternalChartsPath, "cloud-controller-manager-shoot"),
-	Objects: []*chart.Object{
-		{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
-		{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
+	Name: "shoot-system-components",
+	Path: filepath.Join(internal.InternalChartsPath, "shoot-system-components"),
+	SubCharts: []*chart.Chart{
+		{
+			Name: "cloud-controller-manager",
+			Objects: []*chart.Object{
+				{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+			},
+		},
+		{
+			Name:   "csi-azure",
+			Images: []string{azure.CSIDriverImageName, azure.CSINodeDriverRegistrarImageName},
+			Objects: []*chart.Object{
+				{Type: &storagev1.StorageClass{}, Name: "default-csi"},
+				{Type: &appsv1.DaemonSet{}, Name: "csi-driver-node"},
+				{Type: &corev1.ServiceAccount{}, Name: "csi-driver-node"},
+				{Type: &policyv1beta1.PodSecurityPolicy{}, Name: "gardener.kube-system.csi-driver-node"},
+				{Type: &rbacv1.ClusterRole{}, Name: "garden.sapcloud.io:psp:kube-system:csi-driver-node"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "garden.sapcloud.io:psp:csi-driver-node"},
+			},
+		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
 	return &valuesProvider{
-		logger: logger.WithName("azure-values-provider"),
+		logger:            logger.WithName("azure-values-provider"),
+		newKeyVaultClient: keyvault.NewFromClientAuth,
 	}
 }
 
 // valuesProvider is a ValuesProvider that provides azure-specific values for the 2 charts applied by the generic actuator.
 type valuesProvider struct {
-	decoder runtime.Decoder
-	client  client.Client
-	logger  logr.Logger
+	decoder           runtime.Decoder
+	client            client.Client
+	logger            logr.Logger
+	newKeyVaultClient func(clientAuth *internal.ClientAuth) keyvault.Interface
 }
 
 // InjectScheme injects the given scheme into the valuesProvider.
@@ -156,6 +220,9 @@ func (vp *valuesProvider) GetConfigChartValues(
 	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
+	if errs := azurevalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
 
 	// Decode infrastructureProviderStatus
 	infraStatus := &apisazure.InfrastructureStatus{}
@@ -170,7 +237,7 @@ func (vp *valuesProvider) GetConfigChartValues(
 	}
 
 	// Get config chart values
-	return getConfigChartValues(infraStatus, cp, cluster, auth)
+	return getConfigChartValues(cpConfig, infraStatus, cp, cluster, auth)
 }
 
 // GetControlPlaneChartValues returns the values for the control plane chart applied by the generic actuator.
@@ -187,8 +254,8 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// Get control plane chart values
+	return getControlPlaneChartValues(cpConfig, cp, cluster, checksums, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
@@ -197,11 +264,54 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) (map[string]interface{}, error) {
-	return nil, nil
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	// Get control plane shoot chart values
+	return getControlPlaneShootChartValues(cpConfig, cluster)
+}
+
+// GetKMSKeyID returns the identifier of the Key Vault key that the secrets of the shoot are encrypted with, or nil if
+// the shoot doesn't use one. The service principal of the shoot must be allowed to wrap keys with it, which is checked
+// with a test wrapping before the KMS plugin of kube-apiserver uses the key.
+func (vp *valuesProvider) GetKMSKeyID(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (*string, error) {
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if errs := azurevalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if cpConfig.KMS == nil {
+		return nil, nil
+	}
+
+	// The KMS plugin authenticates with the service principal of the client auth, which is passed to it as part of
+	// the cloud provider config
+	clientAuth, err := internal.GetClientAuthData(ctx, vp.client, cp.Spec.SecretRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get client auth from secret '%s/%s'", cp.Spec.SecretRef.Namespace, cp.Spec.SecretRef.Name)
+	}
+
+	keyID := cpConfig.KMS.KeyID
+	if _, err := vp.newKeyVaultClient(clientAuth).WrapKey(ctx, keyID, []byte(controlplane.KMSKeyCheckPlaintext)); err != nil {
+		return nil, errors.Wrapf(err, "could not wrap a key with KMS key '%s' of controlplane '%s'", keyID, util.ObjectName(cp))
+	}
+
+	return &keyID, nil
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
 func getConfigChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
 	infraStatus *apisazure.InfrastructureStatus,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
@@ -213,7 +323,7 @@ func getConfigChartValues(
 	}
 
 	// Collect config chart values
-	return map[string]interface{}{
+	values := map[string]interface{}{
 		"kubernetesVersion":   cluster.Shoot.Spec.Kubernetes.Version,
 		"tenantId":            ca.TenantID,
 		"subscriptionId":      ca.SubscriptionID,
@@ -226,6 +336,36 @@ func getConfigChartValues(
 		"routeTableName":      routeTableName,
 		"securityGroupName":   securityGroupName,
 		"region":              cp.Spec.Region,
+	}
+
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil && ccm.LoadBalancer.SKU != nil {
+		values["loadBalancerSku"] = string(*ccm.LoadBalancer.SKU)
+	}
+
+	return values, nil
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIChartValues(cpConfig, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cloud-controller-manager": ccm,
+		"csi-azure":                csi,
 	}, nil
 }
 
@@ -250,15 +390,70 @@ func getCCMChartValues(
 			"checksum/secret-cloudprovider":            checksums[common.CloudProviderSecretName],
 			"checksum/configmap-cloud-provider-config": checksums[azure.CloudProviderConfigName],
 		},
+		"configureCloudRoutes": true,
 	}
 
 	if cpConfig.CloudControllerManager != nil {
 		values["featureGates"] = cpConfig.CloudControllerManager.FeatureGates
+		if route := cpConfig.CloudControllerManager.Route; route != nil && route.Enabled != nil {
+			values["configureCloudRoutes"] = *route.Enabled
+		}
 	}
 
 	return values, nil
 }
 
+// getCSIChartValues collects and returns the CSI chart values.
+func getCSIChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"enabled":           csiEnabled,
+		"replicas":          extensionscontroller.GetControlPlaneReplicas(cluster.Shoot, scaledDown, 1),
+		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-csi-attacher":             checksums[csiAttacherName],
+			"checksum/secret-csi-provisioner":          checksums[csiProvisionerName],
+			"checksum/secret-cloudprovider":            checksums[common.CloudProviderSecretName],
+			"checksum/configmap-cloud-provider-config": checksums[azure.CloudProviderConfigName],
+		},
+	}, nil
+}
+
+// getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
+func getControlPlaneShootChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"csi-azure": map[string]interface{}{
+			"enabled": csiEnabled,
+		},
+	}, nil
+}
+
+// isCSIEnabled returns true if the shoot opted in for the CSI driver and has a Kubernetes version that supports it.
+func isCSIEnabled(cpConfig *apisazure.ControlPlaneConfig, cluster *extensionscontroller.Cluster) (bool, error) {
+	csiEnabled, err := controlplane.IsCSIEnabled(cpConfig.CSI != nil && cpConfig.CSI.Enabled, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not check whether the CSI driver is enabled for Kubernetes version '%s'", cluster.Shoot.Spec.Kubernetes.Version)
+	}
+	return csiEnabled, nil
+}
+
 // getInfraNames determines the subnet, availability set, route table and security group names from the given infrastructure status.
 func getInfraNames(infraStatus *apisazure.InfrastructureStatus) (string, string, string, string, error) {
 	nodesSubnet, err := azureapihelper.FindSubnetByPurpose(infraStatus.Networks.Subnets, apisazure.PurposeNodes)
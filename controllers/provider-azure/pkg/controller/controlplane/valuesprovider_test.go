@@ -17,12 +17,18 @@ package controlplane
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apisazure "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal"
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal/keyvault"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -44,6 +50,7 @@ import (
 
 const (
 	namespace = "test"
+	keyID     = "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef0123456789abcdef"
 )
 
 var _ = Describe("ValuesProvider", func() {
@@ -54,6 +61,8 @@ var _ = Describe("ValuesProvider", func() {
 		scheme = runtime.NewScheme()
 		_      = apisazure.AddToScheme(scheme)
 
+		loadBalancerSKU = apisazure.LoadBalancerSKUStandard
+
 		cp = &extensionsv1alpha1.ControlPlane{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "control-plane",
@@ -73,6 +82,12 @@ var _ = Describe("ValuesProvider", func() {
 									"CustomResourceValidation": true,
 								},
 							},
+							LoadBalancer: &apisazure.LoadBalancerConfig{
+								SKU: &loadBalancerSKU,
+							},
+							Route: &apisazure.RouteConfig{
+								Enabled: util.BoolPtr(false),
+							},
 						},
 					}),
 				},
@@ -397,6 +412,8 @@ var _ = Describe("ValuesProvider", func() {
 			azure.CloudProviderConfigName:     "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":        "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server": "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			"csi-attacher":                    "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+			"csi-provisioner":                 "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
 		}
 
 		configChartValues = map[string]interface{}{
@@ -412,6 +429,7 @@ var _ = Describe("ValuesProvider", func() {
 			"routeTableName":      "route-table-name",
 			"securityGroupName":   "security-group-name-workers",
 			"kubernetesVersion":   "1.13.4",
+			"loadBalancerSku":     "standard",
 		}
 
 		ccmChartValues = map[string]interface{}{
@@ -428,6 +446,19 @@ var _ = Describe("ValuesProvider", func() {
 			"featureGates": map[string]bool{
 				"CustomResourceValidation": true,
 			},
+			"configureCloudRoutes": false,
+		}
+
+		csiChartValues = map[string]interface{}{
+			"enabled":           false,
+			"replicas":          1,
+			"kubernetesVersion": "1.13.4",
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-csi-attacher":             "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+				"checksum/secret-csi-provisioner":          "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
+				"checksum/secret-cloudprovider":            "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
+				"checksum/configmap-cloud-provider-config": "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
+			},
 		}
 
 		logger = log.Log.WithName("test")
@@ -459,6 +490,30 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(configChartValues))
 		})
+
+		It("should return error, invalid providerConfig", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			invalidSKU := apisazure.LoadBalancerSKU("premium")
+			invalidCP := cp.DeepCopy()
+			invalidCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisazure.ControlPlaneConfig{
+					CloudControllerManager: &apisazure.CloudControllerManagerConfig{
+						LoadBalancer: &apisazure.LoadBalancerConfig{
+							SKU: &invalidSKU,
+						},
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			_, err = vp.GetConfigChartValues(context.TODO(), invalidCP, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid providerConfig"))
+		})
 	})
 
 	Describe("#GetConfigChartValuesNoSubnet", func() {
@@ -551,7 +606,139 @@ var _ = Describe("ValuesProvider", func() {
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(map[string]interface{}{
+				"cloud-controller-manager": ccmChartValues,
+				"csi-azure":                csiChartValues,
+			}))
+		})
+	})
+
+	Describe("#GetControlPlaneShootChartValues", func() {
+		It("should return correct control plane shoot chart values", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-azure": map[string]interface{}{
+					"enabled": false,
+				},
+			}))
+		})
+
+		It("should enable the CSI driver if opted in", func() {
+			csiCP := cp.DeepCopy()
+			csiCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisazure.ControlPlaneConfig{
+					CSI: &apisazure.CSIConfig{
+						Enabled: true,
+					},
+				}),
+			}
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.14.3"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), csiCP, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-azure": map[string]interface{}{
+					"enabled": true,
+				},
+			}))
+		})
+	})
+
+	Describe("#GetKMSKeyID", func() {
+		var kmsCP *extensionsv1alpha1.ControlPlane
+
+		BeforeEach(func() {
+			kmsCP = cp.DeepCopy()
+			kmsCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisazure.ControlPlaneConfig{
+					KMS: &apisazure.KMSConfig{
+						KeyID: keyID,
+					},
+				}),
+			}
+		})
+
+		It("should return nil if no KMS key is configured", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			result, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should return the identifier of the configured KMS key", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newKeyVaultClient = newFakeKeyVaultClient(nil)
+
+			// Call GetKMSKeyID method and check the result
+			result, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(util.StringPtr(keyID)))
+		})
+
+		It("should fail if the service principal is not allowed to wrap keys with the KMS key", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newKeyVaultClient = newFakeKeyVaultClient(fmt.Errorf("Forbidden"))
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(MatchError(ContainSubstring("Forbidden")))
+		})
+
+		It("should fail if the provider secret does not contain a client secret", func() {
+			secret := cpSecret.DeepCopy()
+			delete(secret.Data, "clientSecret")
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(HaveOccurred())
 		})
 	})
 })
@@ -570,3 +757,26 @@ func clientGet(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+// fakeKeyVaultClient is a Key Vault client that checks the test wrapping and returns the given error.
+type fakeKeyVaultClient struct {
+	err error
+}
+
+func (c *fakeKeyVaultClient) WrapKey(_ context.Context, id string, key []byte) ([]byte, error) {
+	Expect(id).To(Equal(keyID))
+	Expect(key).To(Equal([]byte(controlplane.KMSKeyCheckPlaintext)))
+	return []byte("wrapped-key"), c.err
+}
+
+func newFakeKeyVaultClient(err error) func(*internal.ClientAuth) keyvault.Interface {
+	return func(clientAuth *internal.ClientAuth) keyvault.Interface {
+		Expect(clientAuth).To(Equal(&internal.ClientAuth{
+			SubscriptionID: "SubscriptionID",
+			TenantID:       "TenantID",
+			ClientID:       "ClientID",
+			ClientSecret:   "ClientSecret",
+		}))
+		return &fakeKeyVaultClient{err: err}
+	}
+}
@@ -15,8 +15,11 @@
 package worker
 
 import (
+	"time"
+
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/azure"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 
 	machinescheme "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/scheme"
@@ -36,6 +39,10 @@ type AddOptions struct {
 	Controller controller.Options
 	// MachineImages is the default list of machine images.
 	MachineImages []config.MachineImage
+	// RolloutTimeout is the duration after which a rollout of machine deployments is considered failed.
+	RolloutTimeout time.Duration
+	// MachineControllerManager contains the timeouts that are passed to the machine-controller-manager.
+	MachineControllerManager extensionsconfig.MachineControllerManager
 }
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
@@ -50,7 +57,7 @@ func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) error {
 	}
 
 	return worker.Add(mgr, worker.AddArgs{
-		Actuator:          NewActuator(opts.MachineImages),
+		Actuator:          NewActuator(opts.MachineImages, opts.RolloutTimeout, &opts.MachineControllerManager),
 		ControllerOptions: opts.Controller,
 		Predicates:        worker.DefaultPredicates(mgr.GetClient(), azure.Type),
 	})
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	"github.com/gardener/gardener/pkg/utils/chart"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
@@ -29,7 +30,7 @@ import (
 )
 
 var (
-	mcmChart = &chart.Chart{
+	mcmChart = util.NewChart(&chart.Chart{
 		Name:   azure.MachineControllerManagerName,
 		Path:   filepath.Join(azure.InternalChartsPath, azure.MachineControllerManagerName, "seed"),
 		Images: []string{azure.MachineControllerManagerImageName},
@@ -39,16 +40,16 @@ var (
 			{Type: &corev1.ServiceAccount{}, Name: azure.MachineControllerManagerName},
 			{Type: &corev1.Secret{}, Name: azure.MachineControllerManagerName},
 		},
-	}
+	})
 
-	mcmShootChart = &chart.Chart{
+	mcmShootChart = util.NewChart(&chart.Chart{
 		Name: azure.MachineControllerManagerName,
 		Path: filepath.Join(azure.InternalChartsPath, azure.MachineControllerManagerName, "shoot"),
 		Objects: []*chart.Object{
 			{Type: &rbacv1.ClusterRole{}, Name: fmt.Sprintf("extensions.gardener.cloud:%s:%s", azure.Name, azure.MachineControllerManagerName)},
 			{Type: &rbacv1.ClusterRoleBinding{}, Name: fmt.Sprintf("extensions.gardener.cloud:%s:%s", azure.Name, azure.MachineControllerManagerName)},
 		},
-	}
+	})
 )
 
 func (w *workerDelegate) GetMachineControllerManagerChartValues(ctx context.Context) (map[string]interface{}, error) {
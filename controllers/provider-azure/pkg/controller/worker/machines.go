@@ -21,7 +21,7 @@ import (
 
 	azureapi "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure"
 	azureapihelper "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/helper"
-	confighelper "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/config/helper"
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/azure"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
@@ -105,11 +105,17 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	findMachineImage, err := newMachineImageLookup(w.decoder, w.machineImages, w.cluster)
+	if err != nil {
+		return err
+	}
+
 	for _, pool := range w.worker.Spec.Pools {
-		machineImage, err := confighelper.FindImage(w.machineImages, pool.MachineImage.Name, pool.MachineImage.Version)
+		image, err := findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
 		}
+		machineImage := image.(*config.MachineImage)
 
 		volumeSize, err := worker.DiskSize(pool.Volume.Size)
 		if err != nil {
@@ -149,6 +155,7 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 		machineDeployments = append(machineDeployments, worker.MachineDeployment{
 			Name:           deploymentName,
+			PoolName:       pool.Name,
 			ClassName:      className,
 			SecretName:     className,
 			Minimum:        pool.Minimum,
@@ -328,6 +328,7 @@ var _ = Describe("Machines", func() {
 				machineDeployments := worker.MachineDeployments{
 					{
 						Name:           machineClassNamePool1,
+						PoolName:       namePool1,
 						ClassName:      machineClassWithHashPool1,
 						SecretName:     machineClassWithHashPool1,
 						Minimum:        minPool1,
@@ -337,6 +338,7 @@ var _ = Describe("Machines", func() {
 					},
 					{
 						Name:           machineClassNamePool2,
+						PoolName:       namePool2,
 						ClassName:      machineClassWithHashPool2,
 						SecretName:     machineClassWithHashPool2,
 						Minimum:        minPool2,
@@ -361,6 +363,15 @@ var _ = Describe("Machines", func() {
 				Expect(result).To(BeNil())
 			})
 
+			It("should fail because the provider config of the cloud profile is invalid", func() {
+				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+				cluster.CloudProfileConfig = []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"CloudProfileConfig","machineImages":[{"name":""}]}`)
+				workerDelegate = NewWorkerDelegate(c, decoder, machineImages, chartApplier, "", w, cluster)
+				result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+				Expect(err).To(HaveOccurred())
+				Expect(result).To(BeNil())
+			})
+
 			It("should fail because the version is invalid", func() {
 				expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
 
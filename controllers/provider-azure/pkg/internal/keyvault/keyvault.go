@@ -0,0 +1,187 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal"
+)
+
+// The Azure SDK is not vendored, hence the few operations of Key Vault that are needed are implemented here with its
+// REST API.
+const (
+	activeDirectoryEndpoint = "https://login.microsoftonline.com/"
+
+	apiVersion = "7.0"
+
+	// wrapKeyAlgorithm is the algorithm the keys are wrapped with.
+	wrapKeyAlgorithm = "RSA-OAEP"
+)
+
+// Interface is the interface for the Key Vault client.
+type Interface interface {
+	// WrapKey wraps the given key with the Key Vault key with the given identifier and returns the wrapped key.
+	WrapKey(ctx context.Context, keyID string, key []byte) ([]byte, error)
+}
+
+// tokenResponse is the response of the token endpoint of Azure Active Directory.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+}
+
+// keyOperationsRequest is the request of the key operations of Key Vault.
+type keyOperationsRequest struct {
+	Algorithm string `json:"alg"`
+	Value     string `json:"value"`
+}
+
+// keyOperationsResponse is the response of the key operations of Key Vault.
+type keyOperationsResponse struct {
+	KeyID string `json:"kid"`
+	Value string `json:"value"`
+}
+
+type client struct {
+	httpClient   *http.Client
+	tenantID     string
+	clientID     string
+	clientSecret string
+}
+
+// NewFromClientAuth creates a new client that authenticates with the service principal of the given client auth.
+func NewFromClientAuth(clientAuth *internal.ClientAuth) Interface {
+	return New(http.DefaultClient, clientAuth.TenantID, clientAuth.ClientID, clientAuth.ClientSecret)
+}
+
+// New creates a new client that sends its requests with the given HTTP client and authenticates with the given
+// service principal.
+func New(httpClient *http.Client, tenantID, clientID, clientSecret string) Interface {
+	return &client{httpClient, tenantID, clientID, clientSecret}
+}
+
+// WrapKey implements Interface.
+func (c *client) WrapKey(ctx context.Context, keyID string, key []byte) ([]byte, error) {
+	u, err := url.Parse(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The access token is issued for the key vault service of the cloud the key vault belongs to, e.g.
+	// https://vault.azure.net for https://my-vault.vault.azure.net
+	hostParts := strings.SplitN(u.Host, ".", 2)
+	if len(hostParts) != 2 {
+		return nil, fmt.Errorf("%q is not the identifier of a Key Vault key", keyID)
+	}
+	token, err := c.getToken(ctx, u.Scheme+"://"+hostParts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(&keyOperationsRequest{
+		Algorithm: wrapKeyAlgorithm,
+		Value:     base64.RawURLEncoding.EncodeToString(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, keyID+"/wrapkey?api-version="+apiVersion, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	keyOperationsResp := &keyOperationsResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(keyOperationsResp); err != nil {
+		return nil, err
+	}
+	return base64.RawURLEncoding.DecodeString(keyOperationsResp.Value)
+}
+
+// getToken returns an access token of the service principal for the given resource.
+func (c *client) getToken(ctx context.Context, resource string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"resource":      {resource},
+	}
+
+	req, err := http.NewRequest(http.MethodPost, activeDirectoryEndpoint+c.tenantID+"/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp)
+	}
+
+	tokenResp := &tokenResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(tokenResp); err != nil {
+		return "", err
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// responseError returns an error with the message of the given unsuccessful response of Azure Active Directory or
+// Key Vault.
+func responseError(resp *http.Response) error {
+	var errResp struct {
+		// Error is either the error code of Azure Active Directory or the error object of Key Vault
+		Error            json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	var keyVaultErr struct {
+		Message string `json:"message"`
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		if errResp.ErrorDescription != "" {
+			return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, errResp.ErrorDescription)
+		}
+		if err := json.Unmarshal(errResp.Error, &keyVaultErr); err == nil && keyVaultErr.Message != "" {
+			return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, keyVaultErr.Message)
+		}
+	}
+	return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyvault_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/internal/keyvault"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const keyID = "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef0123456789abcdef"
+
+var _ = Describe("Key Vault", func() {
+	var (
+		server       *httptest.Server
+		handler      http.HandlerFunc
+		hosts        []string
+		c            Interface
+		tokenHandler http.HandlerFunc
+	)
+
+	BeforeEach(func() {
+		hosts = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/tenant-id/oauth2/token" {
+				tokenHandler(w, r)
+				return
+			}
+			handler(w, r)
+		}))
+
+		serverURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			// Send the requests to Azure Active Directory and Key Vault to the test server
+			hosts = append(hosts, r.URL.Host)
+			r.URL.Scheme, r.URL.Host = serverURL.Scheme, serverURL.Host
+			return http.DefaultTransport.RoundTrip(r)
+		})}
+		c = New(httpClient, "tenant-id", "client-id", "client-secret")
+
+		tokenHandler = func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(r.ParseForm()).To(Succeed())
+			Expect(r.PostForm).To(Equal(url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {"client-id"},
+				"client_secret": {"client-secret"},
+				"resource":      {"https://vault.azure.net"},
+			}))
+
+			_, _ = w.Write([]byte(`{"token_type":"Bearer","access_token":"token"}`))
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#WrapKey", func() {
+		It("should return the wrapped key", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/keys/my-key/0123456789abcdef0123456789abcdef/wrapkey"))
+				Expect(r.URL.Query().Get("api-version")).To(Equal("7.0"))
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer token"))
+
+				body, err := ioutil.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{"alg":"RSA-OAEP","value":"a2V5"}`))
+
+				_, _ = w.Write([]byte(`{"kid":"` + keyID + `","value":"d3JhcHBlZC1rZXk"}`))
+			}
+
+			Expect(c.WrapKey(context.TODO(), keyID, []byte("key"))).To(Equal([]byte("wrapped-key")))
+			Expect(hosts).To(Equal([]string{"login.microsoftonline.com", "my-vault.vault.azure.net"}))
+		})
+
+		It("should return the error of Azure Active Directory", func() {
+			tokenHandler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"AADSTS7000215: Invalid client secret is provided."}`))
+			}
+
+			_, err := c.WrapKey(context.TODO(), keyID, []byte("key"))
+			Expect(err).To(MatchError("unexpected status code 401: AADSTS7000215: Invalid client secret is provided."))
+		})
+
+		It("should return the error of Key Vault", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":{"code":"Forbidden","message":"The user does not have keys wrapKey permission on key vault 'my-vault'"}}`))
+			}
+
+			_, err := c.WrapKey(context.TODO(), keyID, []byte("key"))
+			Expect(err).To(MatchError("unexpected status code 403: The user does not have keys wrapKey permission on key vault 'my-vault'"))
+		})
+	})
+})
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
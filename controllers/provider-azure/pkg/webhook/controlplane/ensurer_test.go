@@ -17,15 +17,22 @@ package controlplane
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/apis/azure/install"
 	"github.com/gardener/gardener-extensions/controllers/provider-azure/pkg/azure"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	controlplanecontroller "github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/test"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -35,14 +42,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
 const (
 	namespace                  = "test"
 	cloudProviderConfigContent = "[Global]\nauth-url: https://cluster.eu-de-200.cloud.sap:5000/v3/\n"
+	keyID                      = "https://my-vault.vault.azure.net/keys/my-key/0123456789abcdef0123456789abcdef"
 )
 
 func TestController(t *testing.T) {
@@ -64,11 +74,36 @@ var _ = Describe("Ensurer", func() {
 			"checksum/configmap-" + azure.CloudProviderConfigName: "2ac8b96caad089f7b0217f0b2916ff4e8d4346655746de55178207e180cf0bbe",
 		}
 
+		kmsSecretKey = client.ObjectKey{Namespace: namespace, Name: controlplanecontroller.KMSEncryptionConfigSecretName}
+		kmsSecret    = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: controlplanecontroller.KMSEncryptionConfigSecretName},
+			Data: map[string][]byte{
+				controlplanecontroller.KMSKeysSecretKey:             []byte(keyID),
+				controlplanecontroller.KMSEncryptionConfigSecretKey: []byte("foo"),
+			},
+		}
+		kmsNotFound = errors.NewNotFound(corev1.Resource("secrets"), controlplanecontroller.KMSEncryptionConfigSecretName)
+
+		imageVector = imagevector.ImageVector{
+			{
+				Name:       azure.KMSPluginImageName,
+				Repository: "test-repository",
+				Tag:        util.StringPtr("test-tag"),
+			},
+		}
+
 		kubeControllerManagerLabels = map[string]string{
 			"networking.gardener.cloud/to-public-networks":  "allowed",
 			"networking.gardener.cloud/to-private-networks": "allowed",
 			"networking.gardener.cloud/to-blocked-cidrs":    "allowed",
 		}
+
+		clusterAutoscaler = &extensionsconfig.ClusterAutoscaler{
+			Expander:                 util.StringPtr("least-waste"),
+			BalanceSimilarNodeGroups: util.BoolPtr(true),
+			ScaleDownDelayAfterAdd:   &metav1.Duration{Duration: 30 * time.Minute},
+			ScaleDownUnneededTime:    &metav1.Duration{Duration: 20 * time.Minute},
+		}
 	)
 
 	BeforeEach(func() {
@@ -100,10 +135,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(kmsNotFound)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -145,10 +181,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).Return(kmsNotFound)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -157,6 +194,60 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeAPIServerDeployment(dep, annotations)
 		})
+
+		It("should add a KMS plugin for each key to kube-apiserver deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeAPIServerDeploymentName},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+									},
+								},
+							},
+						},
+					},
+				}
+				kmsAnnotations = map[string]string{
+					"checksum/secret-" + controlplanecontroller.KMSEncryptionConfigSecretName: "44fc9a7be17a9e548f50f2dd0f428bfaeb3b4582a5a9313fb0a5b6349b7151bf",
+				}
+			)
+			for k, v := range annotations {
+				kmsAnnotations[k] = v
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kmsSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(kmsSecret)).Times(2)
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			checkKubeAPIServerDeployment(dep, kmsAnnotations)
+
+			name := controlplanecontroller.KMSPluginName(keyID)
+			c := controlplane.ContainerWithName(dep.Spec.Template.Spec.Containers, name)
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Image).To(Equal("test-repository:test-tag"))
+			Expect(c.Command).To(Equal([]string{
+				"/bin/k8s-azure-kms",
+				"--keyvault-name=my-vault",
+				"--key-name=my-key",
+				"--key-version=0123456789abcdef0123456789abcdef",
+				"--listen-addr=unix://" + controlplanecontroller.KMSPluginSocketPath(name),
+				"--config-file-path=/etc/kubernetes/cloudprovider/cloudprovider.conf",
+			}))
+			Expect(c.VolumeMounts).To(ContainElement(cloudProviderConfigVolumeMount))
+		})
 	})
 
 	Describe("#EnsureKubeControllerManagerDeployment", func() {
@@ -180,10 +271,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -224,10 +316,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -236,6 +329,77 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeControllerManagerDeployment(dep, annotations, kubeControllerManagerLabels)
 		})
+
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeControllerManagerDeploymentName},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-controller-manager",
+										Command: []string{"--feature-gates=Foo=true"},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(append(csiMigrationObjects("1.15.0"), cm)...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err := ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			container := controlplane.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(container.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAzureDisk=true,Foo=true"))
+		})
+	})
+	Describe("#EnsureClusterAutoscalerDeployment", func() {
+		It("should add or modify the configured flags of the cluster-autoscaler deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "cluster-autoscaler",
+										Command: []string{
+											"./cluster-autoscaler",
+											"--expander=random",
+											"--scale-down-unneeded-time=30m",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+
+			// Call EnsureClusterAutoscalerDeployment method and check the result
+			err := ensurer.EnsureClusterAutoscalerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--expander=least-waste",
+				"--scale-down-unneeded-time=20m0s",
+				"--balance-similar-node-groups=true",
+				"--scale-down-delay-after-add=30m0s",
+			}))
+		})
 	})
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
@@ -262,7 +426,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 
 			// Call EnsureKubeletServiceUnitOptions method and check the result
 			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), oldUnitOptions)
@@ -288,17 +452,60 @@ var _ = Describe("Ensurer", func() {
 				}
 			)
 
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
+
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig)
+			err = ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig, namespace)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
-	})
 
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
+				FeatureGates: map[string]bool{
+					"Foo": true,
+				},
+			}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.15.0")...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(Equal(map[string]bool{
+				"Foo":                   true,
+				"CSIMigration":          true,
+				"CSIMigrationAzureDisk": true,
+			}))
+		})
+
+		It("should not enable the CSI migration feature gates if the Kubernetes version doesn't support it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.13.4")...)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(BeEmpty())
+		})
+	})
 	Describe("#EnsureKubeletCloudProviderConfig", func() {
 		var (
 			existingData = util.StringPtr("[LoadBalancer]\nlb-version=v2\nlb-provider:\n")
@@ -310,7 +517,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, cm.Name))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -325,7 +532,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -340,7 +547,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, imageVector, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -401,3 +608,35 @@ func clientGet(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+func newFakeClient(objects ...runtime.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(install.AddToScheme(scheme)).To(Succeed())
+	return fake.NewFakeClientWithScheme(scheme, objects...), scheme
+}
+
+func csiMigrationObjects(kubernetesVersion string) []runtime.Object {
+	return []runtime.Object{
+		&extensionsv1alpha1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "control-plane"},
+			Spec: extensionsv1alpha1.ControlPlaneSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: azure.Type},
+				ProviderConfig: &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","csi":{"enabled":true,"migrateVolumes":true}}`),
+				},
+			},
+		},
+		&extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"CloudProfile"}`)},
+				Seed:         runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Seed"}`)},
+				Shoot: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Shoot","spec":{"kubernetes":{"version":"` + kubernetesVersion + `"}}}`),
+				},
+			},
+		},
+	}
+}
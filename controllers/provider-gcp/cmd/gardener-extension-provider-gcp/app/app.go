@@ -25,10 +25,12 @@ import (
 	gcpinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/controller/infrastructure"
 	gcpworker "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
+	gcpcontrolplanewebhook "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/webhook/controlplane"
 	gcpcontrolplanebackup "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/webhook/controlplanebackup"
 	gcpcontrolplaneexposure "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/webhook/controlplaneexposure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -53,6 +55,18 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		controlPlaneCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		controlPlaneReconcileOpts = &controlplane.ReconcilerOptions{
+			ResyncInterval: controlplane.DefaultResyncInterval,
+		}
+		controlPlaneSecretsRotationOpts = &controlplane.SecretsRotationOptions{
+			CertificateRenewalWindow: util.DefaultCertificateRenewalWindow,
+			CABundleDuration:         util.DefaultCABundleDuration,
+		}
+		controlPlaneDeletionOpts = &controlplane.DeletionOptions{
+			ManagedResourceDeletionTimeout: controlplane.DefaultManagedResourceDeletionTimeout,
+			OrphanPolicy:                   string(controlplane.OrphanPolicyNever),
+		}
+		controlPlaneCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(controlPlaneCtrlOpts, controlPlaneReconcileOpts, controlPlaneSecretsRotationOpts, controlPlaneDeletionOpts)
 
 		// options for the infrastructure controller
 		infraCtrlOpts = &controllercmd.ControllerOptions{
@@ -68,7 +82,8 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			MaxConcurrentReconciles: 5,
 		}
 		workerReconcileOpts = &worker.Options{
-			DeployCRDs: true,
+			DeployCRDs:     true,
+			RolloutTimeout: worker.DefaultRolloutTimeout,
 		}
 		workerCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(workerCtrlOpts, workerReconcileOpts)
 
@@ -88,7 +103,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
-			controllercmd.PrefixOption("controlplane-", controlPlaneCtrlOpts),
+			controllercmd.PrefixOption("controlplane-", &controlPlaneCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("infrastructure-", &infraCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("worker-", &workerCtrlOptsUnprefixed),
 			configFileOpts,
@@ -129,17 +144,26 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyMachineImages(&gcpworker.DefaultAddOptions.MachineImages)
 			configFileOpts.Completed().ApplyETCDStorage(&gcpcontrolplaneexposure.DefaultAddOptions.ETCDStorage)
 			configFileOpts.Completed().ApplyETCDBackup(&gcpcontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyClusterAutoscaler(&gcpcontrolplanewebhook.DefaultAddOptions.ClusterAutoscaler)
+			configFileOpts.Completed().ApplyMachineControllerManager(&gcpworker.DefaultAddOptions.MachineControllerManager)
 			controlPlaneCtrlOpts.Completed().Apply(&gcpcontrolplane.Options)
+			controlPlaneReconcileOpts.Completed().ApplyResyncInterval(&gcpcontrolplane.ResyncInterval)
+			controlPlaneSecretsRotationOpts.Completed().Apply(gcpcontrolplane.RotatingSecrets)
+			controlPlaneDeletionOpts.Completed().ApplyManagedResourceDeletionTimeout(&gcpcontrolplane.ManagedResourceDeletionTimeout)
+			controlPlaneDeletionOpts.Completed().ApplyOrphanPolicy(&gcpcontrolplane.OrphanPolicy)
 			infraCtrlOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
 			workerCtrlOpts.Completed().Apply(&gcpworker.DefaultAddOptions.Controller)
+			workerReconcileOpts.Completed().ApplyRolloutTimeout(&gcpworker.DefaultAddOptions.RolloutTimeout)
 
-			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
+			shootWebhookConfig, err := webhookOptions.Completed().AddToManager(mgr)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
 			}
+			gcpcontrolplane.ShootWebhookConfig = shootWebhookConfig
 
-			if err := webhookOptions.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
+			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
 			}
 
 			if err := mgr.Start(ctx.Done()); err != nil {
@@ -23,8 +23,10 @@ package v1alpha1
 import (
 	unsafe "unsafe"
 
-	config "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/config"
+	apisconfig "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/config"
+	config "github.com/gardener/gardener-extensions/pkg/apis/config"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -38,88 +40,146 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
-	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*ClusterAutoscaler)(nil), (*config.ClusterAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(a.(*ClusterAutoscaler), b.(*config.ClusterAutoscaler), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*config.ControllerConfiguration)(nil), (*ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(a.(*config.ControllerConfiguration), b.(*ControllerConfiguration), scope)
+	if err := s.AddGeneratedConversionFunc((*config.ClusterAutoscaler)(nil), (*ClusterAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(a.(*config.ClusterAutoscaler), b.(*ClusterAutoscaler), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ETCD)(nil), (*config.ETCD)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_ETCD_To_config_ETCD(a.(*ETCD), b.(*config.ETCD), scope)
+	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*apisconfig.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*apisconfig.ControllerConfiguration), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*config.ETCD)(nil), (*ETCD)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_config_ETCD_To_v1alpha1_ETCD(a.(*config.ETCD), b.(*ETCD), scope)
+	if err := s.AddGeneratedConversionFunc((*apisconfig.ControllerConfiguration)(nil), (*ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(a.(*apisconfig.ControllerConfiguration), b.(*ControllerConfiguration), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ETCDBackup)(nil), (*config.ETCDBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_ETCDBackup_To_config_ETCDBackup(a.(*ETCDBackup), b.(*config.ETCDBackup), scope)
+	if err := s.AddGeneratedConversionFunc((*ETCD)(nil), (*apisconfig.ETCD)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ETCD_To_config_ETCD(a.(*ETCD), b.(*apisconfig.ETCD), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*config.ETCDBackup)(nil), (*ETCDBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup(a.(*config.ETCDBackup), b.(*ETCDBackup), scope)
+	if err := s.AddGeneratedConversionFunc((*apisconfig.ETCD)(nil), (*ETCD)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ETCD_To_v1alpha1_ETCD(a.(*apisconfig.ETCD), b.(*ETCD), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*ETCDStorage)(nil), (*config.ETCDStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(a.(*ETCDStorage), b.(*config.ETCDStorage), scope)
+	if err := s.AddGeneratedConversionFunc((*ETCDBackup)(nil), (*apisconfig.ETCDBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ETCDBackup_To_config_ETCDBackup(a.(*ETCDBackup), b.(*apisconfig.ETCDBackup), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*config.ETCDStorage)(nil), (*ETCDStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(a.(*config.ETCDStorage), b.(*ETCDStorage), scope)
+	if err := s.AddGeneratedConversionFunc((*apisconfig.ETCDBackup)(nil), (*ETCDBackup)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup(a.(*apisconfig.ETCDBackup), b.(*ETCDBackup), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*config.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_MachineImage_To_config_MachineImage(a.(*MachineImage), b.(*config.MachineImage), scope)
+	if err := s.AddGeneratedConversionFunc((*ETCDStorage)(nil), (*apisconfig.ETCDStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(a.(*ETCDStorage), b.(*apisconfig.ETCDStorage), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*config.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_config_MachineImage_To_v1alpha1_MachineImage(a.(*config.MachineImage), b.(*MachineImage), scope)
+	if err := s.AddGeneratedConversionFunc((*apisconfig.ETCDStorage)(nil), (*ETCDStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(a.(*apisconfig.ETCDStorage), b.(*ETCDStorage), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineControllerManager)(nil), (*config.MachineControllerManager)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(a.(*MachineControllerManager), b.(*config.MachineControllerManager), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.MachineControllerManager)(nil), (*MachineControllerManager)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(a.(*config.MachineControllerManager), b.(*MachineControllerManager), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*apisconfig.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_config_MachineImage(a.(*MachineImage), b.(*apisconfig.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*apisconfig.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MachineImage_To_v1alpha1_MachineImage(a.(*apisconfig.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in *ClusterAutoscaler, out *config.ClusterAutoscaler, s conversion.Scope) error {
+	out.Expander = (*string)(unsafe.Pointer(in.Expander))
+	out.BalanceSimilarNodeGroups = (*bool)(unsafe.Pointer(in.BalanceSimilarNodeGroups))
+	out.ScaleDownDelayAfterAdd = (*v1.Duration)(unsafe.Pointer(in.ScaleDownDelayAfterAdd))
+	out.ScaleDownUnneededTime = (*v1.Duration)(unsafe.Pointer(in.ScaleDownUnneededTime))
+	return nil
+}
+
+// Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in *ClusterAutoscaler, out *config.ClusterAutoscaler, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in, out, s)
+}
+
+func autoConvert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in *config.ClusterAutoscaler, out *ClusterAutoscaler, s conversion.Scope) error {
+	out.Expander = (*string)(unsafe.Pointer(in.Expander))
+	out.BalanceSimilarNodeGroups = (*bool)(unsafe.Pointer(in.BalanceSimilarNodeGroups))
+	out.ScaleDownDelayAfterAdd = (*v1.Duration)(unsafe.Pointer(in.ScaleDownDelayAfterAdd))
+	out.ScaleDownUnneededTime = (*v1.Duration)(unsafe.Pointer(in.ScaleDownUnneededTime))
 	return nil
 }
 
-func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
+// Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler is an autogenerated conversion function.
+func Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in *config.ClusterAutoscaler, out *ClusterAutoscaler, s conversion.Scope) error {
+	return autoConvert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in, out, s)
+}
+
+func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *apisconfig.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*componentbaseconfig.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
-	out.MachineImages = *(*[]config.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	out.MachineImages = *(*[]apisconfig.MachineImage)(unsafe.Pointer(&in.MachineImages))
 	if err := Convert_v1alpha1_ETCD_To_config_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(&in.ClusterAutoscaler, &out.ClusterAutoscaler, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(&in.MachineControllerManager, &out.MachineControllerManager, s); err != nil {
+		return err
+	}
 	return nil
 }
 
 // Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration is an autogenerated conversion function.
-func Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
+func Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *apisconfig.ControllerConfiguration, s conversion.Scope) error {
 	return autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in, out, s)
 }
 
-func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *config.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
+func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *apisconfig.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*configv1alpha1.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
 	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
 	if err := Convert_config_ETCD_To_v1alpha1_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
+	if err := Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(&in.ClusterAutoscaler, &out.ClusterAutoscaler, s); err != nil {
+		return err
+	}
+	if err := Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(&in.MachineControllerManager, &out.MachineControllerManager, s); err != nil {
+		return err
+	}
 	return nil
 }
 
 // Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration is an autogenerated conversion function.
-func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *config.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
+func Convert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in *apisconfig.ControllerConfiguration, out *ControllerConfiguration, s conversion.Scope) error {
 	return autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfiguration(in, out, s)
 }
 
-func autoConvert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *config.ETCD, s conversion.Scope) error {
+func autoConvert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *apisconfig.ETCD, s conversion.Scope) error {
 	if err := Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(&in.Storage, &out.Storage, s); err != nil {
 		return err
 	}
@@ -130,11 +190,11 @@ func autoConvert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *config.ETCD, s conv
 }
 
 // Convert_v1alpha1_ETCD_To_config_ETCD is an autogenerated conversion function.
-func Convert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *config.ETCD, s conversion.Scope) error {
+func Convert_v1alpha1_ETCD_To_config_ETCD(in *ETCD, out *apisconfig.ETCD, s conversion.Scope) error {
 	return autoConvert_v1alpha1_ETCD_To_config_ETCD(in, out, s)
 }
 
-func autoConvert_config_ETCD_To_v1alpha1_ETCD(in *config.ETCD, out *ETCD, s conversion.Scope) error {
+func autoConvert_config_ETCD_To_v1alpha1_ETCD(in *apisconfig.ETCD, out *ETCD, s conversion.Scope) error {
 	if err := Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(&in.Storage, &out.Storage, s); err != nil {
 		return err
 	}
@@ -145,53 +205,77 @@ func autoConvert_config_ETCD_To_v1alpha1_ETCD(in *config.ETCD, out *ETCD, s conv
 }
 
 // Convert_config_ETCD_To_v1alpha1_ETCD is an autogenerated conversion function.
-func Convert_config_ETCD_To_v1alpha1_ETCD(in *config.ETCD, out *ETCD, s conversion.Scope) error {
+func Convert_config_ETCD_To_v1alpha1_ETCD(in *apisconfig.ETCD, out *ETCD, s conversion.Scope) error {
 	return autoConvert_config_ETCD_To_v1alpha1_ETCD(in, out, s)
 }
 
-func autoConvert_v1alpha1_ETCDBackup_To_config_ETCDBackup(in *ETCDBackup, out *config.ETCDBackup, s conversion.Scope) error {
+func autoConvert_v1alpha1_ETCDBackup_To_config_ETCDBackup(in *ETCDBackup, out *apisconfig.ETCDBackup, s conversion.Scope) error {
 	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
 // Convert_v1alpha1_ETCDBackup_To_config_ETCDBackup is an autogenerated conversion function.
-func Convert_v1alpha1_ETCDBackup_To_config_ETCDBackup(in *ETCDBackup, out *config.ETCDBackup, s conversion.Scope) error {
+func Convert_v1alpha1_ETCDBackup_To_config_ETCDBackup(in *ETCDBackup, out *apisconfig.ETCDBackup, s conversion.Scope) error {
 	return autoConvert_v1alpha1_ETCDBackup_To_config_ETCDBackup(in, out, s)
 }
 
-func autoConvert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in *config.ETCDBackup, out *ETCDBackup, s conversion.Scope) error {
+func autoConvert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in *apisconfig.ETCDBackup, out *ETCDBackup, s conversion.Scope) error {
 	out.Schedule = (*string)(unsafe.Pointer(in.Schedule))
 	return nil
 }
 
 // Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup is an autogenerated conversion function.
-func Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in *config.ETCDBackup, out *ETCDBackup, s conversion.Scope) error {
+func Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in *apisconfig.ETCDBackup, out *ETCDBackup, s conversion.Scope) error {
 	return autoConvert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in, out, s)
 }
 
-func autoConvert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *config.ETCDStorage, s conversion.Scope) error {
+func autoConvert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *apisconfig.ETCDStorage, s conversion.Scope) error {
 	out.ClassName = (*string)(unsafe.Pointer(in.ClassName))
 	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
 	return nil
 }
 
 // Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage is an autogenerated conversion function.
-func Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *config.ETCDStorage, s conversion.Scope) error {
+func Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *apisconfig.ETCDStorage, s conversion.Scope) error {
 	return autoConvert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in, out, s)
 }
 
-func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
+func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *apisconfig.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	out.ClassName = (*string)(unsafe.Pointer(in.ClassName))
 	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
 	return nil
 }
 
 // Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage is an autogenerated conversion function.
-func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
+func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *apisconfig.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
 
-func autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *config.MachineImage, s conversion.Scope) error {
+func autoConvert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in *MachineControllerManager, out *config.MachineControllerManager, s conversion.Scope) error {
+	out.MachineCreationTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineCreationTimeout))
+	out.MachineHealthTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineHealthTimeout))
+	out.MachineDrainTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineDrainTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager is an autogenerated conversion function.
+func Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in *MachineControllerManager, out *config.MachineControllerManager, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in, out, s)
+}
+
+func autoConvert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in *config.MachineControllerManager, out *MachineControllerManager, s conversion.Scope) error {
+	out.MachineCreationTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineCreationTimeout))
+	out.MachineHealthTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineHealthTimeout))
+	out.MachineDrainTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineDrainTimeout))
+	return nil
+}
+
+// Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager is an autogenerated conversion function.
+func Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in *config.MachineControllerManager, out *MachineControllerManager, s conversion.Scope) error {
+	return autoConvert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *apisconfig.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
 	out.Image = in.Image
@@ -199,11 +283,11 @@ func autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage,
 }
 
 // Convert_v1alpha1_MachineImage_To_config_MachineImage is an autogenerated conversion function.
-func Convert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *config.MachineImage, s conversion.Scope) error {
+func Convert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *apisconfig.MachineImage, s conversion.Scope) error {
 	return autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in, out, s)
 }
 
-func autoConvert_config_MachineImage_To_v1alpha1_MachineImage(in *config.MachineImage, out *MachineImage, s conversion.Scope) error {
+func autoConvert_config_MachineImage_To_v1alpha1_MachineImage(in *apisconfig.MachineImage, out *MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
 	out.Image = in.Image
@@ -211,6 +295,6 @@ func autoConvert_config_MachineImage_To_v1alpha1_MachineImage(in *config.Machine
 }
 
 // Convert_config_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
-func Convert_config_MachineImage_To_v1alpha1_MachineImage(in *config.MachineImage, out *MachineImage, s conversion.Scope) error {
+func Convert_config_MachineImage_To_v1alpha1_MachineImage(in *apisconfig.MachineImage, out *MachineImage, s conversion.Scope) error {
 	return autoConvert_config_MachineImage_To_v1alpha1_MachineImage(in, out, s)
 }
@@ -31,3 +31,17 @@ func FindSubnetByPurpose(subnets []gcp.Subnet, purpose gcp.SubnetPurpose) (*gcp.
 	}
 	return nil, fmt.Errorf("cannot find subnet with purpose %q", purpose)
 }
+
+// FindImageFromCloudProfile takes a CloudProfileConfig, and the desired image name and version. It tries to find the
+// image with the given name and version. If it cannot be found then an error is returned.
+func FindImageFromCloudProfile(cloudProfileConfig *gcp.CloudProfileConfig, imageName, imageVersion string) (string, error) {
+	if cloudProfileConfig != nil {
+		for _, machineImage := range cloudProfileConfig.MachineImages {
+			if machineImage.Name == imageName && machineImage.Version == imageVersion {
+				return machineImage.Image, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find an image for name %q in version %q in the cloud profile", imageName, imageVersion)
+}
@@ -40,6 +40,25 @@ var _ = Describe("Helper", func() {
 		Entry("entry not found", []gcp.Subnet{{Name: "bar", Purpose: purposeWrong}}, purpose, nil, true),
 		Entry("entry exists", []gcp.Subnet{{Name: "bar", Purpose: purpose}}, purpose, &gcp.Subnet{Name: "bar", Purpose: purpose}, false),
 	)
+
+	DescribeTable("#FindImageFromCloudProfile",
+		func(cloudProfileConfig *gcp.CloudProfileConfig, imageName, imageVersion, expectedImage string) {
+			image, err := FindImageFromCloudProfile(cloudProfileConfig, imageName, imageVersion)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", ""),
+		Entry("no machine images", &gcp.CloudProfileConfig{}, "ubuntu", "1", ""),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "coreos", "1", ""),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "2", ""),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "1", "image-1234"),
+	)
 })
 
 func expectResults(result, expected interface{}, err error, expectErr bool) {
@@ -51,3 +70,15 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func makeCloudProfileConfig(name, version, image string) *gcp.CloudProfileConfig {
+	return &gcp.CloudProfileConfig{
+		MachineImages: []gcp.MachineImage{
+			{
+				Name:    name,
+				Version: version,
+				Image:   image,
+			},
+		},
+	}
+}
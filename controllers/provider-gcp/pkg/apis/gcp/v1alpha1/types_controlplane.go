@@ -31,9 +31,54 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+
+	// CSI contains configuration settings for the CSI driver.
+	// +optional
+	CSI *CSIConfig `json:"csi,omitempty"`
+
+	// KMS contains configuration settings for the encryption of secrets with a Cloud KMS key.
+	// +optional
+	KMS *KMSConfig `json:"kms,omitempty"`
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	gardenv1beta1.KubernetesConfig `json:",inline"`
+
+	// LoadBalancer contains configuration settings for the load balancers of services of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// AdditionalNodeTags are network tags of the nodes that the firewall rules of the load balancers target in
+	// addition to the network tag of the shoot.
+	// +optional
+	AdditionalNodeTags []string `json:"additionalNodeTags,omitempty"`
+	// SubnetworkName is the name of the subnetwork that the IPs of internal load balancers are allocated from.
+	// Defaults to the internal subnetwork of the infrastructure, if any.
+	// +optional
+	SubnetworkName *string `json:"subnetworkName,omitempty"`
+}
+
+// CSIConfig contains configuration settings for the CSI driver.
+type CSIConfig struct {
+	// Enabled deploys the CSI driver and the `default-csi` StorageClass. It is only deployed for shoots with
+	// Kubernetes version 1.14 or higher. Existing persistent volumes keep using the in-tree volume plugin unless
+	// MigrateVolumes is set.
+	Enabled bool `json:"enabled"`
+	// MigrateVolumes enables the `CSIMigration` and `CSIMigrationGCE` feature gates of the kube-controller-manager and the
+	// kubelets, so that the existing persistent volumes of the in-tree volume plugin are handled by the CSI driver.
+	// It requires Enabled and is only applied for shoots with Kubernetes version 1.14 or higher.
+	// +optional
+	MigrateVolumes bool `json:"migrateVolumes,omitempty"`
+}
+
+// KMSConfig contains configuration settings for the encryption of secrets with a Cloud KMS key.
+type KMSConfig struct {
+	// KeyName is the resource name of the Cloud KMS key that secrets are encrypted with, i.e.
+	// projects/<project>/locations/<location>/keyRings/<key-ring>/cryptoKeys/<key>. The key must be in the project
+	// of the shoot, and the service account of the shoot must be allowed to use it.
+	KeyName string `json:"keyName"`
 }
@@ -36,6 +36,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CSIConfig)(nil), (*gcp.CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CSIConfig_To_gcp_CSIConfig(a.(*CSIConfig), b.(*gcp.CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CSIConfig)(nil), (*CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CSIConfig_To_v1alpha1_CSIConfig(a.(*gcp.CSIConfig), b.(*CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*gcp.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CloudControllerManagerConfig_To_gcp_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*gcp.CloudControllerManagerConfig), scope)
 	}); err != nil {
@@ -46,6 +56,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*gcp.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_gcp_CloudProfileConfig(a.(*CloudProfileConfig), b.(*gcp.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*gcp.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*gcp.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_gcp_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*gcp.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -76,6 +96,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMSConfig)(nil), (*gcp.KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMSConfig_To_gcp_KMSConfig(a.(*KMSConfig), b.(*gcp.KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.KMSConfig)(nil), (*KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_KMSConfig_To_v1alpha1_KMSConfig(a.(*gcp.KMSConfig), b.(*KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*gcp.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_gcp_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*gcp.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*gcp.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*gcp.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_gcp_MachineImage(a.(*MachineImage), b.(*gcp.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_MachineImage_To_v1alpha1_MachineImage(a.(*gcp.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkConfig)(nil), (*gcp.NetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(a.(*NetworkConfig), b.(*gcp.NetworkConfig), scope)
 	}); err != nil {
@@ -119,8 +169,31 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_CSIConfig_To_gcp_CSIConfig(in *CSIConfig, out *gcp.CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_v1alpha1_CSIConfig_To_gcp_CSIConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CSIConfig_To_gcp_CSIConfig(in *CSIConfig, out *gcp.CSIConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CSIConfig_To_gcp_CSIConfig(in, out, s)
+}
+
+func autoConvert_gcp_CSIConfig_To_v1alpha1_CSIConfig(in *gcp.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_gcp_CSIConfig_To_v1alpha1_CSIConfig is an autogenerated conversion function.
+func Convert_gcp_CSIConfig_To_v1alpha1_CSIConfig(in *gcp.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	return autoConvert_gcp_CSIConfig_To_v1alpha1_CSIConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_gcp_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *gcp.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*gcp.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
 	return nil
 }
 
@@ -131,6 +204,7 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_gcp_CloudControllerManager
 
 func autoConvert_gcp_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *gcp.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
 	return nil
 }
 
@@ -139,9 +213,31 @@ func Convert_gcp_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManager
 	return autoConvert_gcp_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_gcp_CloudProfileConfig(in *CloudProfileConfig, out *gcp.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]gcp.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_gcp_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_gcp_CloudProfileConfig(in *CloudProfileConfig, out *gcp.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_gcp_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_gcp_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *gcp.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_gcp_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_gcp_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *gcp.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_gcp_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_gcp_ControlPlaneConfig(in *ControlPlaneConfig, out *gcp.ControlPlaneConfig, s conversion.Scope) error {
 	out.Zone = in.Zone
 	out.CloudControllerManager = (*gcp.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*gcp.CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*gcp.KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -153,6 +249,8 @@ func Convert_v1alpha1_ControlPlaneConfig_To_gcp_ControlPlaneConfig(in *ControlPl
 func autoConvert_gcp_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *gcp.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.Zone = in.Zone
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*CSIConfig)(unsafe.Pointer(in.CSI))
+	out.KMS = (*KMSConfig)(unsafe.Pointer(in.KMS))
 	return nil
 }
 
@@ -211,6 +309,72 @@ func Convert_gcp_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *gcp.I
 	return autoConvert_gcp_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_KMSConfig_To_gcp_KMSConfig(in *KMSConfig, out *gcp.KMSConfig, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_v1alpha1_KMSConfig_To_gcp_KMSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KMSConfig_To_gcp_KMSConfig(in *KMSConfig, out *gcp.KMSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMSConfig_To_gcp_KMSConfig(in, out, s)
+}
+
+func autoConvert_gcp_KMSConfig_To_v1alpha1_KMSConfig(in *gcp.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_gcp_KMSConfig_To_v1alpha1_KMSConfig is an autogenerated conversion function.
+func Convert_gcp_KMSConfig_To_v1alpha1_KMSConfig(in *gcp.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	return autoConvert_gcp_KMSConfig_To_v1alpha1_KMSConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerConfig_To_gcp_LoadBalancerConfig(in *LoadBalancerConfig, out *gcp.LoadBalancerConfig, s conversion.Scope) error {
+	out.AdditionalNodeTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNodeTags))
+	out.SubnetworkName = (*string)(unsafe.Pointer(in.SubnetworkName))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_gcp_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_gcp_LoadBalancerConfig(in *LoadBalancerConfig, out *gcp.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_gcp_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_gcp_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *gcp.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.AdditionalNodeTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNodeTags))
+	out.SubnetworkName = (*string)(unsafe.Pointer(in.SubnetworkName))
+	return nil
+}
+
+// Convert_gcp_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_gcp_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *gcp.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_gcp_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_gcp_MachineImage(in *MachineImage, out *gcp.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_gcp_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_gcp_MachineImage(in *MachineImage, out *gcp.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_gcp_MachineImage(in, out, s)
+}
+
+func autoConvert_gcp_MachineImage_To_v1alpha1_MachineImage(in *gcp.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_gcp_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_gcp_MachineImage_To_v1alpha1_MachineImage(in *gcp.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_gcp_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
+
 func autoConvert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(in *NetworkConfig, out *gcp.NetworkConfig, s conversion.Scope) error {
 	out.VPC = (*gcp.VPC)(unsafe.Pointer(in.VPC))
 	out.Internal = (*corev1alpha1.CIDR)(unsafe.Pointer(in.Internal))
@@ -0,0 +1,52 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"regexp"
+
+	apisgcp "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	kmsKeyNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+	nameRegex       = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisgcp.ControlPlaneConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		loadBalancerPath := field.NewPath("cloudControllerManager", "loadBalancer")
+
+		for i, tag := range ccm.LoadBalancer.AdditionalNodeTags {
+			if !nameRegex.MatchString(tag) {
+				allErrs = append(allErrs, field.Invalid(loadBalancerPath.Child("additionalNodeTags").Index(i), tag, "must be a valid network tag"))
+			}
+		}
+		if name := ccm.LoadBalancer.SubnetworkName; name != nil && !nameRegex.MatchString(*name) {
+			allErrs = append(allErrs, field.Invalid(loadBalancerPath.Child("subnetworkName"), *name, "must be a valid subnetwork name"))
+		}
+	}
+
+	if kms := controlPlaneConfig.KMS; kms != nil && !kmsKeyNameRegex.MatchString(kms.KeyName) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("kms", "keyName"), kms.KeyName, "must be the resource name of a Cloud KMS key"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisgcp "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
+	. "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var controlPlaneConfig *apisgcp.ControlPlaneConfig
+
+	BeforeEach(func() {
+		subnetworkName := "internal"
+		controlPlaneConfig = &apisgcp.ControlPlaneConfig{
+			Zone: "europe-west1-b",
+			CloudControllerManager: &apisgcp.CloudControllerManagerConfig{
+				LoadBalancer: &apisgcp.LoadBalancerConfig{
+					AdditionalNodeTags: []string{"lb-target"},
+					SubnetworkName:     &subnetworkName,
+				},
+			},
+			KMS: &apisgcp.KMSConfig{
+				KeyName: "projects/foo/locations/europe-west1/keyRings/bar/cryptoKeys/baz",
+			},
+		}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(BeEmpty())
+		})
+
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControlPlaneConfig(&apisgcp.ControlPlaneConfig{})).To(BeEmpty())
+		})
+
+		It("should forbid invalid node tags and subnetwork names", func() {
+			subnetworkName := "Internal"
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.AdditionalNodeTags = []string{"lb-target", "lb_target"}
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.SubnetworkName = &subnetworkName
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.loadBalancer.additionalNodeTags[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.loadBalancer.subnetworkName"),
+				})),
+			))
+		})
+
+		It("should forbid invalid KMS key names", func() {
+			controlPlaneConfig.KMS.KeyName = "projects/foo/locations/europe-west1/keyRings/bar"
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("kms.keyName"),
+				})),
+			))
+		})
+	})
+})
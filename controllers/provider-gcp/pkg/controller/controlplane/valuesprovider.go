@@ -16,12 +16,16 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	apisgcp "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
+	gcpvalidation "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/validation"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/apihelper"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/kms"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
@@ -39,7 +43,9 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -51,6 +57,9 @@ import (
 const (
 	cloudControllerManagerDeploymentName = "cloud-controller-manager"
 	cloudControllerManagerServerName     = "cloud-controller-manager-server"
+	csiControllerDeploymentName          = "csi-driver-controller"
+	csiAttacherName                      = "csi-attacher"
+	csiProvisionerName                   = "csi-provisioner"
 )
 
 var controlPlaneSecrets = &secrets.Secrets{
@@ -85,11 +94,37 @@ var controlPlaneSecrets = &secrets.Secrets{
 					SigningCA:  cas[gardencorev1alpha1.SecretNameCACluster],
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiAttacherName,
+					CommonName:   "system:csi-attacher",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiProvisionerName,
+					CommonName:   "system:csi-provisioner",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
 		}
 	},
 }
 
-var configChart = &chart.Chart{
+var configChart = util.NewChart(&chart.Chart{
 	Name: "cloud-provider-config",
 	Path: filepath.Join(internal.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
@@ -98,39 +133,70 @@ var configChart = &chart.Chart{
 			Name: internal.CloudProviderConfigName,
 		},
 	},
-}
+})
 
-var ccmChart = &chart.Chart{
-	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(internal.InternalChartsPath, "cloud-controller-manager"),
-	Images: []string{gcp.HyperkubeImageName},
-	Objects: []*chart.Object{
-		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
-		{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+var controlPlaneChart = util.NewChart(&chart.Chart{
+	Name: "seed-controlplane",
+	Path: filepath.Join(internal.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{
+		{
+			Name:   "cloud-controller-manager",
+			Images: []string{gcp.HyperkubeImageName},
+			Objects: []*chart.Object{
+				{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
+				{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+			},
+		},
+		{
+			Name:   "csi-gcp",
+			Images: []string{gcp.CSIDriverImageName, gcp.CSIAttacherImageName, gcp.CSIProvisionerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: csiControllerDeploymentName},
+			},
+		},
 	},
-}
+})
 
-var ccmShootChart = &chart.Chart{
-	Name: "cloud-controller-manager-shoot",
-	Path: filepath.Join(internal.InternalChartsPath, "cloud-controller-manager-shoot"),
-	Objects: []*chart.Object{
-		{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
-		{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
+	Name: "shoot-system-components",
+	Path: filepath.Join(internal.InternalChartsPath, "shoot-system-components"),
+	SubCharts: []*chart.Chart{
+		{
+			Name: "cloud-controller-manager",
+			Objects: []*chart.Object{
+				{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+			},
+		},
+		{
+			Name:   "csi-gcp",
+			Images: []string{gcp.CSIDriverImageName, gcp.CSINodeDriverRegistrarImageName},
+			Objects: []*chart.Object{
+				{Type: &storagev1.StorageClass{}, Name: "default-csi"},
+				{Type: &appsv1.DaemonSet{}, Name: "csi-driver-node"},
+				{Type: &corev1.ServiceAccount{}, Name: "csi-driver-node"},
+				{Type: &policyv1beta1.PodSecurityPolicy{}, Name: "gardener.kube-system.csi-driver-node"},
+				{Type: &rbacv1.ClusterRole{}, Name: "garden.sapcloud.io:psp:kube-system:csi-driver-node"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "garden.sapcloud.io:psp:csi-driver-node"},
+			},
+		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
 	return &valuesProvider{
-		logger: logger.WithName("gcp-values-provider"),
+		logger:       logger.WithName("gcp-values-provider"),
+		newKMSClient: kms.NewFromServiceAccount,
 	}
 }
 
 // valuesProvider is a ValuesProvider that provides AWS-specific values for the 2 charts applied by the generic actuator.
 type valuesProvider struct {
-	decoder runtime.Decoder
-	client  client.Client
-	logger  logr.Logger
+	decoder      runtime.Decoder
+	client       client.Client
+	logger       logr.Logger
+	newKMSClient func(ctx context.Context, serviceAccount []byte) (kms.Interface, error)
 }
 
 // InjectScheme injects the given scheme into the valuesProvider.
@@ -156,6 +222,9 @@ func (vp *valuesProvider) GetConfigChartValues(
 	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
+	if errs := gcpvalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
 
 	// Decode infrastructureProviderStatus
 	infraStatus := &apisgcp.InfrastructureStatus{}
@@ -187,8 +256,8 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// Get control plane chart values
+	return getControlPlaneChartValues(cpConfig, cp, cluster, checksums, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
@@ -197,7 +266,58 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) (map[string]interface{}, error) {
-	return nil, nil
+	// Decode providerConfig
+	cpConfig := &apisgcp.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	// Get control plane shoot chart values
+	return getControlPlaneShootChartValues(cpConfig, cluster)
+}
+
+// GetKMSKeyID returns the resource name of the Cloud KMS key that the secrets of the shoot are encrypted with, or nil
+// if the shoot doesn't use one. The key must be in the project of the service account of the shoot, and the service
+// account must be allowed to encrypt with it, which is checked with a test encryption before the KMS plugin of
+// kube-apiserver uses the key.
+func (vp *valuesProvider) GetKMSKeyID(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (*string, error) {
+	// Decode providerConfig
+	cpConfig := &apisgcp.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if errs := gcpvalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if cpConfig.KMS == nil {
+		return nil, nil
+	}
+
+	// Get service account
+	serviceAccount, err := internal.GetServiceAccount(ctx, vp.client, cp.Spec.SecretRef)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get service account from secret '%s/%s'", cp.Spec.SecretRef.Namespace, cp.Spec.SecretRef.Name)
+	}
+
+	// The KMS plugin authenticates with the service account, which must belong to the project of the key
+	keyName := cpConfig.KMS.KeyName
+	if project := strings.Split(keyName, "/")[1]; project != serviceAccount.ProjectID {
+		return nil, fmt.Errorf("KMS key '%s' of controlplane '%s' is not in project '%s'", keyName, util.ObjectName(cp), serviceAccount.ProjectID)
+	}
+
+	kmsClient, err := vp.newKMSClient(ctx, serviceAccount.Raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create Cloud KMS client for controlplane '%s'", util.ObjectName(cp))
+	}
+	if _, err := kmsClient.Encrypt(ctx, keyName, []byte(controlplane.KMSKeyCheckPlaintext)); err != nil {
+		return nil, errors.Wrapf(err, "could not encrypt with KMS key '%s' of controlplane '%s'", keyName, util.ObjectName(cp))
+	}
+
+	return &keyName, nil
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
@@ -210,13 +330,47 @@ func getConfigChartValues(
 	// Determine network names
 	networkName, subNetworkName := getNetworkNames(infraStatus, cp)
 
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil && ccm.LoadBalancer.SubnetworkName != nil {
+		subNetworkName = *ccm.LoadBalancer.SubnetworkName
+	}
+
 	// Collect config chart values
-	return map[string]interface{}{
+	values := map[string]interface{}{
 		"projectID":      serviceAccount.ProjectID,
 		"networkName":    networkName,
 		"subNetworkName": subNetworkName,
 		"zone":           cpConfig.Zone,
 		"nodeTags":       cp.Namespace,
+	}
+
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil && len(ccm.LoadBalancer.AdditionalNodeTags) > 0 {
+		values["additionalNodeTags"] = ccm.LoadBalancer.AdditionalNodeTags
+	}
+
+	return values, nil
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *apisgcp.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIChartValues(cpConfig, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cloud-controller-manager": ccm,
+		"csi-gcp":                  csi,
 	}, nil
 }
 
@@ -250,6 +404,57 @@ func getCCMChartValues(
 	return values, nil
 }
 
+// getCSIChartValues collects and returns the CSI chart values.
+func getCSIChartValues(
+	cpConfig *apisgcp.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"enabled":           csiEnabled,
+		"replicas":          extensionscontroller.GetControlPlaneReplicas(cluster.Shoot, scaledDown, 1),
+		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-csi-attacher":             checksums[csiAttacherName],
+			"checksum/secret-csi-provisioner":          checksums[csiProvisionerName],
+			"checksum/secret-cloudprovider":            checksums[common.CloudProviderSecretName],
+			"checksum/configmap-cloud-provider-config": checksums[internal.CloudProviderConfigName],
+		},
+	}, nil
+}
+
+// getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
+func getControlPlaneShootChartValues(
+	cpConfig *apisgcp.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"csi-gcp": map[string]interface{}{
+			"enabled": csiEnabled,
+		},
+	}, nil
+}
+
+// isCSIEnabled returns true if the shoot opted in for the CSI driver and has a Kubernetes version that supports it.
+func isCSIEnabled(cpConfig *apisgcp.ControlPlaneConfig, cluster *extensionscontroller.Cluster) (bool, error) {
+	csiEnabled, err := controlplane.IsCSIEnabled(cpConfig.CSI != nil && cpConfig.CSI.Enabled, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not check whether the CSI driver is enabled for Kubernetes version '%s'", cluster.Shoot.Spec.Kubernetes.Version)
+	}
+	return csiEnabled, nil
+}
+
 // getNetworkNames determines the network and sub-network names from the given infrastructure status and controlplane.
 func getNetworkNames(
 	infraStatus *apisgcp.InfrastructureStatus,
@@ -17,12 +17,17 @@ package controlplane
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	apisgcp "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/kms"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -45,6 +50,7 @@ import (
 
 const (
 	namespace = "test"
+	keyName   = "projects/abc/locations/europe-west1/keyRings/foo/cryptoKeys/bar"
 )
 
 var _ = Describe("ValuesProvider", func() {
@@ -74,6 +80,9 @@ var _ = Describe("ValuesProvider", func() {
 									"CustomResourceValidation": true,
 								},
 							},
+							LoadBalancer: &apisgcp.LoadBalancerConfig{
+								AdditionalNodeTags: []string{"lb-target"},
+							},
 						},
 					}),
 				},
@@ -132,14 +141,17 @@ var _ = Describe("ValuesProvider", func() {
 			internal.CloudProviderConfigName:  "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":        "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server": "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			"csi-attacher":                    "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+			"csi-provisioner":                 "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
 		}
 
 		configChartValues = map[string]interface{}{
-			"projectID":      "abc",
-			"networkName":    "vpc-1234",
-			"subNetworkName": "subnet-acbd1234",
-			"zone":           "europe-west1a",
-			"nodeTags":       namespace,
+			"projectID":          "abc",
+			"networkName":        "vpc-1234",
+			"subNetworkName":     "subnet-acbd1234",
+			"zone":               "europe-west1a",
+			"nodeTags":           namespace,
+			"additionalNodeTags": []string{"lb-target"},
 		}
 
 		ccmChartValues = map[string]interface{}{
@@ -158,6 +170,18 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
+		csiChartValues = map[string]interface{}{
+			"enabled":           false,
+			"replicas":          1,
+			"kubernetesVersion": "1.13.4",
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-csi-attacher":             "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+				"checksum/secret-csi-provisioner":          "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
+				"checksum/secret-cloudprovider":            "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
+				"checksum/configmap-cloud-provider-config": "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
+			},
+		}
+
 		logger = log.Log.WithName("test")
 	)
 
@@ -187,6 +211,60 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(configChartValues))
 		})
+
+		It("should use the configured subnetwork for internal load balancers", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			subnetworkCP := cp.DeepCopy()
+			subnetworkCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisgcp.ControlPlaneConfig{
+					Zone: "europe-west1a",
+					CloudControllerManager: &apisgcp.CloudControllerManagerConfig{
+						LoadBalancer: &apisgcp.LoadBalancerConfig{
+							SubnetworkName: util.StringPtr("lb-internal"),
+						},
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), subnetworkCP, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("subNetworkName", "lb-internal"))
+			Expect(values).NotTo(HaveKey("additionalNodeTags"))
+		})
+
+		It("should fail for an invalid providerConfig", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			invalidCP := cp.DeepCopy()
+			invalidCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisgcp.ControlPlaneConfig{
+					Zone: "europe-west1a",
+					CloudControllerManager: &apisgcp.CloudControllerManagerConfig{
+						LoadBalancer: &apisgcp.LoadBalancerConfig{
+							AdditionalNodeTags: []string{"LB-Target"},
+						},
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			_, err = vp.GetConfigChartValues(context.TODO(), invalidCP, cluster)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("#GetControlPlaneChartValues", func() {
@@ -199,7 +277,146 @@ var _ = Describe("ValuesProvider", func() {
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(map[string]interface{}{
+				"cloud-controller-manager": ccmChartValues,
+				"csi-gcp":                  csiChartValues,
+			}))
+		})
+	})
+
+	Describe("#GetControlPlaneShootChartValues", func() {
+		It("should return correct control plane shoot chart values", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-gcp": map[string]interface{}{
+					"enabled": false,
+				},
+			}))
+		})
+
+		It("should enable the CSI driver if opted in", func() {
+			csiCP := cp.DeepCopy()
+			csiCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisgcp.ControlPlaneConfig{
+					CSI: &apisgcp.CSIConfig{
+						Enabled: true,
+					},
+				}),
+			}
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.14.3"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), csiCP, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-gcp": map[string]interface{}{
+					"enabled": true,
+				},
+			}))
+		})
+	})
+
+	Describe("#GetKMSKeyID", func() {
+		var kmsCP *extensionsv1alpha1.ControlPlane
+
+		BeforeEach(func() {
+			kmsCP = cp.DeepCopy()
+			kmsCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisgcp.ControlPlaneConfig{
+					Zone: "europe-west1a",
+					KMS: &apisgcp.KMSConfig{
+						KeyName: keyName,
+					},
+				}),
+			}
+		})
+
+		It("should return nil if no KMS key is configured", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			keyID, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyID).To(BeNil())
+		})
+
+		It("should return the name of the configured KMS key", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newKMSClient = newFakeKMSClient(nil)
+
+			// Call GetKMSKeyID method and check the result
+			keyID, err := vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keyID).To(Equal(util.StringPtr(keyName)))
+		})
+
+		It("should fail if the service account is not allowed to encrypt with the KMS key", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			vp.(*valuesProvider).newKMSClient = newFakeKMSClient(fmt.Errorf("PERMISSION_DENIED"))
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(MatchError(ContainSubstring("PERMISSION_DENIED")))
+		})
+
+		It("should fail if the KMS key is not in the project of the service account", func() {
+			kmsCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisgcp.ControlPlaneConfig{
+					Zone: "europe-west1a",
+					KMS: &apisgcp.KMSConfig{
+						KeyName: "projects/xyz/locations/europe-west1/keyRings/foo/cryptoKeys/bar",
+					},
+				}),
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetKMSKeyID method and check the result
+			_, err = vp.(genericactuator.KMSKeyProvider).GetKMSKeyID(context.TODO(), kmsCP, cluster)
+			Expect(err).To(HaveOccurred())
 		})
 	})
 })
@@ -218,3 +435,21 @@ func clientGet(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+// fakeKMSClient is a Cloud KMS client that checks the test encryption and returns the given error.
+type fakeKMSClient struct {
+	err error
+}
+
+func (c *fakeKMSClient) Encrypt(_ context.Context, name string, plaintext []byte) ([]byte, error) {
+	Expect(name).To(Equal(keyName))
+	Expect(plaintext).To(Equal([]byte(controlplane.KMSKeyCheckPlaintext)))
+	return []byte("ciphertext"), c.err
+}
+
+func newFakeKMSClient(err error) func(context.Context, []byte) (kms.Interface, error) {
+	return func(_ context.Context, serviceAccount []byte) (kms.Interface, error) {
+		Expect(serviceAccount).To(Equal([]byte(`{"project_id":"abc"}`)))
+		return &fakeKMSClient{err: err}, nil
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/config"
+	confighelper "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/config/helper"
+	gcpapi "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
+	gcpapihelper "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/helper"
+	gcpvalidation "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/validation"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// newMachineImageLookup returns a lookup of the images of machine images. The machine images of the CloudProfile take
+// precedence, the machine images of the controller configuration are used as a fallback.
+func newMachineImageLookup(decoder runtime.Decoder, machineImages []config.MachineImage, cluster *extensionscontroller.Cluster) (worker.MachineImageLookup, error) {
+	cloudProfileConfig := &gcpapi.CloudProfileConfig{}
+	ok, err := worker.DecodeCloudProfileConfig(decoder, cluster, cloudProfileConfig, func() field.ErrorList {
+		return gcpvalidation.ValidateCloudProfileConfig(cloudProfileConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var fromCloudProfile worker.MachineImageLookup
+	if ok {
+		fromCloudProfile = func(name, version string) (interface{}, error) {
+			return gcpapihelper.FindImageFromCloudProfile(cloudProfileConfig, name, version)
+		}
+	}
+
+	return worker.MachineImageLookupWithFallback(fromCloudProfile, func(name, version string) (interface{}, error) {
+		return confighelper.FindImage(machineImages, name, version)
+	}), nil
+}
+
+// validateMachineImages checks that the machine images of all pools of the given <w> are known, either by the
+// CloudProfile or by the controller configuration.
+func validateMachineImages(decoder runtime.Decoder, machineImages []config.MachineImage, w *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
+	lookup, err := newMachineImageLookup(decoder, machineImages, cluster)
+	if err != nil {
+		return err
+	}
+	return worker.ValidateMachineImages(w, lookup)
+}
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"path/filepath"
 
-	confighelper "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/config/helper"
 	gcpapi "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
 	gcpapihelper "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/helper"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
@@ -98,10 +97,15 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	findMachineImage, err := newMachineImageLookup(w.decoder, w.machineImages, w.cluster)
+	if err != nil {
+		return err
+	}
+
 	for _, pool := range w.worker.Spec.Pools {
 		zoneLen := len(pool.Zones)
 
-		machineImage, err := confighelper.FindImage(w.machineImages, pool.MachineImage.Name, pool.MachineImage.Version)
+		machineImage, err := findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
 		}
@@ -170,6 +174,8 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 			machineDeployments = append(machineDeployments, worker.MachineDeployment{
 				Name:           deploymentName,
+				PoolName:       pool.Name,
+				Zone:           zone,
 				ClassName:      className,
 				SecretName:     className,
 				Minimum:        worker.DistributeOverZones(zoneIndex, pool.Minimum, zoneLen),
@@ -24,10 +24,20 @@ const (
 
 	// HyperkubeImageName is the name of the hyperkube image.
 	HyperkubeImageName = "hyperkube"
+	// CSIDriverImageName is the name of the GCP PD CSI driver image.
+	CSIDriverImageName = "gcp-compute-persistent-disk-csi-driver"
+	// CSIAttacherImageName is the name of the CSI attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIProvisionerImageName is the name of the CSI provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSINodeDriverRegistrarImageName is the name of the CSI node driver registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
 	// MachineControllerManagerImageName is the name of the MachineControllerManager image.
 	MachineControllerManagerImageName = "machine-controller-manager"
 	// ETCDBackupRestoreImageName is the name of the etcd backup and restore image.
 	ETCDBackupRestoreImageName = "etcd-backup-restore"
+	// KMSPluginImageName is the name of the Cloud KMS plugin image.
+	KMSPluginImageName = "k8s-cloudkms-plugin"
 
 	// ServiceAccountJSONField is the field in a secret where the service account JSON is stored at.
 	ServiceAccountJSONField = "serviceaccount.json"
@@ -44,6 +54,11 @@ const (
 	MachineControllerManagerName = "machine-controller-manager"
 	// BackupSecretName is the name of the secret containing the credentials for storing the backups of Shoot clusters.
 	BackupSecretName = "etcd-backup"
+
+	// CSIMigrationFeatureGate is the feature gate that migrates the volumes of the in-tree GCE PD volume plugin to the CSI driver.
+	CSIMigrationFeatureGate = "CSIMigrationGCE"
+	// CSIMigrationMinimumKubernetesVersion is the minimum Kubernetes version that supports the CSIMigrationFeatureGate.
+	CSIMigrationMinimumKubernetesVersion = "1.14"
 )
 
 var (
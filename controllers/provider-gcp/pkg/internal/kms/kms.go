@@ -0,0 +1,120 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// The vendored Google API client libraries do not contain Cloud KMS, hence the few operations that are needed are
+// implemented here with its REST API.
+const (
+	endpoint = "https://cloudkms.googleapis.com/v1/"
+
+	// cloudKMSScope is the OAuth scope that allows to view and manage the keys of Cloud KMS.
+	cloudKMSScope = "https://www.googleapis.com/auth/cloudkms"
+)
+
+// Interface is the interface for the Cloud KMS client.
+type Interface interface {
+	// Encrypt encrypts the given plaintext with the crypto key with the given resource name and returns the
+	// ciphertext.
+	Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error)
+}
+
+// encryptRequest is the request of the encrypt method of crypto keys.
+type encryptRequest struct {
+	Plaintext []byte `json:"plaintext"`
+}
+
+// encryptResponse is the response of the encrypt method of crypto keys.
+type encryptResponse struct {
+	Name       string `json:"name"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type client struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// NewFromServiceAccount creates a new client from the given service account.
+func NewFromServiceAccount(ctx context.Context, serviceAccount []byte) (Interface, error) {
+	jwt, err := google.JWTConfigFromJSON(serviceAccount, cloudKMSScope)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(oauth2.NewClient(ctx, jwt.TokenSource(ctx)), endpoint), nil
+}
+
+// New creates a new client that sends its requests with the given HTTP client to the given endpoint.
+func New(httpClient *http.Client, endpoint string) Interface {
+	return &client{httpClient, strings.TrimSuffix(endpoint, "/") + "/"}
+}
+
+// Encrypt implements Interface.
+func (c *client) Encrypt(ctx context.Context, name string, plaintext []byte) ([]byte, error) {
+	body, err := json.Marshal(&encryptRequest{Plaintext: plaintext})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+name+":encrypt", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	encryptResp := &encryptResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(encryptResp); err != nil {
+		return nil, err
+	}
+	return encryptResp.Ciphertext, nil
+}
+
+// responseError returns an error with the message of the given unsuccessful response.
+func responseError(resp *http.Response) error {
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	body, _ := ioutil.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Message == "" {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, errResp.Error.Message)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kms_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/kms"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const keyName = "projects/foo/locations/europe-west1/keyRings/bar/cryptoKeys/baz"
+
+var _ = Describe("KMS", func() {
+	var (
+		server  *httptest.Server
+		handler http.HandlerFunc
+		c       Interface
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r)
+		}))
+		c = New(server.Client(), server.URL+"/v1/")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#Encrypt", func() {
+		It("should return the ciphertext", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.URL.Path).To(Equal("/v1/" + keyName + ":encrypt"))
+
+				body, err := ioutil.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body).To(MatchJSON(`{"plaintext":"cGxhaW50ZXh0"}`))
+
+				_, _ = w.Write([]byte(`{"name":"` + keyName + `/cryptoKeyVersions/1","ciphertext":"Y2lwaGVydGV4dA=="}`))
+			}
+
+			Expect(c.Encrypt(context.TODO(), keyName, []byte("plaintext"))).To(Equal([]byte("ciphertext")))
+		})
+
+		It("should return the error of Cloud KMS", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"error":{"code":403,"message":"Permission 'cloudkms.cryptoKeyVersions.useToEncrypt' denied","status":"PERMISSION_DENIED"}}`))
+			}
+
+			_, err := c.Encrypt(context.TODO(), keyName, []byte("plaintext"))
+			Expect(err).To(MatchError("unexpected status code 403: Permission 'cloudkms.cryptoKeyVersions.useToEncrypt' denied"))
+		})
+	})
+})
@@ -94,15 +94,17 @@ func (e *ensurer) getBackupRestoreContainer(name string, cluster *extensionscont
 		defaultSchedule = "0 */24 * * *"
 	)
 
-	// Determine provider, container env variables, and volume mounts
+	// Determine provider, container env variables, volume mounts, and the etcd backup configuration of the shoot
 	// They are only specified for the etcd-main stateful set (backup is enabled)
 	var (
 		provider                string
 		env                     []corev1.EnvVar
 		volumeMounts            []corev1.VolumeMount
+		backupConfig            *controlplane.ETCDBackupConfig
 		volumeClaimTemplateName = name
 	)
 	if name == common.EtcdMainStatefulSetName {
+		backupConfig = controlplane.ETCDBackupConfigFromShoot(cluster.Shoot, e.logger)
 		provider = gcp.StorageProviderName
 		env = []corev1.EnvVar{
 			{
@@ -133,10 +135,10 @@ func (e *ensurer) getBackupRestoreContainer(name string, cluster *extensionscont
 	// Determine schedule
 	var schedule = defaultSchedule
 	if e.etcdBackup.Schedule != nil {
-		schedule = defaultSchedule
+		schedule = *e.etcdBackup.Schedule
 	}
 
-	return controlplane.GetBackupRestoreContainer(name, volumeClaimTemplateName, schedule, provider, image.String(), nil, env, volumeMounts), nil
+	return controlplane.GetBackupRestoreContainer(name, volumeClaimTemplateName, schedule, provider, image.String(), backupConfig, nil, env, volumeMounts), nil
 }
 
 func (e *ensurer) ensureVolumes(ps *corev1.PodSpec, name string) {
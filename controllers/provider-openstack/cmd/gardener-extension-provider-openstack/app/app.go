@@ -25,10 +25,12 @@ import (
 	openstackinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/controller/infrastructure"
 	openstackworker "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
+	openstackcontrolplanewebhook "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/webhook/controlplane"
 	openstackcontrolplanebackup "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/webhook/controlplanebackup"
 	openstackcontrolplaneexposure "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/webhook/controlplaneexposure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -62,13 +64,26 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		controlPlaneCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		controlPlaneReconcileOpts = &controlplane.ReconcilerOptions{
+			ResyncInterval: controlplane.DefaultResyncInterval,
+		}
+		controlPlaneSecretsRotationOpts = &controlplane.SecretsRotationOptions{
+			CertificateRenewalWindow: util.DefaultCertificateRenewalWindow,
+			CABundleDuration:         util.DefaultCABundleDuration,
+		}
+		controlPlaneDeletionOpts = &controlplane.DeletionOptions{
+			ManagedResourceDeletionTimeout: controlplane.DefaultManagedResourceDeletionTimeout,
+			OrphanPolicy:                   string(controlplane.OrphanPolicyNever),
+		}
+		controlPlaneCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(controlPlaneCtrlOpts, controlPlaneReconcileOpts, controlPlaneSecretsRotationOpts, controlPlaneDeletionOpts)
 
 		// options for the worker controller
 		workerCtrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
 		workerReconcileOpts = &worker.Options{
-			DeployCRDs: true,
+			DeployCRDs:     true,
+			RolloutTimeout: worker.DefaultRolloutTimeout,
 		}
 		workerCtrlOptsUnprefixed = controllercmd.NewOptionAggregator(workerCtrlOpts, workerReconcileOpts)
 
@@ -88,7 +103,7 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		aggOption = controllercmd.NewOptionAggregator(
 			restOpts,
 			mgrOpts,
-			controllercmd.PrefixOption("controlplane-", controlPlaneCtrlOpts),
+			controllercmd.PrefixOption("controlplane-", &controlPlaneCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("infrastructure-", &infraCtrlOptsUnprefixed),
 			controllercmd.PrefixOption("worker-", &workerCtrlOptsUnprefixed),
 			controllerSwitches,
@@ -129,17 +144,26 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			configFileOpts.Completed().ApplyMachineImages(&openstackworker.DefaultAddOptions.MachineImagesToCloudProfilesMapping)
 			configFileOpts.Completed().ApplyETCDStorage(&openstackcontrolplaneexposure.DefaultAddOptions.ETCDStorage)
 			configFileOpts.Completed().ApplyETCDBackup(&openstackcontrolplanebackup.DefaultAddOptions.ETCDBackup)
+			configFileOpts.Completed().ApplyClusterAutoscaler(&openstackcontrolplanewebhook.DefaultAddOptions.ClusterAutoscaler)
+			configFileOpts.Completed().ApplyMachineControllerManager(&openstackworker.DefaultAddOptions.MachineControllerManager)
 			controlPlaneCtrlOpts.Completed().Apply(&openstackcontrolplane.Options)
+			controlPlaneReconcileOpts.Completed().ApplyResyncInterval(&openstackcontrolplane.ResyncInterval)
+			controlPlaneSecretsRotationOpts.Completed().Apply(openstackcontrolplane.RotatingSecrets)
+			controlPlaneDeletionOpts.Completed().ApplyManagedResourceDeletionTimeout(&openstackcontrolplane.ManagedResourceDeletionTimeout)
+			controlPlaneDeletionOpts.Completed().ApplyOrphanPolicy(&openstackcontrolplane.OrphanPolicy)
 			infraCtrlOpts.Completed().Apply(&openstackinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&openstackinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
 			workerCtrlOpts.Completed().Apply(&openstackworker.DefaultAddOptions.Controller)
+			workerReconcileOpts.Completed().ApplyRolloutTimeout(&openstackworker.DefaultAddOptions.RolloutTimeout)
 
-			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
+			shootWebhookConfig, err := webhookOptions.Completed().AddToManager(mgr)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
 			}
+			openstackcontrolplane.ShootWebhookConfig = shootWebhookConfig
 
-			if err := webhookOptions.Completed().AddToManager(mgr); err != nil {
-				controllercmd.LogErrAndExit(err, "Could not add webhooks to manager")
+			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
+				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
 			}
 
 			if err := mgr.Start(ctx.Done()); err != nil {
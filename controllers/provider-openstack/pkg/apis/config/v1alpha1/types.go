@@ -35,6 +35,12 @@ type ControllerConfiguration struct {
 	MachineImages []MachineImage `json:"machineImages,omitempty"`
 	// ETCD is the etcd configuration.
 	ETCD ETCD `json:"etcd"`
+	// ClusterAutoscaler is the cluster-autoscaler configuration.
+	// +optional
+	ClusterAutoscaler ClusterAutoscaler `json:"clusterAutoscaler,omitempty"`
+	// MachineControllerManager is the machine-controller-manager configuration.
+	// +optional
+	MachineControllerManager MachineControllerManager `json:"machineControllerManager,omitempty"`
 }
 
 // MachineImage is a mapping from logical names and versions to OpenStack-specific identifiers.
@@ -79,3 +85,32 @@ type ETCDBackup struct {
 	// +optional
 	Schedule *string `json:"schedule,omitempty"`
 }
+
+// ClusterAutoscaler is a cluster-autoscaler configuration.
+type ClusterAutoscaler struct {
+	// Expander is the type of node group expander to be used in scale up.
+	// +optional
+	Expander *string `json:"expander,omitempty"`
+	// BalanceSimilarNodeGroups specifies whether to detect similar node groups and balance the number of nodes between them.
+	// +optional
+	BalanceSimilarNodeGroups *bool `json:"balanceSimilarNodeGroups,omitempty"`
+	// ScaleDownDelayAfterAdd is how long after scale up that scale down evaluation resumes.
+	// +optional
+	ScaleDownDelayAfterAdd *metav1.Duration `json:"scaleDownDelayAfterAdd,omitempty"`
+	// ScaleDownUnneededTime is how long a node should be unneeded before it is eligible for scale down.
+	// +optional
+	ScaleDownUnneededTime *metav1.Duration `json:"scaleDownUnneededTime,omitempty"`
+}
+
+// MachineControllerManager is a machine-controller-manager configuration.
+type MachineControllerManager struct {
+	// MachineCreationTimeout is the timeout after which a machine that hasn't joined the cluster is considered failed.
+	// +optional
+	MachineCreationTimeout *metav1.Duration `json:"machineCreationTimeout,omitempty"`
+	// MachineHealthTimeout is the timeout after which an unhealthy machine is replaced.
+	// +optional
+	MachineHealthTimeout *metav1.Duration `json:"machineHealthTimeout,omitempty"`
+	// MachineDrainTimeout is the timeout after which the drain of a machine that is deleted is given up.
+	// +optional
+	MachineDrainTimeout *metav1.Duration `json:"machineDrainTimeout,omitempty"`
+}
@@ -24,7 +24,9 @@ import (
 	unsafe "unsafe"
 
 	config "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/config"
+	apisconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config"
@@ -48,6 +50,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterAutoscaler)(nil), (*apisconfig.ClusterAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(a.(*ClusterAutoscaler), b.(*apisconfig.ClusterAutoscaler), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*apisconfig.ClusterAutoscaler)(nil), (*ClusterAutoscaler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(a.(*apisconfig.ClusterAutoscaler), b.(*ClusterAutoscaler), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -88,6 +100,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineControllerManager)(nil), (*apisconfig.MachineControllerManager)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(a.(*MachineControllerManager), b.(*apisconfig.MachineControllerManager), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*apisconfig.MachineControllerManager)(nil), (*MachineControllerManager)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(a.(*apisconfig.MachineControllerManager), b.(*MachineControllerManager), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*config.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineImage_To_config_MachineImage(a.(*MachineImage), b.(*config.MachineImage), scope)
 	}); err != nil {
@@ -123,12 +145,44 @@ func Convert_config_CloudProfileMapping_To_v1alpha1_CloudProfileMapping(in *conf
 	return autoConvert_config_CloudProfileMapping_To_v1alpha1_CloudProfileMapping(in, out, s)
 }
 
+func autoConvert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in *ClusterAutoscaler, out *apisconfig.ClusterAutoscaler, s conversion.Scope) error {
+	out.Expander = (*string)(unsafe.Pointer(in.Expander))
+	out.BalanceSimilarNodeGroups = (*bool)(unsafe.Pointer(in.BalanceSimilarNodeGroups))
+	out.ScaleDownDelayAfterAdd = (*v1.Duration)(unsafe.Pointer(in.ScaleDownDelayAfterAdd))
+	out.ScaleDownUnneededTime = (*v1.Duration)(unsafe.Pointer(in.ScaleDownUnneededTime))
+	return nil
+}
+
+// Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in *ClusterAutoscaler, out *apisconfig.ClusterAutoscaler, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(in, out, s)
+}
+
+func autoConvert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in *apisconfig.ClusterAutoscaler, out *ClusterAutoscaler, s conversion.Scope) error {
+	out.Expander = (*string)(unsafe.Pointer(in.Expander))
+	out.BalanceSimilarNodeGroups = (*bool)(unsafe.Pointer(in.BalanceSimilarNodeGroups))
+	out.ScaleDownDelayAfterAdd = (*v1.Duration)(unsafe.Pointer(in.ScaleDownDelayAfterAdd))
+	out.ScaleDownUnneededTime = (*v1.Duration)(unsafe.Pointer(in.ScaleDownUnneededTime))
+	return nil
+}
+
+// Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler is an autogenerated conversion function.
+func Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in *apisconfig.ClusterAutoscaler, out *ClusterAutoscaler, s conversion.Scope) error {
+	return autoConvert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*componentbaseconfig.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
 	out.MachineImages = *(*[]config.MachineImage)(unsafe.Pointer(&in.MachineImages))
 	if err := Convert_v1alpha1_ETCD_To_config_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_ClusterAutoscaler_To_config_ClusterAutoscaler(&in.ClusterAutoscaler, &out.ClusterAutoscaler, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(&in.MachineControllerManager, &out.MachineControllerManager, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -143,6 +197,12 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 	if err := Convert_config_ETCD_To_v1alpha1_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
+	if err := Convert_config_ClusterAutoscaler_To_v1alpha1_ClusterAutoscaler(&in.ClusterAutoscaler, &out.ClusterAutoscaler, s); err != nil {
+		return err
+	}
+	if err := Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(&in.MachineControllerManager, &out.MachineControllerManager, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -223,6 +283,30 @@ func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage,
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
 
+func autoConvert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in *MachineControllerManager, out *apisconfig.MachineControllerManager, s conversion.Scope) error {
+	out.MachineCreationTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineCreationTimeout))
+	out.MachineHealthTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineHealthTimeout))
+	out.MachineDrainTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineDrainTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager is an autogenerated conversion function.
+func Convert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in *MachineControllerManager, out *apisconfig.MachineControllerManager, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineControllerManager_To_config_MachineControllerManager(in, out, s)
+}
+
+func autoConvert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in *apisconfig.MachineControllerManager, out *MachineControllerManager, s conversion.Scope) error {
+	out.MachineCreationTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineCreationTimeout))
+	out.MachineHealthTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineHealthTimeout))
+	out.MachineDrainTimeout = (*v1.Duration)(unsafe.Pointer(in.MachineDrainTimeout))
+	return nil
+}
+
+// Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager is an autogenerated conversion function.
+func Convert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in *apisconfig.MachineControllerManager, out *MachineControllerManager, s conversion.Scope) error {
+	return autoConvert_config_MachineControllerManager_To_v1alpha1_MachineControllerManager(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineImage_To_config_MachineImage(in *MachineImage, out *config.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
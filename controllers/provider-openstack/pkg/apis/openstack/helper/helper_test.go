@@ -52,6 +52,25 @@ var _ = Describe("Helper", func() {
 		Entry("entry not found", []openstack.SecurityGroup{{Name: "bar", Purpose: purposeWrong}}, purpose, nil, true),
 		Entry("entry exists", []openstack.SecurityGroup{{Name: "bar", Purpose: purpose}}, purpose, &openstack.SecurityGroup{Name: "bar", Purpose: purpose}, false),
 	)
+
+	DescribeTable("#FindImageFromCloudProfile",
+		func(cloudProfileConfig *openstack.CloudProfileConfig, imageName, imageVersion, expectedImage string) {
+			image, err := FindImageFromCloudProfile(cloudProfileConfig, imageName, imageVersion)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", ""),
+		Entry("no machine images", &openstack.CloudProfileConfig{}, "ubuntu", "1", ""),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "coreos", "1", ""),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "2", ""),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "1", "image-1234"),
+	)
 })
 
 func expectResults(result, expected interface{}, err error, expectErr bool) {
@@ -63,3 +82,15 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func makeCloudProfileConfig(name, version, image string) *openstack.CloudProfileConfig {
+	return &openstack.CloudProfileConfig{
+		MachineImages: []openstack.MachineImage{
+			{
+				Name:    name,
+				Version: version,
+				Image:   image,
+			},
+		},
+	}
+}
@@ -31,9 +31,68 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+
+	// CSI contains configuration settings for the CSI driver.
+	// +optional
+	CSI *CSIConfig `json:"csi,omitempty"`
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	gardenv1beta1.KubernetesConfig `json:",inline"`
+
+	// LoadBalancer contains configuration settings for the load balancers of services of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig `json:"loadBalancer,omitempty"`
+
+	// Route contains configuration settings for the routes to the pod networks of the nodes.
+	// +optional
+	Route *RouteConfig `json:"route,omitempty"`
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// UseOctavia uses Octavia instead of Neutron LBaaS v2 to create the load balancers.
+	// +optional
+	UseOctavia *bool `json:"useOctavia,omitempty"`
+	// Classes are additional load balancer classes that services can select with the
+	// `loadbalancer.openstack.org/class` annotation, e.g. to use a different floating pool.
+	// +optional
+	Classes []LoadBalancerClass `json:"classes,omitempty"`
+}
+
+// LoadBalancerClass is a load balancer class that overrides the network settings of the default load balancer class.
+type LoadBalancerClass struct {
+	// Name is the name of the load balancer class.
+	Name string `json:"name"`
+	// FloatingNetworkID is the ID of the network the floating IPs are allocated from.
+	// +optional
+	FloatingNetworkID *string `json:"floatingNetworkID,omitempty"`
+	// FloatingSubnetID is the ID of the subnet the floating IPs are allocated from.
+	// +optional
+	FloatingSubnetID *string `json:"floatingSubnetID,omitempty"`
+	// SubnetID is the ID of the subnet the load balancer VIPs are allocated from.
+	// +optional
+	SubnetID *string `json:"subnetID,omitempty"`
+}
+
+// RouteConfig contains configuration settings for the routes to the pod networks of the nodes. If it is set, the
+// cloud-controller-manager creates a route to the pod network of each node in the given router.
+type RouteConfig struct {
+	// RouterID is the ID of the router in which the routes are created. Defaults to the router of the infrastructure.
+	// +optional
+	RouterID *string `json:"routerID,omitempty"`
+}
+
+// CSIConfig contains configuration settings for the CSI driver.
+type CSIConfig struct {
+	// Enabled deploys the CSI driver and the `default-csi` StorageClass. It is only deployed for shoots with
+	// Kubernetes version 1.14 or higher. Existing persistent volumes keep using the in-tree volume plugin unless
+	// MigrateVolumes is set.
+	Enabled bool `json:"enabled"`
+	// MigrateVolumes enables the `CSIMigration` and `CSIMigrationOpenStack` feature gates of the kube-controller-manager and the
+	// kubelets, so that the existing persistent volumes of the in-tree volume plugin are handled by the CSI driver.
+	// It requires Enabled and is only applied for shoots with Kubernetes version 1.14 or higher.
+	// +optional
+	MigrateVolumes bool `json:"migrateVolumes,omitempty"`
 }
@@ -36,6 +36,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CSIConfig)(nil), (*openstack.CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CSIConfig_To_openstack_CSIConfig(a.(*CSIConfig), b.(*openstack.CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.CSIConfig)(nil), (*CSIConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_CSIConfig_To_v1alpha1_CSIConfig(a.(*openstack.CSIConfig), b.(*CSIConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*openstack.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_CloudControllerManagerConfig_To_openstack_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*openstack.CloudControllerManagerConfig), scope)
 	}); err != nil {
@@ -46,6 +56,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*openstack.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_openstack_CloudProfileConfig(a.(*CloudProfileConfig), b.(*openstack.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*openstack.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*openstack.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_openstack_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*openstack.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -86,6 +106,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerClass)(nil), (*openstack.LoadBalancerClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerClass_To_openstack_LoadBalancerClass(a.(*LoadBalancerClass), b.(*openstack.LoadBalancerClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.LoadBalancerClass)(nil), (*LoadBalancerClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_LoadBalancerClass_To_v1alpha1_LoadBalancerClass(a.(*openstack.LoadBalancerClass), b.(*LoadBalancerClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*openstack.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_openstack_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*openstack.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*openstack.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*openstack.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_openstack_MachineImage(a.(*MachineImage), b.(*openstack.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_MachineImage_To_v1alpha1_MachineImage(a.(*openstack.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkStatus)(nil), (*openstack.NetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NetworkStatus_To_openstack_NetworkStatus(a.(*NetworkStatus), b.(*openstack.NetworkStatus), scope)
 	}); err != nil {
@@ -116,6 +166,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RouteConfig)(nil), (*openstack.RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RouteConfig_To_openstack_RouteConfig(a.(*RouteConfig), b.(*openstack.RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*openstack.RouteConfig)(nil), (*RouteConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_openstack_RouteConfig_To_v1alpha1_RouteConfig(a.(*openstack.RouteConfig), b.(*RouteConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Router)(nil), (*openstack.Router)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Router_To_openstack_Router(a.(*Router), b.(*openstack.Router), scope)
 	}); err != nil {
@@ -159,8 +219,32 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_CSIConfig_To_openstack_CSIConfig(in *CSIConfig, out *openstack.CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_v1alpha1_CSIConfig_To_openstack_CSIConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CSIConfig_To_openstack_CSIConfig(in *CSIConfig, out *openstack.CSIConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CSIConfig_To_openstack_CSIConfig(in, out, s)
+}
+
+func autoConvert_openstack_CSIConfig_To_v1alpha1_CSIConfig(in *openstack.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.MigrateVolumes = in.MigrateVolumes
+	return nil
+}
+
+// Convert_openstack_CSIConfig_To_v1alpha1_CSIConfig is an autogenerated conversion function.
+func Convert_openstack_CSIConfig_To_v1alpha1_CSIConfig(in *openstack.CSIConfig, out *CSIConfig, s conversion.Scope) error {
+	return autoConvert_openstack_CSIConfig_To_v1alpha1_CSIConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_openstack_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *openstack.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*openstack.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*openstack.RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -171,6 +255,8 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_openstack_CloudControllerM
 
 func autoConvert_openstack_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *openstack.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.KubernetesConfig = in.KubernetesConfig
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Route = (*RouteConfig)(unsafe.Pointer(in.Route))
 	return nil
 }
 
@@ -179,9 +265,30 @@ func Convert_openstack_CloudControllerManagerConfig_To_v1alpha1_CloudControllerM
 	return autoConvert_openstack_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_openstack_CloudProfileConfig(in *CloudProfileConfig, out *openstack.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]openstack.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_openstack_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_openstack_CloudProfileConfig(in *CloudProfileConfig, out *openstack.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_openstack_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_openstack_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *openstack.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_openstack_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_openstack_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *openstack.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_openstack_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_openstack_ControlPlaneConfig(in *ControlPlaneConfig, out *openstack.ControlPlaneConfig, s conversion.Scope) error {
 	out.LoadBalancerProvider = in.LoadBalancerProvider
 	out.CloudControllerManager = (*openstack.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*openstack.CSIConfig)(unsafe.Pointer(in.CSI))
 	return nil
 }
 
@@ -193,6 +300,7 @@ func Convert_v1alpha1_ControlPlaneConfig_To_openstack_ControlPlaneConfig(in *Con
 func autoConvert_openstack_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *openstack.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.LoadBalancerProvider = in.LoadBalancerProvider
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.CSI = (*CSIConfig)(unsafe.Pointer(in.CSI))
 	return nil
 }
 
@@ -279,6 +387,78 @@ func Convert_openstack_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in
 	return autoConvert_openstack_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_LoadBalancerClass_To_openstack_LoadBalancerClass(in *LoadBalancerClass, out *openstack.LoadBalancerClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.FloatingNetworkID = (*string)(unsafe.Pointer(in.FloatingNetworkID))
+	out.FloatingSubnetID = (*string)(unsafe.Pointer(in.FloatingSubnetID))
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerClass_To_openstack_LoadBalancerClass is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerClass_To_openstack_LoadBalancerClass(in *LoadBalancerClass, out *openstack.LoadBalancerClass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerClass_To_openstack_LoadBalancerClass(in, out, s)
+}
+
+func autoConvert_openstack_LoadBalancerClass_To_v1alpha1_LoadBalancerClass(in *openstack.LoadBalancerClass, out *LoadBalancerClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.FloatingNetworkID = (*string)(unsafe.Pointer(in.FloatingNetworkID))
+	out.FloatingSubnetID = (*string)(unsafe.Pointer(in.FloatingSubnetID))
+	out.SubnetID = (*string)(unsafe.Pointer(in.SubnetID))
+	return nil
+}
+
+// Convert_openstack_LoadBalancerClass_To_v1alpha1_LoadBalancerClass is an autogenerated conversion function.
+func Convert_openstack_LoadBalancerClass_To_v1alpha1_LoadBalancerClass(in *openstack.LoadBalancerClass, out *LoadBalancerClass, s conversion.Scope) error {
+	return autoConvert_openstack_LoadBalancerClass_To_v1alpha1_LoadBalancerClass(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerConfig_To_openstack_LoadBalancerConfig(in *LoadBalancerConfig, out *openstack.LoadBalancerConfig, s conversion.Scope) error {
+	out.UseOctavia = (*bool)(unsafe.Pointer(in.UseOctavia))
+	out.Classes = *(*[]openstack.LoadBalancerClass)(unsafe.Pointer(&in.Classes))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_openstack_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_openstack_LoadBalancerConfig(in *LoadBalancerConfig, out *openstack.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_openstack_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_openstack_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *openstack.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.UseOctavia = (*bool)(unsafe.Pointer(in.UseOctavia))
+	out.Classes = *(*[]LoadBalancerClass)(unsafe.Pointer(&in.Classes))
+	return nil
+}
+
+// Convert_openstack_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_openstack_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *openstack.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_openstack_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineImage_To_openstack_MachineImage(in *MachineImage, out *openstack.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_openstack_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_openstack_MachineImage(in *MachineImage, out *openstack.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_openstack_MachineImage(in, out, s)
+}
+
+func autoConvert_openstack_MachineImage_To_v1alpha1_MachineImage(in *openstack.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_openstack_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_openstack_MachineImage_To_v1alpha1_MachineImage(in *openstack.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_openstack_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
+
 func autoConvert_v1alpha1_NetworkStatus_To_openstack_NetworkStatus(in *NetworkStatus, out *openstack.NetworkStatus, s conversion.Scope) error {
 	out.ID = in.ID
 	if err := Convert_v1alpha1_FloatingPoolStatus_To_openstack_FloatingPoolStatus(&in.FloatingPool, &out.FloatingPool, s); err != nil {
@@ -355,6 +535,26 @@ func Convert_openstack_NodeStatus_To_v1alpha1_NodeStatus(in *openstack.NodeStatu
 	return autoConvert_openstack_NodeStatus_To_v1alpha1_NodeStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_RouteConfig_To_openstack_RouteConfig(in *RouteConfig, out *openstack.RouteConfig, s conversion.Scope) error {
+	out.RouterID = (*string)(unsafe.Pointer(in.RouterID))
+	return nil
+}
+
+// Convert_v1alpha1_RouteConfig_To_openstack_RouteConfig is an autogenerated conversion function.
+func Convert_v1alpha1_RouteConfig_To_openstack_RouteConfig(in *RouteConfig, out *openstack.RouteConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RouteConfig_To_openstack_RouteConfig(in, out, s)
+}
+
+func autoConvert_openstack_RouteConfig_To_v1alpha1_RouteConfig(in *openstack.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	out.RouterID = (*string)(unsafe.Pointer(in.RouterID))
+	return nil
+}
+
+// Convert_openstack_RouteConfig_To_v1alpha1_RouteConfig is an autogenerated conversion function.
+func Convert_openstack_RouteConfig_To_v1alpha1_RouteConfig(in *openstack.RouteConfig, out *RouteConfig, s conversion.Scope) error {
+	return autoConvert_openstack_RouteConfig_To_v1alpha1_RouteConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_Router_To_openstack_Router(in *Router, out *openstack.Router, s conversion.Scope) error {
 	out.ID = in.ID
 	return nil
@@ -0,0 +1,70 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	apisopenstack "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisopenstack.ControlPlaneConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		var (
+			classesPath = field.NewPath("cloudControllerManager", "loadBalancer", "classes")
+			classes     = map[string]bool{}
+		)
+
+		for i, class := range ccm.LoadBalancer.Classes {
+			idxPath := classesPath.Index(i)
+
+			if len(class.Name) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+			} else {
+				for _, msg := range validation.IsDNS1123Label(class.Name) {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), class.Name, msg))
+				}
+				if classes[class.Name] {
+					allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), class.Name))
+				}
+				classes[class.Name] = true
+			}
+
+			if class.FloatingNetworkID == nil && class.FloatingSubnetID == nil && class.SubnetID == nil {
+				allErrs = append(allErrs, field.Required(idxPath, "must provide a floating network, floating subnet or subnet ID"))
+			}
+			allErrs = append(allErrs, validateID(class.FloatingNetworkID, idxPath.Child("floatingNetworkID"))...)
+			allErrs = append(allErrs, validateID(class.FloatingSubnetID, idxPath.Child("floatingSubnetID"))...)
+			allErrs = append(allErrs, validateID(class.SubnetID, idxPath.Child("subnetID"))...)
+		}
+	}
+
+	if ccm := controlPlaneConfig.CloudControllerManager; ccm != nil && ccm.Route != nil {
+		allErrs = append(allErrs, validateID(ccm.Route.RouterID, field.NewPath("cloudControllerManager", "route", "routerID"))...)
+	}
+
+	return allErrs
+}
+
+func validateID(id *string, fldPath *field.Path) field.ErrorList {
+	if id != nil && len(*id) == 0 {
+		return field.ErrorList{field.Required(fldPath, "must not be empty if set")}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	apisopenstack "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack"
+	. "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var controlPlaneConfig *apisopenstack.ControlPlaneConfig
+
+	BeforeEach(func() {
+		floatingNetworkID := "floating-network-1"
+		controlPlaneConfig = &apisopenstack.ControlPlaneConfig{
+			CloudControllerManager: &apisopenstack.CloudControllerManagerConfig{
+				LoadBalancer: &apisopenstack.LoadBalancerConfig{
+					Classes: []apisopenstack.LoadBalancerClass{
+						{
+							Name:              "public",
+							FloatingNetworkID: &floatingNetworkID,
+						},
+					},
+				},
+				Route: &apisopenstack.RouteConfig{},
+			},
+		}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should allow a valid configuration", func() {
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(BeEmpty())
+		})
+
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControlPlaneConfig(&apisopenstack.ControlPlaneConfig{})).To(BeEmpty())
+		})
+
+		It("should forbid load balancer classes without name", func() {
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes[0].Name = ""
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("cloudControllerManager.loadBalancer.classes[0].name"),
+				})),
+			))
+		})
+
+		It("should forbid load balancer classes with invalid name", func() {
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes[0].Name = `"public"`
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.loadBalancer.classes[0].name"),
+				})),
+			))
+		})
+
+		It("should forbid duplicate load balancer classes", func() {
+			classes := controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes = append(classes, classes[0])
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("cloudControllerManager.loadBalancer.classes[1].name"),
+				})),
+			))
+		})
+
+		It("should forbid load balancer classes without or with empty IDs", func() {
+			empty := ""
+			controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes = append(controlPlaneConfig.CloudControllerManager.LoadBalancer.Classes,
+				apisopenstack.LoadBalancerClass{Name: "empty"},
+				apisopenstack.LoadBalancerClass{Name: "internal", SubnetID: &empty},
+			)
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("cloudControllerManager.loadBalancer.classes[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("cloudControllerManager.loadBalancer.classes[2].subnetID"),
+				})),
+			))
+		})
+
+		It("should forbid empty router IDs", func() {
+			empty := ""
+			controlPlaneConfig.CloudControllerManager.Route.RouterID = &empty
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("cloudControllerManager.route.routerID"),
+				})),
+			))
+		})
+	})
+})
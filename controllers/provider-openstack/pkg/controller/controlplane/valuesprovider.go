@@ -27,7 +27,9 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack/helper"
+	openstackvalidation "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack/validation"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/internal"
 	openstacktypes "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
@@ -47,6 +50,9 @@ import (
 const (
 	cloudControllerManagerDeploymentName = "cloud-controller-manager"
 	cloudControllerManagerServerName     = "cloud-controller-manager-server"
+	csiControllerDeploymentName          = "csi-driver-controller"
+	csiAttacherName                      = "csi-attacher"
+	csiProvisionerName                   = "csi-provisioner"
 )
 
 var controlPlaneSecrets = &secrets.Secrets{
@@ -81,11 +87,37 @@ var controlPlaneSecrets = &secrets.Secrets{
 					SigningCA:  cas[gardencorev1alpha1.SecretNameCACluster],
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiAttacherName,
+					CommonName:   "system:csi-attacher",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:         csiProvisionerName,
+					CommonName:   "system:csi-provisioner",
+					Organization: []string{user.SystemPrivilegedGroup},
+					CertType:     secrets.ClientCert,
+					SigningCA:    cas[gardencorev1alpha1.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: common.KubeAPIServerDeploymentName,
+				},
+			},
 		}
 	},
 }
 
-var configChart = &chart.Chart{
+var configChart = util.NewChart(&chart.Chart{
 	Name: "cloud-provider-config",
 	Path: filepath.Join(openstacktypes.InternalChartsPath, "cloud-provider-config"),
 	Objects: []*chart.Object{
@@ -94,26 +126,55 @@ var configChart = &chart.Chart{
 			Name: openstacktypes.CloudProviderConfigName,
 		},
 	},
-}
+})
 
-var ccmChart = &chart.Chart{
-	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(openstacktypes.InternalChartsPath, "cloud-controller-manager"),
-	Images: []string{openstacktypes.HyperkubeImageName},
-	Objects: []*chart.Object{
-		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
-		{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+var controlPlaneChart = util.NewChart(&chart.Chart{
+	Name: "seed-controlplane",
+	Path: filepath.Join(openstacktypes.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{
+		{
+			Name:   "cloud-controller-manager",
+			Images: []string{openstacktypes.HyperkubeImageName},
+			Objects: []*chart.Object{
+				{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
+				{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
+			},
+		},
+		{
+			Name:   "csi-openstack",
+			Images: []string{openstacktypes.CSIDriverImageName, openstacktypes.CSIAttacherImageName, openstacktypes.CSIProvisionerImageName},
+			Objects: []*chart.Object{
+				{Type: &appsv1.Deployment{}, Name: csiControllerDeploymentName},
+			},
+		},
 	},
-}
+})
 
-var ccmShootChart = &chart.Chart{
-	Name: "cloud-controller-manager-shoot",
-	Path: filepath.Join(openstacktypes.InternalChartsPath, "cloud-controller-manager-shoot"),
-	Objects: []*chart.Object{
-		{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
-		{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
+	Name: "shoot-system-components",
+	Path: filepath.Join(openstacktypes.InternalChartsPath, "shoot-system-components"),
+	SubCharts: []*chart.Chart{
+		{
+			Name: "cloud-controller-manager",
+			Objects: []*chart.Object{
+				{Type: &rbacv1.ClusterRole{}, Name: "system:controller:cloud-node-controller"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "system:controller:cloud-node-controller"},
+			},
+		},
+		{
+			Name:   "csi-openstack",
+			Images: []string{openstacktypes.CSIDriverImageName, openstacktypes.CSINodeDriverRegistrarImageName},
+			Objects: []*chart.Object{
+				{Type: &storagev1.StorageClass{}, Name: "default-csi"},
+				{Type: &appsv1.DaemonSet{}, Name: "csi-driver-node"},
+				{Type: &corev1.ServiceAccount{}, Name: "csi-driver-node"},
+				{Type: &policyv1beta1.PodSecurityPolicy{}, Name: "gardener.kube-system.csi-driver-node"},
+				{Type: &rbacv1.ClusterRole{}, Name: "garden.sapcloud.io:psp:kube-system:csi-driver-node"},
+				{Type: &rbacv1.ClusterRoleBinding{}, Name: "garden.sapcloud.io:psp:csi-driver-node"},
+			},
+		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
@@ -152,6 +213,9 @@ func (vp *valuesProvider) GetConfigChartValues(
 	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
+	if errs := openstackvalidation.ValidateControlPlaneConfig(cpConfig); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
 
 	// Decode infrastructureProviderStatus
 	infraStatus := &openstack.InfrastructureStatus{}
@@ -183,17 +247,24 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// Get control plane chart values
+	return getControlPlaneChartValues(cpConfig, cp, cluster, checksums, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by this actuator.
 func (vp *valuesProvider) GetControlPlaneShootChartValues(
-	context.Context,
-	*extensionsv1alpha1.ControlPlane,
-	*extensionscontroller.Cluster,
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
 ) (map[string]interface{}, error) {
-	return nil, nil
+	// Decode providerConfig
+	cpConfig := &openstack.ControlPlaneConfig{}
+	if _, _, err := vp.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	// Get control plane shoot chart values
+	return getControlPlaneShootChartValues(cpConfig, cluster)
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
@@ -211,7 +282,7 @@ func getConfigChartValues(
 	}
 
 	// Collect config chart values
-	return map[string]interface{}{
+	values := map[string]interface{}{
 		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
 		"domainName":        c.DomainName,
 		"tenantName":        c.TenantName,
@@ -223,6 +294,69 @@ func getConfigChartValues(
 		"authUrl":           cluster.CloudProfile.Spec.OpenStack.KeyStoneURL,
 		"dhcpDomain":        cluster.CloudProfile.Spec.OpenStack.DHCPDomain,
 		"requestTimeout":    cluster.CloudProfile.Spec.OpenStack.RequestTimeout,
+	}
+
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.LoadBalancer != nil {
+		if ccm.LoadBalancer.UseOctavia != nil {
+			values["useOctavia"] = *ccm.LoadBalancer.UseOctavia
+		}
+		if len(ccm.LoadBalancer.Classes) > 0 {
+			values["loadBalancerClasses"] = getLoadBalancerClassesChartValues(ccm.LoadBalancer.Classes)
+		}
+	}
+	if ccm := cpConfig.CloudControllerManager; ccm != nil && ccm.Route != nil {
+		routerID := infraStatus.Networks.Router.ID
+		if ccm.Route.RouterID != nil {
+			routerID = *ccm.Route.RouterID
+		}
+		values["routerID"] = routerID
+	}
+
+	return values, nil
+}
+
+// getLoadBalancerClassesChartValues collects and returns the chart values of the given load balancer classes.
+func getLoadBalancerClassesChartValues(classes []openstack.LoadBalancerClass) []map[string]interface{} {
+	values := make([]map[string]interface{}, 0, len(classes))
+	for _, class := range classes {
+		classValues := map[string]interface{}{
+			"name": class.Name,
+		}
+		if class.FloatingNetworkID != nil {
+			classValues["floatingNetworkID"] = *class.FloatingNetworkID
+		}
+		if class.FloatingSubnetID != nil {
+			classValues["floatingSubnetID"] = *class.FloatingSubnetID
+		}
+		if class.SubnetID != nil {
+			classValues["subnetID"] = *class.SubnetID
+		}
+		values = append(values, classValues)
+	}
+	return values
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *openstack.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIChartValues(cpConfig, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cloud-controller-manager": ccm,
+		"csi-openstack":            csi,
 	}, nil
 }
 
@@ -255,3 +389,54 @@ func getCCMChartValues(
 
 	return values, nil
 }
+
+// getCSIChartValues collects and returns the CSI chart values.
+func getCSIChartValues(
+	cpConfig *openstack.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"enabled":           csiEnabled,
+		"replicas":          extensionscontroller.GetControlPlaneReplicas(cluster.Shoot, scaledDown, 1),
+		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-csi-attacher":             checksums[csiAttacherName],
+			"checksum/secret-csi-provisioner":          checksums[csiProvisionerName],
+			"checksum/secret-cloudprovider":            checksums[common.CloudProviderSecretName],
+			"checksum/configmap-cloud-provider-config": checksums[openstacktypes.CloudProviderConfigName],
+		},
+	}, nil
+}
+
+// getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
+func getControlPlaneShootChartValues(
+	cpConfig *openstack.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	csiEnabled, err := isCSIEnabled(cpConfig, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"csi-openstack": map[string]interface{}{
+			"enabled": csiEnabled,
+		},
+	}, nil
+}
+
+// isCSIEnabled returns true if the shoot opted in for the CSI driver and has a Kubernetes version that supports it.
+func isCSIEnabled(cpConfig *openstack.ControlPlaneConfig, cluster *extensionscontroller.Cluster) (bool, error) {
+	csiEnabled, err := controlplane.IsCSIEnabled(cpConfig.CSI != nil && cpConfig.CSI.Enabled, cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not check whether the CSI driver is enabled for Kubernetes version '%s'", cluster.Shoot.Spec.Kubernetes.Version)
+	}
+	return csiEnabled, nil
+}
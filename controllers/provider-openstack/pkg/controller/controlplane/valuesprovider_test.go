@@ -76,6 +76,16 @@ var _ = Describe("ValuesProvider", func() {
 									"CustomResourceValidation": true,
 								},
 							},
+							LoadBalancer: &openstack.LoadBalancerConfig{
+								UseOctavia: util.BoolPtr(true),
+								Classes: []openstack.LoadBalancerClass{
+									{
+										Name:              "public",
+										FloatingNetworkID: util.StringPtr("public-floating-network-id"),
+									},
+								},
+							},
+							Route: &openstack.RouteConfig{},
 						},
 					}),
 				},
@@ -85,6 +95,9 @@ var _ = Describe("ValuesProvider", func() {
 							FloatingPool: openstack.FloatingPoolStatus{
 								ID: "floating-network-id",
 							},
+							Router: openstack.RouterStatus{
+								ID: "router-id",
+							},
 							Subnets: []openstack.Subnet{
 								{
 									ID:      "subnet-acbd1234",
@@ -146,6 +159,8 @@ var _ = Describe("ValuesProvider", func() {
 			openstacktypes.CloudProviderConfigName: "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":             "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server":      "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			"csi-attacher":                         "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+			"csi-provisioner":                      "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
 		}
 
 		configChartValues = map[string]interface{}{
@@ -160,6 +175,14 @@ var _ = Describe("ValuesProvider", func() {
 			"authUrl":           authURL,
 			"dhcpDomain":        dhcpDomain,
 			"requestTimeout":    requestTimeout,
+			"useOctavia":        true,
+			"loadBalancerClasses": []map[string]interface{}{
+				{
+					"name":              "public",
+					"floatingNetworkID": "public-floating-network-id",
+				},
+			},
+			"routerID": "router-id",
 		}
 
 		ccmChartValues = map[string]interface{}{
@@ -178,6 +201,18 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
+		csiChartValues = map[string]interface{}{
+			"enabled":           false,
+			"replicas":          1,
+			"kubernetesVersion": "1.13.4",
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-csi-attacher":             "2da58ad61c401a2af779a909d22fb42eed93a1524cbfdab974ceedb413fcb914",
+				"checksum/secret-csi-provisioner":          "f75b42d40ab501428c383dfb2336cb1fc892bbee1fc1d739675171e4acc4d911",
+				"checksum/secret-cloudprovider":            "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
+				"checksum/configmap-cloud-provider-config": "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
+			},
+		}
+
 		logger = log.Log.WithName("test")
 	)
 
@@ -207,6 +242,31 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(configChartValues))
 		})
+
+		It("should fail for an invalid providerConfig", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			invalidCP := cp.DeepCopy()
+			invalidCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&openstack.ControlPlaneConfig{
+					CloudControllerManager: &openstack.CloudControllerManagerConfig{
+						LoadBalancer: &openstack.LoadBalancerConfig{
+							Classes: []openstack.LoadBalancerClass{
+								{Name: "public"},
+							},
+						},
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			_, err = vp.GetConfigChartValues(context.TODO(), invalidCP, cluster)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid providerConfig"))
+		})
 	})
 
 	Describe("#GetControlPlaneChartValues", func() {
@@ -219,7 +279,55 @@ var _ = Describe("ValuesProvider", func() {
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(map[string]interface{}{
+				"cloud-controller-manager": ccmChartValues,
+				"csi-openstack":            csiChartValues,
+			}))
+		})
+	})
+
+	Describe("#GetControlPlaneShootChartValues", func() {
+		It("should return correct control plane shoot chart values", func() {
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-openstack": map[string]interface{}{
+					"enabled": false,
+				},
+			}))
+		})
+
+		It("should enable the CSI driver if opted in", func() {
+			csiCP := cp.DeepCopy()
+			csiCP.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&openstack.ControlPlaneConfig{
+					CSI: &openstack.CSIConfig{
+						Enabled: true,
+					},
+				}),
+			}
+			csiCluster := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			csiCluster.Shoot.Spec.Kubernetes.Version = "1.14.3"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), csiCP, csiCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"csi-openstack": map[string]interface{}{
+					"enabled": true,
+				},
+			}))
 		})
 	})
 })
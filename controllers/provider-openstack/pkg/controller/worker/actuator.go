@@ -16,10 +16,12 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/imagevector"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker/genericactuator"
@@ -48,7 +50,7 @@ type delegateFactory struct {
 }
 
 // NewActuator creates a new Actuator that updates the status of the handled WorkerPoolConfigs.
-func NewActuator(machineImageToCloudProfilesMapping []config.MachineImage) worker.Actuator {
+func NewActuator(machineImageToCloudProfilesMapping []config.MachineImage, rolloutTimeout time.Duration, mcmConfig *extensionsconfig.MachineControllerManager) worker.Actuator {
 	delegateFactory := &delegateFactory{
 		logger:                             log.Log.WithName("worker-actuator"),
 		machineImageToCloudProfilesMapping: machineImageToCloudProfilesMapping,
@@ -60,6 +62,8 @@ func NewActuator(machineImageToCloudProfilesMapping []config.MachineImage) worke
 		mcmChart,
 		mcmShootChart,
 		imagevector.ImageVector(),
+		rolloutTimeout,
+		mcmConfig,
 	)
 }
 
@@ -80,6 +84,10 @@ func (d *delegateFactory) InjectClient(client client.Client) error {
 }
 
 func (d *delegateFactory) WorkerDelegate(ctx context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (genericactuator.WorkerDelegate, error) {
+	if err := validateMachineImages(d.decoder, d.machineImageToCloudProfilesMapping, worker, cluster); err != nil {
+		return nil, err
+	}
+
 	clientset, err := kubernetes.NewForConfig(d.restConfig)
 	if err != nil {
 		return nil, err
@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
+	"github.com/gardener/gardener-extensions/pkg/util"
 
 	"github.com/gardener/gardener/pkg/utils/chart"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
@@ -29,7 +30,7 @@ import (
 )
 
 var (
-	mcmChart = &chart.Chart{
+	mcmChart = util.NewChart(&chart.Chart{
 		Name:   openstack.MachineControllerManagerName,
 		Path:   filepath.Join(openstack.InternalChartsPath, openstack.MachineControllerManagerName, "seed"),
 		Images: []string{openstack.MachineControllerManagerImageName},
@@ -39,16 +40,16 @@ var (
 			{Type: &corev1.ServiceAccount{}, Name: openstack.MachineControllerManagerName},
 			{Type: &corev1.Secret{}, Name: openstack.MachineControllerManagerName},
 		},
-	}
+	})
 
-	mcmShootChart = &chart.Chart{
+	mcmShootChart = util.NewChart(&chart.Chart{
 		Name: openstack.MachineControllerManagerName,
 		Path: filepath.Join(openstack.InternalChartsPath, openstack.MachineControllerManagerName, "shoot"),
 		Objects: []*chart.Object{
 			{Type: &rbacv1.ClusterRole{}, Name: fmt.Sprintf("extensions.gardener.cloud:%s:%s", openstack.Name, openstack.MachineControllerManagerName)},
 			{Type: &rbacv1.ClusterRoleBinding{}, Name: fmt.Sprintf("extensions.gardener.cloud:%s:%s", openstack.Name, openstack.MachineControllerManagerName)},
 		},
-	}
+	})
 )
 
 func (w *workerDelegate) GetMachineControllerManagerChartValues(ctx context.Context) (map[string]interface{}, error) {
@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"path/filepath"
 
-	confighelper "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/config/helper"
 	openstackapi "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack"
 	openstackapihelper "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack/helper"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/internal"
@@ -104,10 +103,15 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	findMachineImage, err := newMachineImageLookup(w.decoder, w.machineImageToCloudProfilesMapping, w.cluster)
+	if err != nil {
+		return err
+	}
+
 	for _, pool := range w.worker.Spec.Pools {
 		zoneLen := len(pool.Zones)
 
-		machineImage, err := confighelper.FindImageForCloudProfile(w.machineImageToCloudProfilesMapping, pool.MachineImage.Name, pool.MachineImage.Version, w.cluster.CloudProfile.Name)
+		machineImage, err := findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
 		if err != nil {
 			return err
 		}
@@ -139,6 +143,8 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 
 			machineDeployments = append(machineDeployments, worker.MachineDeployment{
 				Name:           deploymentName,
+				PoolName:       pool.Name,
+				Zone:           zone,
 				ClassName:      className,
 				SecretName:     className,
 				Minimum:        worker.DistributeOverZones(zoneIndex, pool.Minimum, zoneLen),
@@ -26,6 +26,14 @@ const (
 	MachineControllerManagerImageName = "machine-controller-manager"
 	// HyperkubeImageName is the name of the hyperkube image.
 	HyperkubeImageName = "hyperkube"
+	// CSIDriverImageName is the name of the OpenStack Cinder CSI driver image.
+	CSIDriverImageName = "cinder-csi-plugin"
+	// CSIAttacherImageName is the name of the CSI attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIProvisionerImageName is the name of the CSI provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSINodeDriverRegistrarImageName is the name of the CSI node driver registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
 	// ETCDBackupRestoreImageName is the name of the etcd backup and restore image.
 	ETCDBackupRestoreImageName = "etcd-backup-restore"
 
@@ -54,6 +62,11 @@ const (
 	// BackupSecretName defines the name of the secret containing the credentials which are required to
 	// authenticate against the respective cloud provider (required to store the backups of Shoot clusters).
 	BackupSecretName = "etcd-backup"
+
+	// CSIMigrationFeatureGate is the feature gate that migrates the volumes of the in-tree OpenStack Cinder volume plugin to the CSI driver.
+	CSIMigrationFeatureGate = "CSIMigrationOpenStack"
+	// CSIMigrationMinimumKubernetesVersion is the minimum Kubernetes version that supports the CSIMigrationFeatureGate.
+	CSIMigrationMinimumKubernetesVersion = "1.14"
 )
 
 var (
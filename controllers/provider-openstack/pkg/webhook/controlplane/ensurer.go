@@ -17,7 +17,12 @@ package controlplane
 import (
 	"context"
 
+	apisopenstack "github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	extensionscontrolplane "github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
 
@@ -28,21 +33,26 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(clusterAutoscaler *extensionsconfig.ClusterAutoscaler, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		logger: logger.WithName("openstack-controlplane-ensurer"),
+		clusterAutoscaler: clusterAutoscaler,
+		logger:            logger.WithName("openstack-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	client client.Client
-	logger logr.Logger
+	clusterAutoscaler *extensionsconfig.ClusterAutoscaler
+	client            client.Client
+	decoder           runtime.Decoder
+	logger            logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -51,6 +61,12 @@ func (e *ensurer) InjectClient(client client.Client) error {
 	return nil
 }
 
+// InjectScheme injects the given scheme into the ensurer.
+func (e *ensurer) InjectScheme(scheme *runtime.Scheme) error {
+	e.decoder = serializer.NewCodecFactory(scheme).UniversalDecoder()
+	return nil
+}
+
 // EnsureKubeAPIServerDeployment ensures that the kube-apiserver deployment conforms to the provider requirements.
 func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
 	template := &dep.Spec.Template
@@ -65,10 +81,16 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, dep *appsv1
 
 // EnsureKubeControllerManagerDeployment ensures that the kube-controller-manager deployment conforms to the provider requirements.
 func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	featureGates, err := e.csiMigrationFeatureGates(ctx, dep.Namespace)
+	if err != nil {
+		return err
+	}
+
 	template := &dep.Spec.Template
 	ps := &template.Spec
 	if c := controlplane.ContainerWithName(ps.Containers, "kube-controller-manager"); c != nil {
 		ensureKubeControllerManagerCommandLineArgs(c)
+		c.Command = controlplane.EnsureFeatureGatesFlag(c.Command, featureGates)
 		ensureVolumeMounts(c)
 	}
 	ensureKubeControllerManagerAnnotations(template)
@@ -127,6 +149,12 @@ func (e *ensurer) ensureChecksumAnnotations(ctx context.Context, template *corev
 	return controlplane.EnsureConfigMapChecksumAnnotation(ctx, template, e.client, namespace, openstack.CloudProviderConfigName)
 }
 
+// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+func (e *ensurer) EnsureClusterAutoscalerDeployment(ctx context.Context, dep *appsv1.Deployment) error {
+	controlplane.EnsureClusterAutoscalerCommandLineArgs(dep, e.clusterAutoscaler)
+	return nil
+}
+
 // EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 func (e *ensurer) EnsureKubeletServiceUnitOptions(ctx context.Context, opts []*unit.UnitOption) ([]*unit.UnitOption, error) {
 	if opt := controlplane.UnitOptionWithSectionAndName(opts, "Service", "ExecStart"); opt != nil {
@@ -149,16 +177,54 @@ func ensureKubeletCommandLineArgs(command []string) []string {
 	return command
 }
 
-// EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) error {
+// EnsureKubeletConfiguration ensures that the kubelet configuration of the shoot in the given namespace conforms to
+// the provider requirements.
+func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, namespace string) error {
 	// Make sure CSI-related feature gates are not enabled
 	// TODO Leaving these enabled shouldn't do any harm, perhaps remove this code when properly tested?
 	delete(kubeletConfig.FeatureGates, "VolumeSnapshotDataSource")
 	delete(kubeletConfig.FeatureGates, "CSINodeInfo")
 	delete(kubeletConfig.FeatureGates, "CSIDriverRegistry")
+
+	// Migrate the volumes of the in-tree volume plugin to the CSI driver if the shoot opted in for it
+	featureGates, err := e.csiMigrationFeatureGates(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	controlplane.EnsureKubeletFeatureGates(kubeletConfig, featureGates)
 	return nil
 }
 
+// csiMigrationFeatureGates returns the feature gates that migrate the volumes of the in-tree volume plugin to the CSI
+// driver if the shoot in the given namespace opted in for it and its Kubernetes version supports it, nil otherwise.
+func (e *ensurer) csiMigrationFeatureGates(ctx context.Context, namespace string) (map[string]bool, error) {
+	cp, err := controlplane.GetControlPlane(ctx, e.client, namespace, openstack.Type)
+	if err != nil || cp == nil || cp.Spec.ProviderConfig == nil {
+		return nil, err
+	}
+	cpConfig := &apisopenstack.ControlPlaneConfig{}
+	if _, _, err := e.decoder.Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+	}
+	if cpConfig.CSI == nil || !cpConfig.CSI.MigrateVolumes {
+		return nil, nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, e.client, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get cluster for namespace '%s'", namespace)
+	}
+	version := cluster.Shoot.Spec.Kubernetes.Version
+	migrationEnabled, err := extensionscontrolplane.IsCSIMigrationEnabled(cpConfig.CSI.Enabled, cpConfig.CSI.MigrateVolumes, version, openstack.CSIMigrationMinimumKubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not check whether the CSI migration is enabled for Kubernetes version '%s'", version)
+	}
+	if !migrationEnabled {
+		return nil, nil
+	}
+	return controlplane.CSIMigrationFeatureGates(openstack.CSIMigrationFeatureGate), nil
+}
+
 // ShouldProvisionKubeletCloudProviderConfig returns true if the cloud provider config file should be added to the kubelet configuration.
 func (e *ensurer) ShouldProvisionKubeletCloudProviderConfig() bool {
 	return true
@@ -17,14 +17,19 @@ package controlplane
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/apis/openstack/install"
 	"github.com/gardener/gardener-extensions/controllers/provider-openstack/pkg/openstack"
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/test"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -35,8 +40,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
@@ -69,6 +76,13 @@ var _ = Describe("Ensurer", func() {
 			"networking.gardener.cloud/to-private-networks": "allowed",
 			"networking.gardener.cloud/to-blocked-cidrs":    "allowed",
 		}
+
+		clusterAutoscaler = &extensionsconfig.ClusterAutoscaler{
+			Expander:                 util.StringPtr("least-waste"),
+			BalanceSimilarNodeGroups: util.BoolPtr(true),
+			ScaleDownDelayAfterAdd:   &metav1.Duration{Duration: 30 * time.Minute},
+			ScaleDownUnneededTime:    &metav1.Duration{Duration: 20 * time.Minute},
+		}
 	)
 
 	BeforeEach(func() {
@@ -103,7 +117,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -148,7 +162,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -180,10 +194,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -224,10 +239,11 @@ var _ = Describe("Ensurer", func() {
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -236,6 +252,77 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeControllerManagerDeployment(dep, annotations, kubeControllerManagerLabels)
 		})
+
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeControllerManagerDeploymentName},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:    "kube-controller-manager",
+										Command: []string{"--feature-gates=Foo=true"},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(append(csiMigrationObjects("1.14.1"), cm)...)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err := ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			container := controlplane.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(container.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationOpenStack=true,Foo=true"))
+		})
+	})
+	Describe("#EnsureClusterAutoscalerDeployment", func() {
+		It("should add or modify the configured flags of the cluster-autoscaler deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "cluster-autoscaler",
+										Command: []string{
+											"./cluster-autoscaler",
+											"--expander=random",
+											"--scale-down-unneeded-time=30m",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+
+			// Call EnsureClusterAutoscalerDeployment method and check the result
+			err := ensurer.EnsureClusterAutoscalerDeployment(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--expander=least-waste",
+				"--scale-down-unneeded-time=20m0s",
+				"--balance-similar-node-groups=true",
+				"--scale-down-delay-after-add=30m0s",
+			}))
+		})
 	})
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
@@ -267,7 +354,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 
 			// Call EnsureKubeletServiceUnitOptions method and check the result
 			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), oldUnitOptions)
@@ -293,17 +380,60 @@ var _ = Describe("Ensurer", func() {
 				}
 			)
 
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.ControlPlaneList{}).Return(nil)
+
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig)
+			err = ensurer.EnsureKubeletConfiguration(context.TODO(), &kubeletConfig, namespace)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
-	})
 
+		It("should enable the CSI migration feature gates if the shoot opted in for it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
+				FeatureGates: map[string]bool{
+					"Foo": true,
+				},
+			}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.14.1")...)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(Equal(map[string]bool{
+				"Foo":                   true,
+				"CSIMigration":          true,
+				"CSIMigrationOpenStack": true,
+			}))
+		})
+
+		It("should not enable the CSI migration feature gates if the Kubernetes version doesn't support it", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+
+			// Create fake client and ensurer
+			c, scheme := newFakeClient(csiMigrationObjects("1.13.4")...)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
+			Expect(ensurer.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(ensurer.(inject.Scheme).InjectScheme(scheme)).To(Succeed())
+
+			// Call EnsureKubeletConfiguration method and check the result
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), kubeletConfig, namespace)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(kubeletConfig.FeatureGates).To(BeEmpty())
+		})
+	})
 	Describe("#EnsureKubeletCloudProviderConfig", func() {
 		var (
 			existingData = util.StringPtr("[LoadBalancer]\nlb-version=v2\nlb-provider:\n")
@@ -315,7 +445,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, cm.Name))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -330,7 +460,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -345,7 +475,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(clusterAutoscaler, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -406,3 +536,35 @@ func clientGet(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+func newFakeClient(objects ...runtime.Object) (client.Client, *runtime.Scheme) {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+	Expect(install.AddToScheme(scheme)).To(Succeed())
+	return fake.NewFakeClientWithScheme(scheme, objects...), scheme
+}
+
+func csiMigrationObjects(kubernetesVersion string) []runtime.Object {
+	return []runtime.Object{
+		&extensionsv1alpha1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "control-plane"},
+			Spec: extensionsv1alpha1.ControlPlaneSpec{
+				DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: openstack.Type},
+				ProviderConfig: &runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"openstack.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","csi":{"enabled":true,"migrateVolumes":true}}`),
+				},
+			},
+		},
+		&extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"CloudProfile"}`)},
+				Seed:         runtime.RawExtension{Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Seed"}`)},
+				Shoot: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion":"garden.sapcloud.io/v1beta1","kind":"Shoot","spec":{"kubernetes":{"version":"` + kubernetesVersion + `"}}}`),
+				},
+			},
+		},
+	}
+}
@@ -91,14 +91,16 @@ func (e *ensurer) getBackupRestoreContainer(name string, cluster *extensionscont
 		defaultSchedule = "0 */24 * * *"
 	)
 
-	// Determine provider and container env variables
+	// Determine provider, container env variables, and the etcd backup configuration of the shoot
 	// They are only specified for the etcd-main stateful set (backup is enabled)
 	var (
 		provider                string
 		env                     []corev1.EnvVar
+		backupConfig            *controlplane.ETCDBackupConfig
 		volumeClaimTemplateName = name
 	)
 	if name == common.EtcdMainStatefulSetName {
+		backupConfig = controlplane.ETCDBackupConfigFromShoot(cluster.Shoot, e.logger)
 		provider = openstack.StorageProviderName
 		env = []corev1.EnvVar{
 			{
@@ -164,8 +166,8 @@ func (e *ensurer) getBackupRestoreContainer(name string, cluster *extensionscont
 	// Determine schedule
 	var schedule = defaultSchedule
 	if e.etcdBackup.Schedule != nil {
-		schedule = defaultSchedule
+		schedule = *e.etcdBackup.Schedule
 	}
 
-	return controlplane.GetBackupRestoreContainer(name, volumeClaimTemplateName, schedule, provider, image.String(), nil, env, nil), nil
+	return controlplane.GetBackupRestoreContainer(name, volumeClaimTemplateName, schedule, provider, image.String(), backupConfig, nil, env, nil), nil
 }
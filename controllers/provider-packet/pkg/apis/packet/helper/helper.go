@@ -0,0 +1,35 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/controllers/provider-packet/pkg/apis/packet"
+)
+
+// FindImageFromCloudProfile takes a CloudProfileConfig, and the desired image name and version. It tries to find the
+// image with the given name and version. If it cannot be found then an error is returned.
+func FindImageFromCloudProfile(cloudProfileConfig *packet.CloudProfileConfig, imageName, imageVersion string) (string, error) {
+	if cloudProfileConfig != nil {
+		for _, machineImage := range cloudProfileConfig.MachineImages {
+			if machineImage.Name == imageName && machineImage.Version == imageVersion {
+				return machineImage.ID, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("could not find an image for name %q in version %q in the cloud profile", imageName, imageVersion)
+}
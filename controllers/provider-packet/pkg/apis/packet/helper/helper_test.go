@@ -0,0 +1,57 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper_test
+
+import (
+	"github.com/gardener/gardener-extensions/controllers/provider-packet/pkg/apis/packet"
+	. "github.com/gardener/gardener-extensions/controllers/provider-packet/pkg/apis/packet/helper"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Helper", func() {
+	DescribeTable("#FindImageFromCloudProfile",
+		func(cloudProfileConfig *packet.CloudProfileConfig, imageName, imageVersion, expectedImage string) {
+			image, err := FindImageFromCloudProfile(cloudProfileConfig, imageName, imageVersion)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("cloud profile config is nil", nil, "ubuntu", "1", ""),
+		Entry("no machine images", &packet.CloudProfileConfig{}, "ubuntu", "1", ""),
+		Entry("entry not found (no name)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "coreos", "1", ""),
+		Entry("entry not found (no version)", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "2", ""),
+		Entry("entry exists", makeCloudProfileConfig("ubuntu", "1", "image-1234"), "ubuntu", "1", "image-1234"),
+	)
+})
+
+func makeCloudProfileConfig(name, version, image string) *packet.CloudProfileConfig {
+	return &packet.CloudProfileConfig{
+		MachineImages: []packet.MachineImage{
+			{
+				Name:    name,
+				Version: version,
+				ID:      image,
+			},
+		},
+	}
+}
@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	unsafe "unsafe"
+
 	packet "github.com/gardener/gardener-extensions/controllers/provider-packet/pkg/apis/packet"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +35,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*CloudProfileConfig)(nil), (*packet.CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProfileConfig_To_packet_CloudProfileConfig(a.(*CloudProfileConfig), b.(*packet.CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*packet.CloudProfileConfig)(nil), (*CloudProfileConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_packet_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(a.(*packet.CloudProfileConfig), b.(*CloudProfileConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*packet.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_packet_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*packet.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -63,9 +75,39 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*packet.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineImage_To_packet_MachineImage(a.(*MachineImage), b.(*packet.MachineImage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*packet.MachineImage)(nil), (*MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_packet_MachineImage_To_v1alpha1_MachineImage(a.(*packet.MachineImage), b.(*MachineImage), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
+func autoConvert_v1alpha1_CloudProfileConfig_To_packet_CloudProfileConfig(in *CloudProfileConfig, out *packet.CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]packet.MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProfileConfig_To_packet_CloudProfileConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProfileConfig_To_packet_CloudProfileConfig(in *CloudProfileConfig, out *packet.CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProfileConfig_To_packet_CloudProfileConfig(in, out, s)
+}
+
+func autoConvert_packet_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *packet.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	out.MachineImages = *(*[]MachineImage)(unsafe.Pointer(&in.MachineImages))
+	return nil
+}
+
+// Convert_packet_CloudProfileConfig_To_v1alpha1_CloudProfileConfig is an autogenerated conversion function.
+func Convert_packet_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *packet.CloudProfileConfig, out *CloudProfileConfig, s conversion.Scope) error {
+	return autoConvert_packet_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_packet_ControlPlaneConfig(in *ControlPlaneConfig, out *packet.ControlPlaneConfig, s conversion.Scope) error {
 	return nil
 }
@@ -121,3 +163,27 @@ func autoConvert_packet_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in
 func Convert_packet_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *packet.InfrastructureStatus, out *InfrastructureStatus, s conversion.Scope) error {
 	return autoConvert_packet_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_MachineImage_To_packet_MachineImage(in *MachineImage, out *packet.MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_MachineImage_To_packet_MachineImage is an autogenerated conversion function.
+func Convert_v1alpha1_MachineImage_To_packet_MachineImage(in *MachineImage, out *packet.MachineImage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineImage_To_packet_MachineImage(in, out, s)
+}
+
+func autoConvert_packet_MachineImage_To_v1alpha1_MachineImage(in *packet.MachineImage, out *MachineImage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_packet_MachineImage_To_v1alpha1_MachineImage is an autogenerated conversion function.
+func Convert_packet_MachineImage_To_v1alpha1_MachineImage(in *packet.MachineImage, out *MachineImage, s conversion.Scope) error {
+	return autoConvert_packet_MachineImage_To_v1alpha1_MachineImage(in, out, s)
+}
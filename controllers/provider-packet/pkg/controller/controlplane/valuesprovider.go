@@ -98,7 +98,7 @@ var controlPlaneSecrets = &secrets.Secrets{
 	},
 }
 
-var controlPlaneChart = &chart.Chart{
+var controlPlaneChart = util.NewChart(&chart.Chart{
 	Name: "seed-controlplane",
 	Path: filepath.Join(packet.InternalChartsPath, "seed-controlplane"),
 	SubCharts: []*chart.Chart{
@@ -118,9 +118,9 @@ var controlPlaneChart = &chart.Chart{
 			},
 		},
 	},
-}
+})
 
-var controlPlaneShootChart = &chart.Chart{
+var controlPlaneShootChart = util.NewChart(&chart.Chart{
 	Name: "shoot-system-components",
 	Path: filepath.Join(packet.InternalChartsPath, "shoot-system-components"),
 	SubCharts: []*chart.Chart{
@@ -155,7 +155,7 @@ var controlPlaneShootChart = &chart.Chart{
 			},
 		},
 	},
-}
+})
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
 func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
@@ -16,6 +16,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
@@ -34,6 +35,10 @@ type Cluster struct {
 	CloudProfile *gardenv1beta1.CloudProfile
 	Seed         *gardenv1beta1.Seed
 	Shoot        *gardenv1beta1.Shoot
+
+	// CloudProfileConfig is the raw provider-specific configuration of the CloudProfile, e.g. the mapping of machine
+	// image names and versions to provider-specific identifiers. It is nil if the CloudProfile has no such configuration.
+	CloudProfileConfig []byte
 }
 
 // GetCluster tries to read Gardener's Cluster extension resource in the given namespace.
@@ -56,7 +61,12 @@ func GetCluster(ctx context.Context, c client.Client, namespace string) (*Cluste
 		return nil, err
 	}
 
-	return &Cluster{cloudProfile, seed, shoot}, nil
+	cloudProfileConfig, err := CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cluster{cloudProfile, seed, shoot, cloudProfileConfig}, nil
 }
 
 // CloudProfileFromCluster returns the CloudProfile resource inside the Cluster resource.
@@ -95,6 +105,25 @@ func ShootFromCluster(cluster *extensionsv1alpha1.Cluster) (*gardenv1beta1.Shoot
 	return shoot, err
 }
 
+// CloudProfileConfigFromCluster returns the raw provider-specific configuration (`spec.providerConfig`) of the
+// CloudProfile inside the Cluster resource, or nil if it has none.
+// TODO: Read it from the decoded CloudProfile once the `gardenv1beta1` API contains this field.
+func CloudProfileConfigFromCluster(cluster *extensionsv1alpha1.Cluster) ([]byte, error) {
+	cloudProfile := &struct {
+		Spec struct {
+			ProviderConfig json.RawMessage `json:"providerConfig,omitempty"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(cluster.Spec.CloudProfile.Raw, cloudProfile); err != nil {
+		return nil, err
+	}
+
+	if config := cloudProfile.Spec.ProviderConfig; len(config) > 0 && string(config) != "null" {
+		return []byte(config), nil
+	}
+	return nil, nil
+}
+
 // ShootIsFailed returns whether the given shoot is marked as 'failed'.
 func ShootIsFailed(shoot *gardenv1beta1.Shoot) bool {
 	lastOperation := shoot.Status.LastOperation
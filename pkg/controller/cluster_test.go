@@ -0,0 +1,53 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster", func() {
+	DescribeTable("#CloudProfileConfigFromCluster",
+		func(cloudProfile string, expected []byte) {
+			cluster := &extensionsv1alpha1.Cluster{
+				Spec: extensionsv1alpha1.ClusterSpec{
+					CloudProfile: runtime.RawExtension{Raw: []byte(cloudProfile)},
+				},
+			}
+
+			Expect(CloudProfileConfigFromCluster(cluster)).To(Equal(expected))
+		},
+
+		Entry("provider config is missing", `{"spec":{}}`, nil),
+		Entry("provider config is null", `{"spec":{"providerConfig":null}}`, nil),
+		Entry("provider config is set", `{"spec":{"providerConfig":{"kind":"CloudProfileConfig"}}}`, []byte(`{"kind":"CloudProfileConfig"}`)),
+	)
+
+	It("should fail if the cloud profile cannot be decoded", func() {
+		cluster := &extensionsv1alpha1.Cluster{
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: []byte(`{`)},
+			},
+		}
+
+		_, err := CloudProfileConfigFromCluster(cluster)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -15,6 +15,8 @@
 package controlplane
 
 import (
+	"time"
+
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 
 	extensionshandler "github.com/gardener/gardener-extensions/pkg/handler"
@@ -32,6 +34,33 @@ const (
 	FinalizerName = "extensions.gardener.cloud/controlplane"
 	// ControllerName is the name of the controller
 	ControllerName = "controlplane-controller"
+	// AnnotationRotateSecrets is the annotation on a controlplane that requests the rotation of its secrets. Its value
+	// is either `certificates` to regenerate all certificates, or `ca` to roll the CAs generated by the extension as well,
+	// which fails if there are none, as the cluster CA is owned by the gardenlet. It is removed once the rotation has been
+	// started.
+	AnnotationRotateSecrets = "controlplane.extensions.gardener.cloud/rotate-secrets"
+	// AnnotationRotateKMSKey is the annotation on a controlplane that requests the next phase of the rotation of its
+	// KMS key. Its value is either `switch` to encrypt with the configured key, or `cleanup` to remove all other keys.
+	// It is removed once the phase has been applied.
+	AnnotationRotateKMSKey = "controlplane.extensions.gardener.cloud/rotate-kms-key"
+	// DefaultResyncInterval is the default interval after which the charts of a controlplane are applied again even
+	// if they have not changed.
+	DefaultResyncInterval = time.Hour
+	// DefaultManagedResourceDeletionTimeout is the default duration to wait for the managed resource of a controlplane
+	// to be deleted.
+	DefaultManagedResourceDeletionTimeout = 5 * time.Minute
+)
+
+// OrphanPolicy determines what happens if the managed resource of a controlplane has not been deleted within the
+// managed resource deletion timeout.
+type OrphanPolicy string
+
+const (
+	// OrphanPolicyNever fails the deletion of the controlplane, so that it is retried.
+	OrphanPolicyNever OrphanPolicy = "Never"
+	// OrphanPolicyAfterTimeout orphans the objects of the managed resource in the shoot and continues the deletion
+	// of the controlplane.
+	OrphanPolicyAfterTimeout OrphanPolicy = "AfterTimeout"
 )
 
 // AddArgs are arguments for adding an controlplane controller to a manager.
@@ -40,6 +69,9 @@ type AddArgs struct {
 	Actuator Actuator
 	// Type is the controlplane type the actuator supports.
 	Type string
+	// ResyncInterval is the interval after which a controlplane is reconciled again, so that its charts are applied
+	// even if neither the controlplane nor its charts have changed. Zero disables the periodic reconciliation.
+	ResyncInterval time.Duration
 	// ControllerOptions are the controller options used for creating a controller.
 	// The options.Reconciler is always overridden with a reconciler created from the
 	// given actuator.
@@ -53,14 +85,14 @@ type AddArgs struct {
 func DefaultPredicates(mgr manager.Manager) []predicate.Predicate {
 	return []predicate.Predicate{
 		extensionscontroller.ShootFailedPredicate(mgr.GetClient()),
-		extensionscontroller.GenerationChangedPredicate(),
+		extensionscontroller.OrPredicate(extensionscontroller.GenerationChangedPredicate(), SecretsRotationRequestedPredicate(), KMSKeyRotationRequestedPredicate()),
 	}
 }
 
 // Add creates a new ControlPlane Controller and adds it to the Manager.
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, args AddArgs) error {
-	args.ControllerOptions.Reconciler = NewReconciler(mgr, args.Actuator)
+	args.ControllerOptions.Reconciler = NewReconciler(mgr, args.Actuator, args.ResyncInterval)
 	return add(mgr, args.Type, args.ControllerOptions, args.Predicates)
 }
 
@@ -16,17 +16,24 @@ package genericactuator
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	"github.com/gardener/gardener-extensions/pkg/util"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/chartrenderer"
 	gardenerkubernetes "github.com/gardener/gardener/pkg/client/kubernetes"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/gardener/gardener/pkg/utils/imagevector"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 
+	resourcesv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
 	"github.com/gardener/gardener-resource-manager/pkg/manager"
 
 	"github.com/go-logr/logr"
@@ -35,6 +42,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -47,8 +55,24 @@ const (
 	// ShootNoCleanupLabel is a constant for a label on a resource indicating the the Gardener cleaner should not delete this
 	// resource when cleaning a shoot during the deletion flow.
 	ShootNoCleanupLabel = "shoot.gardener.cloud/no-cleanup"
+
+	// AnnotationConfigChartHash is the annotation on a controlplane that contains the hash of the last applied config chart.
+	AnnotationConfigChartHash = "controlplane.extensions.gardener.cloud/config-chart-hash"
+	// AnnotationControlPlaneChartHash is the annotation on a controlplane that contains the hash of the last applied
+	// control plane chart.
+	AnnotationControlPlaneChartHash = "controlplane.extensions.gardener.cloud/control-plane-chart-hash"
+	// AnnotationControlPlaneShootChartHash is the annotation on a controlplane that contains the hash of the last applied
+	// control plane shoot chart.
+	AnnotationControlPlaneShootChartHash = "controlplane.extensions.gardener.cloud/control-plane-shoot-chart-hash"
+	// AnnotationLastResyncTime is the annotation on a controlplane that contains the time all charts have been applied
+	// at regardless of their hashes in RFC 3339 format.
+	AnnotationLastResyncTime = "controlplane.extensions.gardener.cloud/last-resync-time"
 )
 
+// managedResourceDeletionRequeueInterval is the duration after which the deletion of a controlplane is requeued while
+// its managed resource has not been deleted yet.
+const managedResourceDeletionRequeueInterval = 5 * time.Second
+
 // ValuesProvider provides values for the 2 charts applied by this actuator.
 type ValuesProvider interface {
 	// GetConfigChartValues returns the values for the config chart applied by this actuator.
@@ -59,6 +83,15 @@ type ValuesProvider interface {
 	GetControlPlaneShootChartValues(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) (map[string]interface{}, error)
 }
 
+// KMSKeyProvider is implemented by ValuesProviders that support encrypting the secrets of shoots with a KMS key.
+type KMSKeyProvider interface {
+	// GetKMSKeyID returns the ID of the KMS key configured for the given controlplane, or nil if no key is configured.
+	// The key must be usable with the provider secret of the controlplane, which should be checked against the KMS
+	// (e.g., by encrypting controlplane.KMSKeyCheckPlaintext with it), since an error is reported on the controlplane
+	// before the key is deployed.
+	GetKMSKeyID(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) (*string, error)
+}
+
 // ChartRendererFactory creates chartrenderer.Interface to be used by this actuator.
 type ChartRendererFactory interface {
 	// NewChartRendererForShoot creates a new chartrenderer.Interface for the shoot cluster.
@@ -73,9 +106,21 @@ func (f ChartRendererFactoryFunc) NewChartRendererForShoot(version string) (char
 	return f(version)
 }
 
+// DeletionHook is run by this actuator before it deletes the control plane chart.
+type DeletionHook interface {
+	// BeforeControlPlaneChartDeletion is called before the control plane chart of the given controlplane is deleted,
+	// e.g. to ensure that no cloud resources managed by the cloud-controller-manager remain.
+	BeforeControlPlaneChartDeletion(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) error
+}
+
 // NewActuator creates a new Actuator that acts upon and updates the status of ControlPlane resources.
 // It creates / deletes the given secrets and applies / deletes the given charts, using the given image vector and
-// the values provided by the given values provider.
+// the values provided by the given values provider. Charts whose hash has not changed since they have been applied last
+// are skipped, unless the given resync interval has elapsed. A zero resync interval applies all charts on every reconciliation.
+// On deletion, it requeues until the managed resource has been deleted, for at most the given timeout after which it
+// proceeds according to the given orphan policy, and runs the given deletion hook, if any, before it deletes the control plane chart.
+// If the given shoot webhook configuration is set, it is created or updated in the shoot cluster on every reconciliation
+// once the shoot apiserver is available, and deleted from it on deletion if the shoot apiserver is available.
 func NewActuator(
 	secrets util.Secrets,
 	configChart, controlPlaneChart, controlPlaneShootChart util.Chart,
@@ -83,18 +128,28 @@ func NewActuator(
 	chartRendererFactory ChartRendererFactory,
 	imageVector imagevector.ImageVector,
 	configName string,
+	resyncInterval time.Duration,
+	deletionHook DeletionHook,
+	managedResourceDeletionTimeout time.Duration,
+	orphanPolicy controlplane.OrphanPolicy,
+	shootWebhookConfig *extensionswebhook.ShootWebhookConfig,
 	logger logr.Logger,
 ) controlplane.Actuator {
 	return &actuator{
-		secrets:                secrets,
-		configChart:            configChart,
-		controlPlaneChart:      controlPlaneChart,
-		controlPlaneShootChart: controlPlaneShootChart,
-		vp:                     vp,
-		chartRendererFactory:   chartRendererFactory,
-		imageVector:            imageVector,
-		configName:             configName,
-		logger:                 logger.WithName("controlplane-actuator"),
+		secrets:                        secrets,
+		configChart:                    configChart,
+		controlPlaneChart:              controlPlaneChart,
+		controlPlaneShootChart:         controlPlaneShootChart,
+		vp:                             vp,
+		chartRendererFactory:           chartRendererFactory,
+		imageVector:                    imageVector,
+		configName:                     configName,
+		resyncInterval:                 resyncInterval,
+		deletionHook:                   deletionHook,
+		managedResourceDeletionTimeout: managedResourceDeletionTimeout,
+		orphanPolicy:                   orphanPolicy,
+		shootWebhookConfig:             shootWebhookConfig,
+		logger:                         logger.WithName("controlplane-actuator"),
 	}
 }
 
@@ -108,6 +163,13 @@ type actuator struct {
 	chartRendererFactory   ChartRendererFactory
 	imageVector            imagevector.ImageVector
 	configName             string
+	resyncInterval         time.Duration
+
+	deletionHook                   DeletionHook
+	managedResourceDeletionTimeout time.Duration
+	orphanPolicy                   controlplane.OrphanPolicy
+
+	shootWebhookConfig *extensionswebhook.ShootWebhookConfig
 
 	clientset         kubernetes.Interface
 	gardenerClientset gardenerkubernetes.Interface
@@ -118,6 +180,11 @@ type actuator struct {
 
 // InjectFunc enables injecting Kubernetes dependencies into actuator's dependencies.
 func (a *actuator) InjectFunc(f inject.Func) error {
+	if a.deletionHook != nil {
+		if err := f(a.deletionHook); err != nil {
+			return err
+		}
+	}
 	return f(a.vp)
 }
 
@@ -162,11 +229,21 @@ func (a *actuator) Reconcile(
 ) (bool, error) {
 	// Deploy secrets
 	a.logger.Info("Deploying secrets", "controlplane", util.ObjectName(cp))
-	deployedSecrets, err := a.secrets.Deploy(a.clientset, a.gardenerClientset, cp.Namespace)
+	deployedSecrets, caRollInProgress, err := a.deploySecrets(ctx, cp)
 	if err != nil {
 		return false, errors.Wrapf(err, "could not deploy secrets for controlplane '%s'", util.ObjectName(cp))
 	}
 
+	// Reconcile KMS encryption config secret
+	kmsRolloutPending, err := a.reconcileKMSEncryptionConfig(ctx, cp, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	// Determine whether all charts must be applied regardless of their hashes
+	resync := a.resyncDue(cp)
+	hashes := map[string]string{}
+
 	// Get config chart values
 	if a.configChart != nil {
 		values, err := a.vp.GetConfigChartValues(ctx, cp, cluster)
@@ -175,9 +252,18 @@ func (a *actuator) Reconcile(
 		}
 
 		// Apply config chart
-		a.logger.Info("Applying configuration chart", "controlplane", util.ObjectName(cp))
-		if err := a.configChart.Apply(ctx, a.chartApplier, cp.Namespace, nil, "", "", values); err != nil {
-			return false, errors.Wrapf(err, "could not apply configuration chart for controlplane '%s'", util.ObjectName(cp))
+		hash, changed, err := a.chartChanged(cp, AnnotationConfigChartHash, resync, a.configChart, a.chartApplier, cp.Namespace, nil, "", "", values)
+		if err != nil {
+			return false, errors.Wrapf(err, "could not compute hash of configuration chart for controlplane '%s'", util.ObjectName(cp))
+		}
+		if changed {
+			a.logger.Info("Applying configuration chart", "controlplane", util.ObjectName(cp))
+			if err := a.configChart.Apply(ctx, a.chartApplier, cp.Namespace, nil, "", "", values); err != nil {
+				return false, errors.Wrapf(err, "could not apply configuration chart for controlplane '%s'", util.ObjectName(cp))
+			}
+			hashes[AnnotationConfigChartHash] = hash
+		} else {
+			a.logger.Info("Skipping unchanged configuration chart", "controlplane", util.ObjectName(cp))
 		}
 	}
 
@@ -187,8 +273,11 @@ func (a *actuator) Reconcile(
 		return false, err
 	}
 
+	// If a CA roll is in progress, requeue so that it is completed once the CA bundle duration has elapsed. If the KMS
+	// keys are waiting for the roll-out of kube-apiserver, requeue so that they are changed once it has finished.
+	requeue := caRollInProgress || kmsRolloutPending
+
 	// If the cluster is hibernated, check if kube-apiserver has been already scaled down
-	requeue := false
 	scaledDown := false
 	if extensionscontroller.IsHibernated(cluster.Shoot) {
 		dep := &appsv1.Deployment{}
@@ -211,9 +300,19 @@ func (a *actuator) Reconcile(
 	}
 
 	// Apply control plane chart
-	a.logger.Info("Applying control plane chart", "controlplane", util.ObjectName(cp))
-	if err := a.controlPlaneChart.Apply(ctx, a.chartApplier, cp.Namespace, a.imageVector, a.gardenerClientset.Version(), cluster.Shoot.Spec.Kubernetes.Version, values); err != nil {
-		return false, errors.Wrapf(err, "could not apply control plane chart for controlplane '%s'", util.ObjectName(cp))
+	seedVersion, shootVersion := a.gardenerClientset.Version(), cluster.Shoot.Spec.Kubernetes.Version
+	hash, changed, err := a.chartChanged(cp, AnnotationControlPlaneChartHash, resync, a.controlPlaneChart, a.chartApplier, cp.Namespace, a.imageVector, seedVersion, shootVersion, values)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not compute hash of control plane chart for controlplane '%s'", util.ObjectName(cp))
+	}
+	if changed {
+		a.logger.Info("Applying control plane chart", "controlplane", util.ObjectName(cp))
+		if err := a.controlPlaneChart.Apply(ctx, a.chartApplier, cp.Namespace, a.imageVector, seedVersion, shootVersion, values); err != nil {
+			return false, errors.Wrapf(err, "could not apply control plane chart for controlplane '%s'", util.ObjectName(cp))
+		}
+		hashes[AnnotationControlPlaneChartHash] = hash
+	} else {
+		a.logger.Info("Skipping unchanged control plane chart", "controlplane", util.ObjectName(cp))
 	}
 
 	// Create shoot chart renderer
@@ -230,42 +329,65 @@ func (a *actuator) Reconcile(
 
 	// Render control plane shoot chart
 	a.logger.Info("Rendering control plane shoot chart", "controlplane", util.ObjectName(cp), "values", values)
-	version := cluster.Shoot.Spec.Kubernetes.Version
-	name, data, err := a.controlPlaneShootChart.Render(chartRenderer, metav1.NamespaceSystem, a.imageVector, version, version, values)
+	name, data, err := a.controlPlaneShootChart.Render(chartRenderer, metav1.NamespaceSystem, a.imageVector, shootVersion, shootVersion, values)
 	if err != nil {
 		return false, errors.Wrapf(err, "could not render control plane shoot chart for controlplane '%s'", util.ObjectName(cp))
 	}
 
-	// Create or update secret containing the rendered control plane shoot chart
-	a.logger.Info("Creating secret of managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
-	if err := manager.NewSecret(a.client).
-		WithNamespacedName(cp.Namespace, resourceName).
-		WithKeyValues(map[string][]byte{name: data}).
-		Reconcile(ctx); err != nil {
-		return false, errors.Wrapf(err, "could not create or update secret '%s/%s' of managed resource containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	// Create or update managed resource containing the rendered control plane shoot chart
+	hash, changed = a.manifestChanged(cp, AnnotationControlPlaneShootChartHash, resync, name, data)
+	if changed {
+		if err := a.reconcileManagedResource(ctx, cp, name, data); err != nil {
+			return false, err
+		}
+		hashes[AnnotationControlPlaneShootChartHash] = hash
+	} else {
+		a.logger.Info("Skipping unchanged managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
 	}
 
-	// Create or update managed resource referencing the previously created secret
-	a.logger.Info("Creating managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
-	if err := manager.NewManagedResource(a.client).
-		WithNamespacedName(cp.Namespace, resourceName).
-		WithInjectedLabels(map[string]string{ShootNoCleanupLabel: "true"}).
-		WithSecretRef(resourceName).
-		Reconcile(ctx); err != nil {
-		return false, errors.Wrapf(err, "could not create or update managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	// Create or update the shoot webhook configuration, unless the shoot apiserver has been scaled down. It is always
+	// applied, so that changes made to it in the shoot cluster are reverted. While the shoot apiserver is not available
+	// yet, e.g. during the creation of the shoot, requeue until the configuration can be applied.
+	if a.shootWebhookConfig != nil && !scaledDown {
+		applied, err := a.reconcileShootWebhookConfig(ctx, cp)
+		if err != nil {
+			return false, err
+		}
+		if !applied {
+			requeue = true
+		}
 	}
 
+	// Record the hashes of the applied charts
+	if err := a.recordHashes(ctx, cp, hashes, resync); err != nil {
+		return false, err
+	}
+
+	// The CCM and CSI deployments carry the checksum of the cloud provider secret, hence they have been rolled with
+	// the current credentials. The condition is persisted together with the last operation by the reconciler.
+	cp.Status.Conditions = extensionscontroller.CredentialsUpToDateConditions(cp.Status.Conditions, common.CloudProviderSecretName, checksums[common.CloudProviderSecretName])
+
 	return requeue, nil
 }
 
 // Delete reconciles the given controlplane and cluster, deleting the additional Shoot
-// control plane components as needed.
+// control plane components as needed. It reports each phase of the deletion in the last operation of the controlplane.
 func (a *actuator) Delete(
 	ctx context.Context,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) error {
+	// Delete the shoot webhook configuration, unless the shoot is hibernated
+	if a.shootWebhookConfig != nil && !extensionscontroller.IsHibernated(cluster.Shoot) {
+		if err := a.deleteShootWebhookConfig(ctx, cp); err != nil {
+			return err
+		}
+	}
+
 	// Delete the managed resource
+	if err := a.updateDeletionProgress(ctx, cp, 10, "Deleting the managed resource containing the shoot chart"); err != nil {
+		return err
+	}
 	a.logger.Info("Deleting managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
 	if err := manager.NewManagedResource(a.client).
 		WithNamespacedName(cp.Namespace, resourceName).
@@ -273,6 +395,15 @@ func (a *actuator) Delete(
 		return errors.Wrapf(err, "could not delete managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
 	}
 
+	// Wait until the managed resource has been deleted, so that the objects in the shoot are gone before the
+	// cloud-controller-manager is deleted
+	if err := a.updateDeletionProgress(ctx, cp, 30, "Waiting until the managed resource containing the shoot chart has been deleted"); err != nil {
+		return err
+	}
+	if err := a.checkManagedResourceDeleted(ctx, cp); err != nil {
+		return err
+	}
+
 	// Delete the secret referenced by the managed resource
 	a.logger.Info("Deleting secret of managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
 	if err := manager.NewSecret(a.client).
@@ -281,7 +412,21 @@ func (a *actuator) Delete(
 		return errors.Wrapf(err, "could not delete secret '%s/%s' of managed resource containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
 	}
 
+	// Run the deletion hook before the cloud-controller-manager is deleted
+	if a.deletionHook != nil {
+		if err := a.updateDeletionProgress(ctx, cp, 50, "Running the deletion hook"); err != nil {
+			return err
+		}
+		a.logger.Info("Running deletion hook", "controlplane", util.ObjectName(cp))
+		if err := a.deletionHook.BeforeControlPlaneChartDeletion(ctx, cp, cluster); err != nil {
+			return errors.Wrapf(err, "deletion hook failed for controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
 	// Delete control plane objects
+	if err := a.updateDeletionProgress(ctx, cp, 70, "Deleting the control plane objects"); err != nil {
+		return err
+	}
 	a.logger.Info("Deleting control plane objects", "controlplane", util.ObjectName(cp))
 	if err := a.controlPlaneChart.Delete(ctx, a.client, cp.Namespace); err != nil {
 		return errors.Wrapf(err, "could not delete control plane objects for controlplane '%s'", util.ObjectName(cp))
@@ -296,6 +441,9 @@ func (a *actuator) Delete(
 	}
 
 	// Delete secrets
+	if err := a.updateDeletionProgress(ctx, cp, 90, "Deleting the secrets"); err != nil {
+		return err
+	}
 	a.logger.Info("Deleting secrets", "controlplane", util.ObjectName(cp))
 	if err := a.secrets.Delete(a.clientset, cp.Namespace); err != nil {
 		return errors.Wrapf(err, "could not delete secrets for controlplane '%s'", util.ObjectName(cp))
@@ -304,6 +452,180 @@ func (a *actuator) Delete(
 	return nil
 }
 
+// checkManagedResourceDeleted checks whether the managed resource of the given controlplane has been deleted. While it
+// still exists, the deletion is requeued until the managed resource deletion timeout has elapsed since the managed
+// resource has been marked for deletion. Afterwards, it either fails or orphans the objects of the managed resource by
+// removing its finalizers, depending on the orphan policy.
+func (a *actuator) checkManagedResourceDeleted(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) error {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := a.client.Get(ctx, kutil.Key(cp.Namespace, resourceName), mr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "could not get managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	}
+
+	if mr.DeletionTimestamp == nil || time.Since(mr.DeletionTimestamp.Time) < a.managedResourceDeletionTimeout {
+		a.logger.Info("Waiting until managed resource containing shoot chart has been deleted", "controlplane", util.ObjectName(cp), "name", resourceName)
+		return &controllererror.RequeueAfterError{
+			Cause:        fmt.Errorf("managed resource '%s/%s' containing shoot chart for controlplane '%s' has not been deleted yet", cp.Namespace, resourceName, util.ObjectName(cp)),
+			RequeueAfter: managedResourceDeletionRequeueInterval,
+		}
+	}
+	if a.orphanPolicy != controlplane.OrphanPolicyAfterTimeout {
+		return fmt.Errorf("managed resource '%s/%s' containing shoot chart for controlplane '%s' has not been deleted within %s", cp.Namespace, resourceName, util.ObjectName(cp), a.managedResourceDeletionTimeout)
+	}
+
+	a.logger.Info("Orphaning objects of managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
+	mr.Finalizers = nil
+	if err := a.client.Update(ctx, mr); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "could not remove finalizers of managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	}
+	return nil
+}
+
+// updateDeletionProgress reports the given progress and description of the deletion in the last operation of the given
+// controlplane. It does not report a phase that has already been reached, so that a requeued deletion, e.g. while waiting
+// for the managed resource to be deleted, keeps the last operation at its current phase.
+func (a *actuator) updateDeletionProgress(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, progress int, description string) error {
+	if lastOperation := cp.Status.LastOperation; lastOperation != nil &&
+		lastOperation.Type == gardencorev1alpha1.LastOperationTypeDelete &&
+		lastOperation.State == gardencorev1alpha1.LastOperationStateProcessing &&
+		lastOperation.Progress >= progress {
+		return nil
+	}
+
+	cp.Status.LastOperation = extensionscontroller.LastOperation(gardencorev1alpha1.LastOperationTypeDelete, gardencorev1alpha1.LastOperationStateProcessing, progress, description)
+	if err := a.client.Status().Update(ctx, cp); err != nil {
+		return errors.Wrapf(err, "could not update last operation of controlplane '%s'", util.ObjectName(cp))
+	}
+	return nil
+}
+
+// reconcileManagedResource creates or updates the managed resource of the given controlplane and its secret containing
+// the given rendered control plane shoot chart.
+func (a *actuator) reconcileManagedResource(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, name string, data []byte) error {
+	// Create or update secret containing the rendered control plane shoot chart
+	a.logger.Info("Creating secret of managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
+	if err := manager.NewSecret(a.client).
+		WithNamespacedName(cp.Namespace, resourceName).
+		WithKeyValues(map[string][]byte{name: data}).
+		Reconcile(ctx); err != nil {
+		return errors.Wrapf(err, "could not create or update secret '%s/%s' of managed resource containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	}
+
+	// Create or update managed resource referencing the previously created secret
+	a.logger.Info("Creating managed resource containing shoot chart", "controlplane", util.ObjectName(cp), "name", resourceName)
+	if err := manager.NewManagedResource(a.client).
+		WithNamespacedName(cp.Namespace, resourceName).
+		WithInjectedLabels(map[string]string{ShootNoCleanupLabel: "true"}).
+		WithSecretRef(resourceName).
+		Reconcile(ctx); err != nil {
+		return errors.Wrapf(err, "could not create or update managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+	}
+
+	return nil
+}
+
+// resyncDue checks whether all charts of the given controlplane must be applied regardless of their hashes. This is
+// the case if no resync interval is set, or if it has elapsed since the last resync.
+func (a *actuator) resyncDue(cp *extensionsv1alpha1.ControlPlane) bool {
+	if a.resyncInterval <= 0 {
+		return true
+	}
+
+	lastResyncTime, err := time.Parse(time.RFC3339, cp.Annotations[AnnotationLastResyncTime])
+	return err != nil || time.Since(lastResyncTime) >= a.resyncInterval
+}
+
+// chartChanged computes the hash of the given chart and checks whether it differs from the hash in the given annotation
+// of the given controlplane. During a resync, the chart is considered changed regardless of its hash. If no resync
+// interval is set, no hash is computed and the chart is always considered changed.
+func (a *actuator) chartChanged(
+	cp *extensionsv1alpha1.ControlPlane,
+	annotation string,
+	resync bool,
+	chart util.Chart,
+	chartRenderer chartrenderer.Interface,
+	namespace string,
+	imageVector imagevector.ImageVector,
+	runtimeVersion, targetVersion string,
+	values map[string]interface{},
+) (string, bool, error) {
+	if a.resyncInterval <= 0 {
+		return "", true, nil
+	}
+
+	hash, err := chart.ComputeHash(chartRenderer, namespace, imageVector, runtimeVersion, targetVersion, values)
+	if err != nil {
+		return "", false, err
+	}
+	return hash, resync || cp.Annotations[annotation] != hash, nil
+}
+
+// manifestChanged is like chartChanged, but for the given already rendered manifests of the chart with the given name.
+func (a *actuator) manifestChanged(cp *extensionsv1alpha1.ControlPlane, annotation string, resync bool, name string, data []byte) (string, bool) {
+	if a.resyncInterval <= 0 {
+		return "", true
+	}
+
+	hash := util.ComputeManifestHash(name, data, a.imageVector)
+	return hash, resync || cp.Annotations[annotation] != hash
+}
+
+// recordHashes records the given hashes of the applied charts, and the time of the resync if one has been done, in the
+// annotations of the given controlplane.
+func (a *actuator) recordHashes(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, hashes map[string]string, resync bool) error {
+	if a.resyncInterval <= 0 || len(hashes) == 0 {
+		return nil
+	}
+
+	for annotation, hash := range hashes {
+		metav1.SetMetaDataAnnotation(&cp.ObjectMeta, annotation, hash)
+	}
+	if resync {
+		metav1.SetMetaDataAnnotation(&cp.ObjectMeta, AnnotationLastResyncTime, time.Now().UTC().Format(time.RFC3339))
+	}
+
+	if err := a.client.Update(ctx, cp); err != nil {
+		return errors.Wrapf(err, "could not record hashes of applied charts for controlplane '%s'", util.ObjectName(cp))
+	}
+	return nil
+}
+
+// deploySecrets deploys the secrets of the given controlplane. If the secrets are rotatable, it rotates them as
+// requested by the AnnotationRotateSecrets annotation, and removes the annotation afterwards. It returns true if a CA
+// roll is in progress.
+func (a *actuator) deploySecrets(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) (map[string]*corev1.Secret, bool, error) {
+	rotation, requested := cp.Annotations[controlplane.AnnotationRotateSecrets]
+
+	rotatableSecrets, ok := a.secrets.(util.RotatableSecrets)
+	if !ok {
+		if requested {
+			return nil, false, fmt.Errorf("secrets of controlplane '%s' do not support rotation", util.ObjectName(cp))
+		}
+		deployedSecrets, err := a.secrets.Deploy(a.clientset, a.gardenerClientset, cp.Namespace)
+		return deployedSecrets, false, err
+	}
+
+	if requested {
+		a.logger.Info("Rotating secrets", "controlplane", util.ObjectName(cp), "rotation", rotation)
+	}
+	deployedSecrets, caRollInProgress, err := rotatableSecrets.DeployWithRotation(a.clientset, a.gardenerClientset, cp.Namespace, util.SecretsRotation(rotation))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if requested {
+		delete(cp.Annotations, controlplane.AnnotationRotateSecrets)
+		if err := a.client.Update(ctx, cp); err != nil {
+			return nil, false, errors.Wrapf(err, "could not remove annotation '%s'", controlplane.AnnotationRotateSecrets)
+		}
+	}
+
+	return deployedSecrets, caRollInProgress, nil
+}
+
 // computeChecksums computes and returns all needed checksums. This includes the checksums for the given deployed secrets,
 // as well as the cloud provider secret and configmap that are fetched from the cluster.
 func (a *actuator) computeChecksums(
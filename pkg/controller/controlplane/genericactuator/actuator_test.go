@@ -17,19 +17,26 @@ package genericactuator
 import (
 	"context"
 	"testing"
+	"time"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	mockgenericactuator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/controller/controlplane/genericactuator"
 	mockutil "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/util"
 	mockchartrenderer "github.com/gardener/gardener-extensions/pkg/mock/gardener/chartrenderer"
 	mockkubernetes "github.com/gardener/gardener-extensions/pkg/mock/gardener/client/kubernetes"
 	"github.com/gardener/gardener-extensions/pkg/util"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1alpha1helper "github.com/gardener/gardener/pkg/apis/core/v1alpha1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/gardener/gardener/pkg/utils/imagevector"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 
 	resourcemanagerv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
 
@@ -39,6 +46,8 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -209,7 +218,7 @@ var _ = Describe("Actuator", func() {
 			vp.EXPECT().GetControlPlaneShootChartValues(context.TODO(), cp, cluster).Return(controlPlaneShootChartValues, nil)
 
 			// Create actuator
-			a := NewActuator(secrets, configChart, ccmChart, ccmShootChart, vp, crf, imageVector, configName, logger)
+			a := NewActuator(secrets, configChart, ccmChart, ccmShootChart, vp, crf, imageVector, configName, 0, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
 			err := a.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 			a.(*actuator).gardenerClientset = gardenerClientset
@@ -219,17 +228,288 @@ var _ = Describe("Actuator", func() {
 			requeue, err := a.Reconcile(context.TODO(), cp, cluster)
 			Expect(requeue).To(Equal(false))
 			Expect(err).NotTo(HaveOccurred())
+
+			condition := gardencorev1alpha1helper.GetCondition(cp.Status.Conditions, extensionscontroller.ConditionTypeCredentialsUpToDate)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(gardencorev1alpha1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring(checksums[common.CloudProviderSecretName]))
 		},
 		Entry("should deploy secrets and apply charts with correct parameters", cloudProviderConfigName, checksums),
 		Entry("should deploy secrets and apply charts with correct parameters (no config)", "", checksumsNoConfig),
 	)
 
+	Describe("#Reconcile with secrets rotation", func() {
+		var rotatingCP *extensionsv1alpha1.ControlPlane
+
+		BeforeEach(func() {
+			rotatingCP = cp.DeepCopy()
+			rotatingCP.Annotations = map[string]string{controlplane.AnnotationRotateSecrets: string(util.SecretsRotationCA)}
+		})
+
+		It("should rotate the secrets, remove the annotation and requeue while the CA roll is in progress", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Update(context.TODO(), rotatingCP).DoAndReturn(func(_ context.Context, obj runtime.Object) error {
+				Expect(obj.(*extensionsv1alpha1.ControlPlane).Annotations).NotTo(HaveKey(controlplane.AnnotationRotateSecrets))
+				return nil
+			})
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Get(context.TODO(), resourceKey, createdSecret).Return(errNotFound)
+			client.EXPECT().Create(context.TODO(), createdSecret).Return(nil)
+			client.EXPECT().Get(context.TODO(), resourceKey, createdManagedResource).Return(errNotFound)
+			client.EXPECT().Create(context.TODO(), createdManagedResource).Return(nil)
+
+			// Create mock Gardener clientset and chart applier
+			gardenerClientset := mockkubernetes.NewMockInterface(ctrl)
+			gardenerClientset.EXPECT().Version().Return(seedVersion)
+			chartApplier := mockkubernetes.NewMockChartApplier(ctrl)
+
+			// Create mock chart renderer and factory
+			chartRenderer := mockchartrenderer.NewMockInterface(ctrl)
+			crf := mockgenericactuator.NewMockChartRendererFactory(ctrl)
+			crf.EXPECT().NewChartRendererForShoot(shootVersion).Return(chartRenderer, nil)
+
+			// Create mock secrets and charts
+			secrets := mockutil.NewMockRotatableSecrets(ctrl)
+			secrets.EXPECT().DeployWithRotation(gomock.Any(), gardenerClientset, namespace, util.SecretsRotationCA).Return(deployedSecrets, true, nil)
+			ccmChart := mockutil.NewMockChart(ctrl)
+			ccmChart.EXPECT().Apply(context.TODO(), chartApplier, namespace, imageVector, seedVersion, shootVersion, controlPlaneChartValues).Return(nil)
+			ccmShootChart := mockutil.NewMockChart(ctrl)
+			ccmShootChart.EXPECT().Render(chartRenderer, metav1.NamespaceSystem, imageVector, shootVersion, shootVersion, controlPlaneShootChartValues).Return(chartName, []byte(renderedContent), nil)
+
+			// Create mock values provider
+			vp := mockgenericactuator.NewMockValuesProvider(ctrl)
+			vp.EXPECT().GetControlPlaneChartValues(context.TODO(), rotatingCP, cluster, checksumsNoConfig, false).Return(controlPlaneChartValues, nil)
+			vp.EXPECT().GetControlPlaneShootChartValues(context.TODO(), rotatingCP, cluster).Return(controlPlaneShootChartValues, nil)
+
+			// Create actuator
+			a := NewActuator(secrets, nil, ccmChart, ccmShootChart, vp, crf, imageVector, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
+			err := a.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			a.(*actuator).gardenerClientset = gardenerClientset
+			a.(*actuator).chartApplier = chartApplier
+
+			// Call Reconcile method and check the result
+			requeue, err := a.Reconcile(context.TODO(), rotatingCP, cluster)
+			Expect(requeue).To(Equal(true))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail if the secrets do not support rotation", func() {
+			// Create actuator
+			a := NewActuator(mockutil.NewMockSecrets(ctrl), nil, nil, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
+
+			// Call Reconcile method and check the result
+			_, err := a.Reconcile(context.TODO(), rotatingCP, cluster)
+			Expect(err).To(MatchError(ContainSubstring("do not support rotation")))
+		})
+	})
+
+	Describe("#Reconcile with shoot webhook config", func() {
+		var (
+			shootWebhookConfig = &extensionswebhook.ShootWebhookConfig{
+				Name:                  "gardener-extension-provider-test-shoot",
+				SeedWebhookConfigName: "gardener-extension-provider-test",
+				Webhooks:              []admissionregistrationv1beta1.Webhook{{Name: "shoot.test.extensions.gardener.cloud"}},
+			}
+			kubeAPIServerKey = kutil.Key(namespace, common.KubeAPIServerDeploymentName)
+
+			c *mockclient.MockClient
+			a controlplane.Actuator
+		)
+
+		BeforeEach(func() {
+			// Create mock client
+			c = mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			c.EXPECT().Get(context.TODO(), resourceKey, createdSecret).Return(errNotFound)
+			c.EXPECT().Create(context.TODO(), createdSecret).Return(nil)
+			c.EXPECT().Get(context.TODO(), resourceKey, createdManagedResource).Return(errNotFound)
+			c.EXPECT().Create(context.TODO(), createdManagedResource).Return(nil)
+
+			// Create mock Gardener clientset and chart applier
+			gardenerClientset := mockkubernetes.NewMockInterface(ctrl)
+			gardenerClientset.EXPECT().Version().Return(seedVersion)
+			chartApplier := mockkubernetes.NewMockChartApplier(ctrl)
+
+			// Create mock chart renderer and factory
+			chartRenderer := mockchartrenderer.NewMockInterface(ctrl)
+			crf := mockgenericactuator.NewMockChartRendererFactory(ctrl)
+			crf.EXPECT().NewChartRendererForShoot(shootVersion).Return(chartRenderer, nil)
+
+			// Create mock secrets and charts
+			secrets := mockutil.NewMockSecrets(ctrl)
+			secrets.EXPECT().Deploy(gomock.Any(), gardenerClientset, namespace).Return(deployedSecrets, nil)
+			ccmChart := mockutil.NewMockChart(ctrl)
+			ccmChart.EXPECT().Apply(context.TODO(), chartApplier, namespace, imageVector, seedVersion, shootVersion, controlPlaneChartValues).Return(nil)
+			ccmShootChart := mockutil.NewMockChart(ctrl)
+			ccmShootChart.EXPECT().Render(chartRenderer, metav1.NamespaceSystem, imageVector, shootVersion, shootVersion, controlPlaneShootChartValues).Return(chartName, []byte(renderedContent), nil)
+
+			// Create mock values provider
+			vp := mockgenericactuator.NewMockValuesProvider(ctrl)
+			vp.EXPECT().GetControlPlaneChartValues(context.TODO(), cp, cluster, checksumsNoConfig, false).Return(controlPlaneChartValues, nil)
+			vp.EXPECT().GetControlPlaneShootChartValues(context.TODO(), cp, cluster).Return(controlPlaneShootChartValues, nil)
+
+			// Create actuator
+			a = NewActuator(secrets, nil, ccmChart, ccmShootChart, vp, crf, imageVector, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, shootWebhookConfig, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+			a.(*actuator).gardenerClientset = gardenerClientset
+			a.(*actuator).chartApplier = chartApplier
+		})
+
+		It("should requeue without applying the shoot webhook configuration if kube-apiserver has not been deployed yet", func() {
+			c.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).Return(errNotFound)
+
+			requeue, err := a.Reconcile(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+		})
+
+		It("should requeue without applying the shoot webhook configuration if kube-apiserver is not available", func() {
+			c.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).Return(nil)
+
+			requeue, err := a.Reconcile(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+		})
+
+		It("should requeue without applying the shoot webhook configuration if the shoot apiserver can't be reached", func() {
+			c.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, dep *appsv1.Deployment) error {
+				dep.Status.AvailableReplicas = 1
+				return nil
+			})
+			c.EXPECT().Get(context.TODO(), kutil.Key(namespace, gardencorev1alpha1.SecretNameGardener), &corev1.Secret{}).Return(errNotFound)
+
+			requeue, err := a.Reconcile(context.TODO(), cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+		})
+	})
+
+	Describe("#Reconcile with resync interval", func() {
+		const controlPlaneChartContent = "controlPlaneChartContent"
+
+		var (
+			controlPlaneChartHash      = util.ComputeManifestHash(chartName, []byte(controlPlaneChartContent), imageVector)
+			controlPlaneShootChartHash = util.ComputeManifestHash(chartName, []byte(renderedContent), imageVector)
+		)
+
+		It("should apply all charts and record their hashes if the resync is due", func() {
+			resyncCP := cp.DeepCopy()
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Get(context.TODO(), resourceKey, createdSecret).Return(errNotFound)
+			client.EXPECT().Create(context.TODO(), createdSecret).Return(nil)
+			client.EXPECT().Get(context.TODO(), resourceKey, createdManagedResource).Return(errNotFound)
+			client.EXPECT().Create(context.TODO(), createdManagedResource).Return(nil)
+			client.EXPECT().Update(context.TODO(), resyncCP).DoAndReturn(func(_ context.Context, obj runtime.Object) error {
+				annotations := obj.(*extensionsv1alpha1.ControlPlane).Annotations
+				Expect(annotations).To(HaveKeyWithValue(AnnotationControlPlaneChartHash, controlPlaneChartHash))
+				Expect(annotations).To(HaveKeyWithValue(AnnotationControlPlaneShootChartHash, controlPlaneShootChartHash))
+				Expect(annotations).To(HaveKey(AnnotationLastResyncTime))
+				return nil
+			})
+
+			// Create mock Gardener clientset and chart applier
+			gardenerClientset := mockkubernetes.NewMockInterface(ctrl)
+			gardenerClientset.EXPECT().Version().Return(seedVersion)
+			chartApplier := mockkubernetes.NewMockChartApplier(ctrl)
+
+			// Create mock chart renderer and factory
+			chartRenderer := mockchartrenderer.NewMockInterface(ctrl)
+			crf := mockgenericactuator.NewMockChartRendererFactory(ctrl)
+			crf.EXPECT().NewChartRendererForShoot(shootVersion).Return(chartRenderer, nil)
+
+			// Create mock secrets and charts
+			secrets := mockutil.NewMockSecrets(ctrl)
+			secrets.EXPECT().Deploy(gomock.Any(), gardenerClientset, namespace).Return(deployedSecrets, nil)
+			ccmChart := mockutil.NewMockChart(ctrl)
+			ccmChart.EXPECT().ComputeHash(chartApplier, namespace, imageVector, seedVersion, shootVersion, controlPlaneChartValues).Return(controlPlaneChartHash, nil)
+			ccmChart.EXPECT().Apply(context.TODO(), chartApplier, namespace, imageVector, seedVersion, shootVersion, controlPlaneChartValues).Return(nil)
+			ccmShootChart := mockutil.NewMockChart(ctrl)
+			ccmShootChart.EXPECT().Render(chartRenderer, metav1.NamespaceSystem, imageVector, shootVersion, shootVersion, controlPlaneShootChartValues).Return(chartName, []byte(renderedContent), nil)
+
+			// Create mock values provider
+			vp := mockgenericactuator.NewMockValuesProvider(ctrl)
+			vp.EXPECT().GetControlPlaneChartValues(context.TODO(), resyncCP, cluster, checksumsNoConfig, false).Return(controlPlaneChartValues, nil)
+			vp.EXPECT().GetControlPlaneShootChartValues(context.TODO(), resyncCP, cluster).Return(controlPlaneShootChartValues, nil)
+
+			// Create actuator
+			a := NewActuator(secrets, nil, ccmChart, ccmShootChart, vp, crf, imageVector, "", time.Hour, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
+			err := a.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			a.(*actuator).gardenerClientset = gardenerClientset
+			a.(*actuator).chartApplier = chartApplier
+
+			// Call Reconcile method and check the result
+			requeue, err := a.Reconcile(context.TODO(), resyncCP, cluster)
+			Expect(requeue).To(Equal(false))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should skip all charts whose hashes have not changed if the resync is not due", func() {
+			resyncCP := cp.DeepCopy()
+			resyncCP.Annotations = map[string]string{
+				AnnotationControlPlaneChartHash:      controlPlaneChartHash,
+				AnnotationControlPlaneShootChartHash: controlPlaneShootChartHash,
+				AnnotationLastResyncTime:             time.Now().UTC().Format(time.RFC3339),
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+
+			// Create mock Gardener clientset and chart applier
+			gardenerClientset := mockkubernetes.NewMockInterface(ctrl)
+			gardenerClientset.EXPECT().Version().Return(seedVersion)
+			chartApplier := mockkubernetes.NewMockChartApplier(ctrl)
+
+			// Create mock chart renderer and factory
+			chartRenderer := mockchartrenderer.NewMockInterface(ctrl)
+			crf := mockgenericactuator.NewMockChartRendererFactory(ctrl)
+			crf.EXPECT().NewChartRendererForShoot(shootVersion).Return(chartRenderer, nil)
+
+			// Create mock secrets and charts
+			secrets := mockutil.NewMockSecrets(ctrl)
+			secrets.EXPECT().Deploy(gomock.Any(), gardenerClientset, namespace).Return(deployedSecrets, nil)
+			ccmChart := mockutil.NewMockChart(ctrl)
+			ccmChart.EXPECT().ComputeHash(chartApplier, namespace, imageVector, seedVersion, shootVersion, controlPlaneChartValues).Return(controlPlaneChartHash, nil)
+			ccmShootChart := mockutil.NewMockChart(ctrl)
+			ccmShootChart.EXPECT().Render(chartRenderer, metav1.NamespaceSystem, imageVector, shootVersion, shootVersion, controlPlaneShootChartValues).Return(chartName, []byte(renderedContent), nil)
+
+			// Create mock values provider
+			vp := mockgenericactuator.NewMockValuesProvider(ctrl)
+			vp.EXPECT().GetControlPlaneChartValues(context.TODO(), resyncCP, cluster, checksumsNoConfig, false).Return(controlPlaneChartValues, nil)
+			vp.EXPECT().GetControlPlaneShootChartValues(context.TODO(), resyncCP, cluster).Return(controlPlaneShootChartValues, nil)
+
+			// Create actuator
+			a := NewActuator(secrets, nil, ccmChart, ccmShootChart, vp, crf, imageVector, "", time.Hour, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
+			err := a.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+			a.(*actuator).gardenerClientset = gardenerClientset
+			a.(*actuator).chartApplier = chartApplier
+
+			// Call Reconcile method and check the result
+			requeue, err := a.Reconcile(context.TODO(), resyncCP, cluster)
+			Expect(requeue).To(Equal(false))
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	DescribeTable("#Delete",
-		func(configName string) {
+		func(configName string, withDeletionHook bool) {
+			deletedCP := cp.DeepCopy()
+
 			// Create mock clients
+			statusWriter := mockclient.NewMockStatusWriter(ctrl)
+			statusWriter.EXPECT().Update(context.TODO(), deletedCP).Return(nil).AnyTimes()
 			client := mockclient.NewMockClient(ctrl)
-			client.EXPECT().Delete(context.TODO(), deletedSecret).Return(nil)
+			client.EXPECT().Status().Return(statusWriter).AnyTimes()
 			client.EXPECT().Delete(context.TODO(), deletedManagedResource).Return(nil)
+			client.EXPECT().Get(gomock.Any(), resourceKey, gomock.AssignableToTypeOf(&resourcemanagerv1alpha1.ManagedResource{})).Return(errNotFound)
+			client.EXPECT().Delete(context.TODO(), deletedSecret).Return(nil)
 
 			// Create mock secrets and charts
 			secrets := mockutil.NewMockSecrets(ctrl)
@@ -243,18 +523,187 @@ var _ = Describe("Actuator", func() {
 			ccmChart := mockutil.NewMockChart(ctrl)
 			ccmChart.EXPECT().Delete(context.TODO(), client, namespace).Return(nil)
 
+			// Create mock deletion hook
+			var deletionHook DeletionHook
+			if withDeletionHook {
+				dh := mockgenericactuator.NewMockDeletionHook(ctrl)
+				dh.EXPECT().BeforeControlPlaneChartDeletion(context.TODO(), deletedCP, cluster).Return(nil)
+				deletionHook = dh
+			}
+
 			// Create actuator
-			a := NewActuator(secrets, configChart, ccmChart, nil, nil, nil, nil, configName, logger)
+			a := NewActuator(secrets, configChart, ccmChart, nil, nil, nil, nil, configName, 0, deletionHook, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
 			err := a.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
 			// Call Delete method and check the result
-			err = a.Delete(context.TODO(), cp, cluster)
+			err = a.Delete(context.TODO(), deletedCP, cluster)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedCP.Status.LastOperation).NotTo(BeNil())
+			Expect(deletedCP.Status.LastOperation.Type).To(Equal(gardencorev1alpha1.LastOperationTypeDelete))
+			Expect(deletedCP.Status.LastOperation.State).To(Equal(gardencorev1alpha1.LastOperationStateProcessing))
+			Expect(deletedCP.Status.LastOperation.Description).To(Equal("Deleting the secrets"))
 		},
-		Entry("should delete secrets and charts", cloudProviderConfigName),
-		Entry("should delete secrets and charts (no config)", ""),
+		Entry("should delete secrets and charts", cloudProviderConfigName, false),
+		Entry("should delete secrets and charts (no config)", "", false),
+		Entry("should delete secrets and charts and run the deletion hook", cloudProviderConfigName, true),
 	)
+
+	Describe("#Delete with shoot webhook config", func() {
+		var shootWebhookConfig = &extensionswebhook.ShootWebhookConfig{Name: "gardener-extension-provider-test-shoot"}
+
+		It("should skip the deletion of the shoot webhook configuration if the shoot apiserver can't be reached", func() {
+			deletedCP := cp.DeepCopy()
+
+			// Create mock clients
+			statusWriter := mockclient.NewMockStatusWriter(ctrl)
+			statusWriter.EXPECT().Update(context.TODO(), deletedCP).Return(nil).AnyTimes()
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Status().Return(statusWriter).AnyTimes()
+			c.EXPECT().Get(gomock.Any(), resourceKey, gomock.AssignableToTypeOf(&resourcemanagerv1alpha1.ManagedResource{})).Return(errNotFound).Times(2)
+			c.EXPECT().Get(context.TODO(), kutil.Key(namespace, common.KubeAPIServerDeploymentName), &appsv1.Deployment{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, dep *appsv1.Deployment) error {
+				dep.Status.AvailableReplicas = 1
+				return nil
+			})
+			c.EXPECT().Get(context.TODO(), kutil.Key(namespace, gardencorev1alpha1.SecretNameGardener), &corev1.Secret{}).Return(errNotFound)
+			c.EXPECT().Delete(context.TODO(), deletedManagedResource).Return(nil)
+			c.EXPECT().Delete(context.TODO(), deletedSecret).Return(nil)
+
+			// Create mock secrets and charts
+			secrets := mockutil.NewMockSecrets(ctrl)
+			secrets.EXPECT().Delete(gomock.Any(), namespace).Return(nil)
+			ccmChart := mockutil.NewMockChart(ctrl)
+			ccmChart.EXPECT().Delete(context.TODO(), c, namespace).Return(nil)
+
+			// Create actuator
+			a := NewActuator(secrets, nil, ccmChart, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, shootWebhookConfig, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			// Call Delete method and check the result
+			Expect(a.Delete(context.TODO(), deletedCP, cluster)).To(Succeed())
+		})
+
+		It("should not access the shoot again once the managed resource is being deleted", func() {
+			deletedCP := cp.DeepCopy()
+			existingMR := &resourcemanagerv1alpha1.ManagedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace, DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+			}
+
+			// Create mock clients
+			statusWriter := mockclient.NewMockStatusWriter(ctrl)
+			statusWriter.EXPECT().Update(context.TODO(), deletedCP).Return(nil).AnyTimes()
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Status().Return(statusWriter).AnyTimes()
+			c.EXPECT().Get(gomock.Any(), resourceKey, gomock.AssignableToTypeOf(&resourcemanagerv1alpha1.ManagedResource{})).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					*obj.(*resourcemanagerv1alpha1.ManagedResource) = *existingMR.DeepCopy()
+					return nil
+				}).Times(2)
+			c.EXPECT().Delete(context.TODO(), deletedManagedResource).Return(nil)
+
+			// Create actuator
+			a := NewActuator(nil, nil, nil, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, shootWebhookConfig, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			// Call Delete method and check that it waits for the managed resource
+			err := a.Delete(context.TODO(), deletedCP, cluster)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+		})
+	})
+
+	Describe("#Delete with managed resource deletion timeout", func() {
+		var (
+			deletedCP  *extensionsv1alpha1.ControlPlane
+			c          *mockclient.MockClient
+			ccmChart   *mockutil.MockChart
+			secrets    *mockutil.MockSecrets
+			existingMR *resourcemanagerv1alpha1.ManagedResource
+
+			statusUpdates int
+		)
+
+		BeforeEach(func() {
+			deletedCP = cp.DeepCopy()
+			statusUpdates = 0
+			existingMR = &resourcemanagerv1alpha1.ManagedResource{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: namespace, Finalizers: []string{"resources.gardener.cloud/gardener-resource-manager"}},
+			}
+
+			statusWriter := mockclient.NewMockStatusWriter(ctrl)
+			statusWriter.EXPECT().Update(context.TODO(), deletedCP).DoAndReturn(func(_ context.Context, _ runtime.Object) error {
+				statusUpdates++
+				return nil
+			}).AnyTimes()
+			c = mockclient.NewMockClient(ctrl)
+			c.EXPECT().Status().Return(statusWriter).AnyTimes()
+			c.EXPECT().Delete(context.TODO(), deletedManagedResource).Return(nil)
+			c.EXPECT().Get(gomock.Any(), resourceKey, gomock.AssignableToTypeOf(&resourcemanagerv1alpha1.ManagedResource{})).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					*obj.(*resourcemanagerv1alpha1.ManagedResource) = *existingMR.DeepCopy()
+					return nil
+				}).AnyTimes()
+
+			ccmChart = mockutil.NewMockChart(ctrl)
+			secrets = mockutil.NewMockSecrets(ctrl)
+		})
+
+		It("should requeue if the managed resource has not been deleted within the timeout yet", func() {
+			existingMR.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+			a := NewActuator(secrets, nil, ccmChart, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyAfterTimeout, nil, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			err := a.Delete(context.TODO(), deletedCP, cluster)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			Expect(err.(*controllererror.RequeueAfterError).Cause).To(MatchError(ContainSubstring("has not been deleted yet")))
+			Expect(deletedCP.Status.LastOperation.Description).To(Equal("Waiting until the managed resource containing the shoot chart has been deleted"))
+		})
+
+		It("should keep the last operation at the current phase if the deletion is requeued", func() {
+			existingMR.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			c.EXPECT().Delete(context.TODO(), deletedManagedResource).Return(nil)
+
+			a := NewActuator(secrets, nil, ccmChart, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyAfterTimeout, nil, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			Expect(a.Delete(context.TODO(), deletedCP, cluster)).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			Expect(statusUpdates).To(Equal(2))
+			Expect(deletedCP.Status.LastOperation.Progress).To(Equal(30))
+
+			Expect(a.Delete(context.TODO(), deletedCP, cluster)).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+			Expect(statusUpdates).To(Equal(2))
+			Expect(deletedCP.Status.LastOperation.Progress).To(Equal(30))
+			Expect(deletedCP.Status.LastOperation.Description).To(Equal("Waiting until the managed resource containing the shoot chart has been deleted"))
+		})
+
+		It("should fail if the managed resource has not been deleted within the timeout and the orphan policy is Never", func() {
+			existingMR.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+			a := NewActuator(secrets, nil, ccmChart, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyNever, nil, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			err := a.Delete(context.TODO(), deletedCP, cluster)
+			Expect(err).To(MatchError(ContainSubstring("has not been deleted within 1m0s")))
+			Expect(deletedCP.Status.LastOperation.Description).To(Equal("Waiting until the managed resource containing the shoot chart has been deleted"))
+		})
+
+		It("should orphan the managed resource and continue if the orphan policy is AfterTimeout", func() {
+			existingMR.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			c.EXPECT().Update(context.TODO(), gomock.AssignableToTypeOf(&resourcemanagerv1alpha1.ManagedResource{})).DoAndReturn(
+				func(_ context.Context, obj runtime.Object) error {
+					Expect(obj.(*resourcemanagerv1alpha1.ManagedResource).Finalizers).To(BeEmpty())
+					return nil
+				})
+			c.EXPECT().Delete(context.TODO(), deletedSecret).Return(nil)
+			ccmChart.EXPECT().Delete(context.TODO(), c, namespace).Return(nil)
+			secrets.EXPECT().Delete(gomock.Any(), namespace).Return(nil)
+
+			a := NewActuator(secrets, nil, ccmChart, nil, nil, nil, nil, "", 0, nil, time.Minute, controlplane.OrphanPolicyAfterTimeout, nil, logger)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			Expect(a.Delete(context.TODO(), deletedCP, cluster)).To(Succeed())
+		})
+	})
 })
 
 func clientGet(result runtime.Object) interface{} {
@@ -0,0 +1,168 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/gardener/gardener/pkg/utils"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// reconcileKMSEncryptionConfig creates or updates the KMS encryption config secret of the given controlplane if
+// the values provider supports KMS keys and a key is configured. It applies the phase of the KMS key rotation
+// requested by the AnnotationRotateKMSKey annotation, rolls out kube-apiserver with the new keys, and removes the
+// annotation afterwards. The keys are only changed once kube-apiserver has been rolled out with the current ones,
+// until then true is returned so that the controlplane is requeued.
+func (a *actuator) reconcileKMSEncryptionConfig(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (bool, error) {
+	kp, ok := a.vp.(KMSKeyProvider)
+	if !ok {
+		return false, nil
+	}
+	keyID, err := kp.GetKMSKeyID(ctx, cp, cluster)
+	if err != nil {
+		return false, err
+	}
+
+	// Get the existing KMS encryption config secret, if any
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: cp.Namespace, Name: controlplane.KMSEncryptionConfigSecretName}}
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: secret.Namespace, Name: secret.Name}, secret); err != nil && !apierrors.IsNotFound(err) {
+		return false, errors.Wrapf(err, "could not get secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+	keys := controlplane.KMSKeysFromSecret(secret)
+
+	// Secrets that have been encrypted with a KMS key can't be read without it, hence the keys are kept
+	if keyID == nil {
+		if len(keys) > 0 {
+			a.logger.Info("Keeping KMS keys that are no longer configured", "controlplane", util.ObjectName(cp))
+		}
+		return false, nil
+	}
+
+	ok, err = utils.CompareVersions(cluster.Shoot.Spec.Kubernetes.Version, ">=", controlplane.KMSMinimumKubernetesVersion)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.Errorf("KMS keys require Kubernetes version %s or later", controlplane.KMSMinimumKubernetesVersion)
+	}
+
+	rotation, requested := cp.Annotations[controlplane.AnnotationRotateKMSKey]
+	newKeys, err := controlplane.RotateKMSKeys(keys, *keyID, rotation)
+	if err != nil {
+		// Remove the annotation so that the invalid request is not retried
+		if err2 := a.removeKMSKeyRotationAnnotation(ctx, cp); err2 != nil {
+			return false, err2
+		}
+		return false, errors.Wrapf(err, "could not rotate KMS key of controlplane '%s'", util.ObjectName(cp))
+	}
+
+	// Every kube-apiserver instance must use the current keys before they are changed again
+	if len(keys) > 0 && !reflect.DeepEqual(newKeys, keys) {
+		rolledOut, err := a.rollOutKubeAPIServer(ctx, cp.Namespace, util.ComputeChecksum(secret.Data))
+		if err != nil {
+			return false, err
+		}
+		if !rolledOut {
+			a.logger.Info("Waiting until kube-apiserver has been rolled out with the current KMS keys", "controlplane", util.ObjectName(cp))
+			return true, nil
+		}
+	}
+	if requested {
+		a.logger.Info("Rotating KMS key", "controlplane", util.ObjectName(cp), "rotation", rotation)
+	}
+
+	encryptionConfig, err := controlplane.KMSEncryptionConfiguration(newKeys)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not create encryption configuration for controlplane '%s'", util.ObjectName(cp))
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, secret, func(existing runtime.Object) error {
+		existing.(*corev1.Secret).Data = map[string][]byte{
+			controlplane.KMSEncryptionConfigSecretKey: encryptionConfig,
+			controlplane.KMSKeysSecretKey:             []byte(strings.Join(newKeys, "\n")),
+		}
+		return nil
+	}); err != nil {
+		return false, errors.Wrapf(err, "could not create or update secret '%s/%s'", secret.Namespace, secret.Name)
+	}
+
+	// Roll out kube-apiserver with the new keys
+	if _, err := a.rollOutKubeAPIServer(ctx, cp.Namespace, util.ComputeChecksum(secret.Data)); err != nil {
+		return false, err
+	}
+
+	if requested {
+		return false, a.removeKMSKeyRotationAnnotation(ctx, cp)
+	}
+	return false, nil
+}
+
+// rollOutKubeAPIServer ensures that the kube-apiserver deployment in the given namespace is rolled out with the KMS
+// encryption config secret that has the given checksum, and returns whether the roll-out has finished. The roll-out
+// is triggered by updating the checksum annotation of the pod template, which is also maintained by the controlplane
+// webhook that adds the KMS plugins. If kube-apiserver has not been deployed yet, there is nothing to roll out.
+func (a *actuator) rollOutKubeAPIServer(ctx context.Context, namespace, checksum string) (bool, error) {
+	dep := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: common.KubeAPIServerDeploymentName}, dep); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "could not get deployment '%s/%s'", namespace, common.KubeAPIServerDeploymentName)
+	}
+
+	if dep.Spec.Template.Annotations[controlplane.KMSEncryptionConfigChecksumAnnotation] != checksum {
+		a.logger.Info("Rolling out kube-apiserver with changed KMS keys", "deployment", util.ObjectName(dep))
+		metav1.SetMetaDataAnnotation(&dep.Spec.Template.ObjectMeta, controlplane.KMSEncryptionConfigChecksumAnnotation, checksum)
+		if err := a.client.Update(ctx, dep); err != nil {
+			return false, errors.Wrapf(err, "could not update deployment '%s/%s'", namespace, common.KubeAPIServerDeploymentName)
+		}
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if dep.Spec.Replicas != nil {
+		replicas = *dep.Spec.Replicas
+	}
+	return dep.Status.ObservedGeneration >= dep.Generation &&
+		dep.Status.UpdatedReplicas == replicas &&
+		dep.Status.Replicas == replicas &&
+		dep.Status.AvailableReplicas == replicas, nil
+}
+
+func (a *actuator) removeKMSKeyRotationAnnotation(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) error {
+	if _, ok := cp.Annotations[controlplane.AnnotationRotateKMSKey]; !ok {
+		return nil
+	}
+	delete(cp.Annotations, controlplane.AnnotationRotateKMSKey)
+	if err := a.client.Update(ctx, cp); err != nil {
+		return errors.Wrapf(err, "could not remove annotation '%s'", controlplane.AnnotationRotateKMSKey)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("KMS", func() {
+	const (
+		oldKey = "old-key"
+		newKey = "new-key"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		c    *mockclient.MockClient
+		ctx  = context.TODO()
+
+		secretKey     = client.ObjectKey{Namespace: namespace, Name: controlplane.KMSEncryptionConfigSecretName}
+		deploymentKey = client.ObjectKey{Namespace: namespace, Name: common.KubeAPIServerDeploymentName}
+		cluster       = &extensionscontroller.Cluster{
+			Shoot: &gardenv1beta1.Shoot{
+				Spec: gardenv1beta1.ShootSpec{
+					Kubernetes: gardenv1beta1.Kubernetes{Version: shootVersion},
+				},
+			},
+		}
+
+		newActuatorWithKey = func(keyID *string) *actuator {
+			return &actuator{
+				vp:     &fakeKMSValuesProvider{keyID: keyID},
+				client: c,
+				logger: log.Log.WithName("test"),
+			}
+		}
+		newControlPlane = func(annotations map[string]string) *extensionsv1alpha1.ControlPlane {
+			return &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace, Annotations: annotations},
+			}
+		}
+		existingSecret = func(keys string) func(context.Context, client.ObjectKey, *corev1.Secret) error {
+			return func(_ context.Context, _ client.ObjectKey, secret *corev1.Secret) error {
+				secret.Data = map[string][]byte{controlplane.KMSKeysSecretKey: []byte(keys)}
+				return nil
+			}
+		}
+		newDeployment = func(checksum string, updatedReplicas int32) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: common.KubeAPIServerDeploymentName, Generation: 2},
+				Spec: appsv1.DeploymentSpec{
+					Replicas: util.Int32Ptr(2),
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{controlplane.KMSEncryptionConfigChecksumAnnotation: checksum},
+						},
+					},
+				},
+				Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: updatedReplicas, AvailableReplicas: 2},
+			}
+		}
+		existingDeployment = func(checksum string, updatedReplicas int32) func(context.Context, client.ObjectKey, *appsv1.Deployment) error {
+			return func(_ context.Context, _ client.ObjectKey, dep *appsv1.Deployment) error {
+				*dep = *newDeployment(checksum, updatedReplicas)
+				return nil
+			}
+		}
+		keysChecksum = func(keys string) string {
+			return util.ComputeChecksum(map[string][]byte{controlplane.KMSKeysSecretKey: []byte(keys)})
+		}
+		expectedSecret = func(keys ...string) *corev1.Secret {
+			encryptionConfig, err := controlplane.KMSEncryptionConfiguration(keys)
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: controlplane.KMSEncryptionConfigSecretName}}
+			secret.Data = map[string][]byte{
+				controlplane.KMSEncryptionConfigSecretKey: encryptionConfig,
+				controlplane.KMSKeysSecretKey:             []byte(keys[0]),
+			}
+			for _, key := range keys[1:] {
+				secret.Data[controlplane.KMSKeysSecretKey] = append(secret.Data[controlplane.KMSKeysSecretKey], []byte("\n"+key)...)
+			}
+			return secret
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#reconcileKMSEncryptionConfig", func() {
+		It("should do nothing if the values provider doesn't support KMS keys", func() {
+			a := &actuator{client: c}
+
+			requeue, err := a.reconcileKMSEncryptionConfig(ctx, newControlPlane(nil), cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+		})
+
+		It("should keep the existing secret if no key is configured", func() {
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(existingSecret(oldKey))
+
+			requeue, err := newActuatorWithKey(nil).reconcileKMSEncryptionConfig(ctx, newControlPlane(nil), cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+		})
+
+		It("should create the secret with the configured key for decryption only", func() {
+			notFound := errors.NewNotFound(schema.GroupResource{}, controlplane.KMSEncryptionConfigSecretName)
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).Return(notFound).Times(2)
+			c.EXPECT().Create(ctx, expectedSecret(controlplane.KMSIdentityKey, newKey)).Return(nil)
+			c.EXPECT().Get(ctx, deploymentKey, gomock.AssignableToTypeOf(&appsv1.Deployment{})).Return(errors.NewNotFound(schema.GroupResource{}, common.KubeAPIServerDeploymentName))
+
+			requeue, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, newControlPlane(nil), cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+		})
+
+		It("should switch to the configured key, roll out kube-apiserver and remove the annotation", func() {
+			cp := newControlPlane(map[string]string{controlplane.AnnotationRotateKMSKey: controlplane.KMSKeyRotationSwitch})
+			secret := expectedSecret(newKey, oldKey)
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(existingSecret(oldKey + "\n" + newKey)).Times(2)
+			c.EXPECT().Get(ctx, deploymentKey, gomock.AssignableToTypeOf(&appsv1.Deployment{})).DoAndReturn(existingDeployment(keysChecksum(oldKey+"\n"+newKey), 2)).Times(2)
+			c.EXPECT().Update(ctx, secret).Return(nil)
+			c.EXPECT().Update(ctx, newDeployment(util.ComputeChecksum(secret.Data), 2)).Return(nil)
+			c.EXPECT().Update(ctx, newControlPlane(map[string]string{})).Return(nil)
+
+			requeue, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+		})
+
+		It("should keep the keys and the annotation until kube-apiserver has been rolled out", func() {
+			cp := newControlPlane(map[string]string{controlplane.AnnotationRotateKMSKey: controlplane.KMSKeyRotationSwitch})
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(existingSecret(oldKey + "\n" + newKey))
+			c.EXPECT().Get(ctx, deploymentKey, gomock.AssignableToTypeOf(&appsv1.Deployment{})).DoAndReturn(existingDeployment(keysChecksum(oldKey+"\n"+newKey), 1))
+
+			requeue, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+			Expect(cp.Annotations).To(HaveKeyWithValue(controlplane.AnnotationRotateKMSKey, controlplane.KMSKeyRotationSwitch))
+		})
+
+		It("should roll out kube-apiserver with the current keys before changing them", func() {
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(existingSecret(oldKey))
+			c.EXPECT().Get(ctx, deploymentKey, gomock.AssignableToTypeOf(&appsv1.Deployment{})).DoAndReturn(existingDeployment("outdated", 2))
+			c.EXPECT().Update(ctx, newDeployment(keysChecksum(oldKey), 2)).Return(nil)
+
+			requeue, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, newControlPlane(nil), cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeTrue())
+		})
+
+		It("should fail and remove the annotation if the requested phase can't be applied", func() {
+			cp := newControlPlane(map[string]string{controlplane.AnnotationRotateKMSKey: controlplane.KMSKeyRotationSwitch})
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(existingSecret(oldKey))
+			c.EXPECT().Update(ctx, newControlPlane(map[string]string{})).Return(nil)
+
+			_, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, cp, cluster)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for shoots with a Kubernetes version that doesn't support KMS keys", func() {
+			oldCluster := &extensionscontroller.Cluster{
+				Shoot: &gardenv1beta1.Shoot{
+					Spec: gardenv1beta1.ShootSpec{
+						Kubernetes: gardenv1beta1.Kubernetes{Version: "1.12.7"},
+					},
+				},
+			}
+			notFound := errors.NewNotFound(schema.GroupResource{}, controlplane.KMSEncryptionConfigSecretName)
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).Return(notFound)
+
+			_, err := newActuatorWithKey(util.StringPtr(newKey)).reconcileKMSEncryptionConfig(ctx, newControlPlane(nil), oldCluster)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+type fakeKMSValuesProvider struct {
+	ValuesProvider
+	keyID *string
+}
+
+func (vp *fakeKMSValuesProvider) GetKMSKeyID(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) (*string, error) {
+	return vp.keyID, nil
+}
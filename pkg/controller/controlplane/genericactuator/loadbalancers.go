@@ -0,0 +1,121 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"github.com/go-logr/logr"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewLoadBalancerServicesDeletionHook creates a new DeletionHook that ensures that no Services of type LoadBalancer remain
+// in the shoot before the cloud-controller-manager is deleted. It deletes the remaining Services and fails until they are
+// gone, so that the cloud-controller-manager can still clean up the load balancers of the cloud provider.
+func NewLoadBalancerServicesDeletionHook(logger logr.Logger) DeletionHook {
+	return &loadBalancerServicesDeletionHook{
+		newShootClient: newShootClient,
+		logger:         logger.WithName("loadbalancer-services-deletion-hook"),
+	}
+}
+
+type loadBalancerServicesDeletionHook struct {
+	client         client.Client
+	newShootClient func(context.Context, client.Client, string) (client.Client, error)
+	logger         logr.Logger
+}
+
+// InjectClient injects the given client into the deletion hook.
+func (h *loadBalancerServicesDeletionHook) InjectClient(client client.Client) error {
+	h.client = client
+	return nil
+}
+
+// BeforeControlPlaneChartDeletion deletes all Services of type LoadBalancer in the shoot of the given controlplane and
+// fails until they are gone. It does nothing if the kube-apiserver of the shoot is not running.
+func (h *loadBalancerServicesDeletionHook) BeforeControlPlaneChartDeletion(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) error {
+	// The shoot can only be reached if its kube-apiserver is running
+	dep := &appsv1.Deployment{}
+	if err := h.client.Get(ctx, kutil.Key(cp.Namespace, common.KubeAPIServerDeploymentName), dep); err != nil {
+		if apierrors.IsNotFound(err) {
+			h.logger.Info("Skipping check for services of type LoadBalancer as kube-apiserver does not exist", "controlplane", util.ObjectName(cp))
+			return nil
+		}
+		return errors.Wrapf(err, "could not get deployment '%s/%s'", cp.Namespace, common.KubeAPIServerDeploymentName)
+	}
+	if dep.Spec.Replicas != nil && *dep.Spec.Replicas == 0 {
+		h.logger.Info("Skipping check for services of type LoadBalancer as kube-apiserver is scaled down", "controlplane", util.ObjectName(cp))
+		return nil
+	}
+
+	shootClient, err := h.newShootClient(ctx, h.client, cp.Namespace)
+	if err != nil {
+		return errors.Wrapf(err, "could not create shoot client for controlplane '%s'", util.ObjectName(cp))
+	}
+
+	services := &corev1.ServiceList{}
+	if err := shootClient.List(ctx, &client.ListOptions{}, services); err != nil {
+		return errors.Wrap(err, "could not list services in shoot")
+	}
+
+	var remaining []string
+	for i := range services.Items {
+		service := &services.Items[i]
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		remaining = append(remaining, util.ObjectName(service))
+		if service.DeletionTimestamp == nil {
+			h.logger.Info("Deleting service of type LoadBalancer", "controlplane", util.ObjectName(cp), "service", util.ObjectName(service))
+			if err := shootClient.Delete(ctx, service); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "could not delete service '%s' in shoot", util.ObjectName(service))
+			}
+		}
+	}
+
+	if len(remaining) > 0 {
+		return fmt.Errorf("waiting until services of type LoadBalancer have been deleted: %s", strings.Join(remaining, ", "))
+	}
+	return nil
+}
+
+func newShootClient(ctx context.Context, c client.Client, namespace string) (client.Client, error) {
+	shootClients, err := util.NewClientsForShoot(ctx, c, namespace, client.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return shootClients.Client(), nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("LoadBalancerServicesDeletionHook", func() {
+	var (
+		ctrl *gomock.Controller
+
+		seedClient  *mockclient.MockClient
+		shootClient *mockclient.MockClient
+		hook        *loadBalancerServicesDeletionHook
+
+		cp = &extensionsv1alpha1.ControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: namespace},
+		}
+		cluster = &extensionscontroller.Cluster{}
+
+		kubeAPIServerKey = client.ObjectKey{Namespace: namespace, Name: common.KubeAPIServerDeploymentName}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		seedClient = mockclient.NewMockClient(ctrl)
+		shootClient = mockclient.NewMockClient(ctrl)
+
+		hook = NewLoadBalancerServicesDeletionHook(log.Log.WithName("test")).(*loadBalancerServicesDeletionHook)
+		hook.newShootClient = func(_ context.Context, c client.Client, ns string) (client.Client, error) {
+			Expect(c).To(BeIdenticalTo(seedClient))
+			Expect(ns).To(Equal(namespace))
+			return shootClient, nil
+		}
+		Expect(hook.InjectClient(seedClient)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should do nothing if kube-apiserver does not exist", func() {
+		seedClient.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).
+			Return(&errors.StatusError{ErrStatus: metav1.Status{Reason: metav1.StatusReasonNotFound}})
+
+		Expect(hook.BeforeControlPlaneChartDeletion(context.TODO(), cp, cluster)).To(Succeed())
+	})
+
+	It("should do nothing if kube-apiserver is scaled down", func() {
+		seedClient.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(kubeAPIServerGet(0))
+
+		Expect(hook.BeforeControlPlaneChartDeletion(context.TODO(), cp, cluster)).To(Succeed())
+	})
+
+	It("should succeed if no services of type LoadBalancer remain", func() {
+		seedClient.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(kubeAPIServerGet(1))
+		shootClient.EXPECT().List(context.TODO(), &client.ListOptions{}, &corev1.ServiceList{}).DoAndReturn(servicesList(
+			corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP},
+			},
+		))
+
+		Expect(hook.BeforeControlPlaneChartDeletion(context.TODO(), cp, cluster)).To(Succeed())
+	})
+
+	It("should delete the remaining services of type LoadBalancer and fail until they are gone", func() {
+		now := metav1.Now()
+		lb := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		}
+		deletingLB := corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "deleting-lb", Namespace: "default", DeletionTimestamp: &now},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		}
+
+		seedClient.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).DoAndReturn(kubeAPIServerGet(1))
+		shootClient.EXPECT().List(context.TODO(), &client.ListOptions{}, &corev1.ServiceList{}).DoAndReturn(servicesList(lb, deletingLB))
+		shootClient.EXPECT().Delete(context.TODO(), &lb).Return(nil)
+
+		err := hook.BeforeControlPlaneChartDeletion(context.TODO(), cp, cluster)
+		Expect(err).To(MatchError(ContainSubstring("default/lb, default/deleting-lb")))
+	})
+})
+
+func kubeAPIServerGet(replicas int32) interface{} {
+	return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+		obj.(*appsv1.Deployment).Spec.Replicas = &replicas
+		return nil
+	}
+}
+
+func servicesList(services ...corev1.Service) interface{} {
+	return func(_ context.Context, _ *client.ListOptions, list runtime.Object) error {
+		list.(*corev1.ServiceList).Items = services
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/util"
+	shootwebhook "github.com/gardener/gardener-extensions/pkg/webhook/shoot"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	resourcesv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileShootWebhookConfig creates or updates the shoot webhook configuration in the shoot cluster of the given
+// controlplane. It returns false if the shoot apiserver is not available yet, and the configuration has not been applied.
+func (a *actuator) reconcileShootWebhookConfig(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) (bool, error) {
+	shootClient, err := a.newShootClientIfAvailable(ctx, cp)
+	if err != nil {
+		return false, err
+	}
+	if shootClient == nil {
+		a.logger.Info("Waiting until the shoot apiserver is available to reconcile the shoot webhook configuration", "controlplane", util.ObjectName(cp))
+		return false, nil
+	}
+
+	webhookConfig, err := shootwebhook.BuildWebhookConfig(ctx, a.client, a.shootWebhookConfig)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not build shoot webhook configuration for controlplane '%s'", util.ObjectName(cp))
+	}
+
+	a.logger.Info("Reconciling shoot webhook configuration", "controlplane", util.ObjectName(cp), "name", webhookConfig.Name)
+	if err := shootwebhook.ReconcileWebhookConfig(ctx, shootClient, webhookConfig); err != nil {
+		return false, errors.Wrapf(err, "could not reconcile shoot webhook configuration for controlplane '%s'", util.ObjectName(cp))
+	}
+	return true, nil
+}
+
+// deleteShootWebhookConfig deletes the shoot webhook configuration from the shoot cluster of the given controlplane.
+// It is skipped if the shoot apiserver is not available. It is also skipped if the managed resource containing the
+// shoot chart is already being deleted, as the configuration has then been handled by an earlier attempt of the deletion.
+func (a *actuator) deleteShootWebhookConfig(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) error {
+	mr := &resourcesv1alpha1.ManagedResource{}
+	if err := a.client.Get(ctx, kutil.Key(cp.Namespace, resourceName), mr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "could not get managed resource '%s/%s' containing shoot chart for controlplane '%s'", cp.Namespace, resourceName, util.ObjectName(cp))
+		}
+	} else if mr.DeletionTimestamp != nil {
+		return nil
+	}
+
+	shootClient, err := a.newShootClientIfAvailable(ctx, cp)
+	if err != nil {
+		return err
+	}
+	if shootClient == nil {
+		a.logger.Info("Skipping deletion of shoot webhook configuration as the shoot apiserver is not available", "controlplane", util.ObjectName(cp), "name", a.shootWebhookConfig.Name)
+		return nil
+	}
+
+	a.logger.Info("Deleting shoot webhook configuration", "controlplane", util.ObjectName(cp), "name", a.shootWebhookConfig.Name)
+	if err := shootwebhook.DeleteWebhookConfig(ctx, shootClient, a.shootWebhookConfig.Name); err != nil {
+		return errors.Wrapf(err, "could not delete shoot webhook configuration for controlplane '%s'", util.ObjectName(cp))
+	}
+	return nil
+}
+
+// newShootClientIfAvailable creates a client for the shoot cluster of the given controlplane. It returns nil if the
+// shoot apiserver is not available, i.e. if kube-apiserver has not been deployed, has no available replicas, or can't
+// be reached.
+func (a *actuator) newShootClientIfAvailable(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) (client.Client, error) {
+	dep := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, kutil.Key(cp.Namespace, common.KubeAPIServerDeploymentName), dep); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "could not get deployment '%s/%s'", cp.Namespace, common.KubeAPIServerDeploymentName)
+	}
+	if dep.Status.AvailableReplicas == 0 {
+		return nil, nil
+	}
+
+	shootClients, err := util.NewClientsForShoot(ctx, a.client, cp.Namespace, client.Options{})
+	if err != nil {
+		a.logger.Info("Could not reach the shoot apiserver", "controlplane", util.ObjectName(cp), "error", err.Error())
+		return nil, nil
+	}
+	return shootClients.Client(), nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KMSMinimumKubernetesVersion is the minimum Kubernetes version of shoots whose secrets can be encrypted with a
+	// KMS key.
+	KMSMinimumKubernetesVersion = "1.13"
+	// KMSEncryptionConfigSecretName is the name of the secret containing the encryption configuration of kube-apiserver
+	// and the KMS keys it refers to.
+	KMSEncryptionConfigSecretName = "kube-apiserver-kms-encryption-config"
+	// KMSEncryptionConfigSecretKey is the key of the encryption configuration in the KMS encryption config secret.
+	KMSEncryptionConfigSecretKey = "encryption-configuration.yaml"
+	// KMSKeysSecretKey is the key of the KMS keys in the KMS encryption config secret. It contains one key per line,
+	// the first one is used for encryption and all others for decryption only.
+	KMSKeysSecretKey = "keys"
+	// KMSIdentityKey is the entry in the KMS keys that stands for the identity provider, i.e. for unencrypted data.
+	// It comes first while the first KMS key has only been deployed for decryption.
+	KMSIdentityKey = "identity"
+	// KMSEncryptionConfigChecksumAnnotation is the annotation on the kube-apiserver pod template that contains the
+	// checksum of the KMS encryption config secret. Changing it rolls kube-apiserver.
+	KMSEncryptionConfigChecksumAnnotation = "checksum/secret-" + KMSEncryptionConfigSecretName
+	// KMSPluginSocketDir is the directory containing the unix sockets of the KMS plugins.
+	KMSPluginSocketDir = "/var/run/kmsplugin"
+	// KMSKeyCheckPlaintext is the plaintext that KMS key providers encrypt with the configured key to check that the
+	// provider secret of the controlplane is allowed to use it before the key is deployed.
+	KMSKeyCheckPlaintext = "gardener-extensions-kms-key-check"
+
+	// KMSKeyRotationSwitch is the value of the AnnotationRotateKMSKey annotation that requests encrypting with
+	// the configured key.
+	KMSKeyRotationSwitch = "switch"
+	// KMSKeyRotationCleanup is the value of the AnnotationRotateKMSKey annotation that requests removing all
+	// keys other than the configured one.
+	KMSKeyRotationCleanup = "cleanup"
+
+	kmsPluginCacheSize = 1000
+	kmsPluginTimeout   = "3s"
+)
+
+// KMSPluginName returns the name of the KMS plugin for the key with the given ID. It is used as the name of the
+// KMS provider in the encryption configuration, which is stored with the encrypted data, hence it must not change.
+func KMSPluginName(keyID string) string {
+	sum := sha256.Sum256([]byte(keyID))
+	return "kms-" + hex.EncodeToString(sum[:])[:10]
+}
+
+// KMSPluginSocketPath returns the path of the unix socket of the KMS plugin with the given name.
+func KMSPluginSocketPath(name string) string {
+	return path.Join(KMSPluginSocketDir, name+".sock")
+}
+
+// KMSKeysFromSecret returns the KMS keys contained in the given KMS encryption config secret.
+func KMSKeysFromSecret(secret *corev1.Secret) []string {
+	var keys []string
+	for _, key := range strings.Split(string(secret.Data[KMSKeysSecretKey]), "\n") {
+		if len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// RotateKMSKeys returns the KMS keys that should be used after the given rotation phase has been applied to the
+// given keys, with the given key configured. A newly configured key is always added for decryption only, so that
+// all kube-apiserver instances can read data encrypted with it before it is used for encryption. The first key is
+// hence added after KMSIdentityKey, which keeps secrets unencrypted until KMSKeyRotationSwitch is requested. The
+// rotation phase is either empty, KMSKeyRotationSwitch, or KMSKeyRotationCleanup.
+func RotateKMSKeys(keys []string, keyID, rotation string) ([]string, error) {
+	index := -1
+	for i, key := range keys {
+		if key == keyID {
+			index = i
+			break
+		}
+	}
+
+	switch rotation {
+	case "":
+		if index < 0 && len(keys) == 0 {
+			return []string{KMSIdentityKey, keyID}, nil
+		}
+		if index < 0 {
+			return append(append([]string{}, keys...), keyID), nil
+		}
+		return keys, nil
+	case KMSKeyRotationSwitch:
+		if index < 0 {
+			return nil, errors.Errorf("cannot encrypt with KMS key '%s' before it has been deployed for decryption", keyID)
+		}
+		result := []string{keyID}
+		result = append(result, keys[:index]...)
+		return append(result, keys[index+1:]...), nil
+	case KMSKeyRotationCleanup:
+		if index != 0 {
+			return nil, errors.Errorf("cannot remove other KMS keys before encrypting with KMS key '%s'", keyID)
+		}
+		return []string{keyID}, nil
+	default:
+		return nil, errors.Errorf("unknown KMS key rotation phase '%s'", rotation)
+	}
+}
+
+type encryptionConfiguration struct {
+	APIVersion string                  `json:"apiVersion"`
+	Kind       string                  `json:"kind"`
+	Resources  []resourceConfiguration `json:"resources"`
+}
+
+type resourceConfiguration struct {
+	Resources []string                `json:"resources"`
+	Providers []providerConfiguration `json:"providers"`
+}
+
+type providerConfiguration struct {
+	KMS      *kmsConfiguration `json:"kms,omitempty"`
+	Identity *struct{}         `json:"identity,omitempty"`
+}
+
+type kmsConfiguration struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	CacheSize int    `json:"cachesize"`
+	Timeout   string `json:"timeout"`
+}
+
+// KMSEncryptionConfiguration returns the kube-apiserver encryption configuration for the given KMS keys. Secrets are
+// encrypted with the first key, and can be decrypted with all keys. Secrets that have not been encrypted yet remain
+// readable, as the identity provider is added last unless the keys contain KMSIdentityKey.
+func KMSEncryptionConfiguration(keys []string) ([]byte, error) {
+	providers := make([]providerConfiguration, 0, len(keys)+1)
+	identity := false
+	for _, key := range keys {
+		if key == KMSIdentityKey {
+			providers = append(providers, providerConfiguration{Identity: &struct{}{}})
+			identity = true
+			continue
+		}
+		name := KMSPluginName(key)
+		providers = append(providers, providerConfiguration{
+			KMS: &kmsConfiguration{
+				Name:      name,
+				Endpoint:  fmt.Sprintf("unix://%s", KMSPluginSocketPath(name)),
+				CacheSize: kmsPluginCacheSize,
+				Timeout:   kmsPluginTimeout,
+			},
+		})
+	}
+	if !identity {
+		providers = append(providers, providerConfiguration{Identity: &struct{}{}})
+	}
+
+	return yaml.Marshal(&encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+		Resources: []resourceConfiguration{
+			{Resources: []string{"secrets"}, Providers: providers},
+		},
+	})
+}
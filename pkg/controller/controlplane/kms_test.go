@@ -0,0 +1,119 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("KMS", func() {
+	const (
+		oldKey = "old-key"
+		newKey = "new-key"
+	)
+
+	Describe("#KMSPluginName", func() {
+		It("should return a stable name that is a valid container name", func() {
+			name := KMSPluginName(newKey)
+			Expect(name).To(MatchRegexp("^kms-[0-9a-f]{10}$"))
+			Expect(KMSPluginName(newKey)).To(Equal(name))
+			Expect(KMSPluginName(oldKey)).NotTo(Equal(name))
+		})
+	})
+
+	Describe("#KMSKeysFromSecret", func() {
+		It("should return the keys contained in the secret", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{KMSKeysSecretKey: []byte(newKey + "\n" + oldKey + "\n")}}
+			Expect(KMSKeysFromSecret(secret)).To(Equal([]string{newKey, oldKey}))
+		})
+
+		It("should return no keys if the secret doesn't contain any", func() {
+			Expect(KMSKeysFromSecret(&corev1.Secret{})).To(BeEmpty())
+		})
+	})
+
+	DescribeTable("#RotateKMSKeys",
+		func(keys []string, keyID, rotation string, expected []string, expectErr bool) {
+			result, err := RotateKMSKeys(keys, keyID, rotation)
+			if expectErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("should add the first key for decryption only", nil, newKey, "", []string{KMSIdentityKey, newKey}, false),
+		Entry("should keep the first key for decryption only", []string{KMSIdentityKey, newKey}, newKey, "", []string{KMSIdentityKey, newKey}, false),
+		Entry("should switch to the first key", []string{KMSIdentityKey, newKey}, newKey, KMSKeyRotationSwitch, []string{newKey, KMSIdentityKey}, false),
+		Entry("should not remove the identity provider before switching to the first key", []string{KMSIdentityKey, newKey}, newKey, KMSKeyRotationCleanup, nil, true),
+		Entry("should keep unchanged keys", []string{newKey, oldKey}, newKey, "", []string{newKey, oldKey}, false),
+		Entry("should add a new key for decryption only", []string{oldKey}, newKey, "", []string{oldKey, newKey}, false),
+		Entry("should switch to a deployed key", []string{oldKey, newKey}, newKey, KMSKeyRotationSwitch, []string{newKey, oldKey}, false),
+		Entry("should not switch to a key that has not been deployed", []string{oldKey}, newKey, KMSKeyRotationSwitch, nil, true),
+		Entry("should remove all other keys", []string{newKey, oldKey}, newKey, KMSKeyRotationCleanup, []string{newKey}, false),
+		Entry("should not remove other keys before switching", []string{oldKey, newKey}, newKey, KMSKeyRotationCleanup, nil, true),
+		Entry("should fail for an unknown phase", []string{newKey}, newKey, "foo", nil, true),
+	)
+
+	Describe("#KMSEncryptionConfiguration", func() {
+		It("should encrypt with the first key and keep unencrypted secrets readable", func() {
+			newName, oldName := KMSPluginName(newKey), KMSPluginName(oldKey)
+
+			data, err := KMSEncryptionConfiguration([]string{newKey, oldKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/` + newName + `.sock
+      name: ` + newName + `
+      timeout: 3s
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/` + oldName + `.sock
+      name: ` + oldName + `
+      timeout: 3s
+  - identity: {}
+  resources:
+  - secrets
+`))
+		})
+
+		It("should not encrypt with a key that follows the identity provider", func() {
+			newName := KMSPluginName(newKey)
+
+			data, err := KMSEncryptionConfiguration([]string{KMSIdentityKey, newKey})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - identity: {}
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/` + newName + `.sock
+      name: ` + newName + `
+      timeout: 3s
+  resources:
+  - secrets
+`))
+		})
+	})
+})
@@ -0,0 +1,182 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// ResyncIntervalFlag is the name of the command line flag to specify the interval after which the charts of a
+	// controlplane are applied again even if they have not changed.
+	ResyncIntervalFlag = "resync-interval"
+
+	// CertificateRenewalWindowFlag is the name of the command line flag to specify the duration before their expiry
+	// in which the certificates of controlplane secrets are renewed.
+	CertificateRenewalWindowFlag = "certificate-renewal-window"
+	// CABundleDurationFlag is the name of the command line flag to specify the duration for which the old CA is kept
+	// in the CA bundle during a CA roll.
+	CABundleDurationFlag = "ca-bundle-duration"
+
+	// ManagedResourceDeletionTimeoutFlag is the name of the command line flag to specify the duration to wait for the
+	// managed resource of a controlplane to be deleted.
+	ManagedResourceDeletionTimeoutFlag = "managed-resource-deletion-timeout"
+	// OrphanPolicyFlag is the name of the command line flag to specify what happens if the managed resource of a
+	// controlplane has not been deleted within the managed resource deletion timeout.
+	OrphanPolicyFlag = "orphan-policy"
+)
+
+// ReconcilerOptions are command line options that can be set for the reconciliation of controlplanes.
+type ReconcilerOptions struct {
+	// ResyncInterval is the interval after which the charts of a controlplane are applied again even if they have not
+	// changed. Zero means that the charts are applied on every reconciliation.
+	ResyncInterval time.Duration
+
+	config *ReconcilerConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (c *ReconcilerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.ResyncInterval, ResyncIntervalFlag, c.ResyncInterval, "The interval after which the charts of a controlplane are applied again even if they have not changed. Zero means that the charts are applied on every reconciliation.")
+}
+
+// Complete implements Completer.Complete.
+func (c *ReconcilerOptions) Complete() error {
+	if c.ResyncInterval < 0 {
+		return fmt.Errorf("resync interval must not be negative, got '%s'", c.ResyncInterval)
+	}
+
+	c.config = &ReconcilerConfig{c.ResyncInterval}
+	return nil
+}
+
+// Completed returns the completed ReconcilerConfig. Only call this if `Complete` was successful.
+func (c *ReconcilerOptions) Completed() *ReconcilerConfig {
+	return c.config
+}
+
+// ReconcilerConfig is a completed configuration for the reconciliation of controlplanes.
+type ReconcilerConfig struct {
+	// ResyncInterval is the interval after which the charts of a controlplane are applied again even if they have not
+	// changed.
+	ResyncInterval time.Duration
+}
+
+// ApplyResyncInterval sets the resync interval of this ReconcilerConfig in the given duration.
+func (c *ReconcilerConfig) ApplyResyncInterval(resyncInterval *time.Duration) {
+	*resyncInterval = c.ResyncInterval
+}
+
+// SecretsRotationOptions are command line options that can be set for the rotation of controlplane secrets.
+type SecretsRotationOptions struct {
+	// CertificateRenewalWindow is the duration before their expiry in which certificates are renewed.
+	CertificateRenewalWindow time.Duration
+	// CABundleDuration is the duration for which the old CA is kept in the CA bundle during a CA roll.
+	CABundleDuration time.Duration
+
+	config *SecretsRotationConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (c *SecretsRotationOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.CertificateRenewalWindow, CertificateRenewalWindowFlag, c.CertificateRenewalWindow, "The duration before their expiry in which certificates are renewed.")
+	fs.DurationVar(&c.CABundleDuration, CABundleDurationFlag, c.CABundleDuration, fmt.Sprintf("The duration for which the old CA is kept in the CA bundle during a CA roll, e.g. '%s'. It must be long enough for all clients to pick up the new CA bundle.", util.DefaultCABundleDuration))
+}
+
+// Complete implements Completer.Complete.
+func (c *SecretsRotationOptions) Complete() error {
+	if c.CABundleDuration <= 0 {
+		return fmt.Errorf("CA bundle duration must be positive, got '%s'", c.CABundleDuration)
+	}
+
+	c.config = &SecretsRotationConfig{c.CertificateRenewalWindow, c.CABundleDuration}
+	return nil
+}
+
+// Completed returns the completed SecretsRotationConfig. Only call this if `Complete` was successful.
+func (c *SecretsRotationOptions) Completed() *SecretsRotationConfig {
+	return c.config
+}
+
+// SecretsRotationConfig is a completed configuration for the rotation of controlplane secrets.
+type SecretsRotationConfig struct {
+	// CertificateRenewalWindow is the duration before their expiry in which certificates are renewed.
+	CertificateRenewalWindow time.Duration
+	// CABundleDuration is the duration for which the old CA is kept in the CA bundle during a CA roll.
+	CABundleDuration time.Duration
+}
+
+// Apply sets the values of this SecretsRotationConfig in the given RotatingSecrets.
+func (c *SecretsRotationConfig) Apply(secrets *util.RotatingSecrets) {
+	secrets.RenewalWindow = c.CertificateRenewalWindow
+	secrets.CABundleDuration = c.CABundleDuration
+}
+
+// DeletionOptions are command line options that can be set for the deletion of controlplanes.
+type DeletionOptions struct {
+	// ManagedResourceDeletionTimeout is the duration to wait for the managed resource of a controlplane to be deleted.
+	ManagedResourceDeletionTimeout time.Duration
+	// OrphanPolicy determines what happens if the managed resource of a controlplane has not been deleted within the
+	// managed resource deletion timeout.
+	OrphanPolicy string
+
+	config *DeletionConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (c *DeletionOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.ManagedResourceDeletionTimeout, ManagedResourceDeletionTimeoutFlag, c.ManagedResourceDeletionTimeout, "The duration to wait for the managed resource of a controlplane to be deleted.")
+	fs.StringVar(&c.OrphanPolicy, OrphanPolicyFlag, c.OrphanPolicy, fmt.Sprintf("What happens if the managed resource of a controlplane has not been deleted in time. Either '%s' to retry the deletion, or '%s' to orphan the objects in the shoot.", OrphanPolicyNever, OrphanPolicyAfterTimeout))
+}
+
+// Complete implements Completer.Complete.
+func (c *DeletionOptions) Complete() error {
+	orphanPolicy := OrphanPolicy(c.OrphanPolicy)
+	if orphanPolicy != OrphanPolicyNever && orphanPolicy != OrphanPolicyAfterTimeout {
+		return fmt.Errorf("unsupported orphan policy '%s'", c.OrphanPolicy)
+	}
+
+	c.config = &DeletionConfig{c.ManagedResourceDeletionTimeout, orphanPolicy}
+	return nil
+}
+
+// Completed returns the completed DeletionConfig. Only call this if `Complete` was successful.
+func (c *DeletionOptions) Completed() *DeletionConfig {
+	return c.config
+}
+
+// DeletionConfig is a completed configuration for the deletion of controlplanes.
+type DeletionConfig struct {
+	// ManagedResourceDeletionTimeout is the duration to wait for the managed resource of a controlplane to be deleted.
+	ManagedResourceDeletionTimeout time.Duration
+	// OrphanPolicy determines what happens if the managed resource of a controlplane has not been deleted within the
+	// managed resource deletion timeout.
+	OrphanPolicy OrphanPolicy
+}
+
+// ApplyManagedResourceDeletionTimeout sets the managed resource deletion timeout of this DeletionConfig in the given duration.
+func (c *DeletionConfig) ApplyManagedResourceDeletionTimeout(timeout *time.Duration) {
+	*timeout = c.ManagedResourceDeletionTimeout
+}
+
+// ApplyOrphanPolicy sets the orphan policy of this DeletionConfig in the given OrphanPolicy.
+func (c *DeletionConfig) ApplyOrphanPolicy(policy *OrphanPolicy) {
+	*policy = c.OrphanPolicy
+}
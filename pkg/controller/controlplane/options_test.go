@@ -0,0 +1,109 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/util"
+	"github.com/gardener/gardener-extensions/pkg/util/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Options", func() {
+	Describe("ReconcilerOptions", func() {
+		It("should parse the flags and complete the config", func() {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts := ReconcilerOptions{ResyncInterval: DefaultResyncInterval}
+			opts.AddFlags(fs)
+
+			Expect(fs.Parse(test.NewCommandBuilder("test").
+				Flags(test.StringFlag(ResyncIntervalFlag, "30m0s")).
+				Command().
+				Slice())).To(Succeed())
+			Expect(opts.Complete()).To(Succeed())
+
+			var resyncInterval time.Duration
+			opts.Completed().ApplyResyncInterval(&resyncInterval)
+			Expect(resyncInterval).To(Equal(30 * time.Minute))
+		})
+
+		It("should fail to complete with a negative resync interval", func() {
+			opts := ReconcilerOptions{ResyncInterval: -time.Minute}
+			Expect(opts.Complete()).To(MatchError(ContainSubstring("resync interval must not be negative")))
+		})
+	})
+
+	Describe("SecretsRotationOptions", func() {
+		It("should parse the flags and complete the config", func() {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts := SecretsRotationOptions{CABundleDuration: util.DefaultCABundleDuration}
+			opts.AddFlags(fs)
+
+			Expect(fs.Parse(test.NewCommandBuilder("test").
+				Flags(
+					test.StringFlag(CertificateRenewalWindowFlag, "720h0m0s"),
+					test.StringFlag(CABundleDurationFlag, "48h0m0s"),
+				).
+				Command().
+				Slice())).To(Succeed())
+			Expect(opts.Complete()).To(Succeed())
+
+			secrets := &util.RotatingSecrets{}
+			opts.Completed().Apply(secrets)
+			Expect(secrets.RenewalWindow).To(Equal(720 * time.Hour))
+			Expect(secrets.CABundleDuration).To(Equal(48 * time.Hour))
+		})
+
+		It("should fail to complete without a positive CA bundle duration", func() {
+			opts := SecretsRotationOptions{}
+			Expect(opts.Complete()).To(MatchError(ContainSubstring("CA bundle duration must be positive")))
+		})
+	})
+
+	Describe("DeletionOptions", func() {
+		It("should parse the flags and complete the config", func() {
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			opts := DeletionOptions{}
+			opts.AddFlags(fs)
+
+			Expect(fs.Parse(test.NewCommandBuilder("test").
+				Flags(
+					test.StringFlag(ManagedResourceDeletionTimeoutFlag, "2m0s"),
+					test.StringFlag(OrphanPolicyFlag, string(OrphanPolicyAfterTimeout)),
+				).
+				Command().
+				Slice())).To(Succeed())
+			Expect(opts.Complete()).To(Succeed())
+
+			var (
+				timeout time.Duration
+				policy  OrphanPolicy
+			)
+			opts.Completed().ApplyManagedResourceDeletionTimeout(&timeout)
+			opts.Completed().ApplyOrphanPolicy(&policy)
+			Expect(timeout).To(Equal(2 * time.Minute))
+			Expect(policy).To(Equal(OrphanPolicyAfterTimeout))
+		})
+
+		It("should fail to complete with an unsupported orphan policy", func() {
+			opts := DeletionOptions{OrphanPolicy: "Sometimes"}
+			Expect(opts.Complete()).To(MatchError(ContainSubstring("unsupported orphan policy")))
+		})
+	})
+})
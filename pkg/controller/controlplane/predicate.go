@@ -27,3 +27,24 @@ func GenerationChangedPredicate() predicate.Predicate {
 		},
 	}
 }
+
+// SecretsRotationRequestedPredicate is a predicate for controlplanes that request the rotation of their secrets.
+func SecretsRotationRequestedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(event event.UpdateEvent) bool {
+			_, ok := event.MetaNew.GetAnnotations()[AnnotationRotateSecrets]
+			return ok
+		},
+	}
+}
+
+// KMSKeyRotationRequestedPredicate is a predicate for controlplanes that request the next phase of the rotation of their
+// KMS key.
+func KMSKeyRotationRequestedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(event event.UpdateEvent) bool {
+			_, ok := event.MetaNew.GetAnnotations()[AnnotationRotateKMSKey]
+			return ok
+		},
+	}
+}
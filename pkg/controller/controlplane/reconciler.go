@@ -20,6 +20,7 @@ import (
 	"time"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
@@ -47,8 +48,9 @@ const (
 )
 
 type reconciler struct {
-	logger   logr.Logger
-	actuator Actuator
+	logger         logr.Logger
+	actuator       Actuator
+	resyncInterval time.Duration
 
 	ctx      context.Context
 	client   client.Client
@@ -56,12 +58,14 @@ type reconciler struct {
 }
 
 // NewReconciler creates a new reconcile.Reconciler that reconciles
-// controlplane resources of Gardener's `extensions.gardener.cloud` API group.
-func NewReconciler(mgr manager.Manager, actuator Actuator) reconcile.Reconciler {
+// controlplane resources of Gardener's `extensions.gardener.cloud` API group. If the given resync interval is set,
+// successfully reconciled controlplanes are requeued after it.
+func NewReconciler(mgr manager.Manager, actuator Actuator, resyncInterval time.Duration) reconcile.Reconciler {
 	return &reconciler{
-		logger:   log.Log.WithName(ControllerName),
-		actuator: actuator,
-		recorder: mgr.GetRecorder(ControllerName),
+		logger:         log.Log.WithName(ControllerName),
+		actuator:       actuator,
+		resyncInterval: resyncInterval,
+		recorder:       mgr.GetRecorder(ControllerName),
 	}
 }
 
@@ -129,7 +133,8 @@ func (r *reconciler) reconcile(ctx context.Context, cp *extensionsv1alpha1.Contr
 	if requeue {
 		return reconcile.Result{RequeueAfter: RequeueAfter}, nil
 	}
-	return reconcile.Result{}, nil
+	// Requeue for the periodic resync, as updates that do not change the generation are filtered by the predicates
+	return reconcile.Result{RequeueAfter: r.resyncInterval}, nil
 }
 
 func (r *reconciler) delete(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (reconcile.Result, error) {
@@ -143,14 +148,24 @@ func (r *reconciler) delete(ctx context.Context, cp *extensionsv1alpha1.ControlP
 		return reconcile.Result{}, nil
 	}
 
+	// A requeued deletion is still processing, hence its last operation is kept at the phase it has reached
 	operationType := gardencorev1alpha1helper.ComputeOperationType(cp.ObjectMeta, cp.Status.LastOperation)
-	if err := r.updateStatusProcessing(ctx, cp, operationType, "Deleting the controlplane"); err != nil {
-		return reconcile.Result{}, err
+	if lastOperation := cp.Status.LastOperation; lastOperation == nil || lastOperation.Type != operationType || lastOperation.State != gardencorev1alpha1.LastOperationStateProcessing {
+		if err := r.updateStatusProcessing(ctx, cp, operationType, "Deleting the controlplane"); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	r.logger.Info("Starting the deletion of controlplane", "controlplane", cp.Name)
 	r.recorder.Event(cp, corev1.EventTypeNormal, EventControlPlaneDeletion, "Deleting the cp")
 	if err := r.actuator.Delete(r.ctx, cp, cluster); err != nil {
+		// A requeue indicates that the deletion is still in progress, e.g. while waiting for the managed resource to be
+		// deleted, hence the controlplane is not failed.
+		if requeueAfter, ok := err.(*controllererror.RequeueAfterError); ok {
+			r.logger.Info("Controlplane deletion is still in progress", "controlplane", cp.Name, "reason", extensionscontroller.ReconcileErrCauseOrErr(err).Error(), "requeueAfter", requeueAfter.RequeueAfter)
+			return extensionscontroller.ReconcileErr(err)
+		}
+
 		msg := "Error deleting controlplane"
 		r.recorder.Eventf(cp, corev1.EventTypeWarning, EventControlPlaneDeletion, "%s: %+v", msg, err)
 		_ = r.updateStatusError(ctx, extensionscontroller.ReconcileErrCauseOrErr(err), cp, operationType, msg)
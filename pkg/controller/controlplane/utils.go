@@ -20,9 +20,14 @@ import (
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 )
 
+// CSIMinimumKubernetesVersion is the minimum Kubernetes version of shoots for which the provider extensions
+// deploy CSI drivers.
+const CSIMinimumKubernetesVersion = "1.14"
+
 // DNSNamesForService returns the possible DNS names for a service with the given name and namespace
 func DNSNamesForService(name, namespace string) []string {
 	return []string{
@@ -55,3 +60,23 @@ func ComputeChecksums(secrets map[string]*corev1.Secret, cms map[string]*corev1.
 	}
 	return checksums
 }
+
+// IsCSIEnabled returns true if a CSI driver should be deployed for a shoot with the given Kubernetes version,
+// i.e. if the shoot opted in and its version is at least CSIMinimumKubernetesVersion.
+func IsCSIEnabled(optIn bool, kubernetesVersion string) (bool, error) {
+	if !optIn {
+		return false, nil
+	}
+	return utils.CompareVersions(kubernetesVersion, ">=", CSIMinimumKubernetesVersion)
+}
+
+// IsCSIMigrationEnabled returns true if the persistent volumes of the in-tree volume plugin of a shoot with the given
+// Kubernetes version should be migrated to the CSI driver, i.e. if the CSI driver is enabled, the shoot opted in for
+// the migration, and its version is at least the given minimum version of the provider's migration feature gate.
+func IsCSIMigrationEnabled(csiOptIn, migrationOptIn bool, kubernetesVersion, minimumKubernetesVersion string) (bool, error) {
+	csiEnabled, err := IsCSIEnabled(csiOptIn, kubernetesVersion)
+	if err != nil || !csiEnabled || !migrationOptIn {
+		return false, err
+	}
+	return utils.CompareVersions(kubernetesVersion, ">=", minimumKubernetesVersion)
+}
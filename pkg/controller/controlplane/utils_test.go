@@ -33,6 +33,35 @@ var _ = Describe("Utils", func() {
 		})
 	})
 
+	Describe("#IsCSIEnabled", func() {
+		It("should only enable CSI for shoots that opted in and have a supported version", func() {
+			Expect(IsCSIEnabled(true, "1.14.1")).To(BeTrue())
+			Expect(IsCSIEnabled(true, "v1.15.0")).To(BeTrue())
+			Expect(IsCSIEnabled(true, "1.13.4")).To(BeFalse())
+			Expect(IsCSIEnabled(false, "1.14.1")).To(BeFalse())
+		})
+
+		It("should fail for an invalid version", func() {
+			_, err := IsCSIEnabled(true, "foo")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#IsCSIMigrationEnabled", func() {
+		It("should only enable the migration for shoots that opted in for CSI and the migration and have a supported version", func() {
+			Expect(IsCSIMigrationEnabled(true, true, "1.14.1", "1.14")).To(BeTrue())
+			Expect(IsCSIMigrationEnabled(true, true, "1.14.1", "1.15")).To(BeFalse())
+			Expect(IsCSIMigrationEnabled(true, false, "1.14.1", "1.14")).To(BeFalse())
+			Expect(IsCSIMigrationEnabled(false, true, "1.14.1", "1.14")).To(BeFalse())
+			Expect(IsCSIMigrationEnabled(true, true, "1.13.4", "1.13")).To(BeFalse())
+		})
+
+		It("should fail for an invalid version", func() {
+			_, err := IsCSIMigrationEnabled(true, true, "foo", "1.14")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("#MergeSecretMaps", func() {
 		var (
 			test0 = getSecret("test0", "default", nil)
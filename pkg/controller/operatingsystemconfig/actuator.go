@@ -27,3 +27,12 @@ type Actuator interface {
 	// Delete the operating system config.
 	Delete(context.Context, *extensionsv1alpha1.OperatingSystemConfig) error
 }
+
+// UnitChecksummer is implemented by Actuators that can compute checksums of the units they write,
+// including the files, units and drop-ins they add to the ones of the OperatingSystemConfig. The
+// reconciler uses them to determine the units that are affected by a change. For Actuators that do
+// not implement it, all units are considered affected.
+type UnitChecksummer interface {
+	// UnitChecksums computes a checksum for every unit of the given operating system config.
+	UnitChecksums(context.Context, *extensionsv1alpha1.OperatingSystemConfig) (map[string]string, error)
+}
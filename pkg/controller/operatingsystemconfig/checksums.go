@@ -0,0 +1,82 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"encoding/json"
+
+	"github.com/gardener/gardener/pkg/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationUnitChecksums is the annotation of the secret containing the generated OSC output that
+// stores the checksums of the units of the last reconciliation as JSON.
+const AnnotationUnitChecksums = "operatingsystemconfig.extensions.gardener.cloud/unit-checksums"
+
+// AnnotationCloudConfigChecksum is the annotation of the secret containing the generated OSC output
+// that stores the checksum of the generated cloud config the unit checksums were recorded for.
+const AnnotationCloudConfigChecksum = "operatingsystemconfig.extensions.gardener.cloud/cloud-config-checksum"
+
+// AffectedUnits returns those of the given units whose checksum differs between the old and the new
+// checksums. Units without a new checksum are always considered affected.
+func AffectedUnits(units []string, oldChecksums, newChecksums map[string]string) []string {
+	affected := make([]string, 0, len(units))
+	for _, unit := range units {
+		newChecksum, ok := newChecksums[unit]
+		if !ok || oldChecksums[unit] != newChecksum {
+			affected = append(affected, unit)
+		}
+	}
+	return affected
+}
+
+// RecordUnitChecksums records the checksum of the given cloud config and the given unit checksums in
+// the annotations of the given secret and returns the units that are affected by the changes since the
+// previously recorded cloud config. As long as the cloud config does not change, the annotations are
+// kept and the given last affected units are returned, so that repeated reconciliations of the same
+// config report the same units.
+func RecordUnitChecksums(secret *corev1.Secret, cloudConfig []byte, units []string, checksums map[string]string, lastAffectedUnits []string) ([]string, error) {
+	cloudConfigChecksum := utils.ComputeSHA256Hex(cloudConfig)
+	if secret.Annotations[AnnotationCloudConfigChecksum] == cloudConfigChecksum && UnitChecksumsFromSecret(secret) != nil {
+		return lastAffectedUnits, nil
+	}
+
+	checksumsJSON, err := json.Marshal(checksums)
+	if err != nil {
+		return nil, err
+	}
+
+	affectedUnits := AffectedUnits(units, UnitChecksumsFromSecret(secret), checksums)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationUnitChecksums, string(checksumsJSON))
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationCloudConfigChecksum, cloudConfigChecksum)
+	return affectedUnits, nil
+}
+
+// UnitChecksumsFromSecret returns the unit checksums stored in the annotations of the given secret.
+// It returns nil if there are none or if they cannot be decoded.
+func UnitChecksumsFromSecret(secret *corev1.Secret) map[string]string {
+	data, ok := secret.Annotations[AnnotationUnitChecksums]
+	if !ok {
+		return nil
+	}
+
+	var checksums map[string]string
+	if err := json.Unmarshal([]byte(data), &checksums); err != nil {
+		return nil
+	}
+	return checksums
+}
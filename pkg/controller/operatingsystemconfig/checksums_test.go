@@ -0,0 +1,81 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig_test
+
+import (
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Checksums", func() {
+	var secret *corev1.Secret
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cloud-config", Namespace: "shoot--foo--bar"}}
+	})
+
+	Describe("#AffectedUnits", func() {
+		It("should consider all units affected without old checksums", func() {
+			Expect(AffectedUnits([]string{"a", "b"}, nil, map[string]string{"a": "1"})).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should only consider units with a changed or without a new checksum affected", func() {
+			Expect(AffectedUnits([]string{"a", "b", "c"}, map[string]string{"a": "1", "b": "1", "c": "1"}, map[string]string{"a": "1", "b": "2"})).To(Equal([]string{"b", "c"}))
+		})
+	})
+
+	Describe("#RecordUnitChecksums", func() {
+		It("should keep the last affected units as long as the cloud config does not change", func() {
+			units := []string{"a", "b"}
+
+			affected, err := RecordUnitChecksums(secret, []byte("config"), units, map[string]string{"a": "1", "b": "1"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(affected).To(Equal(units))
+
+			affected, err = RecordUnitChecksums(secret, []byte("changed"), units, map[string]string{"a": "1", "b": "2"}, affected)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(affected).To(Equal([]string{"b"}))
+			Expect(UnitChecksumsFromSecret(secret)).To(Equal(map[string]string{"a": "1", "b": "2"}))
+
+			affected, err = RecordUnitChecksums(secret, []byte("changed"), units, map[string]string{"a": "1", "b": "2"}, affected)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(affected).To(Equal([]string{"b"}))
+		})
+
+		It("should consider all units affected without checksums", func() {
+			for i := 0; i < 2; i++ {
+				affected, err := RecordUnitChecksums(secret, []byte("config"), []string{"a", "b"}, nil, []string{"a"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(affected).To(Equal([]string{"a", "b"}))
+			}
+		})
+	})
+
+	Describe("#UnitChecksumsFromSecret", func() {
+		It("should return the stored checksums", func() {
+			secret.Annotations = map[string]string{AnnotationUnitChecksums: `{"a":"1"}`}
+			Expect(UnitChecksumsFromSecret(secret)).To(Equal(map[string]string{"a": "1"}))
+		})
+
+		It("should return nil for invalid checksums", func() {
+			secret.Annotations = map[string]string{AnnotationUnitChecksums: `{`}
+			Expect(UnitChecksumsFromSecret(secret)).To(BeNil())
+		})
+	})
+})
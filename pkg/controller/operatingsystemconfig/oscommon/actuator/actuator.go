@@ -33,14 +33,18 @@ type Actuator struct {
 	logger    logr.Logger
 	osName    string
 	generator generator.Generator
+
+	userDataSizeBudget int
 }
 
-// NewActuator creates a new actuator with the given logger.
-func NewActuator(osName string, generator generator.Generator) operatingsystemconfig.Actuator {
+// NewActuator creates a new actuator with the given logger. A positive userDataSizeBudget limits the
+// size of the generated user data in bytes.
+func NewActuator(osName string, generator generator.Generator, userDataSizeBudget int) operatingsystemconfig.Actuator {
 	return &Actuator{
-		logger:    log.Log.WithName(osName + "-operatingsystemconfig-actuator"),
-		osName:    osName,
-		generator: generator,
+		logger:             log.Log.WithName(osName + "-operatingsystemconfig-actuator"),
+		osName:             osName,
+		generator:          generator,
+		userDataSizeBudget: userDataSizeBudget,
 	}
 }
 
@@ -18,16 +18,34 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
 
 // Reconcile reconciles the update of a OperatingSystemConfig regenerating the os-specific format
 func (a *Actuator) Reconcile(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, []string, error) {
+	if errs := operatingsystemconfig.ValidateOperatingSystemConfig(config); len(errs) > 0 {
+		return nil, nil, nil, fmt.Errorf("invalid operating system config: %v", errs.ToAggregate())
+	}
 
 	cloudConfig, cmd, err := CloudConfigFromOperatingSystemConfig(ctx, a.client, config, a.generator)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("could not generate cloud config: %v", err)
 	}
+	if err := cloudinit.ValidateUserData(cloudConfig); err != nil {
+		return nil, nil, nil, fmt.Errorf("generated user data is invalid: %v", err)
+	}
+	if err := content.CheckUserDataSize(config, cloudConfig, a.userDataSizeBudget); err != nil {
+		return nil, nil, nil, err
+	}
 
 	return []byte(cloudConfig), cmd, OperatingSystemConfigUnitNames(config), nil
 }
+
+// UnitChecksums implements operatingsystemconfig.UnitChecksummer.
+func (a *Actuator) UnitChecksums(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) (map[string]string, error) {
+	return UnitChecksumsFromOperatingSystemConfig(ctx, a.client, config)
+}
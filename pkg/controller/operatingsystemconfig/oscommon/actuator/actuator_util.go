@@ -31,66 +31,120 @@ package actuator
 import (
 	"context"
 
-	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
 	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/hardening"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 
-	corev1 "k8s.io/api/core/v1"
-
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // CloudConfigFromOperatingSystemConfig generates a CloudConfig from an OperatingSystemConfig
-// using a Generator
+// using a Generator. The files and the unit of the hardening profile of the provider config are
+// added if the config is reconciled, the containerd configuration is added if containerd is the
+// selected container runtime.
 func CloudConfigFromOperatingSystemConfig(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig, generator commonosgenerator.Generator) ([]byte, *string, error) {
-	files := make([]*commonosgenerator.File, 0, len(config.Spec.Files))
-	for _, file := range config.Spec.Files {
-		data, err := DataForFileContent(ctx, cli, config.Namespace, &file.Content)
-		if err != nil {
-			return nil, nil, err
-		}
+	osc, err := generatorConfigFromOperatingSystemConfig(ctx, cli, config)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		files = append(files, &commonosgenerator.File{Path: file.Path, Content: data, Permissions: file.Permissions})
+	return generator.Generate(osc)
+}
+
+// UnitChecksumsFromOperatingSystemConfig computes a checksum for every unit that is written for the
+// given OperatingSystemConfig, including the files, units and drop-ins added by the actuator.
+func UnitChecksumsFromOperatingSystemConfig(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig) (map[string]string, error) {
+	osc, err := generatorConfigFromOperatingSystemConfig(ctx, cli, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return commonosgenerator.UnitChecksums(osc), nil
+}
+
+func generatorConfigFromOperatingSystemConfig(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig) (*commonosgenerator.OperatingSystemConfig, error) {
+	providerConfig, err := ProviderConfigFromOperatingSystemConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return OperatingSystemConfigContent(ctx, cli, config, providerConfig.Hardening, providerConfig.ContainerRuntime)
+}
+
+// OperatingSystemConfigContent returns the files and units that are written for the given
+// OperatingSystemConfig. The files and the unit of the given hardening profile are added if the
+// config is reconciled. If containerd is the given container runtime, the containerd configuration
+// is added and the kubelet unit is adapted to containerd.
+func OperatingSystemConfigContent(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig, hardeningProfile *hardening.Profile, runtime containerruntime.ContainerRuntime) (*commonosgenerator.OperatingSystemConfig, error) {
+	files, err := content.NewResolver(cli).Resolve(ctx, config)
+	if err != nil {
+		return nil, err
 	}
 
 	units := make([]*commonosgenerator.Unit, 0, len(config.Spec.Units))
 	for _, unit := range config.Spec.Units {
-		var content []byte
+		var unitContent []byte
 		if unit.Content != nil {
-			content = []byte(*unit.Content)
+			content, err := containerruntime.UnitContent(unit.Name, *unit.Content, runtime)
+			if err != nil {
+				return nil, err
+			}
+			unitContent = []byte(content)
 		}
 
 		dropIns := make([]*commonosgenerator.DropIn, 0, len(unit.DropIns))
 		for _, dropIn := range unit.DropIns {
 			dropIns = append(dropIns, &commonosgenerator.DropIn{Name: dropIn.Name, Content: []byte(dropIn.Content)})
 		}
-		units = append(units, &commonosgenerator.Unit{Name: unit.Name, Content: content, DropIns: dropIns})
+		units = append(units, &commonosgenerator.Unit{Name: unit.Name, Content: unitContent, DropIns: dropIns})
+	}
+
+	if runtime == containerruntime.Containerd {
+		permissions := int32(0644)
+		files = append(files, &commonosgenerator.File{
+			Path:        containerruntime.ContainerdConfigPath,
+			Content:     []byte(containerruntime.ContainerdConfig),
+			Permissions: &permissions,
+		})
+		units = ensureDropIn(units, containerruntime.ContainerdUnitName, &commonosgenerator.DropIn{
+			Name:    containerruntime.ContainerdDropInName,
+			Content: []byte(containerruntime.ContainerdDropIn),
+		})
+	}
+
+	if hardeningProfile != nil && config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeReconcile {
+		hardeningFiles, hardeningUnit := hardening.Render(hardeningProfile)
+		files = append(files, hardeningFiles...)
+		units = append(units, hardeningUnit)
 	}
 
-	return generator.Generate(&commonosgenerator.OperatingSystemConfig{
-		Bootstrap: config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeProvision,
-		Files:     files,
-		Units:     units,
-		Path:      config.Spec.ReloadConfigFilePath,
-	})
+	return &commonosgenerator.OperatingSystemConfig{
+		Bootstrap:        config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeProvision,
+		Files:            files,
+		Units:            units,
+		Path:             config.Spec.ReloadConfigFilePath,
+		ContainerRuntime: runtime,
+	}, nil
 }
 
-// DataForFileContent returns the content for a FileContent, retrieving from a Secret if necessary.
-func DataForFileContent(ctx context.Context, cli runtimeclient.Client, namespace string, content *extensionsv1alpha1.FileContent) ([]byte, error) {
-	if inline := content.Inline; inline != nil {
-		if len(inline.Encoding) == 0 {
-			return []byte(inline.Data), nil
+// ensureDropIn adds the given drop-in to the unit with the given name. The unit is added without
+// content if the units do not contain it.
+func ensureDropIn(units []*commonosgenerator.Unit, name string, dropIn *commonosgenerator.DropIn) []*commonosgenerator.Unit {
+	for _, unit := range units {
+		if unit.Name == name {
+			unit.DropIns = append(unit.DropIns, dropIn)
+			return units
 		}
-		return cloudinit.Decode(inline.Encoding, []byte(inline.Data))
 	}
+	return append(units, &commonosgenerator.Unit{Name: name, DropIns: []*commonosgenerator.DropIn{dropIn}})
+}
 
-	key := runtimeclient.ObjectKey{Namespace: namespace, Name: content.SecretRef.Name}
-	secret := &corev1.Secret{}
-	if err := cli.Get(ctx, key, secret); err != nil {
-		return nil, err
-	}
-	return secret.Data[content.SecretRef.DataKey], nil
+// DataForFileContent returns the content for a FileContent, retrieving from a Secret if necessary.
+func DataForFileContent(ctx context.Context, cli runtimeclient.Client, namespace string, fileContent *extensionsv1alpha1.FileContent) ([]byte, error) {
+	return content.NewResolver(cli).ResolveFileContent(ctx, namespace, fileContent)
 }
 
 // OperatingSystemConfigUnitNames returns the names of the units in the OperatingSystemConfig
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/hardening"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// AnnotationProviderConfig is the annotation on OperatingSystemConfigs that carries the provider
+// config of the operating systems based on oscommon as JSON.
+// TODO: Move to `.spec.providerConfig` as soon as the OperatingSystemConfig resource offers it.
+const AnnotationProviderConfig = "operatingsystemconfig.extensions.gardener.cloud/provider-config"
+
+// ProviderConfig is the configuration of an OperatingSystemConfig that is common to the operating
+// systems based on oscommon.
+type ProviderConfig struct {
+	// ContainerRuntime is the container runtime of the machines. Defaults to `docker`.
+	ContainerRuntime containerruntime.ContainerRuntime `json:"containerRuntime,omitempty"`
+	// Hardening is the OS-level hardening profile. The machines are only hardened if it is set.
+	Hardening *hardening.Profile `json:"hardening,omitempty"`
+}
+
+// ProviderConfigFromOperatingSystemConfig reads the provider config from the annotations of the
+// given OperatingSystemConfig, sets the defaults for unset fields and validates it.
+func ProviderConfigFromOperatingSystemConfig(config *extensionsv1alpha1.OperatingSystemConfig) (*ProviderConfig, error) {
+	providerConfig := &ProviderConfig{}
+	if err := DecodeProviderConfig(config, providerConfig); err != nil {
+		return nil, err
+	}
+
+	SetDefaultsProviderConfig(providerConfig)
+
+	if errs := ValidateProviderConfig(providerConfig, nil); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid provider config: %v", errs.ToAggregate())
+	}
+
+	return providerConfig, nil
+}
+
+// DecodeProviderConfig decodes the AnnotationProviderConfig annotation of the given
+// OperatingSystemConfig into <into>. Operating systems with additional settings decode into a type
+// that embeds ProviderConfig. <into> is left unchanged if the annotation is not set.
+func DecodeProviderConfig(config *extensionsv1alpha1.OperatingSystemConfig, into interface{}) error {
+	data, ok := config.Annotations[AnnotationProviderConfig]
+	if !ok {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), into); err != nil {
+		return fmt.Errorf("could not decode provider config: %v", err)
+	}
+	return nil
+}
+
+// SetDefaultsProviderConfig sets the defaults for the unset fields of the given provider config.
+func SetDefaultsProviderConfig(providerConfig *ProviderConfig) {
+	if providerConfig.ContainerRuntime == "" {
+		providerConfig.ContainerRuntime = containerruntime.Docker
+	}
+}
+
+// ValidateProviderConfig validates the given provider config.
+func ValidateProviderConfig(providerConfig *ProviderConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, containerruntime.ValidateContainerRuntime(providerConfig.ContainerRuntime, fldPath.Child("containerRuntime"))...)
+
+	if providerConfig.Hardening != nil {
+		allErrs = append(allErrs, hardening.ValidateProfile(providerConfig.Hardening, fldPath.Child("hardening"))...)
+	}
+
+	return allErrs
+}
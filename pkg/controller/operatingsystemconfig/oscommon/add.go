@@ -23,20 +23,31 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-// Default options
-var options = controller.Options{}
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are the options for adding the controller to the manager.
+type AddOptions struct {
+	// Controller are the controller related options.
+	Controller controller.Options
+	// UserDataSizeBudget is the maximum size of the generated user data in bytes. Zero disables
+	// the check.
+	UserDataSizeBudget int
+}
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
-func AddToManagerWithOptions(mgr manager.Manager, os string, generator generator.Generator, opts controller.Options) error {
+func AddToManagerWithOptions(mgr manager.Manager, os string, generator generator.Generator, opts AddOptions) error {
 	return operatingsystemconfig.Add(mgr, operatingsystemconfig.AddArgs{
-		Actuator:          actuator.NewActuator(os, generator),
+		Actuator:          actuator.NewActuator(os, generator, opts.UserDataSizeBudget),
 		Predicates:        operatingsystemconfig.DefaultPredicates(os),
-		ControllerOptions: opts,
+		ControllerOptions: opts.Controller,
 	})
 }
 
 // AddToManager adds a controller with the default Options.
 func AddToManager(mgr manager.Manager, os string, generator generator.Generator) error {
-	return AddToManagerWithOptions(mgr, os, generator, options)
+	return AddToManagerWithOptions(mgr, os, generator, DefaultAddOptions)
 }
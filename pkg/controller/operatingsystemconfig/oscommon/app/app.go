@@ -20,6 +20,7 @@ import (
 
 	extcontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon"
 	oscommoncmd "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cmd"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 	"github.com/gardener/gardener-extensions/pkg/util"
@@ -41,6 +42,7 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 		ctrlOpts = &controllercmd.ControllerOptions{
 			MaxConcurrentReconciles: 5,
 		}
+		userDataOpts = &oscommoncmd.UserDataOptions{}
 
 		controllerSwitches = oscommoncmd.SwitchOptions(osName, generator)
 
@@ -48,6 +50,7 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 			restOpts,
 			mgrOpts,
 			ctrlOpts,
+			userDataOpts,
 			controllerSwitches,
 		)
 	)
@@ -75,6 +78,9 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 				controllercmd.LogErrAndExit(err, "Could not update manager scheme")
 			}
 
+			ctrlOpts.Completed().Apply(&oscommon.DefaultAddOptions.Controller)
+			userDataOpts.Completed().Apply(&oscommon.DefaultAddOptions.UserDataSizeBudget)
+
 			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
 				controllercmd.LogErrAndExit(err, "Could not add controller to manager")
 			}
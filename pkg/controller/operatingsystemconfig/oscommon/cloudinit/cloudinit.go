@@ -51,6 +51,8 @@ var (
 	B64FileCodec FileCodec = b64FileCodec{}
 	// GZIPFileCodec is the gzip FileCodec.
 	GZIPFileCodec FileCodec = gzipFileCodec{}
+	// GZIPB64FileCodec is the gzip combined with base64 FileCodec.
+	GZIPB64FileCodec FileCodec = gzipB64FileCodec{}
 )
 
 type b64FileCodec struct{}
@@ -92,6 +94,24 @@ func (gzipFileCodec) Decode(data []byte) ([]byte, error) {
 	return ioutil.ReadAll(r)
 }
 
+type gzipB64FileCodec struct{}
+
+func (gzipB64FileCodec) Encode(data []byte) ([]byte, error) {
+	zipped, err := GZIPFileCodec.Encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return B64FileCodec.Encode(zipped)
+}
+
+func (gzipB64FileCodec) Decode(data []byte) ([]byte, error) {
+	zipped, err := B64FileCodec.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return GZIPFileCodec.Decode(zipped)
+}
+
 // ParseFileCodecID tries to parse a string into a FileCodecID.
 func ParseFileCodecID(s string) (FileCodecID, error) {
 	id := FileCodecID(s)
@@ -102,8 +122,9 @@ func ParseFileCodecID(s string) (FileCodecID, error) {
 }
 
 var fileCodecIDToFileCodec = map[FileCodecID]FileCodec{
-	B64FileCodecID:  B64FileCodec,
-	GZIPFileCodecID: GZIPFileCodec,
+	B64FileCodecID:     B64FileCodec,
+	GZIPFileCodecID:    GZIPFileCodec,
+	GZIPB64FileCodecID: GZIPB64FileCodec,
 }
 
 // FileCodecForID retrieves the FileCodec for the given FileCodecID.
@@ -0,0 +1,133 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinit
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// CloudConfigHeader is the header every cloud-init cloud config starts with.
+const CloudConfigHeader = "#cloud-config"
+
+// validWriteFilesEncodings are the encodings cloud-init supports for `write_files` entries.
+var validWriteFilesEncodings = []string{"", "b64", "base64", "gz", "gzip", "gz+b64", "gz+base64", "gzip+b64", "gzip+base64", "text/plain"}
+
+// ShellScriptHeader is the header every shell script user data starts with.
+const ShellScriptHeader = "#!"
+
+// cloudConfig contains the cloud-init modules the generators use. Unknown keys are rejected to
+// catch typos in module names, so a module has to be added here before a template uses it.
+type cloudConfig struct {
+	PackageUpdate bool          `yaml:"package_update"`
+	Packages      []string      `yaml:"packages"`
+	WriteFiles    []writeFile   `yaml:"write_files"`
+	RunCmd        []interface{} `yaml:"runcmd"`
+	BootCmd       []interface{} `yaml:"bootcmd"`
+}
+
+type writeFile struct {
+	Path        string `yaml:"path"`
+	Content     string `yaml:"content"`
+	Encoding    string `yaml:"encoding"`
+	Owner       string `yaml:"owner"`
+	Permissions string `yaml:"permissions"`
+	Append      bool   `yaml:"append"`
+}
+
+// ValidateUserData validates the given generated user data. Shell scripts are not validated, all
+// other user data must be a valid cloud config.
+func ValidateUserData(data []byte) error {
+	if bytes.HasPrefix(data, []byte(ShellScriptHeader)) {
+		return nil
+	}
+	return ValidateCloudConfig(data)
+}
+
+// ValidateCloudConfig validates that the given data is a cloud config, i.e. that it starts with
+// the cloud config header, and validates it against the schema of the cloud-init modules it uses.
+func ValidateCloudConfig(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(CloudConfigHeader)) {
+		return fmt.Errorf("cloud config must start with %q", CloudConfigHeader)
+	}
+
+	config := &cloudConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return fmt.Errorf("could not decode cloud config: %v", err)
+	}
+
+	allErrs := field.ErrorList{}
+
+	for i, file := range config.WriteFiles {
+		idxPath := field.NewPath("write_files").Index(i)
+
+		if len(file.Path) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("path"), "path must not be empty"))
+		} else if !path.IsAbs(file.Path) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), file.Path, "path must be absolute"))
+		}
+
+		if !isValidWriteFilesEncoding(file.Encoding) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("encoding"), file.Encoding, validWriteFilesEncodings))
+		}
+
+		if len(file.Permissions) > 0 {
+			if _, err := strconv.ParseUint(file.Permissions, 8, 32); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("permissions"), file.Permissions, "permissions must be an octal number"))
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validateCommands(field.NewPath("runcmd"), config.RunCmd)...)
+	allErrs = append(allErrs, validateCommands(field.NewPath("bootcmd"), config.BootCmd)...)
+
+	return allErrs.ToAggregate()
+}
+
+// validateCommands validates that every command is either a string or a list of strings.
+func validateCommands(fldPath *field.Path, commands []interface{}) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, command := range commands {
+		switch cmd := command.(type) {
+		case string:
+		case []interface{}:
+			for j, arg := range cmd {
+				if _, ok := arg.(string); !ok {
+					allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Index(j), arg, "argument must be a string"))
+				}
+			}
+		default:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), command, "command must be a string or a list of strings"))
+		}
+	}
+
+	return allErrs
+}
+
+func isValidWriteFilesEncoding(encoding string) bool {
+	for _, valid := range validWriteFilesEncodings {
+		if encoding == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudinit_test
+
+import (
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validation", func() {
+	const validCloudConfig = `#cloud-config
+write_files:
+- path: /var/lib/foo
+  permissions: '0644'
+  content: |
+    foo
+runcmd:
+- /var/lib/foo
+- [systemctl, restart, foo]
+`
+
+	Describe("#ValidateCloudConfig", func() {
+		It("should accept a valid cloud config", func() {
+			Expect(ValidateCloudConfig([]byte(validCloudConfig))).To(Succeed())
+		})
+
+		DescribeTable("should reject invalid cloud configs",
+			func(data string) {
+				Expect(ValidateCloudConfig([]byte(data))).NotTo(Succeed())
+			},
+
+			Entry("missing header", "write_files: []\n"),
+			Entry("shell script", "#!/bin/bash\necho foo\n"),
+			Entry("unknown module", "#cloud-config\nwrite_file: []\n"),
+			Entry("unknown write_files key", "#cloud-config\nwrite_files:\n- path: /foo\n  permission: '0644'\n"),
+			Entry("relative path", "#cloud-config\nwrite_files:\n- path: foo\n"),
+			Entry("invalid encoding", "#cloud-config\nwrite_files:\n- path: /foo\n  encoding: foo\n"),
+			Entry("invalid permissions", "#cloud-config\nwrite_files:\n- path: /foo\n  permissions: '0999'\n"),
+			Entry("invalid command", "#cloud-config\nruncmd:\n- foo: bar\n"),
+		)
+	})
+
+	Describe("#ValidateUserData", func() {
+		It("should accept shell scripts", func() {
+			Expect(ValidateUserData([]byte("#!/bin/bash\necho foo\n"))).To(Succeed())
+		})
+
+		It("should validate cloud configs", func() {
+			Expect(ValidateUserData([]byte(validCloudConfig))).To(Succeed())
+			Expect(ValidateUserData([]byte("write_files: []\n"))).NotTo(Succeed())
+		})
+	})
+})
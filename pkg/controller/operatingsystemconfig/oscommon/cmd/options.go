@@ -15,13 +15,27 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/gardener/gardener-extensions/pkg/controller/cmd"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+const (
+	// UserDataProviderFlag is the name of the command line flag to specify the infrastructure
+	// provider whose user data size budget is enforced.
+	UserDataProviderFlag = "user-data-provider"
+	// UserDataSizeBudgetFlag is the name of the command line flag to specify the user data size
+	// budget in bytes. It takes precedence over the budget of the provider.
+	UserDataSizeBudgetFlag = "user-data-size-budget"
+)
+
 // SwitchOptions are the cmd.SwitchOptions for the provider controllers.
 func SwitchOptions(os string, generator generator.Generator) *cmd.SwitchOptions {
 	return cmd.NewSwitchOptions(
@@ -30,3 +44,54 @@ func SwitchOptions(os string, generator generator.Generator) *cmd.SwitchOptions
 		}),
 	)
 }
+
+// UserDataOptions are command line options for the size budget of the generated user data.
+type UserDataOptions struct {
+	// Provider is the infrastructure provider whose user data size budget is enforced.
+	Provider string
+	// SizeBudget is the user data size budget in bytes.
+	SizeBudget int
+
+	config *UserDataConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (u *UserDataOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&u.Provider, UserDataProviderFlag, u.Provider, "The infrastructure provider whose user data size budget is enforced.")
+	fs.IntVar(&u.SizeBudget, UserDataSizeBudgetFlag, u.SizeBudget, "The user data size budget in bytes. Takes precedence over the budget of the provider.")
+}
+
+// Complete implements Completer.Complete.
+func (u *UserDataOptions) Complete() error {
+	if u.SizeBudget < 0 {
+		return fmt.Errorf("user data size budget must not be negative")
+	}
+
+	sizeBudget := u.SizeBudget
+	if sizeBudget == 0 && len(u.Provider) > 0 {
+		budget, ok := content.UserDataSizeBudgets[u.Provider]
+		if !ok {
+			return fmt.Errorf("unknown user data provider %q", u.Provider)
+		}
+		sizeBudget = budget
+	}
+
+	u.config = &UserDataConfig{sizeBudget}
+	return nil
+}
+
+// Completed returns the completed UserDataConfig. Only call this if `Complete` was successful.
+func (u *UserDataOptions) Completed() *UserDataConfig {
+	return u.config
+}
+
+// UserDataConfig is a completed user data configuration.
+type UserDataConfig struct {
+	// SizeBudget is the user data size budget in bytes. Zero disables the check.
+	SizeBudget int
+}
+
+// Apply sets the user data size budget of this UserDataConfig in the given budget.
+func (u *UserDataConfig) Apply(sizeBudget *int) {
+	*sizeBudget = u.SizeBudget
+}
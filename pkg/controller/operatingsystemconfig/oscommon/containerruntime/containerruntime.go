@@ -0,0 +1,81 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerruntime
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ContainerRuntime is a container runtime of the machines. It is selected in the provider config of
+// the OperatingSystemConfig, which is read by the operating system extensions as well as by the
+// controlplane webhooks that mutate the kubelet unit.
+// TODO: Take the container runtime from the worker pool as soon as the Shoot resource offers it.
+type ContainerRuntime string
+
+const (
+	// Docker is the docker container runtime. It is the default.
+	Docker ContainerRuntime = "docker"
+	// Containerd is the containerd container runtime. The kubelet talks to it via CRI.
+	Containerd ContainerRuntime = "containerd"
+)
+
+const (
+	// ContainerdUnitName is the name of the containerd unit.
+	ContainerdUnitName = "containerd.service"
+	// ContainerdDropInName is the name of the drop-in of the containerd unit that points it to the
+	// configuration at ContainerdConfigPath.
+	ContainerdDropInName = "10-cri.conf"
+	// ContainerdConfigPath is the path of the containerd configuration.
+	ContainerdConfigPath = "/etc/containerd/config.toml"
+	// ContainerdEndpoint is the CRI endpoint of containerd.
+	ContainerdEndpoint = "unix:///run/containerd/containerd.sock"
+	// ContainerdNamespace is the containerd namespace of the images and containers of the CRI plugin.
+	ContainerdNamespace = "k8s.io"
+	// CtrPath is the path of the containerd CLI. The operating systems link it to this path if it is
+	// installed elsewhere.
+	CtrPath = "/bin/ctr"
+)
+
+// ContainerdConfig is the containerd configuration that enables the CRI plugin with the CNI
+// locations of the kubelet.
+const ContainerdConfig = `# containerd configuration with the CRI plugin for the kubelet
+[plugins]
+  [plugins.cri]
+    [plugins.cri.containerd]
+      snapshotter = "overlayfs"
+    [plugins.cri.cni]
+      bin_dir = "/opt/cni/bin"
+      conf_dir = "/etc/cni/net.d"
+`
+
+// ContainerdDropIn is the drop-in of the containerd unit. Container Linux reads the configuration
+// from the CONTAINERD_CONFIG variable, other distributions use ContainerdConfigPath by default. As
+// it refers to the configuration, containerd is restarted whenever the configuration changes.
+const ContainerdDropIn = `[Service]
+Environment=CONTAINERD_CONFIG=` + ContainerdConfigPath + `
+`
+
+// ValidateContainerRuntime validates the given container runtime.
+func ValidateContainerRuntime(runtime ContainerRuntime, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch runtime {
+	case Docker, Containerd:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, runtime, []string{string(Docker), string(Containerd)}))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerruntime_test
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type recordingGenerator struct {
+	config *generator.OperatingSystemConfig
+}
+
+func (g *recordingGenerator) Generate(config *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	g.config = config
+	return []byte("#!/bin/bash"), nil, nil
+}
+
+var _ = Describe("ContainerRuntime", func() {
+	var config *extensionsv1alpha1.OperatingSystemConfig
+
+	BeforeEach(func() {
+		config = &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "shoot--foo--bar"},
+		}
+	})
+
+	Describe("#ValidateContainerRuntime", func() {
+		DescribeTable("should validate the container runtime",
+			func(runtime ContainerRuntime, matcher types.GomegaMatcher) {
+				Expect(ValidateContainerRuntime(runtime, field.NewPath("containerRuntime"))).To(matcher)
+			},
+			Entry("docker", Docker, BeEmpty()),
+			Entry("containerd", Containerd, BeEmpty()),
+			Entry("unsupported container runtime", ContainerRuntime("rkt"), ConsistOf(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("containerRuntime"),
+			})))),
+		)
+	})
+
+	Describe("provider config", func() {
+		DescribeTable("should return the selected container runtime",
+			func(annotations map[string]string, expected ContainerRuntime) {
+				config.Annotations = annotations
+
+				providerConfig, err := actuator.ProviderConfigFromOperatingSystemConfig(config)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(providerConfig.ContainerRuntime).To(Equal(expected))
+			},
+			Entry("docker by default", nil, Docker),
+			Entry("docker by default with other settings", map[string]string{actuator.AnnotationProviderConfig: `{"hardening":{}}`}, Docker),
+			Entry("docker", map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"docker"}`}, Docker),
+			Entry("containerd", map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"containerd"}`}, Containerd),
+		)
+
+		It("should fail for an unsupported container runtime", func() {
+			config.Annotations = map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"rkt"}`}
+
+			_, err := actuator.ProviderConfigFromOperatingSystemConfig(config)
+			Expect(err).To(MatchError(ContainSubstring("containerRuntime")))
+		})
+	})
+
+	Describe("common actuator", func() {
+		It("should configure containerd", func() {
+			content := "[Unit]\nDescription=containerd\n"
+			config.Annotations = map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"containerd"}`}
+			config.Spec.Units = []extensionsv1alpha1.Unit{{Name: ContainerdUnitName, Content: &content}}
+
+			g := &recordingGenerator{}
+			_, _, err := actuator.CloudConfigFromOperatingSystemConfig(context.TODO(), fake.NewFakeClient(), config, g)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.config.ContainerRuntime).To(Equal(Containerd))
+			Expect(g.config.Files).To(ConsistOf(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Path":    Equal(ContainerdConfigPath),
+				"Content": Equal([]byte(ContainerdConfig)),
+			}))))
+			Expect(g.config.Units).To(ConsistOf(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Name":    Equal(ContainerdUnitName),
+				"Content": Equal([]byte(content)),
+				"DropIns": ConsistOf(&generator.DropIn{Name: ContainerdDropInName, Content: []byte(ContainerdDropIn)}),
+			}))))
+		})
+
+		It("should adapt the kubelet unit to containerd", func() {
+			config.Annotations = map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"containerd"}`}
+			config.Spec.Units = []extensionsv1alpha1.Unit{{Name: KubeletUnitName, Content: &gardenerKubeletServiceContent}}
+
+			g := &recordingGenerator{}
+			_, _, err := actuator.CloudConfigFromOperatingSystemConfig(context.TODO(), fake.NewFakeClient(), config, g)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.config.Units).To(ContainElement(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"Name":    Equal(KubeletUnitName),
+				"Content": Equal([]byte(containerdKubeletServiceContent)),
+			}))))
+		})
+
+		It("should fail for a kubelet unit that cannot be adapted to containerd", func() {
+			content := "[Service]\nExecStartPre=/bin/docker run --env FOO=bar busybox true\n"
+			config.Annotations = map[string]string{actuator.AnnotationProviderConfig: `{"containerRuntime":"containerd"}`}
+			config.Spec.Units = []extensionsv1alpha1.Unit{{Name: KubeletUnitName, Content: &content}}
+
+			_, _, err := actuator.CloudConfigFromOperatingSystemConfig(context.TODO(), fake.NewFakeClient(), config, &recordingGenerator{})
+			Expect(err).To(MatchError(ContainSubstring("unsupported flag")))
+		})
+
+		It("should not configure containerd for docker", func() {
+			g := &recordingGenerator{}
+			_, _, err := actuator.CloudConfigFromOperatingSystemConfig(context.TODO(), fake.NewFakeClient(), config, g)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(g.config.ContainerRuntime).To(Equal(Docker))
+			Expect(g.config.Files).To(BeEmpty())
+			Expect(g.config.Units).To(BeEmpty())
+		})
+	})
+})
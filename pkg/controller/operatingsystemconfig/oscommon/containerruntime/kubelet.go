@@ -0,0 +1,224 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerruntime
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/coreos/go-systemd/unit"
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+)
+
+const (
+	// KubeletUnitName is the name of the kubelet unit.
+	KubeletUnitName = "kubelet.service"
+
+	// kubeletCtrContainerID is the ID of the containers that are run by `ctr` before the kubelet is started.
+	kubeletCtrContainerID = "kubelet-exec-start-pre"
+)
+
+// UnitContent returns the given content of the unit with the given name adapted to the given container runtime.
+// Only the content of the kubelet unit is adapted, see KubeletUnitContent.
+func UnitContent(name, content string, runtime ContainerRuntime) (string, error) {
+	if name != KubeletUnitName {
+		return content, nil
+	}
+	return KubeletUnitContent(content, runtime)
+}
+
+// KubeletUnitContent returns the given content of the kubelet unit adapted to the given container runtime. For
+// containerd, the kubelet talks to containerd via CRI, the docker dependencies of the unit are replaced by
+// containerd, and its `docker run` commands (e.g., the copy of the hyperkube binary) are replaced by commands that
+// pull the image and run it with `ctr`, as docker is not installed. The content is returned unchanged for docker.
+func KubeletUnitContent(content string, runtime ContainerRuntime) (string, error) {
+	if runtime != Containerd {
+		return content, nil
+	}
+
+	opts, err := unit.Deserialize(strings.NewReader(content))
+	if err != nil {
+		return "", errors.Wrap(err, "could not deserialize kubelet unit")
+	}
+
+	var result []*unit.UnitOption
+	for _, opt := range opts {
+		switch {
+		case opt.Section == "Service" && opt.Name == "ExecStart":
+			opt.Value = ensureKubeletContainerdFlags(opt.Value)
+		case opt.Section == "Service" && opt.Name == "ExecStartPre":
+			commands, err := dockerRunToCtr(opt.Value)
+			if err != nil {
+				return "", errors.Wrap(err, "could not run kubelet unit ExecStartPre with ctr")
+			}
+			for _, command := range commands {
+				result = append(result, &unit.UnitOption{Section: opt.Section, Name: opt.Name, Value: command})
+			}
+			continue
+		case opt.Section == "Unit" && isDependencyUnitOption(opt.Name):
+			var units []string
+			for _, u := range strings.Fields(opt.Value) {
+				if u != "docker.service" && u != "docker.socket" {
+					units = append(units, u)
+				}
+			}
+			if len(units) == 0 {
+				continue
+			}
+			opt.Value = strings.Join(units, " ")
+		}
+		result = append(result, opt)
+	}
+	result = append(result,
+		&unit.UnitOption{Section: "Unit", Name: "After", Value: ContainerdUnitName},
+		&unit.UnitOption{Section: "Unit", Name: "Wants", Value: ContainerdUnitName},
+	)
+
+	data, err := ioutil.ReadAll(unit.Serialize(result))
+	if err != nil {
+		return "", errors.Wrap(err, "could not serialize kubelet unit")
+	}
+	return string(data), nil
+}
+
+var commandLineSeparator = regexp.MustCompile(`[\\\s]+`)
+
+// ensureKubeletContainerdFlags ensures that the given kubelet command line contains the flags that make the kubelet
+// talk to containerd via CRI.
+func ensureKubeletContainerdFlags(commandLine string) string {
+	command := commandLineSeparator.Split(strings.TrimSpace(commandLine), -1)
+	for _, flag := range []string{
+		"--container-runtime=remote",
+		"--container-runtime-endpoint=" + ContainerdEndpoint,
+		"--runtime-request-timeout=15m",
+	} {
+		command = ensureFlag(command, flag)
+	}
+	return command[0] + " " + strings.Join(command[1:], " \\\n    ")
+}
+
+// ensureFlag ensures that the given command contains the given `--<name>=<value>` flag, replacing other values of it.
+func ensureFlag(command []string, flag string) []string {
+	prefix := flag[:strings.Index(flag, "=")+1]
+	for i, arg := range command {
+		if strings.HasPrefix(arg, prefix) {
+			command[i] = flag
+			return command
+		}
+	}
+	return append(command, flag)
+}
+
+// isDependencyUnitOption returns true if the unit option with the given name of the `[Unit]` section
+// declares dependencies to or an ordering after other units.
+func isDependencyUnitOption(name string) bool {
+	switch name {
+	case "After", "Wants", "Requires", "Requisite", "BindsTo", "PartOf":
+		return true
+	}
+	return false
+}
+
+// dockerRunToCtr returns the commands that pull the image of the given `docker run` command and run it with `ctr` in
+// the containerd namespace of the CRI plugin. Each command is executed by systemd without a shell. Other commands
+// are returned unchanged.
+func dockerRunToCtr(command string) ([]string, error) {
+	args := strings.Fields(command)
+	if len(args) < 2 || path.Base(args[0]) != "docker" || args[1] != "run" {
+		return []string{command}, nil
+	}
+	if strings.ContainsAny(command, `"'\`) {
+		return nil, fmt.Errorf("quoted or escaped arguments are not supported in %q", command)
+	}
+
+	var (
+		flags []string
+		image string
+		i     = 2
+	)
+	for ; i < len(args) && image == ""; i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			named, err := reference.ParseNormalizedNamed(arg)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse image %q in %q", arg, command)
+			}
+			image = reference.TagNameOnly(named).String()
+			continue
+		}
+
+		name, value := arg, ""
+		if j := strings.Index(arg, "="); j >= 0 {
+			name, value = arg[:j], arg[j+1:]
+		}
+		switch name {
+		case "--rm":
+			// `ctr run` always removes the container
+		case "--privileged":
+			flags = append(flags, "--privileged")
+		case "--net", "--network":
+			if value != "host" {
+				return nil, fmt.Errorf("unsupported network %q in %q", value, command)
+			}
+			flags = append(flags, "--net-host")
+		case "-v", "--volume":
+			if value == "" {
+				if i+1 == len(args) {
+					return nil, fmt.Errorf("missing value of flag %q in %q", arg, command)
+				}
+				i++
+				value = args[i]
+			}
+			mount, err := ctrBindMount(value)
+			if err != nil {
+				return nil, err
+			}
+			flags = append(flags, mount)
+		default:
+			return nil, fmt.Errorf("unsupported flag %q in %q", arg, command)
+		}
+	}
+	if image == "" {
+		return nil, fmt.Errorf("missing image in %q", command)
+	}
+
+	ctr := []string{CtrPath, "--namespace", ContainerdNamespace}
+	pull := append(append([]string{}, ctr...), "images", "pull", image)
+	run := append(append([]string{}, ctr...), "run", "--rm")
+	run = append(append(run, flags...), image, kubeletCtrContainerID)
+	run = append(run, args[i:]...)
+	return []string{strings.Join(pull, " "), strings.Join(run, " ")}, nil
+}
+
+// ctrBindMount converts the given docker volume `<source>:<destination>[:<mode>]` into a `ctr` bind mount.
+func ctrBindMount(volume string) (string, error) {
+	parts := strings.Split(volume, ":")
+	if len(parts) < 2 || len(parts) > 3 || !path.IsAbs(parts[0]) || !path.IsAbs(parts[1]) {
+		return "", fmt.Errorf("unsupported volume %q", volume)
+	}
+
+	mode := "rw"
+	if len(parts) == 3 {
+		if parts[2] != "rw" && parts[2] != "ro" {
+			return "", fmt.Errorf("unsupported volume mode %q", parts[2])
+		}
+		mode = parts[2]
+	}
+	return fmt.Sprintf("--mount type=bind,src=%s,dst=%s,options=rbind:%s", parts[0], parts[1], mode), nil
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerruntime_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	gardenerKubeletServiceContent = `[Unit]
+Description=kubelet daemon
+Documentation=https://kubernetes.io/docs/admin/kubelet
+After=docker.service
+Wants=docker.socket rpc-statd.service
+
+[Install]
+WantedBy=multi-user.target
+
+[Service]
+Restart=always
+RestartSec=5
+EnvironmentFile=/etc/environment
+EnvironmentFile=-/var/lib/kubelet/extra_args
+ExecStartPre=/bin/docker run --rm -v /opt/bin:/opt/bin:rw k8s.gcr.io/hyperkube:v1.14.0 cp /hyperkube /opt/bin/
+ExecStartPre=/bin/sh -c 'hostnamectl set-hostname $(hostname -s)'
+ExecStart=/opt/bin/hyperkube kubelet \
+    --bootstrap-kubeconfig=/var/lib/kubelet/kubeconfig-bootstrap \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cni-bin-dir=/opt/cni/bin/ \
+    --cni-conf-dir=/etc/cni/net.d/ \
+    --kubeconfig=/var/lib/kubelet/kubeconfig-real \
+    --network-plugin=cni \
+    --v=2 $KUBELET_EXTRA_ARGS
+`
+
+	containerdKubeletServiceContent = `[Unit]
+Description=kubelet daemon
+Documentation=https://kubernetes.io/docs/admin/kubelet
+Wants=rpc-statd.service
+After=containerd.service
+Wants=containerd.service
+
+[Install]
+WantedBy=multi-user.target
+
+[Service]
+Restart=always
+RestartSec=5
+EnvironmentFile=/etc/environment
+EnvironmentFile=-/var/lib/kubelet/extra_args
+ExecStartPre=/bin/ctr --namespace k8s.io images pull k8s.gcr.io/hyperkube:v1.14.0
+ExecStartPre=/bin/ctr --namespace k8s.io run --rm --mount type=bind,src=/opt/bin,dst=/opt/bin,options=rbind:rw k8s.gcr.io/hyperkube:v1.14.0 kubelet-exec-start-pre cp /hyperkube /opt/bin/
+ExecStartPre=/bin/sh -c 'hostnamectl set-hostname $(hostname -s)'
+ExecStart=/opt/bin/hyperkube kubelet \
+    --bootstrap-kubeconfig=/var/lib/kubelet/kubeconfig-bootstrap \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cni-bin-dir=/opt/cni/bin/ \
+    --cni-conf-dir=/etc/cni/net.d/ \
+    --kubeconfig=/var/lib/kubelet/kubeconfig-real \
+    --network-plugin=cni \
+    --v=2 \
+    $KUBELET_EXTRA_ARGS \
+    --container-runtime=remote \
+    --container-runtime-endpoint=unix:///run/containerd/containerd.sock \
+    --runtime-request-timeout=15m
+`
+)
+
+const kubeletServiceContentFormat = `[Service]
+ExecStartPre=%s
+ExecStart=/opt/bin/kubelet
+`
+
+var _ = Describe("Kubelet", func() {
+	Describe("#UnitContent", func() {
+		It("should adapt the kubelet unit to containerd", func() {
+			Expect(UnitContent(KubeletUnitName, gardenerKubeletServiceContent, Containerd)).To(Equal(containerdKubeletServiceContent))
+		})
+
+		It("should not adapt other units", func() {
+			Expect(UnitContent("docker.service", gardenerKubeletServiceContent, Containerd)).To(Equal(gardenerKubeletServiceContent))
+		})
+
+		It("should not adapt the kubelet unit to docker", func() {
+			Expect(UnitContent(KubeletUnitName, gardenerKubeletServiceContent, Docker)).To(Equal(gardenerKubeletServiceContent))
+		})
+	})
+
+	Describe("#KubeletUnitContent", func() {
+		DescribeTable("should run the docker commands with ctr",
+			func(execStartPre string, expected ...string) {
+				content, err := KubeletUnitContent(kubeletUnitContent(execStartPre), Containerd)
+				Expect(err).NotTo(HaveOccurred())
+
+				var execStartPres []string
+				for _, line := range strings.Split(content, "\n") {
+					if strings.HasPrefix(line, "ExecStartPre=") {
+						execStartPres = append(execStartPres, strings.TrimPrefix(line, "ExecStartPre="))
+					}
+				}
+				Expect(execStartPres).To(Equal(expected))
+			},
+			Entry("normalized image and read-only volume",
+				"/usr/bin/docker run --volume /etc:/host/etc:ro busybox cat /host/etc/hostname",
+				"/bin/ctr --namespace k8s.io images pull docker.io/library/busybox:latest",
+				"/bin/ctr --namespace k8s.io run --rm --mount type=bind,src=/etc,dst=/host/etc,options=rbind:ro docker.io/library/busybox:latest kubelet-exec-start-pre cat /host/etc/hostname",
+			),
+			Entry("privileged host network",
+				"/bin/docker run --privileged --net=host -v=/opt/bin:/opt/bin busybox true",
+				"/bin/ctr --namespace k8s.io images pull docker.io/library/busybox:latest",
+				"/bin/ctr --namespace k8s.io run --rm --privileged --net-host --mount type=bind,src=/opt/bin,dst=/opt/bin,options=rbind:rw docker.io/library/busybox:latest kubelet-exec-start-pre true",
+			),
+			Entry("other docker command", "/bin/docker pull busybox", "/bin/docker pull busybox"),
+		)
+
+		DescribeTable("should fail for docker commands that cannot be run with ctr",
+			func(execStartPre string) {
+				_, err := KubeletUnitContent(kubeletUnitContent(execStartPre), Containerd)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("unsupported flag", "/bin/docker run --env FOO=bar busybox true"),
+			Entry("unsupported network", "/bin/docker run --net=bridge busybox true"),
+			Entry("named volume", "/bin/docker run -v bin:/opt/bin busybox true"),
+			Entry("quoted argument", "/bin/docker run busybox sh -c 'true'"),
+			Entry("missing image", "/bin/docker run --rm"),
+		)
+	})
+})
+
+func kubeletUnitContent(execStartPre string) string {
+	return fmt.Sprintf(kubeletServiceContentFormat, execStartPre)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+import (
+	"fmt"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// UserDataSizeBudgets are the maximum sizes in bytes of the user data that the infrastructure
+// providers accept for their machines.
+var UserDataSizeBudgets = map[string]int{
+	"alicloud":  16 * 1024,
+	"aws":       16 * 1024,
+	"azure":     64 * 1024,
+	"gcp":       256 * 1024,
+	"openstack": 64 * 1024,
+}
+
+// CheckUserDataSize checks that the given generated data of an OperatingSystemConfig does not
+// exceed the given budget. Only the data of the provision purpose is passed as user data to the
+// machines, hence data of other purposes is not checked. A budget of zero disables the check.
+func CheckUserDataSize(config *extensionsv1alpha1.OperatingSystemConfig, data []byte, budget int) error {
+	if budget <= 0 || config.Spec.Purpose != extensionsv1alpha1.OperatingSystemConfigPurposeProvision {
+		return nil
+	}
+
+	if len(data) > budget {
+		return fmt.Errorf("generated user data has %d bytes which exceeds the user data size budget of %d bytes, reference large files by URL instead of embedding them", len(data), budget)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+)
+
+// FetchFunction is the bash function `fetch <path> <sha256> <permissions> <url>` that downloads a
+// remote file if it is missing or if its checksum differs. The download is only moved to the path
+// if its checksum matches, otherwise the function fails. Permissions default to 0644 and are also
+// corrected on files that are up to date. Written paths are recorded in the associative array CHANGED which must be declared by the script.
+const FetchFunction = `# fetch <path> <sha256> <permissions> <url> downloads the file if it is missing or if its checksum
+# differs, verifies the checksum of the download, corrects the permissions, and records the change.
+fetch() {
+  if [[ -f "$1" ]] && [[ "$(sha256sum "$1" | cut -d ' ' -f 1)" == "$2" ]]; then
+    if [[ "$((8#$(stat -c '%a' "$1")))" != "$((8#${3:-0644}))" ]]; then
+      chmod "${3:-0644}" "$1"
+      CHANGED["$1"]=1
+    fi
+    return
+  fi
+  mkdir -p "$(dirname "$1")"
+  local tmp
+  tmp="$(mktemp "$1.XXXXXX")"
+  if ! curl -fsSL --retry 5 -o "$tmp" "$4"; then
+    rm -f "$tmp"
+    echo "could not download $4" >&2
+    return 1
+  fi
+  if [[ "$(sha256sum "$tmp" | cut -d ' ' -f 1)" != "$2" ]]; then
+    rm -f "$tmp"
+    echo "checksum of $4 does not match $2" >&2
+    return 1
+  fi
+  chmod "${3:-0644}" "$tmp"
+  mv "$tmp" "$1"
+  CHANGED["$1"]=1
+}`
+
+// HasRemoteFiles returns whether any of the given files has a remote source.
+func HasRemoteFiles(files []*generator.File) bool {
+	for _, file := range files {
+		if file.Remote != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// DownloadScript returns a bash script that downloads and verifies the given files with remote
+// sources. Other files are ignored.
+func DownloadScript(files []*generator.File) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "#!/bin/bash -eu\n\ndeclare -A CHANGED=()\n\n%s\n\n", FetchFunction)
+	for _, file := range files {
+		if file.Remote == nil {
+			continue
+		}
+
+		var permissions string
+		if file.Permissions != nil {
+			permissions = fmt.Sprintf("%04o", *file.Permissions)
+		}
+		fmt.Fprintf(&buf, "fetch '%s' '%s' '%s' '%s'\n", file.Path, file.Remote.SHA256, permissions, file.Remote.URL)
+	}
+
+	return buf.Bytes()
+}
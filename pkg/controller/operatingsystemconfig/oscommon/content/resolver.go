@@ -0,0 +1,233 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationFileSources is the annotation of an OperatingSystemConfig that contains additional
+// files as JSON list of FileSources. Their content is taken from a ConfigMap or downloaded from a
+// URL instead of being embedded into the OperatingSystemConfig.
+const AnnotationFileSources = "operatingsystemconfig.extensions.gardener.cloud/file-sources"
+
+// FileSource is a file whose content is taken from a ConfigMap or from a URL.
+type FileSource struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+	// Permissions are the permissions of the file. Defaults to 0644.
+	Permissions *int32 `json:"permissions,omitempty"`
+	// ConfigMapRef references a key of a ConfigMap in the namespace of the OperatingSystemConfig.
+	ConfigMapRef *ConfigMapRef `json:"configMapRef,omitempty"`
+	// URL references a remote file that is downloaded on the machine.
+	URL *URLSource `json:"url,omitempty"`
+}
+
+// ConfigMapRef references a key of a ConfigMap.
+type ConfigMapRef struct {
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+	// DataKey is the key in the data or binary data of the ConfigMap.
+	DataKey string `json:"dataKey"`
+}
+
+// URLSource is a remote file that is verified with its checksum.
+type URLSource struct {
+	// URL is the http(s) URL of the file.
+	URL string `json:"url"`
+	// SHA256 is the hex encoded SHA256 checksum of the file.
+	SHA256 string `json:"sha256"`
+}
+
+var sha256Regexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Resolver resolves the contents of the files of an OperatingSystemConfig.
+type Resolver struct {
+	client client.Client
+}
+
+// NewResolver creates a new Resolver that reads referenced Secrets and ConfigMaps with the given client.
+func NewResolver(c client.Client) *Resolver {
+	return &Resolver{client: c}
+}
+
+// Resolve returns the files of the given OperatingSystemConfig followed by the files of its
+// AnnotationFileSources annotation. Inline contents are decoded and Secret and ConfigMap references
+// are read, files with a URL source are returned with their RemoteSource.
+func (r *Resolver) Resolve(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) ([]*generator.File, error) {
+	files := make([]*generator.File, 0, len(config.Spec.Files))
+	for _, file := range config.Spec.Files {
+		data, err := r.ResolveFileContent(ctx, config.Namespace, &file.Content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve content of file %q", file.Path)
+		}
+
+		files = append(files, &generator.File{Path: file.Path, Content: data, Permissions: file.Permissions})
+	}
+
+	sources, err := FileSourcesFromOperatingSystemConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		file := &generator.File{Path: source.Path, Permissions: source.Permissions}
+
+		switch {
+		case source.ConfigMapRef != nil:
+			data, err := r.configMapData(ctx, config.Namespace, source.ConfigMapRef)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not resolve content of file %q", source.Path)
+			}
+			file.Content = data
+		case source.URL != nil:
+			file.Remote = &generator.RemoteSource{URL: source.URL.URL, SHA256: source.URL.SHA256}
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// ResolveFileContent returns the content for a FileContent, decoding inline data and retrieving it
+// from a Secret if necessary.
+func (r *Resolver) ResolveFileContent(ctx context.Context, namespace string, content *extensionsv1alpha1.FileContent) ([]byte, error) {
+	if inline := content.Inline; inline != nil {
+		if len(inline.Encoding) == 0 {
+			return []byte(inline.Data), nil
+		}
+		return cloudinit.Decode(inline.Encoding, []byte(inline.Data))
+	}
+
+	if ref := content.SecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+			return nil, err
+		}
+
+		data, ok := secret.Data[ref.DataKey]
+		if !ok {
+			return nil, fmt.Errorf("could not find key %q in data of secret %q", ref.DataKey, ref.Name)
+		}
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("neither inline content nor a secret reference is set")
+}
+
+func (r *Resolver) configMapData(ctx context.Context, namespace string, ref *ConfigMapRef) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+		return nil, err
+	}
+
+	if data, ok := configMap.Data[ref.DataKey]; ok {
+		return []byte(data), nil
+	}
+	if data, ok := configMap.BinaryData[ref.DataKey]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("could not find key %q in data of config map %q", ref.DataKey, ref.Name)
+}
+
+// FileSourcesFromOperatingSystemConfig decodes and validates the AnnotationFileSources annotation
+// of the given OperatingSystemConfig. It returns nil if the annotation is not set.
+func FileSourcesFromOperatingSystemConfig(config *extensionsv1alpha1.OperatingSystemConfig) ([]FileSource, error) {
+	data, ok := config.Annotations[AnnotationFileSources]
+	if !ok {
+		return nil, nil
+	}
+
+	var sources []FileSource
+	if err := json.Unmarshal([]byte(data), &sources); err != nil {
+		return nil, errors.Wrapf(err, "could not decode annotation %s", AnnotationFileSources)
+	}
+
+	if errs := validateFileSources(config, sources); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid annotation %s", AnnotationFileSources)
+	}
+	return sources, nil
+}
+
+func validateFileSources(config *extensionsv1alpha1.OperatingSystemConfig, sources []FileSource) field.ErrorList {
+	var (
+		allErrs = field.ErrorList{}
+		paths   = make(map[string]struct{}, len(config.Spec.Files)+len(sources))
+	)
+
+	for _, file := range config.Spec.Files {
+		paths[file.Path] = struct{}{}
+	}
+
+	for i, source := range sources {
+		idxPath := field.NewPath("fileSources").Index(i)
+
+		if !path.IsAbs(source.Path) || path.Clean(source.Path) != source.Path {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), source.Path, "file path must be an absolute and clean path"))
+		} else if operatingsystemconfig.ContainsUnsafeShellCharacters(source.Path) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), source.Path, "file path must not contain quotes, backslashes or control characters"))
+		} else if _, ok := paths[source.Path]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("path"), source.Path))
+		}
+		paths[source.Path] = struct{}{}
+
+		if p := source.Permissions; p != nil && (*p < 0 || *p > 07777) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("permissions"), *p, "file permissions must be between 0 and 07777"))
+		}
+
+		if (source.ConfigMapRef == nil) == (source.URL == nil) {
+			allErrs = append(allErrs, field.Invalid(idxPath, source.Path, "exactly one of configMapRef or url must be set"))
+			continue
+		}
+
+		if ref := source.ConfigMapRef; ref != nil {
+			if len(ref.Name) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("configMapRef", "name"), "config map name must not be empty"))
+			}
+			if len(ref.DataKey) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("configMapRef", "dataKey"), "data key must not be empty"))
+			}
+		}
+
+		if src := source.URL; src != nil {
+			if u, err := url.Parse(src.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 || operatingsystemconfig.ContainsUnsafeShellCharacters(src.URL) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("url", "url"), src.URL, "url must be an absolute http(s) URL without quotes, backslashes or control characters"))
+			}
+			if !sha256Regexp.MatchString(src.SHA256) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("url", "sha256"), src.SHA256, "checksum must be a hex encoded SHA256 checksum"))
+			}
+		}
+	}
+
+	return allErrs
+}
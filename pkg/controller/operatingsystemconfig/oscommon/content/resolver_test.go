@@ -0,0 +1,152 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package content_test
+
+import (
+	"context"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const toolChecksum = "fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9"
+
+var _ = Describe("Resolver", func() {
+	var (
+		ctx      = context.TODO()
+		resolver *Resolver
+		config   *extensionsv1alpha1.OperatingSystemConfig
+	)
+
+	BeforeEach(func() {
+		resolver = NewResolver(fake.NewFakeClient(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubelet", Namespace: "shoot--foo--bar"},
+				Data:       map[string][]byte{"ca.crt": []byte("certificate")},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "shoot--foo--bar"},
+				Data:       map[string]string{"health": "#!/bin/bash"},
+				BinaryData: map[string][]byte{"blob": {0x1, 0x2}},
+			},
+		))
+
+		config = &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "shoot--foo--bar"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Files: []extensionsv1alpha1.File{
+					{
+						Path: "/foo",
+						Content: extensionsv1alpha1.FileContent{
+							Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: "YmFy"},
+						},
+					},
+					{
+						Path: "/var/lib/kubelet/ca.crt",
+						Content: extensionsv1alpha1.FileContent{
+							SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "kubelet", DataKey: "ca.crt"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#Resolve", func() {
+		It("should resolve inline, secret, config map and URL sources", func() {
+			permissions := int32(0755)
+			config.Annotations = map[string]string{AnnotationFileSources: `[
+{"path":"/opt/bin/health","permissions":493,"configMapRef":{"name":"scripts","dataKey":"health"}},
+{"path":"/opt/bin/blob","configMapRef":{"name":"scripts","dataKey":"blob"}},
+{"path":"/opt/bin/tool","url":{"url":"https://example.com/tool","sha256":"` + toolChecksum + `"}}
+]`}
+
+			files, err := resolver.Resolve(ctx, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal([]*generator.File{
+				{Path: "/foo", Content: []byte("bar")},
+				{Path: "/var/lib/kubelet/ca.crt", Content: []byte("certificate")},
+				{Path: "/opt/bin/health", Content: []byte("#!/bin/bash"), Permissions: &permissions},
+				{Path: "/opt/bin/blob", Content: []byte{0x1, 0x2}},
+				{Path: "/opt/bin/tool", Remote: &generator.RemoteSource{URL: "https://example.com/tool", SHA256: toolChecksum}},
+			}))
+		})
+
+		It("should fail if a referenced key does not exist", func() {
+			config.Spec.Files[1].Content.SecretRef.DataKey = "foo"
+
+			_, err := resolver.Resolve(ctx, config)
+			Expect(err).To(HaveOccurred())
+		})
+
+		DescribeTable("should fail for invalid file sources",
+			func(annotation string) {
+				config.Annotations = map[string]string{AnnotationFileSources: annotation}
+
+				_, err := resolver.Resolve(ctx, config)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("malformed", `[`),
+			Entry("missing config map", `[{"path":"/bar","configMapRef":{"name":"foo","dataKey":"health"}}]`),
+			Entry("missing config map key", `[{"path":"/bar","configMapRef":{"name":"scripts","dataKey":"foo"}}]`),
+			Entry("relative path", `[{"path":"bar","configMapRef":{"name":"scripts","dataKey":"health"}}]`),
+			Entry("path with quote", `[{"path":"/b'ar","configMapRef":{"name":"scripts","dataKey":"health"}}]`),
+			Entry("path with control character", `[{"path":"/b\tar","configMapRef":{"name":"scripts","dataKey":"health"}}]`),
+			Entry("duplicate path", `[{"path":"/foo","configMapRef":{"name":"scripts","dataKey":"health"}}]`),
+			Entry("no source", `[{"path":"/bar"}]`),
+			Entry("both sources", `[{"path":"/bar","configMapRef":{"name":"scripts","dataKey":"health"},"url":{"url":"https://example.com/tool","sha256":"`+toolChecksum+`"}}]`),
+			Entry("non-http URL", `[{"path":"/bar","url":{"url":"file:///tool","sha256":"`+toolChecksum+`"}}]`),
+			Entry("URL with quotes", `[{"path":"/bar","url":{"url":"https://example.com/'tool'","sha256":"`+toolChecksum+`"}}]`),
+			Entry("URL with backslash", `[{"path":"/bar","url":{"url":"https://example.com/\\tool","sha256":"`+toolChecksum+`"}}]`),
+			Entry("invalid checksum", `[{"path":"/bar","url":{"url":"https://example.com/tool","sha256":"foo"}}]`),
+		)
+	})
+
+	Describe("#DownloadScript", func() {
+		It("should only fetch files with a remote source", func() {
+			permissions := int32(0755)
+			script := string(DownloadScript([]*generator.File{
+				{Path: "/foo", Content: []byte("bar")},
+				{Path: "/opt/bin/tool", Permissions: &permissions, Remote: &generator.RemoteSource{URL: "https://example.com/tool", SHA256: toolChecksum}},
+			}))
+
+			Expect(script).To(HavePrefix("#!/bin/bash -eu\n"))
+			Expect(script).To(ContainSubstring(FetchFunction))
+			Expect(script).To(HaveSuffix("\nfetch '/opt/bin/tool' '" + toolChecksum + "' '0755' 'https://example.com/tool'\n"))
+			Expect(script).NotTo(ContainSubstring("'/foo'"))
+		})
+	})
+
+	Describe("#CheckUserDataSize", func() {
+		DescribeTable("should only fail for provision data exceeding the budget",
+			func(purpose extensionsv1alpha1.OperatingSystemConfigPurpose, size, budget int, matcher OmegaMatcher) {
+				config.Spec.Purpose = purpose
+				Expect(CheckUserDataSize(config, make([]byte, size), budget)).To(matcher)
+			},
+			Entry("within budget", extensionsv1alpha1.OperatingSystemConfigPurposeProvision, 16, 16, Succeed()),
+			Entry("exceeding budget", extensionsv1alpha1.OperatingSystemConfigPurposeProvision, 17, 16, MatchError(ContainSubstring("exceeds the user data size budget of 16 bytes"))),
+			Entry("no budget", extensionsv1alpha1.OperatingSystemConfigPurposeProvision, 17, 0, Succeed()),
+			Entry("reconcile purpose", extensionsv1alpha1.OperatingSystemConfigPurposeReconcile, 17, 16, Succeed()),
+		)
+	})
+})
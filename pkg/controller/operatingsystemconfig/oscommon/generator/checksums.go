@@ -0,0 +1,69 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// UnitChecksums computes a checksum for every unit of the given OperatingSystemConfig. It covers the
+// unit itself, its drop-ins and every file that the unit or one of its drop-ins refers to by path.
+// As opposed to the checksums of the OperatingSystemConfig resource, it also covers the files, units
+// and drop-ins that are added by the actuator.
+func UnitChecksums(config *OperatingSystemConfig) map[string]string {
+	checksums := make(map[string]string, len(config.Units))
+	for _, unit := range config.Units {
+		var (
+			hash     = sha256.New()
+			contents = [][]byte{unit.Content}
+		)
+
+		fmt.Fprintf(hash, "%s\n%s\n", unit.Name, unit.Content)
+		for _, dropIn := range unit.DropIns {
+			fmt.Fprintf(hash, "%s\n%s\n", dropIn.Name, dropIn.Content)
+			contents = append(contents, dropIn.Content)
+		}
+
+		for _, file := range config.Files {
+			for _, content := range contents {
+				if bytes.Contains(content, []byte(file.Path)) {
+					fmt.Fprintf(hash, "%s=%s\n", file.Path, fileChecksum(file))
+					break
+				}
+			}
+		}
+
+		checksums[unit.Name] = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	return checksums
+}
+
+func fileChecksum(file *File) string {
+	hash := sha256.New()
+
+	if file.Permissions != nil {
+		fmt.Fprintf(hash, "%o\n", *file.Permissions)
+	}
+	if file.Remote != nil {
+		fmt.Fprintf(hash, "%s\n%s\n", file.Remote.URL, file.Remote.SHA256)
+	}
+	hash.Write(file.Content)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator_test
+
+import (
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checksums", func() {
+	var config *OperatingSystemConfig
+
+	BeforeEach(func() {
+		permissions := int32(0600)
+		config = &OperatingSystemConfig{
+			Files: []*File{
+				{Path: "/var/lib/kubelet/config", Content: []byte("config"), Permissions: &permissions},
+				{Path: "/etc/containerd/config.toml", Content: []byte("containerd")},
+			},
+			Units: []*Unit{
+				{Name: "kubelet.service", Content: []byte("ExecStart=/kubelet --config=/var/lib/kubelet/config")},
+				{Name: "containerd.service", DropIns: []*DropIn{{Name: "10-config.conf", Content: []byte("--config /etc/containerd/config.toml")}}},
+			},
+		}
+	})
+
+	Describe("#UnitChecksums", func() {
+		It("should only change the checksums of the units referring to a changed file", func() {
+			old := UnitChecksums(config)
+			Expect(old).To(HaveLen(2))
+
+			config.Files[1].Content = []byte("changed")
+			checksums := UnitChecksums(config)
+			Expect(checksums["kubelet.service"]).To(Equal(old["kubelet.service"]))
+			Expect(checksums["containerd.service"]).NotTo(Equal(old["containerd.service"]))
+		})
+
+		It("should change the checksum if only the permissions of a referenced file change", func() {
+			old := UnitChecksums(config)
+
+			permissions := int32(0644)
+			config.Files[0].Permissions = &permissions
+			Expect(UnitChecksums(config)["kubelet.service"]).NotTo(Equal(old["kubelet.service"]))
+		})
+
+		It("should change the checksum if a drop-in changes", func() {
+			old := UnitChecksums(config)
+
+			config.Units[1].DropIns[0].Content = []byte("--config /etc/containerd/config.toml --log-level debug")
+			Expect(UnitChecksums(config)["containerd.service"]).NotTo(Equal(old["containerd.service"]))
+		})
+	})
+})
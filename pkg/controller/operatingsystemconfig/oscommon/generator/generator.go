@@ -14,6 +14,10 @@
 
 package generator
 
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+)
+
 // Generator renders an OperatingSystemConfig into a
 // representation suitable for an specific OS
 // also returns the os specific command for applying this configuration
@@ -26,6 +30,15 @@ type File struct {
 	Path        string
 	Content     []byte
 	Permissions *int32
+	// Remote is the remote source of the file. If set, the file is downloaded and verified on the
+	// machine instead of being embedded, and Content is empty.
+	Remote *RemoteSource
+}
+
+// RemoteSource is a file that is downloaded from a URL and verified with its checksum.
+type RemoteSource struct {
+	URL    string
+	SHA256 string
 }
 
 // Unit is a unit to be created during the cloud init script.
@@ -47,4 +60,6 @@ type OperatingSystemConfig struct {
 	Units     []*Unit
 	Bootstrap bool
 	Path      *string
+	// ContainerRuntime is the container runtime the machines are configured for.
+	ContainerRuntime containerruntime.ContainerRuntime
 }
@@ -0,0 +1,315 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+var updateGoldens = flag.Bool("update-goldens", false, "Write the output of the generator conformance tests to their golden files instead of comparing it.")
+
+// Format is the format of the output of a generator.
+type Format string
+
+const (
+	// FormatCloudConfig is a cloud-init `#cloud-config`.
+	FormatCloudConfig Format = "cloud-config"
+	// FormatContainerLinuxCloudConfig is a Container Linux `#cloud-config`. Its schema differs from the one of
+	// cloud-init and is validated by the respective operating system extension.
+	FormatContainerLinuxCloudConfig Format = "container-linux-cloud-config"
+	// FormatShellScript is a shell script.
+	FormatShellScript Format = "shell-script"
+	// FormatIgnition is an Ignition config.
+	FormatIgnition Format = "ignition"
+)
+
+// RenderFunc renders the given OperatingSystemConfig and returns the generated data and command.
+// Referenced secrets are read with the given client.
+type RenderFunc func(ctx context.Context, c client.Client, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, error)
+
+// GeneratorRenderFunc returns a RenderFunc that renders with the given Generator.
+func GeneratorRenderFunc(g generator.Generator) RenderFunc {
+	return func(ctx context.Context, c client.Client, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, error) {
+		return actuator.CloudConfigFromOperatingSystemConfig(ctx, c, config, g)
+	}
+}
+
+// ActuatorRenderFunc returns a RenderFunc that renders with the given Actuator. The client is
+// injected into the Actuator before every rendering.
+func ActuatorRenderFunc(a operatingsystemconfig.Actuator) RenderFunc {
+	return func(ctx context.Context, c client.Client, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, error) {
+		if err := a.(inject.Client).InjectClient(c); err != nil {
+			return nil, nil, err
+		}
+
+		data, command, _, err := a.Reconcile(ctx, config)
+		return data, command, err
+	}
+}
+
+type conformanceCase struct {
+	name   string
+	config *extensionsv1alpha1.OperatingSystemConfig
+}
+
+const conformanceNamespace = "shoot--foo--bar"
+
+func conformanceCases() []conformanceCase {
+	newConfig := func(purpose extensionsv1alpha1.OperatingSystemConfigPurpose, reloadPath *string) *extensionsv1alpha1.OperatingSystemConfig {
+		var (
+			command        = "start"
+			enable         = true
+			permissions    = int32(0600)
+			kubeletContent = "[Unit]\nDescription=kubelet daemon\n\n[Service]\nExecStart=/opt/bin/kubelet --config=/var/lib/kubelet/config\n\n[Install]\nWantedBy=multi-user.target\n"
+		)
+
+		return &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: conformanceNamespace},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Purpose:              purpose,
+				ReloadConfigFilePath: reloadPath,
+				Units: []extensionsv1alpha1.Unit{
+					{
+						Name:    "kubelet.service",
+						Command: &command,
+						Enable:  &enable,
+						Content: &kubeletContent,
+					},
+					{
+						Name:    "docker.service",
+						Command: &command,
+						Enable:  &enable,
+						DropIns: []extensionsv1alpha1.DropIn{
+							{Name: "10-docker-opts.conf", Content: "[Service]\nEnvironment=DOCKER_OPTS=--log-opt=max-size=60m\n"},
+						},
+					},
+				},
+				Files: []extensionsv1alpha1.File{
+					{
+						Path:        "/var/lib/kubelet/config",
+						Permissions: &permissions,
+						Content: extensionsv1alpha1.FileContent{
+							Inline: &extensionsv1alpha1.FileContentInline{Data: "kind: KubeletConfiguration\n"},
+						},
+					},
+					{
+						Path: "/etc/sysctl.d/99-k8s-general.conf",
+						Content: extensionsv1alpha1.FileContent{
+							Inline: &extensionsv1alpha1.FileContentInline{Encoding: "b64", Data: base64.StdEncoding.EncodeToString([]byte("vm.max_map_count = 135217728\n"))},
+						},
+					},
+					{
+						Path: "/var/lib/kubelet/ca.crt",
+						Content: extensionsv1alpha1.FileContent{
+							SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "kubelet", DataKey: "ca.crt"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	reloadPath := "/var/lib/cloud-config-downloader/downloads/cloud_config"
+	return []conformanceCase{
+		{"provision", newConfig(extensionsv1alpha1.OperatingSystemConfigPurposeProvision, &reloadPath)},
+		{"reconcile", newConfig(extensionsv1alpha1.OperatingSystemConfigPurposeReconcile, &reloadPath)},
+		{"reconcile-without-reload-path", newConfig(extensionsv1alpha1.OperatingSystemConfigPurposeReconcile, nil)},
+	}
+}
+
+func conformanceClient() client.Client {
+	return fake.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "kubelet", Namespace: conformanceNamespace},
+		Data:       map[string][]byte{"ca.crt": []byte("-----BEGIN CERTIFICATE-----\n")},
+	})
+}
+
+// DescribeConformance returns a function which can be used in tests for any generator. It renders a
+// matrix of OperatingSystemConfigs covering the provision and reconcile purposes, units with
+// drop-ins, file permissions, encoded and secret file contents and reload paths with the given
+// RenderFunc. It compares every output with the golden file of the case in goldenDir and checks
+// that the output is valid in the given format and contains every unit, drop-in and file.
+// Running the tests with the `-update-goldens` flag writes the golden files instead.
+func DescribeConformance(render RenderFunc, format Format, goldenDir string) func() {
+	return func() {
+		for _, c := range conformanceCases() {
+			c := c
+
+			ginkgo.Context(c.name, func() {
+				var (
+					data    []byte
+					command *string
+				)
+
+				ginkgo.BeforeEach(func() {
+					var err error
+					data, command, err = render(context.TODO(), conformanceClient(), c.config.DeepCopy())
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				})
+
+				ginkgo.It("should match the golden file", func() {
+					goldenFile := filepath.Join(goldenDir, c.name)
+					if *updateGoldens {
+						gomega.Expect(os.MkdirAll(goldenDir, 0755)).To(gomega.Succeed())
+						gomega.Expect(ioutil.WriteFile(goldenFile, data, 0644)).To(gomega.Succeed())
+					}
+
+					expected, err := ioutil.ReadFile(goldenFile)
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Expect(string(data)).To(gomega.Equal(string(expected)))
+				})
+
+				ginkgo.It("should be valid "+string(format), func() {
+					gomega.Expect(validateFormat(format, data)).To(gomega.Succeed())
+				})
+
+				ginkgo.It("should contain every unit, drop-in and file", func() {
+					contents := decodedContents(data)
+
+					for _, unit := range c.config.Spec.Units {
+						gomega.Expect(contents).To(gomega.ContainSubstring(unit.Name))
+						if unit.Content != nil {
+							expectLines(contents, *unit.Content)
+						}
+						for _, dropIn := range unit.DropIns {
+							gomega.Expect(contents).To(gomega.ContainSubstring(dropIn.Name))
+							expectLines(contents, dropIn.Content)
+						}
+					}
+					for _, file := range c.config.Spec.Files {
+						gomega.Expect(contents).To(gomega.ContainSubstring(file.Path))
+					}
+				})
+
+				ginkgo.It("should return the reload command", func() {
+					if path := c.config.Spec.ReloadConfigFilePath; path != nil {
+						gomega.Expect(command).NotTo(gomega.BeNil())
+						gomega.Expect(*command).To(gomega.ContainSubstring(*path))
+					} else {
+						gomega.Expect(command).To(gomega.BeNil())
+					}
+				})
+			})
+		}
+	}
+}
+
+func validateFormat(format Format, data []byte) error {
+	switch format {
+	case FormatCloudConfig:
+		return cloudinit.ValidateCloudConfig(data)
+	case FormatContainerLinuxCloudConfig:
+		if !bytes.HasPrefix(data, []byte(cloudinit.CloudConfigHeader)) {
+			return fmt.Errorf("cloud config must start with %q", cloudinit.CloudConfigHeader)
+		}
+		var config map[string]interface{}
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("invalid cloud config: %v", err)
+		}
+		return nil
+	case FormatShellScript:
+		if !bytes.HasPrefix(data, []byte(cloudinit.ShellScriptHeader)) {
+			return fmt.Errorf("shell script must start with a shebang")
+		}
+		if _, err := exec.LookPath("bash"); err != nil {
+			return nil
+		}
+		cmd := exec.Command("bash", "-n")
+		cmd.Stdin = bytes.NewReader(data)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("invalid shell script: %v: %s", err, out)
+		}
+		return nil
+	case FormatIgnition:
+		var config struct {
+			Ignition struct {
+				Version string `json:"version"`
+			} `json:"ignition"`
+		}
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("invalid ignition config: %v", err)
+		}
+		if len(config.Ignition.Version) == 0 {
+			return fmt.Errorf("ignition config has no version")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// expectLines expects every non-empty line of the given content in contents. Lines are compared
+// individually since formats like YAML indent embedded contents.
+func expectLines(contents, content string) {
+	for _, line := range strings.Split(content, "\n") {
+		if len(line) > 0 {
+			gomega.ExpectWithOffset(1, contents).To(gomega.ContainSubstring(line))
+		}
+	}
+}
+
+var base64Regexp = regexp.MustCompile(`[A-Za-z0-9+/]{8,}={0,2}`)
+
+// decodedContents returns the given data followed by all base64 encoded parts of it, decoded
+// recursively, so that contents embedded by generators can be searched in plain text.
+func decodedContents(data []byte) string {
+	var (
+		contents = []string{string(data)}
+		pending  = [][]byte{data}
+	)
+
+	for depth := 0; depth < 3 && len(pending) > 0; depth++ {
+		var next [][]byte
+		for _, p := range pending {
+			for _, token := range base64Regexp.FindAll(p, -1) {
+				decoded, err := base64.StdEncoding.DecodeString(string(token))
+				if err != nil {
+					continue
+				}
+				contents = append(contents, string(decoded))
+				next = append(next, decoded)
+			}
+		}
+		pending = next
+	}
+
+	return strings.Join(contents, "\n")
+}
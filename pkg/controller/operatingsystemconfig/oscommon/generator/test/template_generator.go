@@ -15,6 +15,7 @@
 package test
 
 import (
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 	"github.com/gobuffalo/packr"
 	"github.com/onsi/ginkgo"
@@ -61,6 +62,7 @@ var DescribeTest = func(g generator.Generator, box packr.Box) func() {
 
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			gomega.Expect(cloudInit).To(gomega.Equal(expectedCloudInit))
+			gomega.Expect(cloudinit.ValidateUserData(cloudInit)).To(gomega.Succeed())
 		})
 	}
 }
@@ -0,0 +1,352 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hardening
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+)
+
+const (
+	// SysctlPath is the path of the sysctl settings of the profile.
+	SysctlPath = "/etc/sysctl.d/90-hardening.conf"
+	// KernelModuleBlacklistPath is the path of the kernel module blacklist of the profile.
+	KernelModuleBlacklistPath = "/etc/modprobe.d/90-hardening.conf"
+	// SSHDPath is the path of the SSH daemon settings of the profile. They are merged into
+	// /etc/ssh/sshd_config by the apply script since not every distribution supports includes.
+	SSHDPath = "/etc/ssh/sshd_config.hardening"
+	// AuditRulesPath is the path of the auditd rules of the profile.
+	AuditRulesPath = "/etc/audit/rules.d/90-hardening.rules"
+	// JournaldPath is the path of the journald settings of the profile.
+	JournaldPath = "/etc/systemd/journald.conf.d/90-hardening.conf"
+	// ScriptPath is the path of the script that applies the settings of the profile.
+	ScriptPath = "/opt/bin/apply-hardening"
+	// UnitName is the name of the unit that runs the apply script.
+	UnitName = "hardening.service"
+)
+
+// Profile is an OS-level hardening profile. Unset fields default to settings that follow the CIS
+// benchmarks as far as they are compatible with Kubernetes nodes.
+type Profile struct {
+	// Sysctls are kernel parameters that are merged into the defaults. An empty value removes the
+	// default parameter of the same name.
+	Sysctls map[string]string `json:"sysctls,omitempty"`
+	// BlacklistedKernelModules are the kernel modules that must not be loaded. If set, they replace
+	// the defaults, an empty list disables the blacklist.
+	BlacklistedKernelModules []string `json:"blacklistedKernelModules,omitempty"`
+	// SSHD are the settings of the SSH daemon.
+	SSHD *SSHD `json:"sshd,omitempty"`
+	// AuditRules are the auditd rules in auditctl syntax. If set, they replace the defaults, an empty
+	// list disables them. They are only loaded if auditd is available on the machine.
+	AuditRules []string `json:"auditRules,omitempty"`
+	// Journald are the storage limits of journald.
+	Journald *Journald `json:"journald,omitempty"`
+}
+
+// SSHD are the settings of the SSH daemon.
+type SSHD struct {
+	// PermitRootLogin defines whether root can log in. Defaults to `no`.
+	PermitRootLogin *string `json:"permitRootLogin,omitempty"`
+	// PasswordAuthentication defines whether password authentication is allowed. Defaults to false.
+	PasswordAuthentication *bool `json:"passwordAuthentication,omitempty"`
+	// X11Forwarding defines whether X11 forwarding is allowed. Defaults to false.
+	X11Forwarding *bool `json:"x11Forwarding,omitempty"`
+	// MaxAuthTries is the maximum number of authentication attempts per connection. Defaults to 4.
+	MaxAuthTries *int32 `json:"maxAuthTries,omitempty"`
+	// ClientAliveInterval is the idle timeout in seconds after which the client is probed.
+	// Defaults to 300.
+	ClientAliveInterval *int32 `json:"clientAliveInterval,omitempty"`
+	// ClientAliveCountMax is the number of unanswered probes after which the client is
+	// disconnected. Defaults to 3.
+	ClientAliveCountMax *int32 `json:"clientAliveCountMax,omitempty"`
+}
+
+// Journald are the storage limits of journald.
+type Journald struct {
+	// SystemMaxUse is the maximum disk space the journal may use. Defaults to `1G`.
+	SystemMaxUse *string `json:"systemMaxUse,omitempty"`
+	// SystemMaxFileSize is the maximum size of a journal file. Defaults to `100M`.
+	SystemMaxFileSize *string `json:"systemMaxFileSize,omitempty"`
+	// MaxRetentionSec is the maximum time entries are kept. Defaults to `1month`.
+	MaxRetentionSec *string `json:"maxRetentionSec,omitempty"`
+}
+
+// DefaultSysctls are the default kernel parameters. IP forwarding is not restricted as it is
+// required by Kubernetes networking.
+var DefaultSysctls = map[string]string{
+	"fs.suid_dumpable":                           "0",
+	"kernel.dmesg_restrict":                      "1",
+	"kernel.kptr_restrict":                       "1",
+	"kernel.randomize_va_space":                  "2",
+	"net.ipv4.conf.all.accept_redirects":         "0",
+	"net.ipv4.conf.all.accept_source_route":      "0",
+	"net.ipv4.conf.all.log_martians":             "1",
+	"net.ipv4.conf.all.secure_redirects":         "0",
+	"net.ipv4.conf.all.send_redirects":           "0",
+	"net.ipv4.conf.default.accept_redirects":     "0",
+	"net.ipv4.conf.default.accept_source_route":  "0",
+	"net.ipv4.conf.default.log_martians":         "1",
+	"net.ipv4.conf.default.secure_redirects":     "0",
+	"net.ipv4.conf.default.send_redirects":       "0",
+	"net.ipv4.icmp_echo_ignore_broadcasts":       "1",
+	"net.ipv4.icmp_ignore_bogus_error_responses": "1",
+	"net.ipv4.tcp_syncookies":                    "1",
+	"net.ipv6.conf.all.accept_redirects":         "0",
+	"net.ipv6.conf.default.accept_redirects":     "0",
+}
+
+// DefaultBlacklistedKernelModules are the kernel modules of uncommon network protocols that are
+// blacklisted by default.
+var DefaultBlacklistedKernelModules = []string{"dccp", "rds", "sctp", "tipc"}
+
+// DefaultAuditRules are the default auditd rules. They watch changes of identities, privileges and
+// the SSH daemon settings.
+var DefaultAuditRules = []string{
+	"-w /etc/group -p wa -k identity",
+	"-w /etc/passwd -p wa -k identity",
+	"-w /etc/shadow -p wa -k identity",
+	"-w /etc/sudoers -p wa -k scope",
+	"-w /etc/sudoers.d/ -p wa -k scope",
+	"-w /etc/ssh/sshd_config -p wa -k sshd",
+}
+
+// SetDefaults sets the defaults for the unset fields of the given profile.
+func SetDefaults(profile *Profile) {
+	sysctls := make(map[string]string, len(DefaultSysctls)+len(profile.Sysctls))
+	for key, value := range DefaultSysctls {
+		sysctls[key] = value
+	}
+	for key, value := range profile.Sysctls {
+		sysctls[key] = value
+	}
+	profile.Sysctls = sysctls
+
+	if profile.BlacklistedKernelModules == nil {
+		profile.BlacklistedKernelModules = append([]string{}, DefaultBlacklistedKernelModules...)
+	}
+	if profile.AuditRules == nil {
+		profile.AuditRules = append([]string{}, DefaultAuditRules...)
+	}
+
+	if profile.SSHD == nil {
+		profile.SSHD = &SSHD{}
+	}
+	if profile.SSHD.PermitRootLogin == nil {
+		profile.SSHD.PermitRootLogin = stringPtr("no")
+	}
+	if profile.SSHD.PasswordAuthentication == nil {
+		profile.SSHD.PasswordAuthentication = boolPtr(false)
+	}
+	if profile.SSHD.X11Forwarding == nil {
+		profile.SSHD.X11Forwarding = boolPtr(false)
+	}
+	if profile.SSHD.MaxAuthTries == nil {
+		profile.SSHD.MaxAuthTries = int32Ptr(4)
+	}
+	if profile.SSHD.ClientAliveInterval == nil {
+		profile.SSHD.ClientAliveInterval = int32Ptr(300)
+	}
+	if profile.SSHD.ClientAliveCountMax == nil {
+		profile.SSHD.ClientAliveCountMax = int32Ptr(3)
+	}
+
+	if profile.Journald == nil {
+		profile.Journald = &Journald{}
+	}
+	if profile.Journald.SystemMaxUse == nil {
+		profile.Journald.SystemMaxUse = stringPtr("1G")
+	}
+	if profile.Journald.SystemMaxFileSize == nil {
+		profile.Journald.SystemMaxFileSize = stringPtr("100M")
+	}
+	if profile.Journald.MaxRetentionSec == nil {
+		profile.Journald.MaxRetentionSec = stringPtr("1month")
+	}
+}
+
+// Render returns the files and the unit that apply the given profile. The profile is defaulted
+// before it is rendered. The unit is a oneshot unit that runs the apply script for every settings
+// file, hence it has to be restarted whenever one of the files changes.
+func Render(profile *Profile) ([]*generator.File, *generator.Unit) {
+	profile = profile.DeepCopy()
+	SetDefaults(profile)
+
+	var (
+		files      []*generator.File
+		execStarts []string
+		addFile    = func(path string, permissions int32, content []byte) {
+			files = append(files, &generator.File{Path: path, Content: content, Permissions: &permissions})
+		}
+	)
+
+	addFile(ScriptPath, 0755, []byte(applyScript))
+
+	if sysctls := renderSysctls(profile.Sysctls); len(sysctls) > 0 {
+		addFile(SysctlPath, 0644, sysctls)
+		execStarts = append(execStarts, fmt.Sprintf("ExecStart=%s sysctl %s", ScriptPath, SysctlPath))
+	}
+
+	if len(profile.BlacklistedKernelModules) > 0 {
+		var buf bytes.Buffer
+		for _, module := range profile.BlacklistedKernelModules {
+			fmt.Fprintf(&buf, "blacklist %s\ninstall %s /bin/true\n", module, module)
+		}
+		addFile(KernelModuleBlacklistPath, 0644, buf.Bytes())
+	}
+
+	addFile(SSHDPath, 0600, renderSSHD(profile.SSHD))
+	execStarts = append(execStarts, fmt.Sprintf("ExecStart=%s sshd %s", ScriptPath, SSHDPath))
+
+	if len(profile.AuditRules) > 0 {
+		addFile(AuditRulesPath, 0640, []byte(strings.Join(profile.AuditRules, "\n")+"\n"))
+		execStarts = append(execStarts, fmt.Sprintf("ExecStart=%s audit %s", ScriptPath, AuditRulesPath))
+	}
+
+	addFile(JournaldPath, 0644, []byte(fmt.Sprintf("[Journal]\nSystemMaxUse=%s\nSystemMaxFileSize=%s\nMaxRetentionSec=%s\n",
+		*profile.Journald.SystemMaxUse, *profile.Journald.SystemMaxFileSize, *profile.Journald.MaxRetentionSec)))
+	execStarts = append(execStarts, fmt.Sprintf("ExecStart=%s journald %s", ScriptPath, JournaldPath))
+
+	unit := fmt.Sprintf(`[Unit]
+Description=Apply the OS hardening profile
+After=local-fs.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+%s
+
+[Install]
+WantedBy=multi-user.target
+`, strings.Join(execStarts, "\n"))
+
+	return files, &generator.Unit{Name: UnitName, Content: []byte(unit)}
+}
+
+func renderSysctls(sysctls map[string]string) []byte {
+	keys := make([]string, 0, len(sysctls))
+	for key, value := range sysctls {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "%s = %s\n", key, sysctls[key])
+	}
+	return buf.Bytes()
+}
+
+func renderSSHD(sshd *SSHD) []byte {
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	return []byte(fmt.Sprintf("PermitRootLogin %s\nPasswordAuthentication %s\nPermitEmptyPasswords no\nX11Forwarding %s\nMaxAuthTries %d\nClientAliveInterval %d\nClientAliveCountMax %d\n",
+		*sshd.PermitRootLogin, yesNo(*sshd.PasswordAuthentication), yesNo(*sshd.X11Forwarding), *sshd.MaxAuthTries, *sshd.ClientAliveInterval, *sshd.ClientAliveCountMax))
+}
+
+// applyScript applies a settings file of the profile. The SSH daemon settings are placed in a
+// marked block at the top of /etc/ssh/sshd_config since sshd uses the first value of a setting.
+const applyScript = `#!/bin/bash -eu
+
+# apply-hardening <sysctl|sshd|audit|journald> <path> applies a settings file of the hardening profile.
+case "$1" in
+  sysctl)
+    sysctl -q -p "$2"
+    ;;
+  sshd)
+    config=/etc/ssh/sshd_config
+    updated="$(mktemp)"
+    {
+      echo "# BEGIN hardening"
+      cat "$2"
+      echo "# END hardening"
+      sed '/^# BEGIN hardening$/,/^# END hardening$/d' "$config"
+    } > "$updated"
+    if cmp -s "$updated" "$config"; then
+      rm -f "$updated"
+      exit 0
+    fi
+    if ls /etc/ssh/ssh_host_*_key &>/dev/null && ! /usr/sbin/sshd -t -f "$updated"; then
+      rm -f "$updated"
+      echo "hardened sshd config is invalid" >&2
+      exit 1
+    fi
+    chmod 0644 "$updated"
+    mv -f "$updated" "$config"
+    systemctl try-reload-or-restart sshd.service ssh.service || true
+    ;;
+  audit)
+    if command -v augenrules &>/dev/null; then
+      augenrules --load
+    elif command -v auditctl &>/dev/null; then
+      auditctl -R "$2"
+    else
+      echo "auditd is not available, audit rules are not loaded" >&2
+    fi
+    ;;
+  journald)
+    systemctl restart systemd-journald.service
+    ;;
+  *)
+    echo "unknown settings $1" >&2
+    exit 1
+    ;;
+esac
+`
+
+// DeepCopy returns a deep copy of the profile.
+func (p *Profile) DeepCopy() *Profile {
+	out := &Profile{
+		BlacklistedKernelModules: append([]string(nil), p.BlacklistedKernelModules...),
+		AuditRules:               append([]string(nil), p.AuditRules...),
+	}
+	if p.BlacklistedKernelModules != nil && out.BlacklistedKernelModules == nil {
+		out.BlacklistedKernelModules = []string{}
+	}
+	if p.AuditRules != nil && out.AuditRules == nil {
+		out.AuditRules = []string{}
+	}
+	if p.Sysctls != nil {
+		out.Sysctls = make(map[string]string, len(p.Sysctls))
+		for key, value := range p.Sysctls {
+			out.Sysctls[key] = value
+		}
+	}
+	if p.SSHD != nil {
+		sshd := *p.SSHD
+		out.SSHD = &sshd
+	}
+	if p.Journald != nil {
+		journald := *p.Journald
+		out.Journald = &journald
+	}
+	return out
+}
+
+func stringPtr(s string) *string { return &s }
+
+func boolPtr(b bool) *bool { return &b }
+
+func int32Ptr(i int32) *int32 { return &i }
@@ -0,0 +1,193 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hardening_test
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/hardening"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type recordingGenerator struct {
+	config *generator.OperatingSystemConfig
+}
+
+func (g *recordingGenerator) Generate(config *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	g.config = config
+	return []byte("#!/bin/bash"), nil, nil
+}
+
+func filesByPath(files []*generator.File) map[string]string {
+	contents := make(map[string]string, len(files))
+	for _, file := range files {
+		contents[file.Path] = string(file.Content)
+	}
+	return contents
+}
+
+var _ = Describe("Hardening", func() {
+	Describe("#SetDefaults", func() {
+		It("should merge the sysctls into the defaults and keep set fields", func() {
+			permitRootLogin := "prohibit-password"
+			profile := &Profile{
+				Sysctls:    map[string]string{"kernel.dmesg_restrict": "0", "vm.swappiness": "10"},
+				AuditRules: []string{},
+				SSHD:       &SSHD{PermitRootLogin: &permitRootLogin},
+			}
+
+			SetDefaults(profile)
+
+			Expect(profile.Sysctls).To(HaveLen(len(DefaultSysctls) + 1))
+			Expect(profile.Sysctls).To(HaveKeyWithValue("kernel.dmesg_restrict", "0"))
+			Expect(profile.Sysctls).To(HaveKeyWithValue("vm.swappiness", "10"))
+			Expect(profile.BlacklistedKernelModules).To(Equal(DefaultBlacklistedKernelModules))
+			Expect(profile.AuditRules).To(BeEmpty())
+			Expect(profile.SSHD.PermitRootLogin).To(gstruct.PointTo(Equal("prohibit-password")))
+			Expect(profile.SSHD.PasswordAuthentication).To(gstruct.PointTo(BeFalse()))
+			Expect(profile.Journald.SystemMaxUse).To(gstruct.PointTo(Equal("1G")))
+		})
+	})
+
+	Describe("#Render", func() {
+		It("should render the defaults", func() {
+			files, unit := Render(&Profile{})
+
+			contents := filesByPath(files)
+			Expect(contents).To(HaveKey(ScriptPath))
+			Expect(contents).To(HaveKeyWithValue(SysctlPath, ContainSubstring("net.ipv4.tcp_syncookies = 1\n")))
+			Expect(contents).To(HaveKeyWithValue(KernelModuleBlacklistPath, ContainSubstring("install sctp /bin/true\n")))
+			Expect(contents).To(HaveKeyWithValue(SSHDPath, ContainSubstring("PermitRootLogin no\n")))
+			Expect(contents).To(HaveKeyWithValue(AuditRulesPath, ContainSubstring("-w /etc/passwd -p wa -k identity\n")))
+			Expect(contents).To(HaveKeyWithValue(JournaldPath, "[Journal]\nSystemMaxUse=1G\nSystemMaxFileSize=100M\nMaxRetentionSec=1month\n"))
+
+			Expect(unit.Name).To(Equal(UnitName))
+			for _, path := range []string{ScriptPath, SysctlPath, SSHDPath, AuditRulesPath, JournaldPath} {
+				Expect(string(unit.Content)).To(ContainSubstring(path))
+			}
+		})
+
+		It("should omit disabled settings and removed sysctls", func() {
+			sysctls := make(map[string]string, len(DefaultSysctls))
+			for key := range DefaultSysctls {
+				sysctls[key] = ""
+			}
+			profile := &Profile{Sysctls: sysctls, BlacklistedKernelModules: []string{}, AuditRules: []string{}}
+
+			files, unit := Render(profile)
+
+			contents := filesByPath(files)
+			Expect(contents).NotTo(HaveKey(SysctlPath))
+			Expect(contents).NotTo(HaveKey(KernelModuleBlacklistPath))
+			Expect(contents).NotTo(HaveKey(AuditRulesPath))
+			Expect(string(unit.Content)).NotTo(ContainSubstring(SysctlPath))
+			Expect(string(unit.Content)).NotTo(ContainSubstring(AuditRulesPath))
+			Expect(profile.SSHD).To(BeNil(), "the given profile must not be defaulted")
+		})
+
+		It("should render a valid apply script", func() {
+			if _, err := exec.LookPath("bash"); err != nil {
+				Skip("bash is not available")
+			}
+
+			files, _ := Render(&Profile{})
+
+			cmd := exec.Command("bash", "-n")
+			cmd.Stdin = strings.NewReader(filesByPath(files)[ScriptPath])
+			Expect(cmd.CombinedOutput()).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateProfile", func() {
+		DescribeTable("should validate the profile",
+			func(profile *Profile, matcher OmegaMatcher) {
+				Expect(ValidateProfile(profile, field.NewPath("hardening"))).To(matcher)
+			},
+			Entry("empty profile", &Profile{}, BeEmpty()),
+			Entry("invalid sysctl", &Profile{Sysctls: map[string]string{"kernel.panic = 1\nfoo": "1"}}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Type": Equal(field.ErrorTypeInvalid)})),
+			)),
+			Entry("sysctl value with line break", &Profile{Sysctls: map[string]string{"kernel.panic": "1\nfoo"}}, HaveLen(1)),
+			Entry("invalid kernel module", &Profile{BlacklistedKernelModules: []string{"sctp /bin/true"}}, HaveLen(1)),
+			Entry("unsupported root login", &Profile{SSHD: &SSHD{PermitRootLogin: stringPtr("maybe")}}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("hardening.sshd.permitRootLogin"),
+				})),
+			)),
+			Entry("no auth tries", &Profile{SSHD: &SSHD{MaxAuthTries: int32Ptr(0)}}, HaveLen(1)),
+			Entry("invalid audit rule", &Profile{AuditRules: []string{"w /etc/passwd"}}, HaveLen(1)),
+			Entry("invalid journal size", &Profile{Journald: &Journald{SystemMaxUse: stringPtr("1 GB")}}, HaveLen(1)),
+			Entry("invalid retention", &Profile{Journald: &Journald{MaxRetentionSec: stringPtr("-1")}}, HaveLen(1)),
+		)
+	})
+
+	Describe("common actuator", func() {
+		var (
+			ctx    = context.TODO()
+			config *extensionsv1alpha1.OperatingSystemConfig
+		)
+
+		BeforeEach(func() {
+			config = &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pool",
+					Namespace:   "shoot--foo--bar",
+					Annotations: map[string]string{actuator.AnnotationProviderConfig: `{"hardening":{}}`},
+				},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+				},
+			}
+		})
+
+		DescribeTable("should only harden reconciled machines with a profile",
+			func(mutate func(), matcher OmegaMatcher) {
+				mutate()
+
+				g := &recordingGenerator{}
+				_, _, err := actuator.CloudConfigFromOperatingSystemConfig(ctx, fake.NewFakeClient(), config, g)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(g.config.Units).To(matcher)
+			},
+			Entry("reconcile", func() {}, ConsistOf(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(UnitName)})))),
+			Entry("provision", func() { config.Spec.Purpose = extensionsv1alpha1.OperatingSystemConfigPurposeProvision }, BeEmpty()),
+			Entry("no profile", func() { config.Annotations = nil }, BeEmpty()),
+		)
+
+		It("should fail for an invalid profile", func() {
+			config.Annotations[actuator.AnnotationProviderConfig] = `{"hardening":{"blacklistedKernelModules":["a b"]}}`
+
+			_, _, err := actuator.CloudConfigFromOperatingSystemConfig(ctx, fake.NewFakeClient(), config, &recordingGenerator{})
+			Expect(err).To(MatchError(ContainSubstring("invalid provider config")))
+		})
+	})
+})
+
+func stringPtr(s string) *string { return &s }
+
+func int32Ptr(i int32) *int32 { return &i }
@@ -0,0 +1,97 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hardening
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	sysctlKeyRegexp    = regexp.MustCompile(`^[a-z0-9_]+([./][a-zA-Z0-9_-]+)+$`)
+	kernelModuleRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	sizeRegexp         = regexp.MustCompile(`^[0-9]+[KMGTPE]?$`)
+	timeSpanRegexp     = regexp.MustCompile(`^[0-9]+[a-z]*$`)
+
+	permitRootLoginValues = []string{"yes", "no", "prohibit-password", "without-password", "forced-commands-only"}
+)
+
+// ValidateProfile validates the given hardening profile.
+func ValidateProfile(profile *Profile, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for key, value := range profile.Sysctls {
+		if !sysctlKeyRegexp.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sysctls").Key(key), key, "must be a valid kernel parameter name"))
+		}
+		if strings.ContainsAny(value, "\n\r") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("sysctls").Key(key), value, "must not contain line breaks"))
+		}
+	}
+
+	for i, module := range profile.BlacklistedKernelModules {
+		if !kernelModuleRegexp.MatchString(module) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("blacklistedKernelModules").Index(i), module, "must be a valid kernel module name"))
+		}
+	}
+
+	if sshd := profile.SSHD; sshd != nil {
+		sshdPath := fldPath.Child("sshd")
+		if sshd.PermitRootLogin != nil && !contains(permitRootLoginValues, *sshd.PermitRootLogin) {
+			allErrs = append(allErrs, field.NotSupported(sshdPath.Child("permitRootLogin"), *sshd.PermitRootLogin, permitRootLoginValues))
+		}
+		if sshd.MaxAuthTries != nil && *sshd.MaxAuthTries < 1 {
+			allErrs = append(allErrs, field.Invalid(sshdPath.Child("maxAuthTries"), *sshd.MaxAuthTries, "must be at least 1"))
+		}
+		if sshd.ClientAliveInterval != nil && *sshd.ClientAliveInterval < 0 {
+			allErrs = append(allErrs, field.Invalid(sshdPath.Child("clientAliveInterval"), *sshd.ClientAliveInterval, "must not be negative"))
+		}
+		if sshd.ClientAliveCountMax != nil && *sshd.ClientAliveCountMax < 0 {
+			allErrs = append(allErrs, field.Invalid(sshdPath.Child("clientAliveCountMax"), *sshd.ClientAliveCountMax, "must not be negative"))
+		}
+	}
+
+	for i, rule := range profile.AuditRules {
+		if !strings.HasPrefix(rule, "-") || strings.ContainsAny(rule, "\n\r") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("auditRules").Index(i), rule, "must be a single auditctl rule"))
+		}
+	}
+
+	if journald := profile.Journald; journald != nil {
+		journaldPath := fldPath.Child("journald")
+		if journald.SystemMaxUse != nil && !sizeRegexp.MatchString(*journald.SystemMaxUse) {
+			allErrs = append(allErrs, field.Invalid(journaldPath.Child("systemMaxUse"), *journald.SystemMaxUse, "must be a size like 1G"))
+		}
+		if journald.SystemMaxFileSize != nil && !sizeRegexp.MatchString(*journald.SystemMaxFileSize) {
+			allErrs = append(allErrs, field.Invalid(journaldPath.Child("systemMaxFileSize"), *journald.SystemMaxFileSize, "must be a size like 100M"))
+		}
+		if journald.MaxRetentionSec != nil && !timeSpanRegexp.MatchString(*journald.MaxRetentionSec) {
+			allErrs = append(allErrs, field.Invalid(journaldPath.Child("maxRetentionSec"), *journald.MaxRetentionSec, "must be a time span like 1month"))
+		}
+	}
+
+	return allErrs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
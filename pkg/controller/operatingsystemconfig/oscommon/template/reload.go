@@ -0,0 +1,124 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+)
+
+// ReloadScriptPath is the path templates write the reload script to. Templates refer to it as
+// `.ReloadScriptPath` and to the script as `.ReloadScript`.
+const ReloadScriptPath = "/var/lib/osc/reload-config"
+
+// reloadScriptTemplate renders the reload script. The script writes only those files, units and
+// drop-ins whose checksum differs from the one of the file on disk; remote files are downloaded and
+// verified. It (re)starts a unit only if its unit file, one of its drop-ins or one of the files it
+// refers to has been written, or if it is not active.
+var reloadScriptTemplate = template.Must(template.New("reload-config").Parse(`#!/bin/bash -eu
+
+declare -A CHANGED=()
+
+# apply <path> <checksum> <permissions> <base64 content> writes the file if it is missing or if
+# its checksum differs, corrects its permissions if they differ, and records the change.
+apply() {
+  if [[ ! -f "$1" ]] || [[ "$(sha256sum "$1" | cut -d ' ' -f 1)" != "$2" ]]; then
+    mkdir -p "$(dirname "$1")"
+    echo "$4" | base64 -d > "$1"
+    CHANGED["$1"]=1
+  fi
+  if [[ -n "$3" ]] && [[ "$((8#$(stat -c '%a' "$1")))" != "$((8#$3))" ]]; then
+    chmod "$3" "$1"
+    CHANGED["$1"]=1
+  fi
+}
+{{ if .HasRemoteFiles }}
+{{ .FetchFunction }}
+{{ end }}
+# changed <path>... returns whether any of the given paths has been written.
+changed() {
+  for path in "$@"; do
+    if [[ -n "${CHANGED[$path]:-}" ]]; then
+      return 0
+    fi
+  done
+  return 1
+}
+
+{{ range $_, $file := .Files -}}
+{{ if $file.URL -}}
+fetch '{{ $file.Path }}' '{{ $file.Checksum }}' '{{ if $file.Permissions }}{{ $file.Permissions }}{{ end }}' '{{ $file.URL }}'
+{{ else -}}
+apply '{{ $file.Path }}' '{{ $file.Checksum }}' '{{ if $file.Permissions }}{{ $file.Permissions }}{{ end }}' '{{ $file.Content }}'
+{{ end -}}
+{{ end -}}
+{{ range $_, $unit := .Units -}}
+{{ if $unit.Content -}}
+apply '{{ $unit.Path }}' '{{ $unit.Checksum }}' '' '{{ $unit.Content }}'
+{{ end -}}
+{{ if $unit.DropIns -}}
+{{ range $_, $dropIn := $unit.DropIns.Items -}}
+apply '{{ $dropIn.Path }}' '{{ $dropIn.Checksum }}' '' '{{ $dropIn.Content }}'
+{{ end -}}
+{{ end -}}
+{{ end }}
+if [[ ${#CHANGED[@]} -gt 0 ]]; then
+  systemctl daemon-reload
+fi
+{{ range $_, $unit := .Units }}
+if changed{{ range $_, $path := $unit.Triggers }} '{{ $path }}'{{ end }} || ! systemctl is-active --quiet '{{ $unit.Name }}'; then
+  systemctl enable '{{ $unit.Name }}' && systemctl restart '{{ $unit.Name }}'
+fi
+{{- end }}
+`))
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// unitTriggers returns the paths whose change requires a restart of the given unit, i.e., the path
+// of the unit itself, of its drop-ins and of every file the unit or one of its drop-ins refers to.
+func unitTriggers(unitsPath string, unit *generator.Unit, files []*generator.File) []string {
+	var (
+		triggers []string
+		contents = []string{string(unit.Content)}
+	)
+
+	if unit.Content != nil {
+		triggers = append(triggers, path.Join(unitsPath, unit.Name))
+	}
+	for _, dropIn := range unit.DropIns {
+		triggers = append(triggers, path.Join(unitsPath, fmt.Sprintf("%s.d", unit.Name), dropIn.Name))
+		contents = append(contents, string(dropIn.Content))
+	}
+
+	for _, file := range files {
+		for _, content := range contents {
+			if strings.Contains(content, file.Path) {
+				triggers = append(triggers, file.Path)
+				break
+			}
+		}
+	}
+
+	return triggers
+}
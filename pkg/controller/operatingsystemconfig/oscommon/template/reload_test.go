@@ -0,0 +1,218 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Reload script", func() {
+	It("should only write and restart what changed", func() {
+		g := NewCloudInitGenerator(template.Must(template.New("reload").Parse("{{ .ReloadScriptPath }}\n{{ .ReloadScript }}")), DefaultUnitsPath, "%s")
+
+		permissions := int32(0600)
+		out, _, err := g.Generate(&generator.OperatingSystemConfig{
+			Files: []*generator.File{
+				{Path: "/var/lib/kubelet/config", Content: []byte("config"), Permissions: &permissions},
+				{Path: "/foo", Content: []byte("bar")},
+			},
+			Units: []*generator.Unit{
+				{Name: "kubelet.service", Content: []byte("ExecStart=/kubelet --config=/var/lib/kubelet/config")},
+				{Name: "docker.service", DropIns: []*generator.DropIn{{Name: "10-docker-opts.conf", Content: []byte("override")}}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var (
+			path   = string(out[:len(ReloadScriptPath)])
+			script = string(out[len(ReloadScriptPath)+1:])
+		)
+		Expect(path).To(Equal(ReloadScriptPath))
+		Expect(script).To(HaveSuffix(`
+apply '/var/lib/kubelet/config' 'b79606fb3afea5bd1609ed40b622142f1c98125abcfe89a76a661b0e8e343910' '0600' 'Y29uZmln'
+apply '/foo' 'fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9' '' 'YmFy'
+apply '/etc/systemd/system/kubelet.service' '3e0df4afc13299d83bf312dbec69c7c75481af18fdac7dcbecb607e8cb870429' '' 'RXhlY1N0YXJ0PS9rdWJlbGV0IC0tY29uZmlnPS92YXIvbGliL2t1YmVsZXQvY29uZmln'
+apply '/etc/systemd/system/docker.service.d/10-docker-opts.conf' 'ce603774135699e9abdfd65eb1f2733774da58af91782528e82ef5f9efdb8fba' '' 'b3ZlcnJpZGU='
+
+if [[ ${#CHANGED[@]} -gt 0 ]]; then
+  systemctl daemon-reload
+fi
+
+if changed '/etc/systemd/system/kubelet.service' '/var/lib/kubelet/config' || ! systemctl is-active --quiet 'kubelet.service'; then
+  systemctl enable 'kubelet.service' && systemctl restart 'kubelet.service'
+fi
+if changed '/etc/systemd/system/docker.service.d/10-docker-opts.conf' || ! systemctl is-active --quiet 'docker.service'; then
+  systemctl enable 'docker.service' && systemctl restart 'docker.service'
+fi
+`))
+	})
+
+	It("should download and verify remote files", func() {
+		g := NewCloudInitGenerator(template.Must(template.New("reload").Parse("{{ .ReloadScript }}")), DefaultUnitsPath, "%s")
+
+		permissions := int32(0755)
+		out, _, err := g.Generate(&generator.OperatingSystemConfig{
+			Files: []*generator.File{
+				{
+					Path:        "/opt/bin/tool",
+					Permissions: &permissions,
+					Remote: &generator.RemoteSource{
+						URL:    "https://example.com/tool",
+						SHA256: "fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9",
+					},
+				},
+			},
+			Units: []*generator.Unit{
+				{Name: "tool.service", Content: []byte("ExecStart=/opt/bin/tool")},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(string(out)).To(ContainSubstring(content.FetchFunction))
+		Expect(string(out)).To(ContainSubstring(`
+fetch '/opt/bin/tool' 'fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9' '0755' 'https://example.com/tool'
+`))
+		Expect(string(out)).To(ContainSubstring(`if changed '/etc/systemd/system/tool.service' '/opt/bin/tool' || `))
+	})
+
+	It("should run the reload script after applying the cloud-config", func() {
+		g := NewCloudInitGenerator(template.Must(template.New("reload").Parse("")), DefaultUnitsPath, CloudInitCmd)
+
+		path := "/var/lib/osc/cloud-config"
+		_, cmd, err := g.Generate(&generator.OperatingSystemConfig{Path: &path})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd).To(gstruct.PointTo(Equal("/usr/bin/cloud-init clean && /usr/bin/cloud-init --file /var/lib/osc/cloud-config init && " + ReloadScriptPath)))
+	})
+
+	Context("running the script", func() {
+		var (
+			dir       string
+			unitsPath string
+			log       string
+			run       func(*generator.OperatingSystemConfig) []string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "reload-config")
+			Expect(err).NotTo(HaveOccurred())
+
+			// Fake systemctl that records its invocations and reports every unit as active.
+			var (
+				bin       = filepath.Join(dir, "bin")
+				systemctl = filepath.Join(bin, "systemctl")
+			)
+			log = filepath.Join(dir, "systemctl.log")
+			Expect(os.MkdirAll(bin, 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(systemctl, []byte("#!/bin/bash\necho \"$*\" >> '"+log+"'\n"), 0755)).To(Succeed())
+
+			unitsPath = filepath.Join(dir, "units")
+			g := NewCloudInitGenerator(template.Must(template.New("reload").Parse("{{ .ReloadScript }}")), unitsPath, "%s")
+
+			run = func(config *generator.OperatingSystemConfig) []string {
+				script, _, err := g.Generate(config)
+				Expect(err).NotTo(HaveOccurred())
+
+				scriptPath := filepath.Join(dir, "reload-config")
+				Expect(ioutil.WriteFile(scriptPath, script, 0755)).To(Succeed())
+				Expect(ioutil.WriteFile(log, nil, 0644)).To(Succeed())
+
+				cmd := exec.Command(scriptPath)
+				cmd.Env = append(os.Environ(), "PATH="+bin+":"+os.Getenv("PATH"))
+				out, err := cmd.CombinedOutput()
+				Expect(err).NotTo(HaveOccurred(), string(out))
+
+				data, err := ioutil.ReadFile(log)
+				Expect(err).NotTo(HaveOccurred())
+				return strings.Split(strings.TrimSpace(string(data)), "\n")
+			}
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("should only write and restart what changed", func() {
+			var (
+				configPath  = filepath.Join(dir, "kubelet", "config")
+				permissions = int32(0600)
+				config      = &generator.OperatingSystemConfig{
+					Files: []*generator.File{
+						{Path: configPath, Content: []byte("config"), Permissions: &permissions},
+					},
+					Units: []*generator.Unit{
+						{Name: "kubelet.service", Content: []byte("ExecStart=/kubelet --config=" + configPath)},
+						{Name: "docker.service", DropIns: []*generator.DropIn{{Name: "10-docker-opts.conf", Content: []byte("override")}}},
+					},
+				}
+			)
+
+			By("applying the initial config")
+			Expect(run(config)).To(Equal([]string{
+				"daemon-reload",
+				"enable kubelet.service",
+				"restart kubelet.service",
+				"enable docker.service",
+				"restart docker.service",
+			}))
+			data, err := ioutil.ReadFile(filepath.Join(unitsPath, "docker.service.d", "10-docker-opts.conf"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("override"))
+
+			By("applying the unchanged config")
+			Expect(run(config)).To(Equal([]string{
+				"is-active --quiet kubelet.service",
+				"is-active --quiet docker.service",
+			}))
+
+			By("changing the content of a file")
+			config.Files[0].Content = []byte("changed")
+			Expect(run(config)).To(Equal([]string{
+				"daemon-reload",
+				"enable kubelet.service",
+				"restart kubelet.service",
+				"is-active --quiet docker.service",
+			}))
+			data, err = ioutil.ReadFile(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("changed"))
+
+			By("changing only the permissions of a file")
+			permissions = 0640
+			Expect(run(config)).To(Equal([]string{
+				"daemon-reload",
+				"enable kubelet.service",
+				"restart kubelet.service",
+				"is-active --quiet docker.service",
+			}))
+			info, err := os.Stat(configPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+		})
+	})
+})
@@ -18,26 +18,43 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
-	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 	"path"
+	"strings"
 	"text/template"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/containerruntime"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/content"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	"github.com/gobuffalo/packr/v2"
 )
 
-// DefaultUnitsPath is the default CoreOS path where to store units at.
-const DefaultUnitsPath = "/etc/systemd/system"
+const (
+	// DefaultUnitsPath is the default CoreOS path where to store units at.
+	DefaultUnitsPath = "/etc/systemd/system"
+	// CloudInitTemplateName is the name of the cloud-init template in the template box of an operating system.
+	CloudInitTemplateName = "cloud-init.template"
+	// CloudInitCmd is the command that applies a cloud-config with cloud-init. As the init stage does
+	// not run `runcmd`, it runs the reload script written by the cloud-config itself.
+	CloudInitCmd = "/usr/bin/cloud-init clean && /usr/bin/cloud-init --file %s init && " + ReloadScriptPath
+)
 
 type fileData struct {
 	Path        string
 	Content     string
+	Checksum    string
 	Dirname     string
 	Permissions *string
+	URL         string
 }
 
 type unitData struct {
-	Path    string
-	Name    string
-	Content *string
-	DropIns *dropInsData
+	Path     string
+	Name     string
+	Content  *string
+	Checksum string
+	DropIns  *dropInsData
+	Triggers []string
 }
 
 type dropInsData struct {
@@ -46,14 +63,35 @@ type dropInsData struct {
 }
 
 type dropInData struct {
-	Path    string
-	Content string
+	Path     string
+	Content  string
+	Checksum string
 }
 
 type initScriptData struct {
-	Files     []*fileData
-	Units     []*unitData
-	Bootstrap bool
+	Hooks
+	Files            []*fileData
+	Units            []*unitData
+	Bootstrap        bool
+	ReloadScript     string
+	ReloadScriptPath string
+	HasRemoteFiles   bool
+	FetchFunction    string
+	ContainerRuntime string
+	Containerd       bool
+}
+
+// Hooks are additional commands that are passed to the template of a CloudInitGenerator. Each
+// command may span multiple lines. Templates refer to them as `.PreBootstrap`, `.PostBootstrap`
+// and `.Commands`.
+type Hooks struct {
+	// PreBootstrap are the commands run on bootstrap before the files and units are written.
+	PreBootstrap []string
+	// PostBootstrap are the commands run on bootstrap after the files and units have been written.
+	PostBootstrap []string
+	// Commands are additional commands run after the units have been written, e.g., additional
+	// `runcmd` steps of a cloud-config.
+	Commands []string
 }
 
 // CloudInitGenerator generates cloud-init scripts.
@@ -61,6 +99,28 @@ type CloudInitGenerator struct {
 	cloudInitTemplate *template.Template
 	unitsPath         string
 	cmd               string
+	hooks             Hooks
+}
+
+// Funcs are the functions available to the templates of CloudInitGenerators created with
+// NewCloudInitGeneratorFromBox.
+var Funcs = template.FuncMap{
+	"indent": indent,
+}
+
+// indent prefixes every non-empty line of the given text with n spaces, e.g., to embed it in a YAML
+// block scalar.
+func indent(n int, text string) string {
+	var (
+		prefix = strings.Repeat(" ", n)
+		lines  = strings.Split(text, "\n")
+	)
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 func b64(data []byte) string {
@@ -72,14 +132,19 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 	var tFiles []*fileData
 	for _, file := range data.Files {
 		tFile := &fileData{
-			Path:    file.Path,
-			Content: b64(file.Content),
-			Dirname: path.Dir(file.Path),
+			Path:     file.Path,
+			Content:  b64(file.Content),
+			Checksum: checksum(file.Content),
+			Dirname:  path.Dir(file.Path),
 		}
 		if file.Permissions != nil {
 			permissions := fmt.Sprintf("%04o", *file.Permissions)
 			tFile.Permissions = &permissions
 		}
+		if file.Remote != nil {
+			tFile.URL = file.Remote.URL
+			tFile.Checksum = file.Remote.SHA256
+		}
 		tFiles = append(tFiles, tFile)
 	}
 
@@ -91,9 +156,11 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 			content = &encoded
 		}
 		tUnit := &unitData{
-			Name:    unit.Name,
-			Path:    path.Join(t.unitsPath, unit.Name),
-			Content: content,
+			Name:     unit.Name,
+			Path:     path.Join(t.unitsPath, unit.Name),
+			Content:  content,
+			Checksum: checksum(unit.Content),
+			Triggers: unitTriggers(t.unitsPath, unit, data.Files),
 		}
 		if len(unit.DropIns) != 0 {
 			dropInPath := path.Join(t.unitsPath, fmt.Sprintf("%s.d", unit.Name))
@@ -101,8 +168,9 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 			var items []*dropInData
 			for _, dropIn := range unit.DropIns {
 				items = append(items, &dropInData{
-					Path:    path.Join(dropInPath, dropIn.Name),
-					Content: b64(dropIn.Content),
+					Path:     path.Join(dropInPath, dropIn.Name),
+					Content:  b64(dropIn.Content),
+					Checksum: checksum(dropIn.Content),
 				})
 			}
 			tUnit.DropIns = &dropInsData{
@@ -114,12 +182,26 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 		tUnits = append(tUnits, tUnit)
 	}
 
+	scriptData := &initScriptData{
+		Hooks:            t.hooks,
+		Files:            tFiles,
+		Units:            tUnits,
+		Bootstrap:        data.Bootstrap,
+		ReloadScriptPath: ReloadScriptPath,
+		HasRemoteFiles:   content.HasRemoteFiles(data.Files),
+		FetchFunction:    content.FetchFunction,
+		ContainerRuntime: string(data.ContainerRuntime),
+		Containerd:       data.ContainerRuntime == containerruntime.Containerd,
+	}
+
+	var reloadScript bytes.Buffer
+	if err := reloadScriptTemplate.Execute(&reloadScript, scriptData); err != nil {
+		return nil, nil, err
+	}
+	scriptData.ReloadScript = reloadScript.String()
+
 	var buf bytes.Buffer
-	if err := t.cloudInitTemplate.Execute(&buf, &initScriptData{
-		Files:     tFiles,
-		Units:     tUnits,
-		Bootstrap: data.Bootstrap,
-	}); err != nil {
+	if err := t.cloudInitTemplate.Execute(&buf, scriptData); err != nil {
 		return nil, nil, err
 	}
 
@@ -134,9 +216,32 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 
 // NewCloudInitGenerator creates a new CloudInitGenerator with the given units path.
 func NewCloudInitGenerator(template *template.Template, unitsPath string, cmd string) *CloudInitGenerator {
+	return NewCloudInitGeneratorWithHooks(template, unitsPath, cmd, Hooks{})
+}
+
+// NewCloudInitGeneratorWithHooks creates a new CloudInitGenerator with the given units path and
+// the given hooks.
+func NewCloudInitGeneratorWithHooks(template *template.Template, unitsPath string, cmd string, hooks Hooks) *CloudInitGenerator {
 	return &CloudInitGenerator{
 		cloudInitTemplate: template,
 		unitsPath:         unitsPath,
 		cmd:               cmd,
+		hooks:             hooks,
 	}
 }
+
+// NewCloudInitGeneratorFromBox creates a new CloudInitGenerator with the given units path and the
+// given hooks from the CloudInitTemplateName template of the given box.
+func NewCloudInitGeneratorFromBox(box *packr.Box, unitsPath string, cmd string, hooks Hooks) (*CloudInitGenerator, error) {
+	cloudInitTemplateString, err := box.FindString(CloudInitTemplateName)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudInitTemplate, err := template.New("cloud-init").Funcs(Funcs).Parse(cloudInitTemplateString)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCloudInitGeneratorWithHooks(cloudInitTemplate, unitsPath, cmd, hooks), nil
+}
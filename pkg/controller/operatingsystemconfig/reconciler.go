@@ -118,8 +118,25 @@ func (r *reconciler) reconcile(ctx context.Context, osc *extensionsv1alpha1.Oper
 		return extensionscontroller.ReconcileErr(err)
 	}
 
-	secret := &corev1.Secret{ObjectMeta: SecretObjectMetaForConfig(osc)}
+	checksums, err := r.unitChecksums(ctx, osc)
+	if err != nil {
+		msg := "Could not compute checksums of units"
+		utilruntime.HandleError(r.updateStatusError(ctx, extensionscontroller.ReconcileErrCauseOrErr(err), osc, operationType, msg))
+		r.logger.Error(err, msg, "osc", osc.Name)
+		return extensionscontroller.ReconcileErr(err)
+	}
+
+	var (
+		secret        = &corev1.Secret{ObjectMeta: SecretObjectMetaForConfig(osc)}
+		affectedUnits []string
+	)
 	if err := controller.CreateOrUpdate(ctx, r.client, secret, func() error {
+		// Only report the units that are affected by the changes since the last change of the cloud config.
+		var err error
+		if affectedUnits, err = RecordUnitChecksums(secret, userData, units, checksums, osc.Status.Units); err != nil {
+			return err
+		}
+
 		if secret.Data == nil {
 			secret.Data = make(map[string][]byte)
 		}
@@ -139,7 +156,7 @@ func (r *reconciler) reconcile(ctx context.Context, osc *extensionsv1alpha1.Oper
 			Namespace: secret.Namespace,
 		},
 	}
-	osc.Status.Units = units
+	osc.Status.Units = affectedUnits
 	if command != nil {
 		osc.Status.Command = command
 	}
@@ -153,6 +170,16 @@ func (r *reconciler) reconcile(ctx context.Context, osc *extensionsv1alpha1.Oper
 	return reconcile.Result{}, nil
 }
 
+// unitChecksums computes the checksums of the units of the given OperatingSystemConfig with the
+// actuator if it implements UnitChecksummer. Otherwise, it returns no checksums, so that all units
+// are considered affected.
+func (r *reconciler) unitChecksums(ctx context.Context, osc *extensionsv1alpha1.OperatingSystemConfig) (map[string]string, error) {
+	if checksummer, ok := r.actuator.(UnitChecksummer); ok {
+		return checksummer.UnitChecksums(ctx, osc)
+	}
+	return nil, nil
+}
+
 func (r *reconciler) delete(ctx context.Context, osc *extensionsv1alpha1.OperatingSystemConfig) (reconcile.Result, error) {
 	hasFinalizer, err := extensionscontroller.HasFinalizer(osc, FinalizerName)
 	if err != nil {
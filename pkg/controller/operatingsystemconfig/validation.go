@@ -0,0 +1,130 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"bufio"
+	"errors"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/coreos/go-systemd/unit"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateOperatingSystemConfig statically validates the units and files of the given
+// OperatingSystemConfig. Every unit and drop-in must be parseable as systemd unit, and files must
+// have unique absolute paths and valid permissions.
+func ValidateOperatingSystemConfig(config *extensionsv1alpha1.OperatingSystemConfig) field.ErrorList {
+	var (
+		allErrs   = field.ErrorList{}
+		specPath  = field.NewPath("spec")
+		unitNames = make(map[string]struct{}, len(config.Spec.Units))
+		filePaths = make(map[string]struct{}, len(config.Spec.Files))
+	)
+
+	for i, u := range config.Spec.Units {
+		idxPath := specPath.Child("units").Index(i)
+
+		if len(u.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "unit name must not be empty"))
+		} else if strings.Contains(u.Name, "/") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), u.Name, "unit name must not contain '/'"))
+		} else if ContainsUnsafeShellCharacters(u.Name) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), u.Name, "unit name must not contain quotes, backslashes or control characters"))
+		} else if _, ok := unitNames[u.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), u.Name))
+		}
+		unitNames[u.Name] = struct{}{}
+
+		if u.Content != nil {
+			if err := validateUnitContent(*u.Content); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("content"), *u.Content, err.Error()))
+			}
+		}
+
+		dropInNames := make(map[string]struct{}, len(u.DropIns))
+		for j, dropIn := range u.DropIns {
+			dropInPath := idxPath.Child("dropIns").Index(j)
+
+			if len(dropIn.Name) == 0 {
+				allErrs = append(allErrs, field.Required(dropInPath.Child("name"), "drop-in name must not be empty"))
+			} else if _, ok := dropInNames[dropIn.Name]; ok {
+				allErrs = append(allErrs, field.Duplicate(dropInPath.Child("name"), dropIn.Name))
+			}
+			dropInNames[dropIn.Name] = struct{}{}
+
+			if err := validateUnitContent(dropIn.Content); err != nil {
+				allErrs = append(allErrs, field.Invalid(dropInPath.Child("content"), dropIn.Content, err.Error()))
+			}
+		}
+	}
+
+	for i, file := range config.Spec.Files {
+		idxPath := specPath.Child("files").Index(i)
+
+		if len(file.Path) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("path"), "file path must not be empty"))
+		} else if !path.IsAbs(file.Path) || path.Clean(file.Path) != file.Path {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), file.Path, "file path must be an absolute and clean path"))
+		} else if ContainsUnsafeShellCharacters(file.Path) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("path"), file.Path, "file path must not contain quotes, backslashes or control characters"))
+		} else if _, ok := filePaths[file.Path]; ok {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("path"), file.Path))
+		}
+		filePaths[file.Path] = struct{}{}
+
+		if p := file.Permissions; p != nil && (*p < 0 || *p > 07777) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("permissions"), *p, "file permissions must be between 0 and 07777"))
+		}
+
+		if (file.Content.Inline == nil) == (file.Content.SecretRef == nil) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("content"), file.Content, "exactly one of inline or secretRef must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+// ContainsUnsafeShellCharacters returns true if the given value contains quotes, backslashes or control
+// characters. The generators interpolate paths and URLs into single-quoted shell words, hence such
+// values must be rejected.
+func ContainsUnsafeShellCharacters(value string) bool {
+	return strings.IndexFunc(value, func(r rune) bool {
+		return r == '\'' || r == '"' || r == '\\' || unicode.IsControl(r)
+	}) >= 0
+}
+
+// validateUnitContent checks that the given unit or drop-in content can be parsed as systemd unit.
+// The parser silently skips lines before the first section, hence they are rejected explicitly.
+func validateUnitContent(content string) error {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			return errors.New("found assignment outside of a section")
+		}
+		break
+	}
+
+	_, err := unit.Deserialize(strings.NewReader(content))
+	return err
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig_test
+
+import (
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Validation", func() {
+	var config *extensionsv1alpha1.OperatingSystemConfig
+
+	BeforeEach(func() {
+		var (
+			content     = "[Unit]\nDescription=docker\n\n[Service]\nExecStart=/usr/bin/dockerd\n"
+			permissions = int32(0644)
+		)
+
+		config = &extensionsv1alpha1.OperatingSystemConfig{
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Units: []extensionsv1alpha1.Unit{
+					{
+						Name:    "docker.service",
+						Content: &content,
+						DropIns: []extensionsv1alpha1.DropIn{
+							{Name: "10-opts.conf", Content: "# options\n[Service]\nEnvironment=DOCKER_OPTS=--debug\n"},
+						},
+					},
+				},
+				Files: []extensionsv1alpha1.File{
+					{
+						Path:        "/etc/docker/daemon.json",
+						Permissions: &permissions,
+						Content: extensionsv1alpha1.FileContent{
+							Inline: &extensionsv1alpha1.FileContentInline{Data: "{}"},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#ValidateOperatingSystemConfig", func() {
+		It("should accept a valid config", func() {
+			Expect(ValidateOperatingSystemConfig(config)).To(BeEmpty())
+		})
+
+		DescribeTable("should reject invalid configs",
+			func(mutate func(), errType field.ErrorType, errField string) {
+				mutate()
+
+				Expect(ValidateOperatingSystemConfig(config)).To(ConsistOf(gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(errType),
+					"Field": Equal(errField),
+				}))))
+			},
+			Entry("empty unit name", func() {
+				config.Spec.Units[0].Name = ""
+			}, field.ErrorTypeRequired, "spec.units[0].name"),
+			Entry("unit name with slash", func() {
+				config.Spec.Units[0].Name = "../docker.service"
+			}, field.ErrorTypeInvalid, "spec.units[0].name"),
+			Entry("unit name with quote", func() {
+				config.Spec.Units[0].Name = "docker'.service"
+			}, field.ErrorTypeInvalid, "spec.units[0].name"),
+			Entry("duplicate unit", func() {
+				config.Spec.Units = append(config.Spec.Units, extensionsv1alpha1.Unit{Name: "docker.service"})
+			}, field.ErrorTypeDuplicate, "spec.units[1].name"),
+			Entry("assignment outside of a section", func() {
+				content := "ExecStart=/usr/bin/dockerd\n"
+				config.Spec.Units[0].Content = &content
+			}, field.ErrorTypeInvalid, "spec.units[0].content"),
+			Entry("unterminated section", func() {
+				content := "[Unit\nDescription=docker\n"
+				config.Spec.Units[0].Content = &content
+			}, field.ErrorTypeInvalid, "spec.units[0].content"),
+			Entry("drop-in option without value", func() {
+				config.Spec.Units[0].DropIns[0].Content = "[Service]\nEnvironment\n"
+			}, field.ErrorTypeInvalid, "spec.units[0].dropIns[0].content"),
+			Entry("duplicate drop-in", func() {
+				config.Spec.Units[0].DropIns = append(config.Spec.Units[0].DropIns, config.Spec.Units[0].DropIns[0])
+			}, field.ErrorTypeDuplicate, "spec.units[0].dropIns[1].name"),
+			Entry("relative file path", func() {
+				config.Spec.Files[0].Path = "etc/docker/daemon.json"
+			}, field.ErrorTypeInvalid, "spec.files[0].path"),
+			Entry("unclean file path", func() {
+				config.Spec.Files[0].Path = "/etc/docker/../daemon.json"
+			}, field.ErrorTypeInvalid, "spec.files[0].path"),
+			Entry("file path with quote", func() {
+				config.Spec.Files[0].Path = "/etc/docker/'daemon.json"
+			}, field.ErrorTypeInvalid, "spec.files[0].path"),
+			Entry("file path with newline", func() {
+				config.Spec.Files[0].Path = "/etc/docker/daemon\n.json"
+			}, field.ErrorTypeInvalid, "spec.files[0].path"),
+			Entry("duplicate file path", func() {
+				config.Spec.Files = append(config.Spec.Files, config.Spec.Files[0])
+			}, field.ErrorTypeDuplicate, "spec.files[1].path"),
+			Entry("invalid permissions", func() {
+				permissions := int32(010000)
+				config.Spec.Files[0].Permissions = &permissions
+			}, field.ErrorTypeInvalid, "spec.files[0].permissions"),
+			Entry("missing content", func() {
+				config.Spec.Files[0].Content.Inline = nil
+			}, field.ErrorTypeInvalid, "spec.files[0].content"),
+		)
+	})
+})
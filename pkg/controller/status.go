@@ -15,11 +15,23 @@
 package controller
 
 import (
+	"fmt"
+
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1alpha1helper "github.com/gardener/gardener/pkg/apis/core/v1alpha1/helper"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// ConditionTypeCredentialsUpToDate is a condition type indicating that the cloud provider credentials referenced
+	// by an extension resource have been picked up by all components that use them.
+	ConditionTypeCredentialsUpToDate gardencorev1alpha1.ConditionType = "CredentialsUpToDate"
+	// ConditionReasonCredentialsPickedUp is the reason of the `CredentialsUpToDate` condition after all components
+	// that use the credentials run with them.
+	ConditionReasonCredentialsPickedUp = "CredentialsPickedUp"
+)
+
 // LastOperation creates a new LastOperation from the given parameters.
 func LastOperation(t gardencorev1alpha1.LastOperationType, state gardencorev1alpha1.LastOperationState, progress int, description string) *gardencorev1alpha1.LastOperation {
 	return &gardencorev1alpha1.LastOperation{
@@ -48,3 +60,21 @@ func ReconcileSucceeded(t gardencorev1alpha1.LastOperationType, description stri
 func ReconcileError(t gardencorev1alpha1.LastOperationType, description string, progress int, codes ...gardencorev1alpha1.ErrorCode) (*gardencorev1alpha1.LastOperation, *gardencorev1alpha1.LastError) {
 	return LastOperation(t, gardencorev1alpha1.LastOperationStateError, progress, description), LastError(description, codes...)
 }
+
+// CredentialsUpToDateConditions returns the given conditions with a `CredentialsUpToDate` condition reflecting that
+// the credentials of the secret with the given name and checksum have been picked up. The condition is only updated
+// if the checksum differs from the one that was previously reported, hence its last update time tells when the
+// currently used credentials have been rolled out.
+func CredentialsUpToDateConditions(conditions []gardencorev1alpha1.Condition, secretName, checksum string) []gardencorev1alpha1.Condition {
+	message := fmt.Sprintf("Credentials of secret %q (checksum %s) have been picked up.", secretName, checksum)
+
+	condition := gardencorev1alpha1helper.GetCondition(conditions, ConditionTypeCredentialsUpToDate)
+	if condition == nil {
+		initializedCondition := gardencorev1alpha1helper.InitCondition(ConditionTypeCredentialsUpToDate)
+		condition = &initializedCondition
+	} else if condition.Status == gardencorev1alpha1.ConditionTrue && condition.Message == message {
+		return conditions
+	}
+
+	return gardencorev1alpha1helper.MergeConditions(conditions, gardencorev1alpha1helper.UpdatedCondition(*condition, gardencorev1alpha1.ConditionTrue, ConditionReasonCredentialsPickedUp, message))
+}
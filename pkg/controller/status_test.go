@@ -0,0 +1,53 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller_test
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status", func() {
+	Describe("#CredentialsUpToDateConditions", func() {
+		It("should add the condition if it does not exist yet", func() {
+			conditions := controller.CredentialsUpToDateConditions(nil, "cloudprovider", "abc")
+
+			Expect(conditions).To(HaveLen(1))
+			Expect(conditions[0].Type).To(Equal(controller.ConditionTypeCredentialsUpToDate))
+			Expect(conditions[0].Status).To(Equal(gardencorev1alpha1.ConditionTrue))
+			Expect(conditions[0].Reason).To(Equal(controller.ConditionReasonCredentialsPickedUp))
+			Expect(conditions[0].Message).To(ContainSubstring("abc"))
+		})
+
+		It("should not touch the condition if the checksum did not change", func() {
+			conditions := controller.CredentialsUpToDateConditions(nil, "cloudprovider", "abc")
+
+			Expect(controller.CredentialsUpToDateConditions(conditions, "cloudprovider", "abc")).To(Equal(conditions))
+		})
+
+		It("should update the condition if the checksum changed", func() {
+			other := gardencorev1alpha1.Condition{Type: "Other", Status: gardencorev1alpha1.ConditionFalse}
+			conditions := controller.CredentialsUpToDateConditions([]gardencorev1alpha1.Condition{other}, "cloudprovider", "abc")
+
+			updated := controller.CredentialsUpToDateConditions(conditions, "cloudprovider", "def")
+			Expect(updated).To(HaveLen(2))
+			Expect(updated[0]).To(Equal(other))
+			Expect(updated[1].Message).To(ContainSubstring("def"))
+		})
+	})
+})
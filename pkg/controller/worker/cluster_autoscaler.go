@@ -0,0 +1,106 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// AnnotationFrozenNodeGroups is the annotation of the cluster-autoscaler deployment which contains the original
+	// `--nodes` flags of the node groups which have been frozen during a rolling update.
+	AnnotationFrozenNodeGroups = "worker.extensions.gardener.cloud/frozen-node-groups"
+
+	nodesFlagPrefix = "--nodes="
+)
+
+// FreezeNodeGroups freezes the node groups of the given cluster-autoscaler <args> whose machine deployment names are
+// keys of <replicas>, i.e. their `--nodes=<min>:<max>:<namespace>.<name>` flag is replaced with one whose minimum
+// and maximum are set to the current number of replicas. The `--nodes` flags are the only source of the node group
+// sizes of the cluster-autoscaler, hence it keeps running but leaves these machine deployments alone. It returns the new arguments and the original flags of the frozen node groups, keyed by
+// the machine deployment name. Node groups which are already frozen (i.e. part of <frozen>) keep their original flag.
+func FreezeNodeGroups(args []string, replicas map[string]int32, frozen map[string]string) ([]string, map[string]string) {
+	var (
+		out       = make([]string, 0, len(args))
+		originals = make(map[string]string, len(frozen))
+	)
+
+	for name, flag := range frozen {
+		originals[name] = flag
+	}
+
+	for _, arg := range args {
+		name, ok := nodeGroupMachineDeploymentName(arg)
+		if !ok {
+			out = append(out, arg)
+			continue
+		}
+
+		current, ok := replicas[name]
+		if !ok {
+			out = append(out, arg)
+			continue
+		}
+
+		if _, ok := originals[name]; !ok {
+			originals[name] = arg
+		}
+		out = append(out, fmt.Sprintf("%s%d:%d:%s", nodesFlagPrefix, current, current, nodeGroupID(arg)))
+	}
+
+	return out, originals
+}
+
+// RestoreNodeGroups replaces the `--nodes` flags of the given cluster-autoscaler <args> with the <originals> that
+// have been returned by FreezeNodeGroups.
+func RestoreNodeGroups(args []string, originals map[string]string) []string {
+	out := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		if name, ok := nodeGroupMachineDeploymentName(arg); ok {
+			if original, ok := originals[name]; ok {
+				out = append(out, original)
+				continue
+			}
+		}
+		out = append(out, arg)
+	}
+
+	return out
+}
+
+// nodeGroupID returns the `<namespace>.<name>` part of a `--nodes=<min>:<max>:<namespace>.<name>` flag.
+func nodeGroupID(arg string) string {
+	parts := strings.SplitN(strings.TrimPrefix(arg, nodesFlagPrefix), ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// nodeGroupMachineDeploymentName returns the machine deployment name of a `--nodes` flag and whether <arg> is such
+// a flag at all.
+func nodeGroupMachineDeploymentName(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, nodesFlagPrefix) {
+		return "", false
+	}
+
+	id := nodeGroupID(arg)
+	if i := strings.Index(id, "."); i >= 0 && i < len(id)-1 {
+		return id[i+1:], true
+	}
+	return "", false
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker_test
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClusterAutoscaler", func() {
+	var args = []string{
+		"./cluster-autoscaler",
+		"--cloud-provider=mcm",
+		"--nodes=1:5:shoot--foo--bar.shoot--foo--bar-pool-1-z1",
+		"--nodes=2:4:shoot--foo--bar.shoot--foo--bar-pool-2-z1",
+		"--v=2",
+	}
+
+	Describe("#FreezeNodeGroups", func() {
+		It("should freeze only the node groups of the given machine deployments", func() {
+			frozenArgs, originals := worker.FreezeNodeGroups(args, map[string]int32{"shoot--foo--bar-pool-1-z1": 3}, nil)
+
+			Expect(frozenArgs).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--cloud-provider=mcm",
+				"--nodes=3:3:shoot--foo--bar.shoot--foo--bar-pool-1-z1",
+				"--nodes=2:4:shoot--foo--bar.shoot--foo--bar-pool-2-z1",
+				"--v=2",
+			}))
+			Expect(originals).To(Equal(map[string]string{
+				"shoot--foo--bar-pool-1-z1": "--nodes=1:5:shoot--foo--bar.shoot--foo--bar-pool-1-z1",
+			}))
+		})
+
+		It("should keep the original flag of already frozen node groups", func() {
+			frozenArgs, originals := worker.FreezeNodeGroups(args, map[string]int32{"shoot--foo--bar-pool-1-z1": 3}, nil)
+			frozenArgs, originals = worker.FreezeNodeGroups(frozenArgs, map[string]int32{"shoot--foo--bar-pool-1-z1": 4}, originals)
+
+			Expect(frozenArgs[2]).To(Equal("--nodes=4:4:shoot--foo--bar.shoot--foo--bar-pool-1-z1"))
+			Expect(originals).To(Equal(map[string]string{
+				"shoot--foo--bar-pool-1-z1": "--nodes=1:5:shoot--foo--bar.shoot--foo--bar-pool-1-z1",
+			}))
+		})
+
+		It("should not touch anything if no machine deployment is given", func() {
+			frozenArgs, originals := worker.FreezeNodeGroups(args, nil, nil)
+
+			Expect(frozenArgs).To(Equal(args))
+			Expect(originals).To(BeEmpty())
+		})
+	})
+
+	Describe("#RestoreNodeGroups", func() {
+		It("should restore the original flags of the frozen node groups", func() {
+			frozenArgs, originals := worker.FreezeNodeGroups(args, map[string]int32{
+				"shoot--foo--bar-pool-1-z1": 3,
+				"shoot--foo--bar-pool-2-z1": 2,
+			}, nil)
+
+			Expect(worker.RestoreNodeGroups(frozenArgs, originals)).To(Equal(args))
+		})
+	})
+})
@@ -16,7 +16,9 @@ package genericactuator
 
 import (
 	"context"
+	"time"
 
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
@@ -44,6 +46,8 @@ type genericActuator struct {
 	mcmSeedChart    util.Chart
 	mcmShootChart   util.Chart
 	imageVector     imagevector.ImageVector
+	rolloutTimeout  time.Duration
+	mcmConfig       *extensionsconfig.MachineControllerManager
 
 	client            client.Client
 	clientset         kubernetes.Interface
@@ -51,10 +55,20 @@ type genericActuator struct {
 	chartApplier      gardenerkubernetes.ChartApplier
 }
 
+// rolloutRequeueInterval is the duration after which the rollout of the machine deployments is re-checked in case
+// no change of the machine deployments triggered a reconciliation before.
+const rolloutRequeueInterval = 30 * time.Second
+
 // NewActuator creates a new Actuator that reconciles
 // Worker resources of Gardener's `extensions.gardener.cloud` API group.
 // It provides a default implementation that allows easier integration of providers.
-func NewActuator(logger logr.Logger, delegateFactory DelegateFactory, mcmName string, mcmSeedChart, mcmShootChart util.Chart, imageVector imagevector.ImageVector) worker.Actuator {
+// If the given rollout timeout is zero then worker.DefaultRolloutTimeout is used.
+// The timeouts of the given machine-controller-manager configuration are passed to the machine-controller-manager chart.
+func NewActuator(logger logr.Logger, delegateFactory DelegateFactory, mcmName string, mcmSeedChart, mcmShootChart util.Chart, imageVector imagevector.ImageVector, rolloutTimeout time.Duration, mcmConfig *extensionsconfig.MachineControllerManager) worker.Actuator {
+	if rolloutTimeout == 0 {
+		rolloutTimeout = worker.DefaultRolloutTimeout
+	}
+
 	return &genericActuator{
 		logger: logger.WithName("worker-actuator"),
 
@@ -63,6 +77,8 @@ func NewActuator(logger logr.Logger, delegateFactory DelegateFactory, mcmName st
 		mcmSeedChart:    mcmSeedChart,
 		mcmShootChart:   mcmShootChart,
 		imageVector:     imageVector,
+		rolloutTimeout:  rolloutTimeout,
+		mcmConfig:       mcmConfig,
 	}
 }
 
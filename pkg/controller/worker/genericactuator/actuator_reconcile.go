@@ -21,11 +21,11 @@ import (
 
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
-	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
+	workercontroller "github.com/gardener/gardener-extensions/pkg/controller/worker"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	"github.com/pkg/errors"
 
-	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	gardencorev1alpha1helper "github.com/gardener/gardener/pkg/apis/core/v1alpha1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	extensionsv1alpha1helper "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1/helper"
@@ -34,9 +34,7 @@ import (
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -47,9 +45,23 @@ func (a *genericActuator) Reconcile(ctx context.Context, worker *extensionsv1alp
 		return errors.Wrapf(err, "could not instantiate actuator context")
 	}
 
+	// Start a new rollout unless one is already in progress for the current generation of the Worker. The rollout
+	// timeout is measured from its start.
+	rollout, err := a.startRollout(ctx, worker)
+	if err != nil {
+		return err
+	}
+
+	// Compute the checksum of the cloud provider credentials. They are not part of the machine class hash, hence a
+	// rotation only updates the machine class secrets and the machine-controller-manager but does not roll the nodes.
+	credentialsChecksum, err := a.computeCredentialsChecksum(ctx, worker)
+	if err != nil {
+		return err
+	}
+
 	// Deploy the machine-controller-manager into the cluster.
 	a.logger.Info("Deploying the machine-controller-manager", "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
-	if err := a.deployMachineControllerManager(ctx, worker, cluster, workerDelegate); err != nil {
+	if err := a.deployMachineControllerManager(ctx, worker, cluster, workerDelegate, credentialsChecksum); err != nil {
 		return err
 	}
 
@@ -65,32 +77,6 @@ func (a *genericActuator) Reconcile(ctx context.Context, worker *extensionsv1alp
 		return err
 	}
 
-	// During the time a rolling update happens we do not want the cluster autoscaler to interfer, hence it
-	// is removed for now.
-	clusterAutoscalerRequired := extensionsv1alpha1helper.ClusterAutoscalerRequired(worker.Spec.Pools)
-	if clusterAutoscalerRequired {
-		rollingUpdate := false
-		// Check whether new machine classes have been computed (resulting in a rolling update of the nodes).
-		for _, machineDeployment := range wantedMachineDeployments {
-			if !existingMachineClassNames.Has(machineDeployment.ClassName) {
-				rollingUpdate = true
-				break
-			}
-		}
-
-		// When the Shoot gets hibernated we want to remove the cluster auto scaler so that it does not interfer
-		// with Gardeners modifications on the machine deployment's replicas fields.
-		if controller.IsHibernated(cluster.Shoot) || rollingUpdate {
-			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: worker.Namespace, Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler}}
-			if err := a.client.Delete(ctx, deployment); err != nil && !apierrors.IsNotFound(err) {
-				return err
-			}
-			if err := util.WaitUntilResourceDeleted(ctx, a.client, deployment, 5*time.Second); err != nil {
-				return err
-			}
-		}
-	}
-
 	// Deploy generated machine classes.
 	a.logger.Info("Deploying the machine classes", "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
 	if err := workerDelegate.DeployMachineClasses(ctx); err != nil {
@@ -103,18 +89,70 @@ func (a *genericActuator) Reconcile(ctx context.Context, worker *extensionsv1alp
 		return err
 	}
 
+	// During the time a rolling update happens we do not want the cluster autoscaler to interfere with the machine
+	// deployments being rolled, hence their node groups are frozen until the rollout is finished. All other machine
+	// deployments are still scaled by the cluster autoscaler.
+	clusterAutoscalerRequired := extensionsv1alpha1helper.ClusterAutoscalerRequired(worker.Spec.Pools)
+	if clusterAutoscalerRequired {
+		// When the Shoot gets hibernated we want to pause the cluster auto scaler so that it does not interfere
+		// with Gardeners modifications on the machine deployment's replicas fields.
+		if controller.IsHibernated(cluster.Shoot) {
+			if err := a.scaleDownClusterAutoscaler(ctx, worker.Namespace); err != nil {
+				return errors.Wrapf(err, "failed to scale down the cluster autoscaler")
+			}
+		} else {
+			// Check whether new machine classes have been computed (resulting in a rolling update of the nodes).
+			var rollingMachineDeployments workercontroller.MachineDeployments
+			for _, machineDeployment := range wantedMachineDeployments {
+				if !existingMachineClassNames.Has(machineDeployment.ClassName) {
+					rollingMachineDeployments = append(rollingMachineDeployments, machineDeployment)
+				}
+			}
+
+			if err := a.pauseClusterAutoscaler(ctx, worker.Namespace, rollingMachineDeployments, existingMachineDeployments); err != nil {
+				return errors.Wrapf(err, "failed to pause the cluster autoscaler")
+			}
+		}
+	}
+
 	// Generate machine deployment configuration based on previously computed list of deployments and deploy them.
 	a.logger.Info("Deploying the machine deployments", "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
 	if err := a.deployMachineDeployments(ctx, cluster, worker, existingMachineDeployments, wantedMachineDeployments, workerDelegate.MachineClassKind(), clusterAutoscalerRequired); err != nil {
 		return errors.Wrapf(err, "failed to generate the machine deployment config")
 	}
 
-	// Wait until all generated machine deployments are healthy/available.
-	timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
-	defer cancel()
+	// Check whether all generated machine deployments are healthy/available. We do not block until the rollout is
+	// finished but requeue the Worker. It is also re-checked whenever the machine deployments change.
+	a.logger.Info("Checking the rollout of the machine deployments", "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
+	available, progress, checkErr := a.checkMachineDeploymentsAvailable(ctx, cluster, worker, wantedMachineDeployments)
+
+	// Report the replicas, machine classes, images and failed machines of all machine deployments.
+	if err := a.updateWorkerState(ctx, worker, wantedMachineDeployments); err != nil {
+		return errors.Wrapf(err, "failed to update the state in the Worker resource")
+	}
 
-	if err := a.waitUntilMachineDeploymentsAvailable(timeoutCtx, cluster, worker, wantedMachineDeployments); err != nil {
-		return gardencorev1alpha1helper.DetermineError(fmt.Sprintf("Failed while waiting for all machine deployments to be ready: '%s'", err.Error()))
+	// The credentials are only reported as picked up once the machine-controller-manager runs with them. Until then,
+	// the rollout is not finished.
+	if available && checkErr == nil {
+		credentialsPickedUp, err := a.machineControllerManagerRolledOut(ctx, worker, credentialsChecksum)
+		if err != nil {
+			return err
+		}
+		if credentialsPickedUp {
+			if err := a.updateWorkerStatusCredentials(ctx, worker, credentialsChecksum); err != nil {
+				return errors.Wrapf(err, "failed to update the credentials condition in the Worker resource")
+			}
+		} else {
+			available, progress = false, "Waiting for the machine-controller-manager to pick up the current credentials"
+		}
+	}
+
+	if err := a.checkRollout(ctx, worker, rollout, available, progress, checkErr); err != nil {
+		return err
+	}
+
+	if err := a.updateRolloutPhase(ctx, worker, workercontroller.RolloutPhaseCleaningUp, "Cleaning up old machine deployments, classes and secrets"); err != nil {
+		return err
 	}
 
 	// Delete all old machine deployments (i.e. those which were not previously computed but exist in the cluster).
@@ -147,10 +185,15 @@ func (a *genericActuator) Reconcile(ctx context.Context, worker *extensionsv1alp
 		return errors.Wrapf(err, "failed to update the status in the Worker resource")
 	}
 
-	return nil
+	// The rollout is finished, hence the cluster autoscaler may scale all machine deployments again.
+	if err := a.resumeClusterAutoscaler(ctx, worker.Namespace); err != nil {
+		return errors.Wrapf(err, "failed to resume the cluster autoscaler")
+	}
+
+	return a.updateRolloutPhase(ctx, worker, workercontroller.RolloutPhaseSucceeded, "All machine deployments have been rolled out")
 }
 
-func (a *genericActuator) deployMachineDeployments(ctx context.Context, cluster *controller.Cluster, worker *extensionsv1alpha1.Worker, existingMachineDeployments *machinev1alpha1.MachineDeploymentList, wantedMachineDeployments worker.MachineDeployments, classKind string, clusterAutoscalerRequired bool) error {
+func (a *genericActuator) deployMachineDeployments(ctx context.Context, cluster *controller.Cluster, worker *extensionsv1alpha1.Worker, existingMachineDeployments *machinev1alpha1.MachineDeploymentList, wantedMachineDeployments workercontroller.MachineDeployments, classKind string, clusterAutoscalerRequired bool) error {
 	for _, deployment := range wantedMachineDeployments {
 		var (
 			labels                    = map[string]string{"name": deployment.Name}
@@ -244,64 +287,127 @@ func (a *genericActuator) deployMachineDeployments(ctx context.Context, cluster
 	return nil
 }
 
-// waitUntilMachineDeploymentsAvailable waits for a maximum of 30 minutes until all the desired <machineDeployments>
-// were marked as healthy/available by the machine-controller-manager. It polls the status every 5 seconds.
-func (a *genericActuator) waitUntilMachineDeploymentsAvailable(ctx context.Context, cluster *controller.Cluster, worker *extensionsv1alpha1.Worker, wantedMachineDeployments worker.MachineDeployments) error {
-	return wait.PollUntil(5*time.Second, func() (bool, error) {
-		var numHealthyDeployments, numUpdated, numDesired, numberOfAwakeMachines int32
+// checkMachineDeploymentsAvailable checks whether all the desired <machineDeployments> were marked as healthy/available
+// by the machine-controller-manager. If they are not available yet it returns a description of the current progress.
+func (a *genericActuator) checkMachineDeploymentsAvailable(ctx context.Context, cluster *controller.Cluster, worker *extensionsv1alpha1.Worker, wantedMachineDeployments workercontroller.MachineDeployments) (bool, string, error) {
+	var numHealthyDeployments, numUpdated, numDesired, numberOfAwakeMachines int32
 
-		// Get the list of all existing machine deployments
-		existingMachineDeployments := &machinev1alpha1.MachineDeploymentList{}
-		if err := a.client.List(ctx, &client.ListOptions{Namespace: worker.Namespace}, existingMachineDeployments); err != nil {
-			return false, err
-		}
+	// Get the list of all existing machine deployments
+	existingMachineDeployments := &machinev1alpha1.MachineDeploymentList{}
+	if err := a.client.List(ctx, &client.ListOptions{Namespace: worker.Namespace}, existingMachineDeployments); err != nil {
+		return false, "", err
+	}
 
-		// Collect the numbers of ready and desired replicas.
-		for _, existingMachineDeployment := range existingMachineDeployments.Items {
-			// If the shoot get hibernated we want to wait until all machine deployments have been deleted entirely.
-			if controller.IsHibernated(cluster.Shoot) {
-				numberOfAwakeMachines += existingMachineDeployment.Status.Replicas
-				continue
-			}
+	// Collect the numbers of ready and desired replicas.
+	for _, existingMachineDeployment := range existingMachineDeployments.Items {
+		// If the shoot get hibernated we want to wait until all machine deployments have been deleted entirely.
+		if controller.IsHibernated(cluster.Shoot) {
+			numberOfAwakeMachines += existingMachineDeployment.Status.Replicas
+			continue
+		}
 
-			// If the Shoot is not hibernated we want to wait until all machine deployments have been as many ready
-			// replicas as desired (specified in the .spec.replicas). However, if we see any error in the status of
-			// the deployment then we return it.
-			for _, failedMachine := range existingMachineDeployment.Status.FailedMachines {
-				return false, fmt.Errorf("Machine %s failed: %s", failedMachine.Name, failedMachine.LastOperation.Description)
-			}
+		// If the Shoot is not hibernated we want to wait until all machine deployments have been as many ready
+		// replicas as desired (specified in the .spec.replicas). However, if we see any error in the status of
+		// the deployment then we return it.
+		for _, failedMachine := range existingMachineDeployment.Status.FailedMachines {
+			return false, "", fmt.Errorf("Machine %s failed: %s", failedMachine.Name, failedMachine.LastOperation.Description)
+		}
 
-			// If the Shoot is not hibernated we want to wait until all machine deployments have been as many ready
-			// replicas as desired (specified in the .spec.replicas).
-			for _, machineDeployment := range wantedMachineDeployments {
-				if machineDeployment.Name == existingMachineDeployment.Name {
-					if health.CheckMachineDeployment(&existingMachineDeployment) == nil {
-						numHealthyDeployments++
-					}
-					numDesired += existingMachineDeployment.Spec.Replicas
-					numUpdated += existingMachineDeployment.Status.UpdatedReplicas
+		// If the Shoot is not hibernated we want to wait until all machine deployments have been as many ready
+		// replicas as desired (specified in the .spec.replicas).
+		for _, machineDeployment := range wantedMachineDeployments {
+			if machineDeployment.Name == existingMachineDeployment.Name {
+				if health.CheckMachineDeployment(&existingMachineDeployment) == nil {
+					numHealthyDeployments++
 				}
+				numDesired += existingMachineDeployment.Spec.Replicas
+				numUpdated += existingMachineDeployment.Status.UpdatedReplicas
 			}
 		}
+	}
+
+	var progress string
+	switch {
+	case !controller.IsHibernated(cluster.Shoot):
+		if numUpdated >= numDesired && int(numHealthyDeployments) == len(wantedMachineDeployments) {
+			return true, "", nil
+		}
+		progress = fmt.Sprintf("Waiting until all desired machines are ready (%d/%d machine objects up-to-date, %d/%d machinedeployments available)", numUpdated, numDesired, numHealthyDeployments, len(wantedMachineDeployments))
+	default:
+		if numberOfAwakeMachines == 0 {
+			return true, "", nil
+		}
+		progress = fmt.Sprintf("Waiting until all machines have been hibernated (%d still awake)", numberOfAwakeMachines)
+	}
 
-		switch {
-		case !controller.IsHibernated(cluster.Shoot):
-			a.logger.Info(fmt.Sprintf("Waiting until all desired machines are ready (%d/%d machine objects up-to-date, %d/%d machinedeployments available)...", numUpdated, numDesired, numHealthyDeployments, len(wantedMachineDeployments)), "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
-			if numUpdated >= numDesired && int(numHealthyDeployments) == len(wantedMachineDeployments) {
-				return true, nil
-			}
-		default:
-			if numberOfAwakeMachines == 0 {
-				return true, nil
-			}
-			a.logger.Info(fmt.Sprintf("Waiting until all machines have been hibernated (%d still awake)...", numberOfAwakeMachines), "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
+	a.logger.Info(progress, "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
+	return false, progress, nil
+}
+
+// startRollout returns the rollout that is in progress or has failed for the current generation of the given <worker>.
+// If there is none, a new rollout is started and stored in the state of the <worker>.
+func (a *genericActuator) startRollout(ctx context.Context, worker *extensionsv1alpha1.Worker) (*workercontroller.Rollout, error) {
+	if rollout := workercontroller.CurrentRollout(worker); rollout != nil {
+		return rollout, nil
+	}
+
+	rollout := &workercontroller.Rollout{Generation: worker.Generation, StartTime: metav1.Now()}
+	return rollout, extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, worker, func() error {
+		state, err := workercontroller.GetState(worker)
+		if err != nil {
+			state = &workercontroller.State{}
+		}
+		state.Rollout = rollout
+
+		data, err := state.Marshal()
+		if err != nil {
+			return err
 		}
+		worker.Status.State = data
+		worker.Status.Conditions = workercontroller.RolloutConditions(worker.Status.Conditions, workercontroller.RolloutPhaseDeploying, "Deploying the machine-controller-manager, machine classes and machine deployments")
+		return nil
+	})
+}
+
+// checkRollout fails the given <rollout> if the machine deployments could not be checked or if the rollout timed out.
+// It requeues the <worker> while the machine deployments are not available yet, and returns nil once they are.
+func (a *genericActuator) checkRollout(ctx context.Context, worker *extensionsv1alpha1.Worker, rollout *workercontroller.Rollout, available bool, progress string, checkErr error) error {
+	if checkErr != nil {
+		return a.failRollout(ctx, worker, fmt.Sprintf("Failed while waiting for all machine deployments to be ready: '%s'", checkErr.Error()))
+	}
+	if available {
+		return nil
+	}
 
-		return false, nil
-	}, ctx.Done())
+	if time.Since(rollout.StartTime.Time) > a.rolloutTimeout {
+		return a.failRollout(ctx, worker, fmt.Sprintf("Timed out after %s while waiting for all machine deployments to be ready: '%s'", a.rolloutTimeout, progress))
+	}
+	if err := a.updateRolloutPhase(ctx, worker, workercontroller.RolloutPhaseWaiting, progress); err != nil {
+		return err
+	}
+	return &controllererror.RequeueAfterError{RequeueAfter: rolloutRequeueInterval}
 }
 
-func (a *genericActuator) updateWorkerStatus(ctx context.Context, worker *extensionsv1alpha1.Worker, machineDeployments worker.MachineDeployments) error {
+// failRollout marks the rollout of the given <worker> as failed and returns an error with the given message.
+// The cluster autoscaler is resumed so that the node groups do not stay frozen until the next rollout.
+func (a *genericActuator) failRollout(ctx context.Context, worker *extensionsv1alpha1.Worker, message string) error {
+	if err := a.resumeClusterAutoscaler(ctx, worker.Namespace); err != nil {
+		return err
+	}
+	if err := a.updateRolloutPhase(ctx, worker, workercontroller.RolloutPhaseFailed, message); err != nil {
+		return err
+	}
+	return gardencorev1alpha1helper.DetermineError(message)
+}
+
+func (a *genericActuator) updateRolloutPhase(ctx context.Context, worker *extensionsv1alpha1.Worker, phase workercontroller.RolloutPhase, message string) error {
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, worker, func() error {
+		worker.Status.Conditions = workercontroller.RolloutConditions(worker.Status.Conditions, phase, message)
+		return nil
+	})
+}
+
+func (a *genericActuator) updateWorkerStatus(ctx context.Context, worker *extensionsv1alpha1.Worker, machineDeployments workercontroller.MachineDeployments) error {
 	var statusMachineDeployments []extensionsv1alpha1.MachineDeployment
 
 	for _, machineDeployment := range machineDeployments {
@@ -318,6 +424,40 @@ func (a *genericActuator) updateWorkerStatus(ctx context.Context, worker *extens
 	})
 }
 
+// updateWorkerState stores the detailed status of the <wantedMachineDeployments> in the state of the given <worker>.
+func (a *genericActuator) updateWorkerState(ctx context.Context, worker *extensionsv1alpha1.Worker, wantedMachineDeployments workercontroller.MachineDeployments) error {
+	existingMachineDeployments := &machinev1alpha1.MachineDeploymentList{}
+	if err := a.client.List(ctx, &client.ListOptions{Namespace: worker.Namespace}, existingMachineDeployments); err != nil {
+		return err
+	}
+
+	state, err := workercontroller.ComputeState(worker, wantedMachineDeployments, existingMachineDeployments.Items).Marshal()
+	if err != nil {
+		return err
+	}
+
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, worker, func() error {
+		worker.Status.State = state
+		return nil
+	})
+}
+
+func (a *genericActuator) updateWorkerStatusCredentials(ctx context.Context, worker *extensionsv1alpha1.Worker, credentialsChecksum string) error {
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, worker, func() error {
+		worker.Status.Conditions = extensionscontroller.CredentialsUpToDateConditions(worker.Status.Conditions, worker.Spec.SecretRef.Name, credentialsChecksum)
+		return nil
+	})
+}
+
+// computeCredentialsChecksum computes the checksum of the cloud provider secret referenced by the given <worker>.
+func (a *genericActuator) computeCredentialsChecksum(ctx context.Context, worker *extensionsv1alpha1.Worker) (string, error) {
+	secret, err := extensionscontroller.GetSecretByReference(ctx, a.client, &worker.Spec.SecretRef)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not get secret '%s/%s'", worker.Spec.SecretRef.Namespace, worker.Spec.SecretRef.Name)
+	}
+	return util.ComputeChecksum(secret.Data), nil
+}
+
 // Helper functions
 
 func shootIsAwake(isHibernated bool, existingMachineDeployments *machinev1alpha1.MachineDeploymentList) bool {
@@ -0,0 +1,183 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
+	workercontroller "github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("Actuator", func() {
+	var (
+		ctx = context.TODO()
+
+		c         client.Client
+		a         *genericActuator
+		worker    *extensionsv1alpha1.Worker
+		rollout   *workercontroller.Rollout
+		getWorker func() *extensionsv1alpha1.Worker
+	)
+
+	BeforeEach(func() {
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+		Expect(machinev1alpha1.AddToScheme(s)).To(Succeed())
+
+		worker = &extensionsv1alpha1.Worker{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shoot--foo--bar", Generation: 1},
+		}
+		c = fake.NewFakeClientWithScheme(s, worker.DeepCopy())
+		a = &genericActuator{logger: log.Log.WithName("test"), client: c, rolloutTimeout: 10 * time.Minute}
+
+		getWorker = func() *extensionsv1alpha1.Worker {
+			w := &extensionsv1alpha1.Worker{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: worker.Namespace, Name: worker.Name}, w)).To(Succeed())
+			return w
+		}
+	})
+
+	Describe("#startRollout", func() {
+		It("should start a new rollout and store it in the state", func() {
+			var err error
+			rollout, err = a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rollout.Generation).To(Equal(int64(1)))
+
+			w := getWorker()
+			Expect(workercontroller.IsRolloutInProgress(w)).To(BeTrue())
+			Expect(workercontroller.GetRolloutCondition(w).Reason).To(Equal(string(workercontroller.RolloutPhaseDeploying)))
+			Expect(workercontroller.CurrentRollout(w)).NotTo(BeNil())
+		})
+
+		It("should continue the rollout in progress for the same generation", func() {
+			started, err := a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+
+			rollout, err = a.startRollout(ctx, getWorker())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rollout.StartTime.Unix()).To(Equal(started.StartTime.Unix()))
+		})
+
+		It("should keep the start time of a failed rollout for the same generation", func() {
+			started, err := a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(a.failRollout(ctx, getWorker(), "machine deployment is frozen")).To(HaveOccurred())
+
+			rollout, err = a.startRollout(ctx, getWorker())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rollout.StartTime.Unix()).To(Equal(started.StartTime.Unix()))
+		})
+
+		It("should start a new rollout if the worker changed after a failed rollout", func() {
+			started, err := a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(a.failRollout(ctx, getWorker(), "machine deployment is frozen")).To(HaveOccurred())
+
+			w := getWorker()
+			w.Generation = 2
+			Expect(c.Update(ctx, w)).To(Succeed())
+
+			rollout, err = a.startRollout(ctx, w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rollout.Generation).To(Equal(int64(2)))
+			Expect(rollout.StartTime.Time).NotTo(BeTemporally("<", started.StartTime.Time))
+		})
+
+		It("should start a new rollout if the worker changed during a rollout", func() {
+			_, err := a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+
+			w := getWorker()
+			w.Generation = 2
+			Expect(c.Update(ctx, w)).To(Succeed())
+
+			rollout, err = a.startRollout(ctx, w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rollout.Generation).To(Equal(int64(2)))
+			Expect(workercontroller.CurrentRollout(getWorker()).Generation).To(Equal(int64(2)))
+		})
+	})
+
+	Describe("#checkRollout", func() {
+		BeforeEach(func() {
+			var err error
+			rollout, err = a.startRollout(ctx, worker)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should succeed if the machine deployments are available", func() {
+			Expect(a.checkRollout(ctx, worker, rollout, true, "", nil)).To(Succeed())
+		})
+
+		It("should requeue while the machine deployments are not available", func() {
+			err := a.checkRollout(ctx, worker, rollout, false, "1/2 machines available", nil)
+			Expect(err).To(Equal(&controllererror.RequeueAfterError{RequeueAfter: rolloutRequeueInterval}))
+
+			condition := workercontroller.GetRolloutCondition(getWorker())
+			Expect(condition.Status).To(Equal(gardencorev1alpha1.ConditionProgressing))
+			Expect(condition.Reason).To(Equal(string(workercontroller.RolloutPhaseWaiting)))
+			Expect(condition.Message).To(Equal("1/2 machines available"))
+		})
+
+		It("should fail the rollout once it timed out", func() {
+			rollout.StartTime = metav1.NewTime(time.Now().Add(-11 * time.Minute))
+
+			err := a.checkRollout(ctx, worker, rollout, false, "1/2 machines available", nil)
+			Expect(err).To(MatchError(ContainSubstring("Timed out after 10m0s")))
+			Expect(err).NotTo(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+
+			condition := workercontroller.GetRolloutCondition(getWorker())
+			Expect(condition.Status).To(Equal(gardencorev1alpha1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(workercontroller.RolloutPhaseFailed)))
+		})
+
+		It("should not time out a rollout that was started recently although the condition is older", func() {
+			w := getWorker()
+			w.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-time.Hour))
+			Expect(c.Status().Update(ctx, w)).To(Succeed())
+
+			err := a.checkRollout(ctx, w, rollout, false, "1/2 machines available", nil)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.RequeueAfterError{}))
+		})
+
+		It("should fail the rollout if the machine deployments could not be checked", func() {
+			err := a.checkRollout(ctx, worker, rollout, false, "", fmt.Errorf("machine deployment is frozen"))
+			Expect(err).To(MatchError(ContainSubstring("machine deployment is frozen")))
+
+			condition := workercontroller.GetRolloutCondition(getWorker())
+			Expect(condition.Status).To(Equal(gardencorev1alpha1.ConditionFalse))
+			Expect(condition.Message).To(ContainSubstring("Failed while waiting for all machine deployments to be ready"))
+		})
+	})
+})
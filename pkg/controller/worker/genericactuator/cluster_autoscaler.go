@@ -0,0 +1,151 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/gardener-extensions/pkg/controller"
+	workercontroller "github.com/gardener/gardener-extensions/pkg/controller/worker"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+)
+
+// getClusterAutoscaler returns the cluster-autoscaler deployment in the given <namespace> or nil if it does not exist.
+func (a *genericActuator) getClusterAutoscaler(ctx context.Context, namespace string) (*appsv1.Deployment, error) {
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, kutil.Key(namespace, gardencorev1alpha1.DeploymentNameClusterAutoscaler), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// scaleDownClusterAutoscaler scales the cluster-autoscaler deployment in the given <namespace> to zero replicas so that
+// it does not interfere with the replicas of the machine deployments while the Shoot is hibernated. Its
+// configuration is kept and Gardener scales it up again when the Shoot wakes up.
+func (a *genericActuator) scaleDownClusterAutoscaler(ctx context.Context, namespace string) error {
+	deployment, err := a.getClusterAutoscaler(ctx, namespace)
+	if err != nil || deployment == nil {
+		return err
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		return nil
+	}
+	return util.ScaleDeployment(ctx, a.client, deployment, 0)
+}
+
+// pauseClusterAutoscaler freezes the node groups of the given <machineDeployments> in the cluster-autoscaler deployment
+// in the given <namespace> to their current number of replicas. The cluster-autoscaler is restarted with the frozen
+// `--nodes` flags and keeps scaling all other machine deployments. The original flags are remembered in an annotation
+// and restored by resumeClusterAutoscaler. Gardener deploys the cluster-autoscaler again only after the Worker has been
+// reconciled, hence it does not revert the frozen flags during the rollout.
+func (a *genericActuator) pauseClusterAutoscaler(ctx context.Context, namespace string, machineDeployments workercontroller.MachineDeployments, existingMachineDeployments *machinev1alpha1.MachineDeploymentList) error {
+	replicas := make(map[string]int32, len(machineDeployments))
+	for _, machineDeployment := range machineDeployments {
+		if existingMachineDeployment := getExistingMachineDeployment(existingMachineDeployments, machineDeployment.Name); existingMachineDeployment != nil {
+			replicas[machineDeployment.Name] = existingMachineDeployment.Spec.Replicas
+		}
+	}
+	if len(replicas) == 0 {
+		return nil
+	}
+
+	deployment, err := a.getClusterAutoscaler(ctx, namespace)
+	if err != nil || deployment == nil {
+		return err
+	}
+
+	a.logger.Info("Pausing the cluster-autoscaler for the machine deployments being rolled", "namespace", namespace)
+	return controller.TryUpdate(ctx, retry.DefaultBackoff, a.client, deployment, func() error {
+		frozen, err := getFrozenNodeGroups(deployment)
+		if err != nil {
+			return err
+		}
+
+		for i := range deployment.Spec.Template.Spec.Containers {
+			container := &deployment.Spec.Template.Spec.Containers[i]
+			container.Command, frozen = workercontroller.FreezeNodeGroups(container.Command, replicas, frozen)
+			container.Args, frozen = workercontroller.FreezeNodeGroups(container.Args, replicas, frozen)
+		}
+
+		return setFrozenNodeGroups(deployment, frozen)
+	})
+}
+
+// resumeClusterAutoscaler restores the node groups of the cluster-autoscaler deployment in the given <namespace>
+// which have been frozen by pauseClusterAutoscaler.
+func (a *genericActuator) resumeClusterAutoscaler(ctx context.Context, namespace string) error {
+	deployment, err := a.getClusterAutoscaler(ctx, namespace)
+	if err != nil || deployment == nil {
+		return err
+	}
+	if _, ok := deployment.Annotations[workercontroller.AnnotationFrozenNodeGroups]; !ok {
+		return nil
+	}
+
+	a.logger.Info("Resuming the cluster-autoscaler", "namespace", namespace)
+	return controller.TryUpdate(ctx, retry.DefaultBackoff, a.client, deployment, func() error {
+		frozen, err := getFrozenNodeGroups(deployment)
+		if err != nil {
+			return err
+		}
+
+		for i := range deployment.Spec.Template.Spec.Containers {
+			container := &deployment.Spec.Template.Spec.Containers[i]
+			container.Command = workercontroller.RestoreNodeGroups(container.Command, frozen)
+			container.Args = workercontroller.RestoreNodeGroups(container.Args, frozen)
+		}
+
+		delete(deployment.Annotations, workercontroller.AnnotationFrozenNodeGroups)
+		return nil
+	})
+}
+
+func getFrozenNodeGroups(deployment *appsv1.Deployment) (map[string]string, error) {
+	frozen := map[string]string{}
+	if data, ok := deployment.Annotations[workercontroller.AnnotationFrozenNodeGroups]; ok {
+		if err := json.Unmarshal([]byte(data), &frozen); err != nil {
+			return nil, err
+		}
+	}
+	return frozen, nil
+}
+
+func setFrozenNodeGroups(deployment *appsv1.Deployment, frozen map[string]string) error {
+	if len(frozen) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(frozen)
+	if err != nil {
+		return err
+	}
+
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[workercontroller.AnnotationFrozenNodeGroups] = string(data)
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+
+	workercontroller "github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("ClusterAutoscaler", func() {
+	const namespace = "shoot--foo--bar"
+
+	var (
+		ctx = context.TODO()
+
+		c                      client.Client
+		a                      *genericActuator
+		clusterAutoscaler      *appsv1.Deployment
+		getMachineDeployment   func(name string) *machinev1alpha1.MachineDeployment
+		listMachineDeployments func() *machinev1alpha1.MachineDeploymentList
+	)
+
+	BeforeEach(func() {
+		s := runtime.NewScheme()
+		Expect(scheme.AddToScheme(s)).To(Succeed())
+		Expect(machinev1alpha1.AddToScheme(s)).To(Succeed())
+
+		clusterAutoscaler = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:    "cluster-autoscaler",
+								Command: []string{"./cluster-autoscaler", "--nodes=1:5:shoot--foo--bar.pool-1-z1", "--nodes=1:5:shoot--foo--bar.pool-2-z1"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		c = fake.NewFakeClientWithScheme(s,
+			clusterAutoscaler.DeepCopy(),
+			&machinev1alpha1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool-1-z1", Namespace: namespace},
+				Spec:       machinev1alpha1.MachineDeploymentSpec{Replicas: 3},
+			},
+			&machinev1alpha1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "pool-2-z1", Namespace: namespace},
+				Spec:       machinev1alpha1.MachineDeploymentSpec{Replicas: 2},
+			},
+		)
+		a = &genericActuator{logger: log.Log.WithName("test"), client: c}
+
+		getMachineDeployment = func(name string) *machinev1alpha1.MachineDeployment {
+			machineDeployment := &machinev1alpha1.MachineDeployment{}
+			Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, machineDeployment)).To(Succeed())
+			return machineDeployment
+		}
+		listMachineDeployments = func() *machinev1alpha1.MachineDeploymentList {
+			machineDeployments := &machinev1alpha1.MachineDeploymentList{}
+			Expect(c.List(ctx, &client.ListOptions{Namespace: namespace}, machineDeployments)).To(Succeed())
+			return machineDeployments
+		}
+	})
+
+	getClusterAutoscaler := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterAutoscaler.Name}, deployment)).To(Succeed())
+		return deployment
+	}
+
+	Describe("#pauseClusterAutoscaler", func() {
+		It("should only freeze the node groups of the machine deployments being rolled", func() {
+			rolling := workercontroller.MachineDeployments{{Name: "pool-1-z1"}, {Name: "pool-3-z1"}}
+			Expect(a.pauseClusterAutoscaler(ctx, namespace, rolling, listMachineDeployments())).To(Succeed())
+
+			deployment := getClusterAutoscaler()
+			Expect(deployment.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{
+				"./cluster-autoscaler",
+				"--nodes=3:3:shoot--foo--bar.pool-1-z1",
+				"--nodes=1:5:shoot--foo--bar.pool-2-z1",
+			}))
+			Expect(deployment.Annotations).To(HaveKeyWithValue(workercontroller.AnnotationFrozenNodeGroups, `{"pool-1-z1":"--nodes=1:5:shoot--foo--bar.pool-1-z1"}`))
+		})
+
+		It("should keep the original flags of node groups that are already frozen", func() {
+			rolling := workercontroller.MachineDeployments{{Name: "pool-1-z1"}}
+			Expect(a.pauseClusterAutoscaler(ctx, namespace, rolling, listMachineDeployments())).To(Succeed())
+
+			machineDeployment := getMachineDeployment("pool-1-z1")
+			machineDeployment.Spec.Replicas = 4
+			Expect(c.Update(ctx, machineDeployment)).To(Succeed())
+
+			Expect(a.pauseClusterAutoscaler(ctx, namespace, rolling, listMachineDeployments())).To(Succeed())
+			deployment := getClusterAutoscaler()
+			Expect(deployment.Spec.Template.Spec.Containers[0].Command[1]).To(Equal("--nodes=4:4:shoot--foo--bar.pool-1-z1"))
+			Expect(deployment.Annotations).To(HaveKeyWithValue(workercontroller.AnnotationFrozenNodeGroups, `{"pool-1-z1":"--nodes=1:5:shoot--foo--bar.pool-1-z1"}`))
+		})
+
+		It("should not change the cluster-autoscaler if no machine deployment is rolled", func() {
+			Expect(a.pauseClusterAutoscaler(ctx, namespace, nil, listMachineDeployments())).To(Succeed())
+
+			deployment := getClusterAutoscaler()
+			Expect(deployment.Spec).To(Equal(clusterAutoscaler.Spec))
+			Expect(deployment.Annotations).To(BeEmpty())
+		})
+	})
+
+	Describe("#resumeClusterAutoscaler", func() {
+		It("should restore the original flags of all frozen node groups", func() {
+			rolling := workercontroller.MachineDeployments{{Name: "pool-1-z1"}, {Name: "pool-2-z1"}}
+			Expect(a.pauseClusterAutoscaler(ctx, namespace, rolling, listMachineDeployments())).To(Succeed())
+
+			Expect(a.resumeClusterAutoscaler(ctx, namespace)).To(Succeed())
+
+			deployment := getClusterAutoscaler()
+			Expect(deployment.Spec).To(Equal(clusterAutoscaler.Spec))
+			Expect(deployment.Annotations).NotTo(HaveKey(workercontroller.AnnotationFrozenNodeGroups))
+		})
+
+		It("should succeed if no node group is frozen", func() {
+			Expect(a.resumeClusterAutoscaler(ctx, namespace)).To(Succeed())
+
+			deployment := getClusterAutoscaler()
+			Expect(deployment.Spec).To(Equal(clusterAutoscaler.Spec))
+			Expect(deployment.Annotations).To(BeEmpty())
+		})
+	})
+})
@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
 	"github.com/gardener/gardener/pkg/utils/secrets"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -33,7 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (a *genericActuator) deployMachineControllerManager(ctx context.Context, workerObj *extensionsv1alpha1.Worker, cluster *controller.Cluster, workerDelegate WorkerDelegate) error {
+func (a *genericActuator) deployMachineControllerManager(ctx context.Context, workerObj *extensionsv1alpha1.Worker, cluster *controller.Cluster, workerDelegate WorkerDelegate, credentialsChecksum string) error {
 	mcmValues, err := workerDelegate.GetMachineControllerManagerChartValues(ctx)
 	if err != nil {
 		return err
@@ -45,6 +47,9 @@ func (a *genericActuator) deployMachineControllerManager(ctx context.Context, wo
 		return err
 	}
 	injectPodAnnotation(mcmValues, "checksum/secret-machine-controller-manager", util.ComputeChecksum(mcmKubeconfigSecret.Data))
+	// Inject the checksum of the cloud provider credentials so that the machine-controller-manager is rolled when they change.
+	injectPodAnnotation(mcmValues, "checksum/secret-"+workerObj.Spec.SecretRef.Name, credentialsChecksum)
+	injectMachineControllerManagerConfig(mcmValues, a.mcmConfig)
 
 	// If the shoot is hibernated then we want to scale down the machine-controller-manager. However, we want to first allow it to delete
 	// all remaining worker nodes. Hence, we cannot set the replicas=0 here (otherwise it would be offline and not able to delete the nodes).
@@ -72,6 +77,34 @@ func (a *genericActuator) deployMachineControllerManager(ctx context.Context, wo
 	return nil
 }
 
+// machineControllerManagerRolledOut returns whether all replicas of the machine-controller-manager run with the
+// credentials of the given checksum and are available.
+func (a *genericActuator) machineControllerManagerRolledOut(ctx context.Context, workerObj *extensionsv1alpha1.Worker, credentialsChecksum string) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, kutil.Key(workerObj.Namespace, a.mcmName), deployment); err != nil {
+		return false, err
+	}
+	return deploymentRolledOut(deployment, "checksum/secret-"+workerObj.Spec.SecretRef.Name, credentialsChecksum), nil
+}
+
+// deploymentRolledOut returns whether the pod template of the given deployment has the given annotation value, and
+// whether all of its replicas have been updated and are available.
+func deploymentRolledOut(deployment *appsv1.Deployment, annotationKey, annotationValue string) bool {
+	if deployment.Spec.Template.Annotations[annotationKey] != annotationValue {
+		return false
+	}
+	if health.CheckDeployment(deployment) != nil {
+		return false
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	return status.Replicas == replicas && status.UpdatedReplicas == replicas && status.AvailableReplicas == replicas
+}
+
 func (a *genericActuator) applyMachineControllerManagerShootChart(ctx context.Context, workerDelegate WorkerDelegate, workerObj *extensionsv1alpha1.Worker, cluster *controller.Cluster) error {
 	shootClients, err := util.NewClientsForShoot(ctx, a.client, workerObj.Namespace, client.Options{})
 	if err != nil {
@@ -112,3 +145,20 @@ func injectPodAnnotation(values map[string]interface{}, key string, value interf
 		podAnnotations.(map[string]interface{})[key] = value
 	}
 }
+
+// injectMachineControllerManagerConfig sets the timeouts of the given machine-controller-manager configuration in the
+// given chart values. Timeouts that are not configured keep the defaults of the chart.
+func injectMachineControllerManagerConfig(values map[string]interface{}, config *extensionsconfig.MachineControllerManager) {
+	if config == nil {
+		return
+	}
+	if config.MachineCreationTimeout != nil {
+		values["machineCreationTimeout"] = config.MachineCreationTimeout.Duration.String()
+	}
+	if config.MachineHealthTimeout != nil {
+		values["machineHealthTimeout"] = config.MachineHealthTimeout.Duration.String()
+	}
+	if config.MachineDrainTimeout != nil {
+		values["machineDrainTimeout"] = config.MachineDrainTimeout.Duration.String()
+	}
+}
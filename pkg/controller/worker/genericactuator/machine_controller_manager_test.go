@@ -0,0 +1,96 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"time"
+
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("MachineControllerManager", func() {
+	const annotationKey = "checksum/secret-cloudprovider"
+
+	newDeployment := func(checksum string, replicas, updatedReplicas, availableReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotationKey: checksum}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 2,
+				Replicas:           updatedReplicas,
+				UpdatedReplicas:    updatedReplicas,
+				AvailableReplicas:  availableReplicas,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	DescribeTable("#deploymentRolledOut",
+		func(deployment *appsv1.Deployment, expected bool) {
+			Expect(deploymentRolledOut(deployment, annotationKey, "new")).To(Equal(expected))
+		},
+		Entry("rolled out", newDeployment("new", 1, 1, 1), true),
+		Entry("scaled down", newDeployment("new", 0, 0, 0), true),
+		Entry("old checksum", newDeployment("old", 1, 1, 1), false),
+		Entry("replicas not updated", newDeployment("new", 2, 1, 1), false),
+		Entry("replicas not available", newDeployment("new", 1, 1, 0), false),
+		Entry("generation not observed", func() *appsv1.Deployment {
+			deployment := newDeployment("new", 1, 1, 1)
+			deployment.Generation = 3
+			return deployment
+		}(), false),
+	)
+
+	Describe("#injectMachineControllerManagerConfig", func() {
+		It("should not change the values if nothing is configured", func() {
+			values := map[string]interface{}{"replicas": 1}
+
+			injectMachineControllerManagerConfig(values, nil)
+			injectMachineControllerManagerConfig(values, &extensionsconfig.MachineControllerManager{})
+
+			Expect(values).To(Equal(map[string]interface{}{"replicas": 1}))
+		})
+
+		It("should set the configured timeouts", func() {
+			values := map[string]interface{}{"replicas": 1}
+
+			injectMachineControllerManagerConfig(values, &extensionsconfig.MachineControllerManager{
+				MachineCreationTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+				MachineDrainTimeout:    &metav1.Duration{Duration: 2 * time.Hour},
+			})
+
+			Expect(values).To(Equal(map[string]interface{}{
+				"replicas":               1,
+				"machineCreationTimeout": "30m0s",
+				"machineDrainTimeout":    "2h0m0s",
+			}))
+		})
+	})
+})
@@ -0,0 +1,88 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// MachineImageLookup looks up the provider-specific image of the machine image with the given name and version.
+type MachineImageLookup func(name, version string) (interface{}, error)
+
+// DecodeCloudProfileConfig decodes the provider-specific configuration of the CloudProfile of the given <cluster> into
+// <into> and validates it with <validate>. It returns false if the CloudProfile does not contain such a configuration.
+func DecodeCloudProfileConfig(decoder runtime.Decoder, cluster *extensionscontroller.Cluster, into runtime.Object, validate func() field.ErrorList) (bool, error) {
+	if cluster.CloudProfileConfig == nil {
+		return false, nil
+	}
+
+	name := ""
+	if cluster.CloudProfile != nil {
+		name = cluster.CloudProfile.Name
+	}
+
+	if _, _, err := decoder.Decode(cluster.CloudProfileConfig, nil, into); err != nil {
+		return false, errors.Wrapf(err, "could not decode the provider config of cloud profile %q", name)
+	}
+	if errs := validate(); len(errs) > 0 {
+		return false, errors.Wrapf(errs.ToAggregate(), "invalid provider config of cloud profile %q", name)
+	}
+
+	return true, nil
+}
+
+// MachineImageLookupWithFallback returns a lookup which looks up machine images with <fromCloudProfile> first and
+// falls back to <fromControllerConfiguration> if the CloudProfile does not know the machine image. <fromCloudProfile>
+// may be nil if the CloudProfile has no provider-specific configuration. If neither lookup knows the machine image, the
+// errors of both lookups are returned.
+func MachineImageLookupWithFallback(fromCloudProfile, fromControllerConfiguration MachineImageLookup) MachineImageLookup {
+	return func(name, version string) (interface{}, error) {
+		var errs []error
+
+		if fromCloudProfile != nil {
+			image, err := fromCloudProfile(name, version)
+			if err == nil {
+				return image, nil
+			}
+			errs = append(errs, err)
+		}
+
+		image, err := fromControllerConfiguration(name, version)
+		if err != nil {
+			return nil, utilerrors.NewAggregate(append(errs, err))
+		}
+		return image, nil
+	}
+}
+
+// ValidateMachineImages checks that the machine images of all pools of the given <worker> are known to <lookup>. It is
+// called before anything is deployed so that unknown machine images are rejected early.
+func ValidateMachineImages(worker *extensionsv1alpha1.Worker, lookup MachineImageLookup) error {
+	var errs []error
+	for _, pool := range worker.Spec.Pools {
+		if _, err := lookup(pool.MachineImage.Name, pool.MachineImage.Version); err != nil {
+			errs = append(errs, fmt.Errorf("worker pool %q uses an unknown machine image: %v", pool.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
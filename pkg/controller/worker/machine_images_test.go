@@ -0,0 +1,137 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker_test
+
+import (
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MachineImages", func() {
+	lookup := func(images map[string]string) worker.MachineImageLookup {
+		return func(name, version string) (interface{}, error) {
+			if image, ok := images[name+"/"+version]; ok {
+				return image, nil
+			}
+			return nil, fmt.Errorf("unknown machine image %s/%s", name, version)
+		}
+	}
+
+	Describe("#DecodeCloudProfileConfig", func() {
+		var (
+			decoder runtime.Decoder
+			cluster *extensionscontroller.Cluster
+			into    *corev1.ConfigMap
+			valid   func() field.ErrorList
+		)
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			decoder = serializer.NewCodecFactory(scheme).UniversalDecoder()
+
+			cluster = &extensionscontroller.Cluster{
+				CloudProfile:       &gardenv1beta1.CloudProfile{ObjectMeta: metav1.ObjectMeta{Name: "profile"}},
+				CloudProfileConfig: []byte(`{"apiVersion":"v1","kind":"ConfigMap","data":{"foo":"bar"}}`),
+			}
+			into = &corev1.ConfigMap{}
+			valid = func() field.ErrorList { return nil }
+		})
+
+		It("should return false if the cloud profile has no provider config", func() {
+			cluster.CloudProfileConfig = nil
+
+			ok, err := worker.DecodeCloudProfileConfig(decoder, cluster, into, valid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should decode the provider config", func() {
+			ok, err := worker.DecodeCloudProfileConfig(decoder, cluster, into, valid)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(into.Data).To(Equal(map[string]string{"foo": "bar"}))
+		})
+
+		It("should return the decoding error", func() {
+			cluster.CloudProfileConfig = []byte(`{`)
+
+			_, err := worker.DecodeCloudProfileConfig(decoder, cluster, into, valid)
+			Expect(err).To(MatchError(ContainSubstring(`could not decode the provider config of cloud profile "profile"`)))
+		})
+
+		It("should return the validation errors", func() {
+			invalid := func() field.ErrorList {
+				return field.ErrorList{field.Required(field.NewPath("data"), "must be set")}
+			}
+
+			_, err := worker.DecodeCloudProfileConfig(decoder, cluster, into, invalid)
+			Expect(err).To(MatchError(ContainSubstring(`invalid provider config of cloud profile "profile"`)))
+		})
+	})
+
+	Describe("#MachineImageLookupWithFallback", func() {
+		var (
+			fromCloudProfile            = lookup(map[string]string{"coreos/1.0": "cloud-profile-image"})
+			fromControllerConfiguration = lookup(map[string]string{"coreos/1.0": "config-image", "coreos/2.0": "config-image-2"})
+		)
+
+		It("should prefer the image of the cloud profile", func() {
+			Expect(worker.MachineImageLookupWithFallback(fromCloudProfile, fromControllerConfiguration)("coreos", "1.0")).To(Equal("cloud-profile-image"))
+		})
+
+		It("should fall back to the image of the controller configuration", func() {
+			Expect(worker.MachineImageLookupWithFallback(fromCloudProfile, fromControllerConfiguration)("coreos", "2.0")).To(Equal("config-image-2"))
+		})
+
+		It("should only use the controller configuration if the cloud profile has no config", func() {
+			Expect(worker.MachineImageLookupWithFallback(nil, fromControllerConfiguration)("coreos", "1.0")).To(Equal("config-image"))
+		})
+
+		It("should return the errors of both lookups", func() {
+			_, err := worker.MachineImageLookupWithFallback(fromCloudProfile, fromControllerConfiguration)("coreos", "3.0")
+			Expect(err).To(MatchError("[unknown machine image coreos/3.0, unknown machine image coreos/3.0]"))
+		})
+	})
+
+	Describe("#ValidateMachineImages", func() {
+		It("should accept known machine images and reject unknown ones", func() {
+			w := &extensionsv1alpha1.Worker{
+				Spec: extensionsv1alpha1.WorkerSpec{
+					Pools: []extensionsv1alpha1.WorkerPool{
+						{Name: "known", MachineImage: extensionsv1alpha1.MachineImage{Name: "coreos", Version: "1.0"}},
+						{Name: "unknown", MachineImage: extensionsv1alpha1.MachineImage{Name: "coreos", Version: "3.0"}},
+					},
+				},
+			}
+
+			err := worker.ValidateMachineImages(w, lookup(map[string]string{"coreos/1.0": "image"}))
+			Expect(err).To(MatchError(`worker pool "unknown" uses an unknown machine image: unknown machine image coreos/3.0`))
+		})
+	})
+})
@@ -34,10 +34,12 @@ func init() {
 	diskSizeRegexp = regexp
 }
 
-// MachineDeployment holds information about the name, class, replicas of a MachineDeployment
+// MachineDeployment holds information about the name, pool, zone, class, replicas of a MachineDeployment
 // managed by the machine-controller-manager.
 type MachineDeployment struct {
 	Name           string
+	PoolName       string
+	Zone           string
 	ClassName      string
 	SecretName     string
 	Minimum        int
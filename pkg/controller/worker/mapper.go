@@ -79,6 +79,51 @@ func SecretToWorkerMapper(client client.Client, predicates []predicate.Predicate
 	return &secretToWorkerMapper{client, predicates}
 }
 
+type machineDeploymentToWorkerMapper struct {
+	client     client.Client
+	predicates []predicate.Predicate
+}
+
+func (m *machineDeploymentToWorkerMapper) Map(obj handler.MapObject) []reconcile.Request {
+	ctx := context.TODO()
+
+	if obj.Meta == nil {
+		return nil
+	}
+
+	workerList := &extensions1alpha1.WorkerList{}
+	if err := m.client.List(ctx, client.InNamespace(obj.Meta.GetNamespace()), workerList); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, worker := range workerList.Items {
+		if !extensionscontroller.EvalGenericPredicate(&worker, m.predicates...) {
+			continue
+		}
+
+		// Changes of machine deployments are only relevant for Workers whose rollout is still in progress.
+		if !IsRolloutInProgress(&worker) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: worker.Namespace,
+				Name:      worker.Name,
+			},
+		})
+	}
+
+	return requests
+}
+
+// MachineDeploymentToWorkerMapper returns a mapper that returns requests for Workers in the namespace of
+// modified machine deployments whose rollout is still in progress.
+func MachineDeploymentToWorkerMapper(client client.Client, predicates []predicate.Predicate) handler.Mapper {
+	return &machineDeploymentToWorkerMapper{client, predicates}
+}
+
 // ClusterToWorkerMapper returns a mapper that returns requests for Worker whose
 // referenced clusters have been modified.
 func ClusterToWorkerMapper(client client.Client, predicates []predicate.Predicate) handler.Mapper {
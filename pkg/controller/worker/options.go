@@ -15,6 +15,8 @@
 package worker
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
 )
 
@@ -22,12 +24,17 @@ const (
 	// DeployCRDsFlag is the name of the command line flag to specify whether the worker CRDs
 	// should be deployed or not.
 	DeployCRDsFlag = "deploy-crds"
+	// RolloutTimeoutFlag is the name of the command line flag to specify the duration after which a rollout of
+	// machine deployments is considered failed.
+	RolloutTimeoutFlag = "rollout-timeout"
 )
 
 // Options are command line options that can be set for controller.Options.
 type Options struct {
 	// DeployCRDs defines whether to ignore the operation annotation or not.
 	DeployCRDs bool
+	// RolloutTimeout is the duration after which a rollout of machine deployments is considered failed.
+	RolloutTimeout time.Duration
 
 	config *Config
 }
@@ -35,11 +42,12 @@ type Options struct {
 // AddFlags implements Flagger.AddFlags.
 func (c *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&c.DeployCRDs, DeployCRDsFlag, c.DeployCRDs, "Deploy the required worker CRDs.")
+	fs.DurationVar(&c.RolloutTimeout, RolloutTimeoutFlag, c.RolloutTimeout, "The duration after which a rollout of machine deployments is considered failed.")
 }
 
 // Complete implements Completer.Complete.
 func (c *Options) Complete() error {
-	c.config = &Config{c.DeployCRDs}
+	c.config = &Config{c.DeployCRDs, c.RolloutTimeout}
 	return nil
 }
 
@@ -52,9 +60,16 @@ func (c *Options) Completed() *Config {
 type Config struct {
 	// DeployCRDs defines whether to ignore the operation annotation or not.
 	DeployCRDs bool
+	// RolloutTimeout is the duration after which a rollout of machine deployments is considered failed.
+	RolloutTimeout time.Duration
 }
 
 // Apply sets the values of this Config in the given controller.Options.
 func (c *Config) Apply(ignore *bool) {
 	*ignore = c.DeployCRDs
 }
+
+// ApplyRolloutTimeout sets the rollout timeout of this Config in the given duration.
+func (c *Config) ApplyRolloutTimeout(timeout *time.Duration) {
+	*timeout = c.RolloutTimeout
+}
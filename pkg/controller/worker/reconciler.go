@@ -20,6 +20,7 @@ import (
 
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
@@ -135,6 +136,13 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	}
 
 	if err := r.actuator.Reconcile(r.ctx, worker, cluster); err != nil {
+		// A requeue without a cause indicates that the rollout is still in progress, hence the worker is neither
+		// failed nor successfully reconciled yet.
+		if requeueAfter, ok := err.(*controllererror.RequeueAfterError); ok && requeueAfter.Cause == nil {
+			r.logger.Info("Worker reconciliation is still in progress", "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name), "requeueAfter", requeueAfter.RequeueAfter)
+			return extensionscontroller.ReconcileErr(err)
+		}
+
 		msg := "Error reconciling worker"
 		utilruntime.HandleError(r.updateStatusError(r.ctx, extensionscontroller.ReconcileErrCauseOrErr(err), worker, operationType, msg))
 		r.logger.Error(err, msg, "worker", fmt.Sprintf("%s/%s", worker.Namespace, worker.Name))
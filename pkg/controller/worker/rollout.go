@@ -0,0 +1,107 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"time"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1alpha1helper "github.com/gardener/gardener/pkg/apis/core/v1alpha1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeMachineDeploymentsRolledOut is a condition type indicating whether the machine deployments of a
+	// Worker have been rolled out. While a rollout is ongoing the condition has the status `Progressing` and its
+	// reason reflects the current RolloutPhase.
+	ConditionTypeMachineDeploymentsRolledOut gardencorev1alpha1.ConditionType = "MachineDeploymentsRolledOut"
+
+	// DefaultRolloutTimeout is the default duration after which a rollout of machine deployments is considered failed.
+	DefaultRolloutTimeout = 30 * time.Minute
+)
+
+// RolloutPhase is the phase of a rollout of the machine deployments of a Worker.
+type RolloutPhase string
+
+const (
+	// RolloutPhaseDeploying is the phase in which the machine-controller-manager, the machine classes and the machine
+	// deployments are deployed.
+	RolloutPhaseDeploying RolloutPhase = "Deploying"
+	// RolloutPhaseWaiting is the phase in which the controller waits for the machine deployments to become available.
+	RolloutPhaseWaiting RolloutPhase = "WaitingForMachineDeployments"
+	// RolloutPhaseCleaningUp is the phase in which old machine deployments, classes and secrets are deleted.
+	RolloutPhaseCleaningUp RolloutPhase = "CleaningUp"
+	// RolloutPhaseSucceeded is the phase of a finished rollout.
+	RolloutPhaseSucceeded RolloutPhase = "Succeeded"
+	// RolloutPhaseFailed is the phase of a failed or timed out rollout.
+	RolloutPhaseFailed RolloutPhase = "Failed"
+)
+
+// Rollout is a rollout of the machine deployments of a Worker. It is stored in the State of the Worker.
+type Rollout struct {
+	// Generation is the generation of the Worker the rollout has been started for.
+	Generation int64 `json:"generation"`
+	// StartTime is the time at which the rollout has been started. The rollout timeout is measured from it.
+	StartTime metav1.Time `json:"startTime"`
+}
+
+// CurrentRollout returns the rollout for the current generation of the given Worker, or nil if there is none, if it
+// has succeeded, or if the state of the Worker cannot be decoded. A failed rollout is returned as well, so that retries
+// for the same generation keep its start time and hence do not restart the rollout timeout. Only a change of the
+// Worker or a reconciliation after a successful rollout starts a new rollout.
+func CurrentRollout(worker *extensionsv1alpha1.Worker) *Rollout {
+	if condition := GetRolloutCondition(worker); condition == nil || condition.Status == gardencorev1alpha1.ConditionTrue {
+		return nil
+	}
+
+	state, err := GetState(worker)
+	if err != nil || state.Rollout == nil || state.Rollout.Generation != worker.Generation {
+		return nil
+	}
+	return state.Rollout
+}
+
+// GetRolloutCondition returns the `MachineDeploymentsRolledOut` condition of the given Worker or nil if it does
+// not exist yet.
+func GetRolloutCondition(worker *extensionsv1alpha1.Worker) *gardencorev1alpha1.Condition {
+	return gardencorev1alpha1helper.GetCondition(worker.Status.Conditions, ConditionTypeMachineDeploymentsRolledOut)
+}
+
+// IsRolloutInProgress checks whether a rollout of the machine deployments of the given Worker is ongoing.
+func IsRolloutInProgress(worker *extensionsv1alpha1.Worker) bool {
+	condition := GetRolloutCondition(worker)
+	return condition != nil && condition.Status == gardencorev1alpha1.ConditionProgressing
+}
+
+// RolloutConditions returns the given conditions with an updated `MachineDeploymentsRolledOut` condition for the
+// given phase and message. The status of the condition is derived from the phase.
+func RolloutConditions(conditions []gardencorev1alpha1.Condition, phase RolloutPhase, message string) []gardencorev1alpha1.Condition {
+	condition := gardencorev1alpha1helper.GetCondition(conditions, ConditionTypeMachineDeploymentsRolledOut)
+	if condition == nil {
+		initializedCondition := gardencorev1alpha1helper.InitCondition(ConditionTypeMachineDeploymentsRolledOut)
+		condition = &initializedCondition
+	}
+
+	status := gardencorev1alpha1.ConditionProgressing
+	switch phase {
+	case RolloutPhaseSucceeded:
+		status = gardencorev1alpha1.ConditionTrue
+	case RolloutPhaseFailed:
+		status = gardencorev1alpha1.ConditionFalse
+	}
+
+	return gardencorev1alpha1helper.MergeConditions(conditions, gardencorev1alpha1helper.UpdatedCondition(*condition, status, string(phase), message))
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker_test
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Rollout", func() {
+	DescribeTable("#RolloutConditions",
+		func(phase worker.RolloutPhase, expectedStatus gardencorev1alpha1.ConditionStatus) {
+			conditions := worker.RolloutConditions(nil, phase, "message")
+
+			Expect(conditions).To(HaveLen(1))
+			Expect(conditions[0].Type).To(Equal(worker.ConditionTypeMachineDeploymentsRolledOut))
+			Expect(conditions[0].Status).To(Equal(expectedStatus))
+			Expect(conditions[0].Reason).To(Equal(string(phase)))
+			Expect(conditions[0].Message).To(Equal("message"))
+		},
+
+		Entry("deploying", worker.RolloutPhaseDeploying, gardencorev1alpha1.ConditionProgressing),
+		Entry("waiting", worker.RolloutPhaseWaiting, gardencorev1alpha1.ConditionProgressing),
+		Entry("cleaning up", worker.RolloutPhaseCleaningUp, gardencorev1alpha1.ConditionProgressing),
+		Entry("succeeded", worker.RolloutPhaseSucceeded, gardencorev1alpha1.ConditionTrue),
+		Entry("failed", worker.RolloutPhaseFailed, gardencorev1alpha1.ConditionFalse),
+	)
+
+	Describe("#CurrentRollout", func() {
+		var (
+			w       *extensionsv1alpha1.Worker
+			rollout *worker.Rollout
+		)
+
+		BeforeEach(func() {
+			rollout = &worker.Rollout{Generation: 2, StartTime: metav1.Now()}
+			state, err := (&worker.State{Rollout: rollout}).Marshal()
+			Expect(err).NotTo(HaveOccurred())
+
+			w = &extensionsv1alpha1.Worker{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+			w.Status.State = state
+			w.Status.Conditions = worker.RolloutConditions(nil, worker.RolloutPhaseWaiting, "")
+		})
+
+		It("should return the rollout in progress for the current generation", func() {
+			current := worker.CurrentRollout(w)
+			Expect(current).NotTo(BeNil())
+			Expect(current.Generation).To(Equal(rollout.Generation))
+			Expect(current.StartTime.Unix()).To(Equal(rollout.StartTime.Unix()))
+		})
+
+		It("should return nil if the worker has changed since the rollout was started", func() {
+			w.Generation = 3
+			Expect(worker.CurrentRollout(w)).To(BeNil())
+		})
+
+		It("should return the failed rollout for the current generation", func() {
+			w.Status.Conditions = worker.RolloutConditions(w.Status.Conditions, worker.RolloutPhaseFailed, "")
+			current := worker.CurrentRollout(w)
+			Expect(current).NotTo(BeNil())
+			Expect(current.StartTime.Unix()).To(Equal(rollout.StartTime.Unix()))
+		})
+
+		It("should return nil if the rollout has succeeded", func() {
+			w.Status.Conditions = worker.RolloutConditions(w.Status.Conditions, worker.RolloutPhaseSucceeded, "")
+			Expect(worker.CurrentRollout(w)).To(BeNil())
+		})
+
+		It("should return nil if no rollout has been started", func() {
+			w.Status.Conditions = nil
+			Expect(worker.CurrentRollout(w)).To(BeNil())
+		})
+
+		It("should return nil if the state cannot be decoded", func() {
+			w.Status.State = "{"
+			Expect(worker.CurrentRollout(w)).To(BeNil())
+		})
+	})
+
+	Describe("#IsRolloutInProgress", func() {
+		It("should return false if the condition does not exist", func() {
+			Expect(worker.IsRolloutInProgress(&extensionsv1alpha1.Worker{})).To(BeFalse())
+		})
+
+		It("should return true while the rollout is progressing", func() {
+			w := &extensionsv1alpha1.Worker{}
+			w.Status.Conditions = worker.RolloutConditions(nil, worker.RolloutPhaseWaiting, "")
+			Expect(worker.IsRolloutInProgress(w)).To(BeTrue())
+		})
+
+		It("should return false once the rollout has succeeded", func() {
+			w := &extensionsv1alpha1.Worker{}
+			w.Status.Conditions = worker.RolloutConditions(nil, worker.RolloutPhaseWaiting, "")
+			w.Status.Conditions = worker.RolloutConditions(w.Status.Conditions, worker.RolloutPhaseSucceeded, "")
+			Expect(worker.IsRolloutInProgress(w)).To(BeFalse())
+		})
+	})
+})
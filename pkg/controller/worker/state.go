@@ -0,0 +1,151 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"encoding/json"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// State is the detailed status of the machine deployments of a Worker. It is stored as JSON in the `.status.state`
+// field of the Worker resource so that users can inspect the node provisioning without access to the seed.
+type State struct {
+	// MachineDeployments contains the status of each machine deployment of the Worker.
+	MachineDeployments []MachineDeploymentStatus `json:"machineDeployments,omitempty"`
+	// Rollout is the last rollout of the machine deployments of the Worker.
+	Rollout *Rollout `json:"rollout,omitempty"`
+}
+
+// MachineDeploymentStatus is the status of a single machine deployment of a worker pool in a zone.
+type MachineDeploymentStatus struct {
+	// Name is the name of the `MachineDeployment` resource.
+	Name string `json:"name"`
+	// Pool is the name of the worker pool the machine deployment belongs to.
+	Pool string `json:"pool,omitempty"`
+	// Zone is the availability zone of the machine deployment.
+	Zone string `json:"zone,omitempty"`
+	// MachineClass is the name of the machine class currently used by the machine deployment.
+	MachineClass string `json:"machineClass,omitempty"`
+	// MachineImage is the machine image (name and version) of the worker pool.
+	MachineImage *extensionsv1alpha1.MachineImage `json:"machineImage,omitempty"`
+	// Replicas is the number of non-terminated machines of the machine deployment.
+	Replicas int32 `json:"replicas"`
+	// ReadyReplicas is the number of ready machines of the machine deployment.
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// UpdatedReplicas is the number of machines that have the desired machine class.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// AvailableReplicas is the number of available machines of the machine deployment.
+	AvailableReplicas int32 `json:"availableReplicas"`
+	// FailedMachines is a list of machines whose last operation failed.
+	FailedMachines []FailedMachine `json:"failedMachines,omitempty"`
+}
+
+// FailedMachine contains information about a machine whose last operation failed.
+type FailedMachine struct {
+	// Name is the name of the `Machine` resource.
+	Name string `json:"name"`
+	// ProviderID is the ID of the machine at the infrastructure provider.
+	ProviderID string `json:"providerID,omitempty"`
+	// Operation is the type of the last operation, e.g. `Create`.
+	Operation string `json:"operation,omitempty"`
+	// Description is the description of the last operation.
+	Description string `json:"description,omitempty"`
+	// LastUpdateTime is the time of the last update of the last operation.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ComputeState computes the detailed status of the <wantedMachineDeployments> of the given <worker> based on the
+// status of the <existingMachineDeployments>. Wanted machine deployments which do not exist yet are reported
+// without any replicas. The rollout is taken over from the current state of the <worker>.
+func ComputeState(worker *extensionsv1alpha1.Worker, wantedMachineDeployments MachineDeployments, existingMachineDeployments []machinev1alpha1.MachineDeployment) *State {
+	state := &State{}
+	if currentState, err := GetState(worker); err == nil {
+		state.Rollout = currentState.Rollout
+	}
+
+	for _, wantedMachineDeployment := range wantedMachineDeployments {
+		status := MachineDeploymentStatus{
+			Name:         wantedMachineDeployment.Name,
+			Pool:         wantedMachineDeployment.PoolName,
+			Zone:         wantedMachineDeployment.Zone,
+			MachineClass: wantedMachineDeployment.ClassName,
+		}
+
+		for _, pool := range worker.Spec.Pools {
+			if pool.Name == wantedMachineDeployment.PoolName {
+				machineImage := pool.MachineImage
+				status.MachineImage = &machineImage
+				break
+			}
+		}
+
+		for _, existingMachineDeployment := range existingMachineDeployments {
+			if existingMachineDeployment.Name != wantedMachineDeployment.Name {
+				continue
+			}
+
+			if className := existingMachineDeployment.Spec.Template.Spec.Class.Name; len(className) > 0 {
+				status.MachineClass = className
+			}
+			status.Replicas = existingMachineDeployment.Status.Replicas
+			status.ReadyReplicas = existingMachineDeployment.Status.ReadyReplicas
+			status.UpdatedReplicas = existingMachineDeployment.Status.UpdatedReplicas
+			status.AvailableReplicas = existingMachineDeployment.Status.AvailableReplicas
+
+			for _, failedMachine := range existingMachineDeployment.Status.FailedMachines {
+				if failedMachine == nil {
+					continue
+				}
+				status.FailedMachines = append(status.FailedMachines, FailedMachine{
+					Name:           failedMachine.Name,
+					ProviderID:     failedMachine.ProviderID,
+					Operation:      string(failedMachine.LastOperation.Type),
+					Description:    failedMachine.LastOperation.Description,
+					LastUpdateTime: failedMachine.LastOperation.LastUpdateTime,
+				})
+			}
+			break
+		}
+
+		state.MachineDeployments = append(state.MachineDeployments, status)
+	}
+
+	return state
+}
+
+// Marshal returns the JSON representation of the state which is stored in the `.status.state` field of a Worker.
+func (s *State) Marshal() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// GetState returns the detailed status stored in the `.status.state` field of the given Worker. It returns an empty
+// state if the field is not set.
+func GetState(worker *extensionsv1alpha1.Worker) (*State, error) {
+	state := &State{}
+	if len(worker.Status.State) == 0 {
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(worker.Status.State), state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
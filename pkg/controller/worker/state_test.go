@@ -0,0 +1,153 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker_test
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/worker"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("State", func() {
+	var (
+		w                 *extensionsv1alpha1.Worker
+		wantedDeployments worker.MachineDeployments
+		now               = metav1.Now()
+	)
+
+	BeforeEach(func() {
+		w = &extensionsv1alpha1.Worker{
+			Spec: extensionsv1alpha1.WorkerSpec{
+				Pools: []extensionsv1alpha1.WorkerPool{
+					{
+						Name:         "pool-1",
+						MachineImage: extensionsv1alpha1.MachineImage{Name: "coreos", Version: "2023.5.0"},
+					},
+				},
+			},
+		}
+		wantedDeployments = worker.MachineDeployments{
+			{Name: "pool-1-z1", PoolName: "pool-1", Zone: "zone-a", ClassName: "pool-1-z1-new"},
+			{Name: "pool-1-z2", PoolName: "pool-1", Zone: "zone-b", ClassName: "pool-1-z2-new"},
+		}
+	})
+
+	Describe("#ComputeState", func() {
+		It("should compute the state of the wanted machine deployments", func() {
+			existingDeployments := []machinev1alpha1.MachineDeployment{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pool-1-z1"},
+					Spec: machinev1alpha1.MachineDeploymentSpec{
+						Template: machinev1alpha1.MachineTemplateSpec{
+							Spec: machinev1alpha1.MachineSpec{Class: machinev1alpha1.ClassSpec{Name: "pool-1-z1-old"}},
+						},
+					},
+					Status: machinev1alpha1.MachineDeploymentStatus{
+						Replicas:          3,
+						ReadyReplicas:     2,
+						UpdatedReplicas:   1,
+						AvailableReplicas: 2,
+						FailedMachines: []*machinev1alpha1.MachineSummary{
+							{
+								Name:       "machine-1",
+								ProviderID: "id-1",
+								LastOperation: machinev1alpha1.LastOperation{
+									Type:           machinev1alpha1.MachineOperationCreate,
+									Description:    "quota exceeded",
+									LastUpdateTime: now,
+								},
+							},
+						},
+					},
+				},
+				{ObjectMeta: metav1.ObjectMeta{Name: "obsolete"}},
+			}
+
+			state := worker.ComputeState(w, wantedDeployments, existingDeployments)
+
+			machineImage := &extensionsv1alpha1.MachineImage{Name: "coreos", Version: "2023.5.0"}
+			Expect(state.MachineDeployments).To(Equal([]worker.MachineDeploymentStatus{
+				{
+					Name:              "pool-1-z1",
+					Pool:              "pool-1",
+					Zone:              "zone-a",
+					MachineClass:      "pool-1-z1-old",
+					MachineImage:      machineImage,
+					Replicas:          3,
+					ReadyReplicas:     2,
+					UpdatedReplicas:   1,
+					AvailableReplicas: 2,
+					FailedMachines: []worker.FailedMachine{
+						{
+							Name:           "machine-1",
+							ProviderID:     "id-1",
+							Operation:      string(machinev1alpha1.MachineOperationCreate),
+							Description:    "quota exceeded",
+							LastUpdateTime: now,
+						},
+					},
+				},
+				{
+					Name:         "pool-1-z2",
+					Pool:         "pool-1",
+					Zone:         "zone-b",
+					MachineClass: "pool-1-z2-new",
+					MachineImage: machineImage,
+				},
+			}))
+		})
+
+		It("should take over the rollout of the current state", func() {
+			rollout := &worker.Rollout{Generation: 1, StartTime: now}
+			state, err := (&worker.State{Rollout: rollout}).Marshal()
+			Expect(err).NotTo(HaveOccurred())
+			w.Status.State = state
+
+			computed := worker.ComputeState(w, nil, nil).Rollout
+			Expect(computed).NotTo(BeNil())
+			Expect(computed.Generation).To(Equal(int64(1)))
+			Expect(computed.StartTime.Unix()).To(Equal(now.Unix()))
+		})
+	})
+
+	Describe("#GetState", func() {
+		It("should return an empty state if none is stored", func() {
+			state, err := worker.GetState(w)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.MachineDeployments).To(BeEmpty())
+		})
+
+		It("should return the stored state", func() {
+			state := worker.ComputeState(w, wantedDeployments, nil)
+			data, err := state.Marshal()
+			Expect(err).NotTo(HaveOccurred())
+			w.Status.State = data
+
+			Expect(worker.GetState(w)).To(Equal(state))
+		})
+
+		It("should fail if the stored state is invalid", func() {
+			w.Status.State = "{"
+
+			_, err := worker.GetState(w)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
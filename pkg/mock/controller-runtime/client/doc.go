@@ -12,6 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate mockgen -destination=mocks.go -package=client sigs.k8s.io/controller-runtime/pkg/client Client
+//go:generate mockgen -destination=mocks.go -package=client sigs.k8s.io/controller-runtime/pkg/client Client,StatusWriter
 
 package client
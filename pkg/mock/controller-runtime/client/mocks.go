@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: sigs.k8s.io/controller-runtime/pkg/client (interfaces: Client)
+// Source: sigs.k8s.io/controller-runtime/pkg/client (interfaces: Client,StatusWriter)
 
 // Package client is a generated GoMock package.
 package client
@@ -124,3 +124,40 @@ func (mr *MockClientMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClient)(nil).Update), arg0, arg1)
 }
+
+// MockStatusWriter is a mock of StatusWriter interface
+type MockStatusWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusWriterMockRecorder
+}
+
+// MockStatusWriterMockRecorder is the mock recorder for MockStatusWriter
+type MockStatusWriterMockRecorder struct {
+	mock *MockStatusWriter
+}
+
+// NewMockStatusWriter creates a new mock instance
+func NewMockStatusWriter(ctrl *gomock.Controller) *MockStatusWriter {
+	mock := &MockStatusWriter{ctrl: ctrl}
+	mock.recorder = &MockStatusWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStatusWriter) EXPECT() *MockStatusWriterMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method
+func (m *MockStatusWriter) Update(arg0 context.Context, arg1 runtime.Object) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockStatusWriterMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStatusWriter)(nil).Update), arg0, arg1)
+}
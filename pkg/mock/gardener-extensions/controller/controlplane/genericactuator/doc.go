@@ -12,6 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate mockgen -package=genericactuator -destination=mocks.go github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator ValuesProvider,ChartRendererFactory
+//go:generate mockgen -package=genericactuator -destination=mocks.go github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator ValuesProvider,ChartRendererFactory,DeletionHook
 
 package genericactuator
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator (interfaces: ValuesProvider,ChartRendererFactory)
+// Source: github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator (interfaces: ValuesProvider,ChartRendererFactory,DeletionHook)
 
 // Package genericactuator is a generated GoMock package.
 package genericactuator
@@ -118,3 +118,40 @@ func (mr *MockChartRendererFactoryMockRecorder) NewChartRendererForShoot(arg0 in
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewChartRendererForShoot", reflect.TypeOf((*MockChartRendererFactory)(nil).NewChartRendererForShoot), arg0)
 }
+
+// MockDeletionHook is a mock of DeletionHook interface
+type MockDeletionHook struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeletionHookMockRecorder
+}
+
+// MockDeletionHookMockRecorder is the mock recorder for MockDeletionHook
+type MockDeletionHookMockRecorder struct {
+	mock *MockDeletionHook
+}
+
+// NewMockDeletionHook creates a new mock instance
+func NewMockDeletionHook(ctrl *gomock.Controller) *MockDeletionHook {
+	mock := &MockDeletionHook{ctrl: ctrl}
+	mock.recorder = &MockDeletionHookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeletionHook) EXPECT() *MockDeletionHookMockRecorder {
+	return m.recorder
+}
+
+// BeforeControlPlaneChartDeletion mocks base method
+func (m *MockDeletionHook) BeforeControlPlaneChartDeletion(arg0 context.Context, arg1 *v1alpha1.ControlPlane, arg2 *controller.Cluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeforeControlPlaneChartDeletion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BeforeControlPlaneChartDeletion indicates an expected call of BeforeControlPlaneChartDeletion
+func (mr *MockDeletionHookMockRecorder) BeforeControlPlaneChartDeletion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeforeControlPlaneChartDeletion", reflect.TypeOf((*MockDeletionHook)(nil).BeforeControlPlaneChartDeletion), arg0, arg1, arg2)
+}
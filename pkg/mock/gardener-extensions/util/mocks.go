@@ -1,11 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/gardener-extensions/pkg/util (interfaces: Secrets,Chart,ShootClients)
+// Source: github.com/gardener/gardener-extensions/pkg/util (interfaces: Secrets,RotatableSecrets,Chart,ShootClients)
 
 // Package util is a generated GoMock package.
 package util
 
 import (
 	context "context"
+	util "github.com/gardener/gardener-extensions/pkg/util"
 	chartrenderer "github.com/gardener/gardener/pkg/chartrenderer"
 	kubernetes "github.com/gardener/gardener/pkg/client/kubernetes"
 	imagevector "github.com/gardener/gardener/pkg/utils/imagevector"
@@ -69,6 +70,74 @@ func (mr *MockSecretsMockRecorder) Deploy(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deploy", reflect.TypeOf((*MockSecrets)(nil).Deploy), arg0, arg1, arg2)
 }
 
+// MockRotatableSecrets is a mock of RotatableSecrets interface
+type MockRotatableSecrets struct {
+	ctrl     *gomock.Controller
+	recorder *MockRotatableSecretsMockRecorder
+}
+
+// MockRotatableSecretsMockRecorder is the mock recorder for MockRotatableSecrets
+type MockRotatableSecretsMockRecorder struct {
+	mock *MockRotatableSecrets
+}
+
+// NewMockRotatableSecrets creates a new mock instance
+func NewMockRotatableSecrets(ctrl *gomock.Controller) *MockRotatableSecrets {
+	mock := &MockRotatableSecrets{ctrl: ctrl}
+	mock.recorder = &MockRotatableSecretsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRotatableSecrets) EXPECT() *MockRotatableSecretsMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method
+func (m *MockRotatableSecrets) Delete(arg0 kubernetes0.Interface, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockRotatableSecretsMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRotatableSecrets)(nil).Delete), arg0, arg1)
+}
+
+// Deploy mocks base method
+func (m *MockRotatableSecrets) Deploy(arg0 kubernetes0.Interface, arg1 kubernetes.Interface, arg2 string) (map[string]*v1.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deploy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]*v1.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deploy indicates an expected call of Deploy
+func (mr *MockRotatableSecretsMockRecorder) Deploy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deploy", reflect.TypeOf((*MockRotatableSecrets)(nil).Deploy), arg0, arg1, arg2)
+}
+
+// DeployWithRotation mocks base method
+func (m *MockRotatableSecrets) DeployWithRotation(arg0 kubernetes0.Interface, arg1 kubernetes.Interface, arg2 string, arg3 util.SecretsRotation) (map[string]*v1.Secret, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeployWithRotation", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]*v1.Secret)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeployWithRotation indicates an expected call of DeployWithRotation
+func (mr *MockRotatableSecretsMockRecorder) DeployWithRotation(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployWithRotation", reflect.TypeOf((*MockRotatableSecrets)(nil).DeployWithRotation), arg0, arg1, arg2, arg3)
+}
+
 // MockChart is a mock of Chart interface
 type MockChart struct {
 	ctrl     *gomock.Controller
@@ -106,6 +175,21 @@ func (mr *MockChartMockRecorder) Apply(arg0, arg1, arg2, arg3, arg4, arg5, arg6
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockChart)(nil).Apply), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// ComputeHash mocks base method
+func (m *MockChart) ComputeHash(arg0 chartrenderer.Interface, arg1 string, arg2 imagevector.ImageVector, arg3, arg4 string, arg5 map[string]interface{}) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputeHash", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputeHash indicates an expected call of ComputeHash
+func (mr *MockChartMockRecorder) ComputeHash(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputeHash", reflect.TypeOf((*MockChart)(nil).ComputeHash), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // Delete mocks base method
 func (m *MockChart) Delete(arg0 context.Context, arg1 client.Client, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -38,6 +38,20 @@ func (m *MockEnsurer) EXPECT() *MockEnsurerMockRecorder {
 	return m.recorder
 }
 
+// EnsureClusterAutoscalerDeployment mocks base method
+func (m *MockEnsurer) EnsureClusterAutoscalerDeployment(arg0 context.Context, arg1 *v1.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureClusterAutoscalerDeployment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureClusterAutoscalerDeployment indicates an expected call of EnsureClusterAutoscalerDeployment
+func (mr *MockEnsurerMockRecorder) EnsureClusterAutoscalerDeployment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureClusterAutoscalerDeployment", reflect.TypeOf((*MockEnsurer)(nil).EnsureClusterAutoscalerDeployment), arg0, arg1)
+}
+
 // EnsureETCDStatefulSet mocks base method
 func (m *MockEnsurer) EnsureETCDStatefulSet(arg0 context.Context, arg1 *v1.StatefulSet, arg2 *controller.Cluster) error {
 	m.ctrl.T.Helper()
@@ -123,17 +137,17 @@ func (mr *MockEnsurerMockRecorder) EnsureKubeletCloudProviderConfig(arg0, arg1,
 }
 
 // EnsureKubeletConfiguration mocks base method
-func (m *MockEnsurer) EnsureKubeletConfiguration(arg0 context.Context, arg1 *v1beta1.KubeletConfiguration) error {
+func (m *MockEnsurer) EnsureKubeletConfiguration(arg0 context.Context, arg1 *v1beta1.KubeletConfiguration, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EnsureKubeletConfiguration", arg0, arg1)
+	ret := m.ctrl.Call(m, "EnsureKubeletConfiguration", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // EnsureKubeletConfiguration indicates an expected call of EnsureKubeletConfiguration
-func (mr *MockEnsurerMockRecorder) EnsureKubeletConfiguration(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockEnsurerMockRecorder) EnsureKubeletConfiguration(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureKubeletConfiguration", reflect.TypeOf((*MockEnsurer)(nil).EnsureKubeletConfiguration), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureKubeletConfiguration", reflect.TypeOf((*MockEnsurer)(nil).EnsureKubeletConfiguration), arg0, arg1, arg2)
 }
 
 // EnsureKubeletServiceUnitOptions mocks base method
@@ -0,0 +1,56 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/gardener/gardener/pkg/chartrenderer"
+	gardenerchart "github.com/gardener/gardener/pkg/utils/chart"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+)
+
+// chart is a Chart backed by a Gardener chart.
+type chart struct {
+	*gardenerchart.Chart
+}
+
+// NewChart creates a new Chart for the given Gardener chart.
+func NewChart(c *gardenerchart.Chart) Chart {
+	return &chart{c}
+}
+
+// ComputeHash implements Chart.ComputeHash.
+func (c *chart) ComputeHash(
+	chartRenderer chartrenderer.Interface,
+	namespace string,
+	imageVector imagevector.ImageVector,
+	runtimeVersion, targetVersion string,
+	values map[string]interface{},
+) (string, error) {
+	name, data, err := c.Render(chartRenderer, namespace, imageVector, runtimeVersion, targetVersion, values)
+	if err != nil {
+		return "", err
+	}
+	return ComputeManifestHash(name, data, imageVector), nil
+}
+
+// ComputeManifestHash computes a hash of the given rendered manifests of the chart with the given name together with
+// the given image vector.
+func ComputeManifestHash(name string, data []byte, imageVector imagevector.ImageVector) string {
+	return ComputeChecksum(map[string]interface{}{
+		"name":        name,
+		"manifest":    string(data),
+		"imageVector": imageVector,
+	})
+}
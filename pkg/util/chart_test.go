@@ -0,0 +1,81 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	mockchartrenderer "github.com/gardener/gardener-extensions/pkg/mock/gardener/chartrenderer"
+	. "github.com/gardener/gardener-extensions/pkg/util"
+
+	"github.com/gardener/gardener/pkg/chartrenderer"
+	gardenerchart "github.com/gardener/gardener/pkg/utils/chart"
+	"github.com/gardener/gardener/pkg/utils/imagevector"
+
+	"github.com/golang/mock/gomock"
+	"k8s.io/helm/pkg/manifest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Chart", func() {
+	const (
+		namespace = "test"
+		chartName = "chart"
+		version   = "1.14.0"
+	)
+
+	var (
+		ctrl *gomock.Controller
+
+		imageVector = imagevector.ImageVector{{Name: "foo", Repository: "foo"}}
+		values      = map[string]interface{}{"foo": "bar"}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#ComputeHash", func() {
+		It("should compute the hash of the rendered manifests", func() {
+			chartRenderer := mockchartrenderer.NewMockInterface(ctrl)
+			chartRenderer.EXPECT().Render("path", chartName, namespace, values).Return(&chartrenderer.RenderedChart{
+				ChartName: chartName,
+				Manifests: []manifest.Manifest{{Name: "manifest", Content: "manifest"}},
+			}, nil)
+			chart := NewChart(&gardenerchart.Chart{Name: chartName, Path: "path"})
+
+			hash, err := chart.ComputeHash(chartRenderer, namespace, imageVector, version, version, values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hash).To(Equal(ComputeManifestHash(chartName, []byte("\n---\n# Source: manifest\nmanifest"), imageVector)))
+		})
+	})
+
+	Describe("#ComputeManifestHash", func() {
+		It("should change if the manifests change", func() {
+			Expect(ComputeManifestHash(chartName, []byte("manifest"), imageVector)).
+				NotTo(Equal(ComputeManifestHash(chartName, []byte("other"), imageVector)))
+		})
+
+		It("should change if the image vector changes", func() {
+			otherImageVector := imagevector.ImageVector{{Name: "foo", Repository: "bar"}}
+			Expect(ComputeManifestHash(chartName, []byte("manifest"), imageVector)).
+				NotTo(Equal(ComputeManifestHash(chartName, []byte("manifest"), otherImageVector)))
+		})
+	})
+})
@@ -33,6 +33,14 @@ type Secrets interface {
 	Delete(kubernetes.Interface, string) error
 }
 
+// RotatableSecrets represents a set of secrets whose certificates can be rotated on demand.
+type RotatableSecrets interface {
+	Secrets
+	// DeployWithRotation generates and deploys the secrets into the given namespace like Deploy. In addition, it rotates
+	// the secrets of the given rotation. It returns true if a CA roll is in progress.
+	DeployWithRotation(kubernetes.Interface, gardenerkubernetes.Interface, string, SecretsRotation) (map[string]*corev1.Secret, bool, error)
+}
+
 // Chart represents a Helm chart that can be applied and deleted.
 type Chart interface {
 	// Apply applies this chart in the given namespace using the given ChartApplier. Before applying the chart,
@@ -41,6 +49,9 @@ type Chart interface {
 	// Render renders this chart in the given namespace using the given chartRenderer. Before rendering the chart,
 	// it collects its values, injecting images and merging the given values as needed.
 	Render(chartrenderer.Interface, string, imagevector.ImageVector, string, string, map[string]interface{}) (string, []byte, error)
+	// ComputeHash renders this chart in the given namespace using the given chartRenderer like Render, and computes
+	// a hash of the rendered manifests together with the given image vector.
+	ComputeHash(chartrenderer.Interface, string, imagevector.ImageVector, string, string, map[string]interface{}) (string, error)
 	// Delete deletes this chart's objects from the given namespace.
 	Delete(context.Context, client.Client, string) error
 }
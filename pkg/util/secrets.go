@@ -0,0 +1,295 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardenerkubernetes "github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/secrets"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SecretsRotation is a rotation of a set of secrets that is requested on demand.
+type SecretsRotation string
+
+const (
+	// SecretsRotationNone does not rotate any secrets on demand. Certificates are still renewed before they expire.
+	SecretsRotationNone SecretsRotation = ""
+	// SecretsRotationCertificates regenerates all certificates that are signed by a CA.
+	SecretsRotationCertificates SecretsRotation = "certificates"
+	// SecretsRotationCA starts a two-phase roll of all CAs generated by the extension and regenerates all certificates.
+	// It is rejected if the extension does not generate any CA.
+	SecretsRotationCA SecretsRotation = "ca"
+)
+
+const (
+	// DefaultCertificateRenewalWindow is the default duration before their expiry in which certificates are renewed.
+	DefaultCertificateRenewalWindow = 30 * 24 * time.Hour
+	// DefaultCABundleDuration is the default duration for which the old CA is kept in the CA bundle during a CA roll.
+	// It must be long enough for all clients to pick up the new CA bundle before the old CA certificate is removed.
+	DefaultCABundleDuration = 24 * time.Hour
+
+	// AnnotationCARotationPhase is the annotation on a CA secret that contains the phase of an ongoing CA roll.
+	AnnotationCARotationPhase = "secrets.extensions.gardener.cloud/ca-rotation-phase"
+	// AnnotationCARotationStartTime is the annotation on a CA secret that contains the time an ongoing CA roll has
+	// been started at in RFC 3339 format.
+	AnnotationCARotationStartTime = "secrets.extensions.gardener.cloud/ca-rotation-start-time"
+	// CARotationPhaseBundled is the phase of a CA roll in which the `ca.crt` of the CA secret and of all secrets signed
+	// by it contains both the new and the old CA certificate.
+	CARotationPhaseBundled = "Bundled"
+)
+
+// RotatingSecrets is a set of secrets that renews certificates before they expire and that can be rotated on demand.
+// CAs are rolled in two phases. In the first phase, a new CA is generated and bundled with the old CA certificate, so that
+// both the old and the new certificates are trusted, and all certificates are regenerated. In the second phase, after
+// CABundleDuration has elapsed, the old CA certificate is removed from the bundle and all certificates are regenerated again.
+// Only CAs generated by the extension are rolled. The cluster CA is owned by the gardenlet and is never changed.
+type RotatingSecrets struct {
+	*secrets.Secrets
+
+	// RenewalWindow is the duration before their expiry in which certificates are renewed.
+	RenewalWindow time.Duration
+	// CABundleDuration is the duration for which the old CA is kept in the CA bundle during a CA roll.
+	CABundleDuration time.Duration
+
+	now func() time.Time
+}
+
+// NewRotatingSecrets creates a new RotatingSecrets for the given secrets with the given renewal window and CA bundle duration.
+func NewRotatingSecrets(s *secrets.Secrets, renewalWindow, caBundleDuration time.Duration) *RotatingSecrets {
+	return &RotatingSecrets{
+		Secrets:          s,
+		RenewalWindow:    renewalWindow,
+		CABundleDuration: caBundleDuration,
+		now:              time.Now,
+	}
+}
+
+// Deploy generates and deploys the secrets into the given namespace, taking into account existing secrets.
+// Certificates that expire within the renewal window are regenerated.
+func (s *RotatingSecrets) Deploy(cs kubernetes.Interface, gcs gardenerkubernetes.Interface, namespace string) (map[string]*corev1.Secret, error) {
+	deployedSecrets, _, err := s.DeployWithRotation(cs, gcs, namespace, SecretsRotationNone)
+	return deployedSecrets, err
+}
+
+// DeployWithRotation generates and deploys the secrets into the given namespace like Deploy. In addition, it rotates the
+// secrets of the given rotation and continues an ongoing CA roll. It returns true if a CA roll is in progress. A CA roll
+// is rejected if there are no RollableCAs.
+func (s *RotatingSecrets) DeployWithRotation(
+	cs kubernetes.Interface,
+	gcs gardenerkubernetes.Interface,
+	namespace string,
+	rotation SecretsRotation,
+) (map[string]*corev1.Secret, bool, error) {
+	if rotation != SecretsRotationNone && rotation != SecretsRotationCertificates && rotation != SecretsRotationCA {
+		return nil, false, fmt.Errorf("unsupported secrets rotation '%s'", rotation)
+	}
+
+	rollableCAs := s.RollableCAs()
+	if rotation == SecretsRotationCA && len(rollableCAs) == 0 {
+		return nil, false, fmt.Errorf("secrets rotation '%s' is not supported, as no CA is generated by the extension", rotation)
+	}
+
+	existingSecrets, err := getSecrets(cs, namespace)
+	if err != nil {
+		return nil, false, err
+	}
+
+	now := s.now()
+
+	// Start or complete the roll of the CAs
+	var caRolled, caRollInProgress bool
+	for name, config := range rollableCAs {
+		secret, ok := existingSecrets[name]
+		if !ok {
+			continue
+		}
+
+		rolled, inProgress, err := s.rollCA(cs, secret, config, rotation == SecretsRotationCA, now)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "could not roll CA secret '%s/%s'", namespace, name)
+		}
+		caRolled = caRolled || rolled
+		caRollInProgress = caRollInProgress || inProgress
+	}
+
+	// Delete the secrets whose certificates must be regenerated
+	for _, config := range s.SecretConfigsFunc(nil, namespace) {
+		secret, ok := existingSecrets[config.GetName()]
+		if !ok {
+			continue
+		}
+
+		renew := caRolled || rotation != SecretsRotationNone
+		if !renew {
+			if renew, err = CertificatesExpireWithin(secret, s.RenewalWindow, now); err != nil {
+				return nil, false, errors.Wrapf(err, "could not check expiry of certificates in secret '%s/%s'", namespace, secret.Name)
+			}
+		}
+		if renew {
+			if err := deleteSecret(cs, namespace, secret.Name); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	deployedSecrets, err := s.Secrets.Deploy(cs, gcs, namespace)
+	if err != nil {
+		return nil, false, err
+	}
+	return deployedSecrets, caRollInProgress, nil
+}
+
+// RollableCAs returns the configs of the CAs that are generated by the extension and hence can be rolled, i.e. all CAs
+// except the cluster CA.
+func (s *RotatingSecrets) RollableCAs() map[string]*secrets.CertificateSecretConfig {
+	rollableCAs := make(map[string]*secrets.CertificateSecretConfig, len(s.CertificateSecretConfigs))
+	for name, config := range s.CertificateSecretConfigs {
+		if name != gardencorev1alpha1.SecretNameCACluster {
+			rollableCAs[name] = config
+		}
+	}
+	return rollableCAs
+}
+
+// rollCA starts the roll of the given CA secret if it is forced or if the CA expires within the renewal window, and
+// completes it once the CA bundle duration has elapsed. It returns whether the CA secret has been changed and whether
+// a roll is in progress.
+func (s *RotatingSecrets) rollCA(cs kubernetes.Interface, secret *corev1.Secret, config *secrets.CertificateSecretConfig, force bool, now time.Time) (bool, bool, error) {
+	if secret.Annotations[AnnotationCARotationPhase] == CARotationPhaseBundled {
+		startTime, err := time.Parse(time.RFC3339, secret.Annotations[AnnotationCARotationStartTime])
+		if err != nil {
+			return false, false, errors.Wrapf(err, "could not parse annotation '%s'", AnnotationCARotationStartTime)
+		}
+		if now.Sub(startTime) < s.CABundleDuration {
+			return false, true, nil
+		}
+
+		if err := UnbundleCA(secret); err != nil {
+			return false, false, err
+		}
+		_, err = cs.CoreV1().Secrets(secret.Namespace).Update(secret)
+		return true, false, err
+	}
+
+	if !force {
+		expires, err := CertificatesExpireWithin(secret, s.RenewalWindow, now)
+		if err != nil || !expires {
+			return false, false, err
+		}
+	}
+
+	ca, err := config.GenerateCertificate()
+	if err != nil {
+		return false, false, err
+	}
+	BundleCA(secret, ca, now)
+	_, err = cs.CoreV1().Secrets(secret.Namespace).Update(secret)
+	return true, true, err
+}
+
+// BundleCA starts the roll of the given CA secret to the given new CA. The `ca.crt` of the secret contains the new CA
+// certificate, followed by the old one, and the `ca.key` contains the private key of the new CA.
+func BundleCA(secret *corev1.Secret, ca *secrets.Certificate, now time.Time) {
+	oldCertificatePEM := firstPEMBlock(secret.Data[secrets.DataKeyCertificateCA])
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[secrets.DataKeyCertificateCA] = append(append([]byte{}, ca.CertificatePEM...), oldCertificatePEM...)
+	secret.Data[secrets.DataKeyPrivateKeyCA] = ca.PrivateKeyPEM
+
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationCARotationPhase, CARotationPhaseBundled)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, AnnotationCARotationStartTime, now.UTC().Format(time.RFC3339))
+}
+
+// UnbundleCA completes the roll of the given CA secret by removing the old CA certificate from its `ca.crt`.
+func UnbundleCA(secret *corev1.Secret) error {
+	certificatePEM := firstPEMBlock(secret.Data[secrets.DataKeyCertificateCA])
+	if len(certificatePEM) == 0 {
+		return fmt.Errorf("could not decode the PEM-encoded CA certificate")
+	}
+
+	secret.Data[secrets.DataKeyCertificateCA] = certificatePEM
+	delete(secret.Annotations, AnnotationCARotationPhase)
+	delete(secret.Annotations, AnnotationCARotationStartTime)
+	return nil
+}
+
+// CertificatesExpireWithin checks whether any certificate in the `.crt` data keys of the given secret expires within
+// the given duration after the given time. The certificates of the CA bundle are not checked, as they are renewed
+// together with their CA.
+func CertificatesExpireWithin(secret *corev1.Secret, duration time.Duration, now time.Time) (bool, error) {
+	for key, data := range secret.Data {
+		if !strings.HasSuffix(key, ".crt") {
+			continue
+		}
+		if key == secrets.DataKeyCertificateCA && len(secret.Data[secrets.DataKeyPrivateKeyCA]) == 0 {
+			continue
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return false, fmt.Errorf("could not decode the PEM-encoded certificate in data key '%s'", key)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false, errors.Wrapf(err, "could not parse the certificate in data key '%s'", key)
+		}
+		if certificate.NotAfter.Before(now.Add(duration)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func firstPEMBlock(data []byte) []byte {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil
+	}
+	return pem.EncodeToMemory(block)
+}
+
+func getSecrets(cs kubernetes.Interface, namespace string) (map[string]*corev1.Secret, error) {
+	secretList, err := cs.CoreV1().Secrets(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not list secrets in namespace '%s'", namespace)
+	}
+
+	result := make(map[string]*corev1.Secret, len(secretList.Items))
+	for i := range secretList.Items {
+		result[secretList.Items[i].Name] = &secretList.Items[i]
+	}
+	return result, nil
+}
+
+func deleteSecret(cs kubernetes.Interface, namespace, name string) error {
+	if err := cs.CoreV1().Secrets(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil {
+		return errors.Wrapf(err, "could not delete secret '%s/%s'", namespace, name)
+	}
+	return nil
+}
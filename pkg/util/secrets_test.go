@@ -0,0 +1,218 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/gardener/gardener-extensions/pkg/util"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/secrets"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// secretsClientset is a clientset that only serves secrets from memory and records their updates and deletions.
+type secretsClientset struct {
+	kubernetes.Interface
+	secrets *secretsClient
+}
+
+func (c *secretsClientset) CoreV1() corev1client.CoreV1Interface {
+	return &coreV1Client{secrets: c.secrets}
+}
+
+type coreV1Client struct {
+	corev1client.CoreV1Interface
+	secrets *secretsClient
+}
+
+func (c *coreV1Client) Secrets(string) corev1client.SecretInterface { return c.secrets }
+
+type secretsClient struct {
+	corev1client.SecretInterface
+
+	secrets  []corev1.Secret
+	modified []string
+}
+
+func (c *secretsClient) List(metav1.ListOptions) (*corev1.SecretList, error) {
+	return &corev1.SecretList{Items: c.secrets}, nil
+}
+
+func (c *secretsClient) Update(secret *corev1.Secret) (*corev1.Secret, error) {
+	c.modified = append(c.modified, secret.Name)
+	return secret, nil
+}
+
+func (c *secretsClient) Delete(name string, _ *metav1.DeleteOptions) error {
+	c.modified = append(c.modified, name)
+	return nil
+}
+
+var _ = Describe("Secrets", func() {
+	var (
+		now = time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+
+		generateCA = func() *secrets.Certificate {
+			ca, err := (&secrets.CertificateSecretConfig{Name: "ca", CommonName: "kubernetes", CertType: secrets.CACert}).GenerateCertificate()
+			Expect(err).NotTo(HaveOccurred())
+			return ca
+		}
+
+		caSecret = func(ca *secrets.Certificate) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "test"},
+				Data:       ca.SecretData(),
+			}
+		}
+	)
+
+	Describe("#CertificatesExpireWithin", func() {
+		It("should check the certificate of a CA secret", func() {
+			secret := caSecret(generateCA())
+
+			Expect(CertificatesExpireWithin(secret, DefaultCertificateRenewalWindow, time.Now())).To(BeFalse())
+			Expect(CertificatesExpireWithin(secret, 11*365*24*time.Hour, time.Now())).To(BeTrue())
+		})
+
+		It("should check the certificates of a secret signed by a CA, but not the CA certificate", func() {
+			controlPlane, err := (&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:       "cloud-controller-manager",
+					CommonName: "system:cloud-controller-manager",
+					CertType:   secrets.ClientCert,
+					SigningCA:  generateCA(),
+				},
+			}).GenerateControlPlane()
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{Data: controlPlane.SecretData()}
+
+			Expect(CertificatesExpireWithin(secret, DefaultCertificateRenewalWindow, time.Now())).To(BeFalse())
+			Expect(CertificatesExpireWithin(secret, 11*365*24*time.Hour, time.Now())).To(BeTrue())
+
+			secret.Data[secrets.DataKeyCertificateCA] = []byte("not a certificate")
+			Expect(CertificatesExpireWithin(secret, DefaultCertificateRenewalWindow, time.Now())).To(BeFalse())
+		})
+
+		It("should fail for an undecodable certificate", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{"tls.crt": []byte("not a certificate")}}
+
+			_, err := CertificatesExpireWithin(secret, DefaultCertificateRenewalWindow, time.Now())
+			Expect(err).To(MatchError(ContainSubstring("tls.crt")))
+		})
+	})
+
+	Describe("#BundleCA", func() {
+		It("should bundle the new CA certificate with the old one and switch to the new private key", func() {
+			oldCA, newCA := generateCA(), generateCA()
+			secret := caSecret(oldCA)
+
+			BundleCA(secret, newCA, now)
+
+			Expect(secret.Data[secrets.DataKeyCertificateCA]).To(Equal(append(append([]byte{}, newCA.CertificatePEM...), oldCA.CertificatePEM...)))
+			Expect(secret.Data[secrets.DataKeyPrivateKeyCA]).To(Equal(newCA.PrivateKeyPEM))
+			Expect(secret.Annotations).To(Equal(map[string]string{
+				AnnotationCARotationPhase:     CARotationPhaseBundled,
+				AnnotationCARotationStartTime: "2019-07-01T12:00:00Z",
+			}))
+
+			// The bundled CA must be loaded as the new CA, so that certificates are signed by it
+			ca, err := secrets.LoadCertificate("ca", secret.Data[secrets.DataKeyPrivateKeyCA], secret.Data[secrets.DataKeyCertificateCA])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ca.Certificate.SerialNumber).To(Equal(newCA.Certificate.SerialNumber))
+		})
+
+		It("should only keep the first certificate of an existing bundle", func() {
+			oldCA, newCA := generateCA(), generateCA()
+			secret := caSecret(oldCA)
+			secret.Data[secrets.DataKeyCertificateCA] = append(append([]byte{}, oldCA.CertificatePEM...), generateCA().CertificatePEM...)
+
+			BundleCA(secret, newCA, now)
+
+			Expect(secret.Data[secrets.DataKeyCertificateCA]).To(Equal(append(append([]byte{}, newCA.CertificatePEM...), oldCA.CertificatePEM...)))
+		})
+	})
+
+	Describe("#UnbundleCA", func() {
+		It("should remove the old CA certificate from the bundle", func() {
+			oldCA, newCA := generateCA(), generateCA()
+			secret := caSecret(oldCA)
+			BundleCA(secret, newCA, now)
+
+			Expect(UnbundleCA(secret)).To(Succeed())
+			Expect(secret.Data[secrets.DataKeyCertificateCA]).To(Equal(newCA.CertificatePEM))
+			Expect(secret.Data[secrets.DataKeyPrivateKeyCA]).To(Equal(newCA.PrivateKeyPEM))
+			Expect(secret.Annotations).To(BeEmpty())
+		})
+
+		It("should fail for an undecodable CA certificate", func() {
+			secret := &corev1.Secret{Data: map[string][]byte{secrets.DataKeyCertificateCA: []byte("not a certificate")}}
+
+			Expect(UnbundleCA(secret)).To(MatchError(ContainSubstring("could not decode")))
+			Expect(bytes.Equal(secret.Data[secrets.DataKeyCertificateCA], []byte("not a certificate"))).To(BeTrue())
+		})
+	})
+
+	Describe("#DeployWithRotation", func() {
+		It("should reject a CA roll if no CA is generated by the extension", func() {
+			client := &secretsClient{secrets: []corev1.Secret{*caSecret(generateCA())}}
+			rotatingSecrets := NewRotatingSecrets(&secrets.Secrets{
+				CertificateSecretConfigs: map[string]*secrets.CertificateSecretConfig{
+					gardencorev1alpha1.SecretNameCACluster: {
+						Name:       gardencorev1alpha1.SecretNameCACluster,
+						CommonName: "kubernetes",
+						CertType:   secrets.CACert,
+					},
+				},
+				SecretConfigsFunc: func(map[string]*secrets.Certificate, string) []secrets.ConfigInterface { return nil },
+			}, DefaultCertificateRenewalWindow, DefaultCABundleDuration)
+
+			Expect(rotatingSecrets.RollableCAs()).To(BeEmpty())
+			_, _, err := rotatingSecrets.DeployWithRotation(&secretsClientset{secrets: client}, nil, "test", SecretsRotationCA)
+			Expect(err).To(HaveOccurred())
+			Expect(client.modified).To(BeEmpty())
+		})
+
+		It("should start the roll of a CA generated by the extension", func() {
+			ca := generateCA()
+			extensionCASecret := caSecret(ca)
+			extensionCASecret.Name = "ca-extension"
+			client := &secretsClient{secrets: []corev1.Secret{*extensionCASecret}}
+			rotatingSecrets := NewRotatingSecrets(&secrets.Secrets{
+				CertificateSecretConfigs: map[string]*secrets.CertificateSecretConfig{
+					"ca-extension": {Name: "ca-extension", CommonName: "extension", CertType: secrets.CACert},
+				},
+				SecretConfigsFunc: func(map[string]*secrets.Certificate, string) []secrets.ConfigInterface { return nil },
+			}, DefaultCertificateRenewalWindow, DefaultCABundleDuration)
+
+			_, caRollInProgress, err := rotatingSecrets.DeployWithRotation(&secretsClientset{secrets: client}, nil, "test", SecretsRotationCA)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(caRollInProgress).To(BeTrue())
+			Expect(client.modified).To(Equal([]string{"ca-extension"}))
+			Expect(client.secrets[0].Annotations).To(HaveKeyWithValue(AnnotationCARotationPhase, CARotationPhaseBundled))
+			Expect(bytes.HasSuffix(client.secrets[0].Data[secrets.DataKeyCertificateCA], ca.CertificatePEM)).To(BeTrue())
+			Expect(client.secrets[0].Data[secrets.DataKeyPrivateKeyCA]).NotTo(Equal(ca.PrivateKeyPEM))
+		})
+	})
+})
@@ -45,6 +45,8 @@ const (
 
 	// DisableFlag is the name of the command line flag to disable individual webhooks.
 	DisableFlag = "disable-webhooks"
+	// DryRunFlag is the name of the command line flag to run individual webhooks in dry-run mode.
+	DryRunFlag = "dry-run-webhooks"
 )
 
 // Webhook config modes
@@ -157,9 +159,11 @@ type NameToFactory struct {
 	Func func(manager.Manager) (webhook.Webhook, error)
 }
 
-// SwitchOptions are options to build an AddToManager function that filters the disabled webhooks.
+// SwitchOptions are options to build an AddToManager function that filters the disabled webhooks and runs
+// the dry-run webhooks in dry-run mode.
 type SwitchOptions struct {
 	Disabled []string
+	DryRun   []string
 
 	nameToWebhookFactory     map[string]func(manager.Manager) (webhook.Webhook, error)
 	webhookFactoryAggregator extensionwebhook.FactoryAggregator
@@ -175,6 +179,7 @@ func (w *SwitchOptions) Register(pairs ...NameToFactory) {
 // AddFlags implements Option.
 func (w *SwitchOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&w.Disabled, DisableFlag, w.Disabled, "List of webhooks to disable")
+	fs.StringSliceVar(&w.DryRun, DryRunFlag, w.DryRun, "List of webhooks that only log their mutations instead of applying them")
 }
 
 // Complete implements Option.
@@ -187,10 +192,22 @@ func (w *SwitchOptions) Complete() error {
 		disabled.Insert(disabledName)
 	}
 
+	dryRun := sets.NewString()
+	for _, dryRunName := range w.DryRun {
+		if _, ok := w.nameToWebhookFactory[dryRunName]; !ok {
+			return fmt.Errorf("cannot dry-run unknown webhook %q", dryRunName)
+		}
+		dryRun.Insert(dryRunName)
+	}
+
 	for name, addToManager := range w.nameToWebhookFactory {
-		if !disabled.Has(name) {
-			w.webhookFactoryAggregator.Register(addToManager)
+		if disabled.Has(name) {
+			continue
+		}
+		if dryRun.Has(name) {
+			addToManager = extensionwebhook.DryRun(addToManager)
 		}
+		w.webhookFactoryAggregator.Register(addToManager)
 	}
 	return nil
 }
@@ -269,10 +286,11 @@ type AddToManagerConfig struct {
 
 // AddToManager instantiates all webhooks of this configuration. If there are any webhooks, it creates a
 // webhook server, registers the webhooks and adds the server to the manager. Otherwise, it is a no-op.
-func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) error {
+// It returns the configuration to register the shoot webhooks in the shoot clusters, or nil if there are none.
+func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) (*extensionwebhook.ShootWebhookConfig, error) {
 	webhooks, err := c.Switch.WebhooksFactory(mgr)
 	if err != nil {
-		return errors.Wrapf(err, "could not create webhooks")
+		return nil, errors.Wrapf(err, "could not create webhooks")
 	}
 
 	return extensionwebhook.NewServerBuilder(c.serverName, c.Server.Options(), webhooks...).AddToManager(mgr)
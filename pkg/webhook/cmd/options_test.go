@@ -180,6 +180,32 @@ var _ = Describe("Options", func() {
 				Expect(switches.Disabled).To(Equal([]string{name1, name2}))
 			})
 
+			It("should correctly parse the dry-run flag", func() {
+				var (
+					name1    = "foo"
+					name2    = "bar"
+					switches = NewSwitchOptions(
+						Switch(name1, nil),
+						Switch(name2, nil),
+					)
+				)
+
+				fs := pflag.NewFlagSet(commandName, pflag.ContinueOnError)
+				switches.AddFlags(fs)
+
+				err := fs.Parse(test.NewCommandBuilder(commandName).
+					Flags(
+						test.StringSliceFlag(DryRunFlag, name1),
+					).
+					Command().
+					Slice())
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(switches.Complete()).To(Succeed())
+
+				Expect(switches.DryRun).To(Equal([]string{name1}))
+			})
+
 			It("should error on an unknown webhook", func() {
 				switches := NewSwitchOptions()
 
@@ -196,6 +222,23 @@ var _ = Describe("Options", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(switches.Complete()).To(HaveOccurred())
 			})
+
+			It("should error on an unknown dry-run webhook", func() {
+				switches := NewSwitchOptions()
+
+				fs := pflag.NewFlagSet(commandName, pflag.ContinueOnError)
+				switches.AddFlags(fs)
+
+				err := fs.Parse(test.NewCommandBuilder(commandName).
+					Flags(
+						test.StringSliceFlag(DryRunFlag, "unknown"),
+					).
+					Command().
+					Slice())
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(switches.Complete()).To(HaveOccurred())
+			})
 		})
 
 		Describe("#AddToManager", func() {
@@ -225,6 +268,30 @@ var _ = Describe("Options", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(webhooks).To(Equal([]webhook.Webhook{wh1}))
 			})
+
+			It("should return a configuration that fails for dry-run webhooks that do not support a dry-run mode", func() {
+				var (
+					f1 = mockextensionswebhook.NewMockFactory(ctrl)
+
+					name1 = "name1"
+
+					switches = NewSwitchOptions(
+						Switch(name1, f1.Do),
+					)
+
+					wh1 = mockwebhook.NewMockWebhook(ctrl)
+				)
+
+				f1.EXPECT().Do(nil).Return(wh1, nil)
+				wh1.EXPECT().GetName().Return(name1)
+
+				switches.DryRun = []string{name1}
+
+				Expect(switches.Complete()).To(Succeed())
+
+				_, err := switches.Completed().WebhooksFactory(nil)
+				Expect(err).To(HaveOccurred())
+			})
 		})
 	})
 })
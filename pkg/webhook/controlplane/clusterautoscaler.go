@@ -0,0 +1,49 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"strconv"
+
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+const clusterAutoscalerContainerName = "cluster-autoscaler"
+
+// EnsureClusterAutoscalerCommandLineArgs ensures that the command of the cluster-autoscaler container of the given
+// deployment contains the flags of the given configuration. Flags that are not configured are left unchanged. The
+// node groups, i.e. the `--nodes` flags, are never changed: they are owned by Gardener, and the worker actuator
+// temporarily rewrites them to freeze the node groups being rolled, which must not be reverted by this webhook.
+func EnsureClusterAutoscalerCommandLineArgs(dep *appsv1.Deployment, config *extensionsconfig.ClusterAutoscaler) {
+	c := ContainerWithName(dep.Spec.Template.Spec.Containers, clusterAutoscalerContainerName)
+	if c == nil {
+		return
+	}
+
+	if config.Expander != nil {
+		c.Command = EnsureStringWithPrefix(c.Command, "--expander=", *config.Expander)
+	}
+	if config.BalanceSimilarNodeGroups != nil {
+		c.Command = EnsureStringWithPrefix(c.Command, "--balance-similar-node-groups=", strconv.FormatBool(*config.BalanceSimilarNodeGroups))
+	}
+	if config.ScaleDownDelayAfterAdd != nil {
+		c.Command = EnsureStringWithPrefix(c.Command, "--scale-down-delay-after-add=", config.ScaleDownDelayAfterAdd.Duration.String())
+	}
+	if config.ScaleDownUnneededTime != nil {
+		c.Command = EnsureStringWithPrefix(c.Command, "--scale-down-unneeded-time=", config.ScaleDownUnneededTime.Duration.String())
+	}
+}
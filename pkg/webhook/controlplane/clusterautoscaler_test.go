@@ -0,0 +1,70 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"time"
+
+	extensionsconfig "github.com/gardener/gardener-extensions/pkg/apis/config"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ClusterAutoscaler", func() {
+	Describe("#EnsureClusterAutoscalerCommandLineArgs", func() {
+		var newDeployment = func(command ...string) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "cluster-autoscaler", Command: command}},
+						},
+					},
+				},
+			}
+		}
+
+		It("should add or modify the configured flags and keep the node groups", func() {
+			dep := newDeployment("./cluster-autoscaler", "--expander=random", "--scale-down-unneeded-time=30m", "--nodes=0:2:shoot--foo--bar-worker-z1")
+
+			EnsureClusterAutoscalerCommandLineArgs(dep, &extensionsconfig.ClusterAutoscaler{
+				Expander:                 util.StringPtr("least-waste"),
+				BalanceSimilarNodeGroups: util.BoolPtr(true),
+				ScaleDownDelayAfterAdd:   &metav1.Duration{Duration: 30 * time.Minute},
+				ScaleDownUnneededTime:    &metav1.Duration{Duration: 20 * time.Minute},
+			})
+			Expect(dep).To(Equal(newDeployment(
+				"./cluster-autoscaler",
+				"--expander=least-waste",
+				"--scale-down-unneeded-time=20m0s",
+				"--nodes=0:2:shoot--foo--bar-worker-z1",
+				"--balance-similar-node-groups=true",
+				"--scale-down-delay-after-add=30m0s",
+			)))
+		})
+
+		It("should not modify the deployment if nothing is configured", func() {
+			dep := newDeployment("./cluster-autoscaler", "--expander=random")
+
+			EnsureClusterAutoscalerCommandLineArgs(dep, &extensionsconfig.ClusterAutoscaler{})
+			Expect(dep).To(Equal(newDeployment("./cluster-autoscaler", "--expander=random")))
+		})
+	})
+})
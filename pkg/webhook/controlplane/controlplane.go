@@ -31,6 +31,10 @@ const (
 	ExposureWebhookName = "controlplaneexposure"
 	// BackupWebhookName is the backup webhook name.
 	BackupWebhookName = "controlplanebackup"
+
+	// AnnotationSkipMutation is the annotation that opts an object out of the mutations of the controlplane webhooks
+	// if it is set to "true", e.g. during incidents.
+	AnnotationSkipMutation = "controlplane.webhook.extensions.gardener.cloud/skip-mutation"
 )
 
 var logger = log.Log.WithName("controlplane-webhook")
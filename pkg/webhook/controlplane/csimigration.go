@@ -0,0 +1,104 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FeatureGateCSIMigration is the feature gate that enables the migration of in-tree volume plugins to CSI drivers.
+	// It must be enabled together with the provider-specific feature gate of the in-tree volume plugin.
+	FeatureGateCSIMigration = "CSIMigration"
+
+	featureGatesFlagPrefix = "--feature-gates="
+)
+
+// CSIMigrationFeatureGates returns the feature gates that migrate the in-tree volume plugin with the given
+// provider-specific feature gate, e.g. `CSIMigrationAWS`, to its CSI driver.
+func CSIMigrationFeatureGates(pluginFeatureGate string) map[string]bool {
+	return map[string]bool{
+		FeatureGateCSIMigration: true,
+		pluginFeatureGate:       true,
+	}
+}
+
+// EnsureFeatureGatesFlag ensures that the `--feature-gates` flag in the given command line contains the given feature
+// gates with the given values, keeping all other feature gates it already contains.
+func EnsureFeatureGatesFlag(command []string, featureGates map[string]bool) []string {
+	if len(featureGates) == 0 {
+		return command
+	}
+
+	gates := make(map[string]string)
+	if i := StringWithPrefixIndex(command, featureGatesFlagPrefix); i >= 0 {
+		for _, gate := range strings.Split(strings.TrimPrefix(command[i], featureGatesFlagPrefix), ",") {
+			if kv := strings.SplitN(gate, "=", 2); len(kv) == 2 {
+				gates[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+			}
+		}
+	}
+	for name, enabled := range featureGates {
+		gates[name] = strconv.FormatBool(enabled)
+	}
+
+	names := make([]string, 0, len(gates))
+	for name := range gates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=%s", name, gates[name]))
+	}
+	return EnsureStringWithPrefix(command, featureGatesFlagPrefix, strings.Join(values, ","))
+}
+
+// EnsureKubeletFeatureGates ensures that the given kubelet configuration contains the given feature gates with the
+// given values.
+func EnsureKubeletFeatureGates(kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, featureGates map[string]bool) {
+	if len(featureGates) == 0 {
+		return
+	}
+	if kubeletConfig.FeatureGates == nil {
+		kubeletConfig.FeatureGates = make(map[string]bool)
+	}
+	for name, enabled := range featureGates {
+		kubeletConfig.FeatureGates[name] = enabled
+	}
+}
+
+// GetControlPlane returns the ControlPlane of the given extension type in the given namespace, or nil if there is none.
+func GetControlPlane(ctx context.Context, c client.Client, namespace, extensionType string) (*extensionsv1alpha1.ControlPlane, error) {
+	cpList := &extensionsv1alpha1.ControlPlaneList{}
+	if err := c.List(ctx, client.InNamespace(namespace), cpList); err != nil {
+		return nil, errors.Wrapf(err, "could not list controlplanes in namespace '%s'", namespace)
+	}
+	for _, cp := range cpList.Items {
+		if cp.Spec.Type == extensionType {
+			return cp.DeepCopy(), nil
+		}
+	}
+	return nil, nil
+}
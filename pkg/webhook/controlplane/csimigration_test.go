@@ -0,0 +1,99 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CSI migration", func() {
+	const namespace = "shoot--foo--bar"
+
+	var featureGates = CSIMigrationFeatureGates("CSIMigrationAWS")
+
+	Describe("#CSIMigrationFeatureGates", func() {
+		It("should enable the generic and the plugin feature gate", func() {
+			Expect(featureGates).To(Equal(map[string]bool{"CSIMigration": true, "CSIMigrationAWS": true}))
+		})
+	})
+
+	Describe("#EnsureFeatureGatesFlag", func() {
+		It("should add the flag if it doesn't exist", func() {
+			Expect(EnsureFeatureGatesFlag([]string{"kcm", "--foo=bar"}, featureGates)).To(Equal(
+				[]string{"kcm", "--foo=bar", "--feature-gates=CSIMigration=true,CSIMigrationAWS=true"}))
+		})
+
+		It("should keep the existing feature gates and override conflicting values", func() {
+			Expect(EnsureFeatureGatesFlag([]string{"kcm", "--feature-gates=VolumeSnapshotDataSource=true,CSIMigration=false", "--foo=bar"}, featureGates)).To(Equal(
+				[]string{"kcm", "--feature-gates=CSIMigration=true,CSIMigrationAWS=true,VolumeSnapshotDataSource=true", "--foo=bar"}))
+		})
+
+		It("should not change the command line without feature gates", func() {
+			Expect(EnsureFeatureGatesFlag([]string{"kcm"}, nil)).To(Equal([]string{"kcm"}))
+		})
+	})
+
+	Describe("#EnsureKubeletFeatureGates", func() {
+		It("should add the feature gates to the kubelet configuration", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{FeatureGates: map[string]bool{"Foo": true, "CSIMigration": false}}
+			EnsureKubeletFeatureGates(kubeletConfig, featureGates)
+			Expect(kubeletConfig.FeatureGates).To(Equal(map[string]bool{"Foo": true, "CSIMigration": true, "CSIMigrationAWS": true}))
+		})
+
+		It("should initialize the feature gates of the kubelet configuration", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{}
+			EnsureKubeletFeatureGates(kubeletConfig, featureGates)
+			Expect(kubeletConfig.FeatureGates).To(Equal(featureGates))
+		})
+	})
+
+	Describe("#GetControlPlane", func() {
+		newControlPlane := func(name, extensionType string) *extensionsv1alpha1.ControlPlane {
+			return &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: extensionType},
+				},
+			}
+		}
+
+		It("should return the controlplane of the given type", func() {
+			s := runtime.NewScheme()
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+			c := fake.NewFakeClientWithScheme(s, newControlPlane("exposure", "foo"), newControlPlane("control-plane", "aws"))
+
+			cp, err := GetControlPlane(context.TODO(), c, namespace, "aws")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cp).NotTo(BeNil())
+			Expect(cp.Name).To(Equal("control-plane"))
+		})
+
+		It("should return nil if there is no controlplane of the given type", func() {
+			s := runtime.NewScheme()
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+			c := fake.NewFakeClientWithScheme(s, newControlPlane("control-plane", "foo"))
+
+			Expect(GetControlPlane(context.TODO(), c, namespace, "aws")).To(BeNil())
+		})
+	})
+})
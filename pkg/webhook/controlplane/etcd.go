@@ -28,9 +28,11 @@ import (
 const EtcdMainVolumeClaimTemplateName = "main-etcd"
 
 // GetBackupRestoreContainer returns an etcd backup-restore container with the given name, schedule, provider, image,
-// and additional provider-specific command line args and env variables.
+// and additional provider-specific command line args and env variables. The settings of the given etcd backup
+// configuration, if any, take precedence over the given schedule and the default settings.
 func GetBackupRestoreContainer(
 	name, volumeClaimTemplateName, schedule, provider, image string,
+	backupConfig *ETCDBackupConfig,
 	args map[string]string,
 	env []corev1.EnvVar,
 	volumeMounts []corev1.VolumeMount,
@@ -92,6 +94,11 @@ func GetBackupRestoreContainer(
 		},
 	}
 
+	// Ensure the settings of the etcd backup configuration
+	if backupConfig != nil {
+		c.Command = ensureETCDBackupConfigCommandLineArgs(c.Command, backupConfig)
+	}
+
 	// Ensure additional command line args
 	for k, v := range args {
 		c.Command = EnsureStringWithPrefix(c.Command, fmt.Sprintf("--%s=", k), v)
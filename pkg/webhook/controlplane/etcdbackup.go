@@ -0,0 +1,255 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// AnnotationETCDBackupConfig is the annotation on Shoots that contains the ETCDBackupConfig
+	// of the shoot as JSON object.
+	AnnotationETCDBackupConfig = "controlplane.extensions.gardener.cloud/etcd-backup"
+
+	// GarbageCollectionPolicyExponential is the garbage collection policy that keeps the full snapshots
+	// of the last hours, days, and weeks with an exponentially decreasing frequency.
+	GarbageCollectionPolicyExponential = "Exponential"
+	// GarbageCollectionPolicyLimitBased is the garbage collection policy that keeps a fixed number of
+	// the most recent full snapshots.
+	GarbageCollectionPolicyLimitBased = "LimitBased"
+)
+
+// ETCDBackupConfig is the etcd backup configuration of a shoot. It takes precedence over the backup
+// settings of the provider and the defaults of the etcd backup-restore container.
+type ETCDBackupConfig struct {
+	// Schedule is the cron schedule of full snapshots.
+	Schedule *string `json:"schedule,omitempty"`
+	// DeltaSnapshotPeriod is the period between delta snapshots. A period of 0 disables delta snapshots.
+	DeltaSnapshotPeriod *metav1.Duration `json:"deltaSnapshotPeriod,omitempty"`
+	// DeltaSnapshotMemoryLimit is the amount of memory after which the events of a delta snapshot
+	// are written to the store before the period is over.
+	DeltaSnapshotMemoryLimit *resource.Quantity `json:"deltaSnapshotMemoryLimit,omitempty"`
+	// GarbageCollectionPolicy is the policy for garbage collecting old snapshots, either
+	// `Exponential` or `LimitBased`. It defaults to `LimitBased` if MaxBackups is set.
+	GarbageCollectionPolicy *string `json:"garbageCollectionPolicy,omitempty"`
+	// MaxBackups is the number of full snapshots to keep with the `LimitBased` garbage collection policy.
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+var (
+	garbageCollectionPolicies = []string{GarbageCollectionPolicyExponential, GarbageCollectionPolicyLimitBased}
+
+	// cronFields are the names and value ranges of the five fields of a cron schedule.
+	// The day of the week allows 7 as an alias for Sunday.
+	cronFields = []struct {
+		name     string
+		min, max int
+	}{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day of month", 1, 31},
+		{"month", 1, 12},
+		{"day of week", 0, 7},
+	}
+)
+
+// ETCDBackupConfigFromShoot returns the etcd backup configuration of the given shoot, or nil if it has none.
+// The schedule and maximum of the `backup` field of the shoot are overridden by the fields of the
+// AnnotationETCDBackupConfig annotation. An annotation that can't be decoded or is invalid is logged
+// and ignored, so that it doesn't block updates of the etcd stateful set.
+func ETCDBackupConfigFromShoot(shoot *gardenv1beta1.Shoot, logger logr.Logger) *ETCDBackupConfig {
+	config := &ETCDBackupConfig{}
+	if backup := shoot.Spec.Backup; backup != nil {
+		if len(backup.Schedule) > 0 {
+			schedule := backup.Schedule
+			config.Schedule = &schedule
+		}
+		if backup.Maximum > 0 {
+			maxBackups := int32(backup.Maximum)
+			config.MaxBackups = &maxBackups
+		}
+	}
+
+	if data, ok := shoot.Annotations[AnnotationETCDBackupConfig]; ok {
+		overrides := &ETCDBackupConfig{}
+		if err := json.Unmarshal([]byte(data), overrides); err != nil {
+			logger.Error(err, "Ignoring undecodable etcd backup configuration annotation", "annotation", AnnotationETCDBackupConfig, "shoot", shoot.Name)
+		} else if errs := ValidateETCDBackupConfig(overrides, field.NewPath("metadata", "annotations").Key(AnnotationETCDBackupConfig)); len(errs) > 0 {
+			logger.Error(errs.ToAggregate(), "Ignoring invalid etcd backup configuration annotation", "annotation", AnnotationETCDBackupConfig, "shoot", shoot.Name)
+		} else {
+			mergeETCDBackupConfig(config, overrides)
+		}
+	}
+
+	if *config == (ETCDBackupConfig{}) {
+		return nil
+	}
+	if config.MaxBackups != nil && config.GarbageCollectionPolicy == nil {
+		policy := GarbageCollectionPolicyLimitBased
+		config.GarbageCollectionPolicy = &policy
+	}
+	return config
+}
+
+// ValidateETCDBackupConfig validates the given etcd backup configuration.
+func ValidateETCDBackupConfig(config *ETCDBackupConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.Schedule != nil {
+		if err := validateCronSchedule(*config.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), *config.Schedule, fmt.Sprintf("must be a cron schedule: %v", err)))
+		}
+	}
+
+	if period := config.DeltaSnapshotPeriod; period != nil {
+		if period.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("deltaSnapshotPeriod"), period.Duration.String(), "must not be negative"))
+		} else if period.Duration%time.Second != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("deltaSnapshotPeriod"), period.Duration.String(), "must be a whole number of seconds"))
+		}
+	}
+
+	if limit := config.DeltaSnapshotMemoryLimit; limit != nil && limit.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("deltaSnapshotMemoryLimit"), limit.String(), "must be greater than 0"))
+	}
+
+	if policy := config.GarbageCollectionPolicy; policy != nil && !containsString(garbageCollectionPolicies, *policy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("garbageCollectionPolicy"), *policy, garbageCollectionPolicies))
+	}
+
+	if config.MaxBackups != nil {
+		if *config.MaxBackups <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackups"), *config.MaxBackups, "must be greater than 0"))
+		}
+		if config.GarbageCollectionPolicy != nil && *config.GarbageCollectionPolicy != GarbageCollectionPolicyLimitBased {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("maxBackups"), "is only supported with garbage collection policy "+GarbageCollectionPolicyLimitBased))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCronSchedule checks that the given schedule has five fields whose values are within the ranges
+// of cronFields. Every field is a comma-separated list of `*`, values, or ranges `a-b`, each optionally
+// followed by a step `/n`.
+func validateCronSchedule(schedule string) error {
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	for i, f := range fields {
+		for _, item := range strings.Split(f, ",") {
+			if err := validateCronItem(item, cronFields[i].min, cronFields[i].max); err != nil {
+				return errors.Wrapf(err, "invalid %s field '%s'", cronFields[i].name, f)
+			}
+		}
+	}
+	return nil
+}
+
+func validateCronItem(item string, min, max int) error {
+	expr := item
+	if i := strings.Index(item, "/"); i >= 0 {
+		expr = item[:i]
+		if _, err := parseCronValue(item[i+1:], 1, max); err != nil {
+			return errors.Wrap(err, "invalid step")
+		}
+	}
+	if expr == "*" {
+		return nil
+	}
+
+	bounds := strings.SplitN(expr, "-", 2)
+	low, err := parseCronValue(bounds[0], min, max)
+	if err != nil {
+		return err
+	}
+	if len(bounds) == 2 {
+		high, err := parseCronValue(bounds[1], min, max)
+		if err != nil {
+			return err
+		}
+		if high < low {
+			return fmt.Errorf("range '%s' ends before it starts", expr)
+		}
+	}
+	return nil
+}
+
+func parseCronValue(s string, min, max int) (int, error) {
+	if len(s) == 0 || strings.Trim(s, "0123456789") != "" {
+		return 0, fmt.Errorf("'%s' is not a number", s)
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < min || value > max {
+		return 0, fmt.Errorf("'%s' is not between %d and %d", s, min, max)
+	}
+	return value, nil
+}
+
+func mergeETCDBackupConfig(config, overrides *ETCDBackupConfig) {
+	if overrides.Schedule != nil {
+		config.Schedule = overrides.Schedule
+	}
+	if overrides.DeltaSnapshotPeriod != nil {
+		config.DeltaSnapshotPeriod = overrides.DeltaSnapshotPeriod
+	}
+	if overrides.DeltaSnapshotMemoryLimit != nil {
+		config.DeltaSnapshotMemoryLimit = overrides.DeltaSnapshotMemoryLimit
+	}
+	if overrides.GarbageCollectionPolicy != nil {
+		config.GarbageCollectionPolicy = overrides.GarbageCollectionPolicy
+		// The maximum of the shoot's backup field doesn't apply to other policies
+		if *overrides.GarbageCollectionPolicy != GarbageCollectionPolicyLimitBased {
+			config.MaxBackups = nil
+		}
+	}
+	if overrides.MaxBackups != nil {
+		config.MaxBackups = overrides.MaxBackups
+	}
+}
+
+func ensureETCDBackupConfigCommandLineArgs(command []string, config *ETCDBackupConfig) []string {
+	if config.Schedule != nil {
+		command = EnsureStringWithPrefix(command, "--schedule=", *config.Schedule)
+	}
+	if config.DeltaSnapshotPeriod != nil {
+		command = EnsureStringWithPrefix(command, "--delta-snapshot-period-seconds=",
+			strconv.FormatInt(int64(config.DeltaSnapshotPeriod.Duration/time.Second), 10))
+	}
+	if config.DeltaSnapshotMemoryLimit != nil {
+		command = EnsureStringWithPrefix(command, "--delta-snapshot-memory-limit=",
+			strconv.FormatInt(config.DeltaSnapshotMemoryLimit.Value(), 10))
+	}
+	if config.GarbageCollectionPolicy != nil {
+		command = EnsureStringWithPrefix(command, "--garbage-collection-policy=", *config.GarbageCollectionPolicy)
+	}
+	if config.MaxBackups != nil {
+		command = EnsureStringWithPrefix(command, "--max-backups=", strconv.Itoa(int(*config.MaxBackups)))
+	}
+	return command
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+var _ = Describe("ETCDBackupConfig", func() {
+	Describe("#ETCDBackupConfigFromShoot", func() {
+		var (
+			shoot  *gardenv1beta1.Shoot
+			logger = log.Log.WithName("test")
+		)
+
+		BeforeEach(func() {
+			shoot = &gardenv1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "bar"},
+				Spec: gardenv1beta1.ShootSpec{
+					Backup: &gardenv1beta1.Backup{
+						Schedule: "0 */12 * * *",
+						Maximum:  5,
+					},
+				},
+			}
+		})
+
+		It("should return the schedule and maximum of the backup field", func() {
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(Equal(&ETCDBackupConfig{
+				Schedule:                util.StringPtr("0 */12 * * *"),
+				GarbageCollectionPolicy: util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:              util.Int32Ptr(5),
+			}))
+		})
+
+		It("should override the backup field with the annotation", func() {
+			shoot.Annotations = map[string]string{
+				AnnotationETCDBackupConfig: `{"schedule":"30 */6 * * *","deltaSnapshotPeriod":"1m","deltaSnapshotMemoryLimit":"200Mi","maxBackups":10}`,
+			}
+
+			deltaSnapshotMemoryLimit := resource.MustParse("200Mi")
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(Equal(&ETCDBackupConfig{
+				Schedule:                 util.StringPtr("30 */6 * * *"),
+				DeltaSnapshotPeriod:      &metav1.Duration{Duration: time.Minute},
+				DeltaSnapshotMemoryLimit: &deltaSnapshotMemoryLimit,
+				GarbageCollectionPolicy:  util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:               util.Int32Ptr(10),
+			}))
+		})
+
+		It("should drop the maximum of the backup field for the exponential garbage collection policy", func() {
+			shoot.Annotations = map[string]string{
+				AnnotationETCDBackupConfig: `{"garbageCollectionPolicy":"Exponential"}`,
+			}
+
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(Equal(&ETCDBackupConfig{
+				Schedule:                util.StringPtr("0 */12 * * *"),
+				GarbageCollectionPolicy: util.StringPtr(GarbageCollectionPolicyExponential),
+			}))
+		})
+
+		It("should return nil without backup field and annotation", func() {
+			shoot.Spec.Backup = nil
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(BeNil())
+		})
+
+		It("should ignore an undecodable annotation", func() {
+			shoot.Annotations = map[string]string{AnnotationETCDBackupConfig: `{"maxBackups":"many"}`}
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(Equal(&ETCDBackupConfig{
+				Schedule:                util.StringPtr("0 */12 * * *"),
+				GarbageCollectionPolicy: util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:              util.Int32Ptr(5),
+			}))
+		})
+
+		It("should ignore an invalid annotation", func() {
+			shoot.Annotations = map[string]string{AnnotationETCDBackupConfig: `{"schedule":"99 99 * * *","maxBackups":10}`}
+			Expect(ETCDBackupConfigFromShoot(shoot, logger)).To(Equal(&ETCDBackupConfig{
+				Schedule:                util.StringPtr("0 */12 * * *"),
+				GarbageCollectionPolicy: util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:              util.Int32Ptr(5),
+			}))
+		})
+	})
+
+	Describe("#ValidateETCDBackupConfig", func() {
+		DescribeTable("should validate the configuration",
+			func(config *ETCDBackupConfig, matcher OmegaMatcher) {
+				Expect(ValidateETCDBackupConfig(config, field.NewPath("etcdBackup"))).To(matcher)
+			},
+			Entry("empty configuration", &ETCDBackupConfig{}, BeEmpty()),
+			Entry("valid configuration", &ETCDBackupConfig{
+				Schedule:                 util.StringPtr("*/30 1-23 * * 1,3,5"),
+				DeltaSnapshotPeriod:      &metav1.Duration{Duration: 0},
+				DeltaSnapshotMemoryLimit: resource.NewQuantity(1024, resource.BinarySI),
+				GarbageCollectionPolicy:  util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:               util.Int32Ptr(7),
+			}, BeEmpty()),
+			Entry("invalid schedule", &ETCDBackupConfig{Schedule: util.StringPtr("0 */24 * *")}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("etcdBackup.schedule"),
+				})),
+			)),
+			Entry("negative delta snapshot period", &ETCDBackupConfig{DeltaSnapshotPeriod: &metav1.Duration{Duration: -time.Minute}}, HaveLen(1)),
+			Entry("fractional delta snapshot period", &ETCDBackupConfig{DeltaSnapshotPeriod: &metav1.Duration{Duration: 1500 * time.Millisecond}}, HaveLen(1)),
+			Entry("zero delta snapshot memory limit", &ETCDBackupConfig{DeltaSnapshotMemoryLimit: resource.NewQuantity(0, resource.BinarySI)}, HaveLen(1)),
+			Entry("unsupported garbage collection policy", &ETCDBackupConfig{GarbageCollectionPolicy: util.StringPtr("KeepAll")}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("etcdBackup.garbageCollectionPolicy"),
+				})),
+			)),
+			Entry("non-positive max backups", &ETCDBackupConfig{MaxBackups: util.Int32Ptr(0)}, HaveLen(1)),
+			Entry("max backups with exponential garbage collection policy", &ETCDBackupConfig{
+				GarbageCollectionPolicy: util.StringPtr(GarbageCollectionPolicyExponential),
+				MaxBackups:              util.Int32Ptr(7),
+			}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("etcdBackup.maxBackups"),
+				})),
+			)),
+		)
+	})
+
+	Describe("#GetBackupRestoreContainer", func() {
+		It("should apply the etcd backup configuration", func() {
+			deltaSnapshotMemoryLimit := resource.MustParse("50Mi")
+			c := GetBackupRestoreContainer("etcd-main", EtcdMainVolumeClaimTemplateName, "0 */24 * * *", "S3", "image", &ETCDBackupConfig{
+				Schedule:                 util.StringPtr("0 */12 * * *"),
+				DeltaSnapshotPeriod:      &metav1.Duration{Duration: 2 * time.Minute},
+				DeltaSnapshotMemoryLimit: &deltaSnapshotMemoryLimit,
+				GarbageCollectionPolicy:  util.StringPtr(GarbageCollectionPolicyLimitBased),
+				MaxBackups:               util.Int32Ptr(7),
+			}, nil, nil, nil)
+
+			Expect(c.Command).To(ContainElement("--schedule=0 */12 * * *"))
+			Expect(c.Command).To(ContainElement("--delta-snapshot-period-seconds=120"))
+			Expect(c.Command).To(ContainElement("--delta-snapshot-memory-limit=52428800"))
+			Expect(c.Command).To(ContainElement("--garbage-collection-policy=LimitBased"))
+			Expect(c.Command).To(ContainElement("--max-backups=7"))
+			Expect(c.Command).NotTo(ContainElement("--schedule=0 */24 * * *"))
+		})
+
+		It("should use the given schedule and the defaults without etcd backup configuration", func() {
+			c := GetBackupRestoreContainer("etcd-main", EtcdMainVolumeClaimTemplateName, "0 */24 * * *", "S3", "image", nil, nil, nil, nil)
+
+			Expect(c.Command).To(ContainElement("--schedule=0 */24 * * *"))
+			Expect(c.Command).To(ContainElement("--delta-snapshot-period-seconds=300"))
+			Expect(c.Command).NotTo(ContainElement(HavePrefix("--max-backups=")))
+		})
+	})
+
+	DescribeTable("#validateCronSchedule",
+		func(schedule string, valid bool) {
+			if err := validateCronSchedule(schedule); valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("every minute", "* * * * *", true),
+		Entry("steps", "*/15 0-23/2 */3 * *", true),
+		Entry("lists and ranges", "0,30 8-18 1,15 1-6,9 1-5", true),
+		Entry("start with step", "5/10 * * * *", true),
+		Entry("sunday as 7", "0 0 * * 7", true),
+		Entry("extra whitespace", " 0  0 * * * ", true),
+		Entry("too few fields", "0 */24 * *", false),
+		Entry("too many fields", "0 0 * * * *", false),
+		Entry("minute out of range", "60 * * * *", false),
+		Entry("impossible time", "99 99 * * *", false),
+		Entry("day of month zero", "0 0 0 * *", false),
+		Entry("month out of range", "0 0 * 13 *", false),
+		Entry("day of week out of range", "0 0 * * 8", false),
+		Entry("reversed range", "0 18-8 * * *", false),
+		Entry("zero step", "*/0 * * * *", false),
+		Entry("missing step", "*/ * * * *", false),
+		Entry("empty list item", "0,,30 * * * *", false),
+		Entry("signed value", "+5 * * * *", false),
+		Entry("name", "0 0 * * MON", false),
+	)
+})
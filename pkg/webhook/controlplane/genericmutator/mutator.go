@@ -23,6 +23,7 @@ import (
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/common"
 	"github.com/go-logr/logr"
@@ -46,12 +47,15 @@ type Ensurer interface {
 	EnsureKubeControllerManagerDeployment(context.Context, *appsv1.Deployment) error
 	// EnsureKubeSchedulerDeployment ensures that the kube-scheduler deployment conforms to the provider requirements.
 	EnsureKubeSchedulerDeployment(context.Context, *appsv1.Deployment) error
+	// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+	EnsureClusterAutoscalerDeployment(context.Context, *appsv1.Deployment) error
 	// EnsureETCDStatefulSet ensures that the etcd stateful sets conform to the provider requirements.
 	EnsureETCDStatefulSet(context.Context, *appsv1.StatefulSet, *extensionscontroller.Cluster) error
 	// EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 	EnsureKubeletServiceUnitOptions(context.Context, []*unit.UnitOption) ([]*unit.UnitOption, error)
-	// EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-	EnsureKubeletConfiguration(context.Context, *kubeletconfigv1beta1.KubeletConfiguration) error
+	// EnsureKubeletConfiguration ensures that the kubelet configuration of the shoot in the given namespace conforms to
+	// the provider requirements.
+	EnsureKubeletConfiguration(context.Context, *kubeletconfigv1beta1.KubeletConfiguration, string) error
 	// EnsureKubernetesGeneralConfiguration ensures that the kubernetes general configuration conforms to the provider requirements.
 	EnsureKubernetesGeneralConfiguration(context.Context, *string) error
 	// ShouldProvisionKubeletCloudProviderConfig returns true if the cloud provider config file should be added to the kubelet configuration.
@@ -96,6 +100,14 @@ func (m *mutator) InjectClient(client client.Client) error {
 	return nil
 }
 
+// InjectScheme injects the given scheme into the ensurer.
+func (m *mutator) InjectScheme(scheme *runtime.Scheme) error {
+	if _, err := inject.SchemeInto(scheme, m.ensurer); err != nil {
+		return errors.Wrap(err, "could not inject the scheme into the ensurer")
+	}
+	return nil
+}
+
 // Mutate validates and if needed mutates the given object.
 func (m *mutator) Mutate(ctx context.Context, obj runtime.Object) error {
 	switch x := obj.(type) {
@@ -112,6 +124,8 @@ func (m *mutator) Mutate(ctx context.Context, obj runtime.Object) error {
 			return m.ensurer.EnsureKubeControllerManagerDeployment(ctx, x)
 		case common.KubeSchedulerDeploymentName:
 			return m.ensurer.EnsureKubeSchedulerDeployment(ctx, x)
+		case gardencorev1alpha1.DeploymentNameClusterAutoscaler:
+			return m.ensurer.EnsureClusterAutoscalerDeployment(ctx, x)
 		}
 	case *appsv1.StatefulSet:
 		switch x.Name {
@@ -142,7 +156,7 @@ func (m *mutator) mutateOperatingSystemConfig(ctx context.Context, osc *extensio
 
 	// Mutate kubelet configuration file, if present
 	if f := controlplane.FileWithPath(osc.Spec.Files, "/var/lib/kubelet/config/kubelet"); f != nil && f.Content.Inline != nil {
-		if err := m.ensureKubeletConfigFileContent(ctx, f.Content.Inline); err != nil {
+		if err := m.ensureKubeletConfigFileContent(ctx, f.Content.Inline, osc); err != nil {
 			return err
 		}
 	}
@@ -185,19 +199,30 @@ func (m *mutator) ensureKubeletServiceUnitContent(ctx context.Context, content *
 	return nil
 }
 
-func (m *mutator) ensureKubeletConfigFileContent(ctx context.Context, fci *extensionsv1alpha1.FileContentInline) error {
+func (m *mutator) ensureKubeletConfigFileContent(ctx context.Context, fci *extensionsv1alpha1.FileContentInline, osc *extensionsv1alpha1.OperatingSystemConfig) error {
 	var kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration
 	var err error
 
+	// Get the kubelet configuration overrides of the worker pool
+	var allOverrides []*controlplane.KubeletConfigOverrides
+	if allOverrides, err = m.kubeletConfigOverrides(ctx, osc); err != nil {
+		return err
+	}
+
 	// Decode kubelet configuration from inline content
 	if kubeletConfig, err = m.kubeletConfigCodec.Decode(fci); err != nil {
 		return errors.Wrap(err, "could not decode kubelet configuration")
 	}
 
-	if err = m.ensurer.EnsureKubeletConfiguration(ctx, kubeletConfig); err != nil {
+	if err = m.ensurer.EnsureKubeletConfiguration(ctx, kubeletConfig, osc.Namespace); err != nil {
 		return err
 	}
 
+	// Apply the overrides of the worker pool on top of the provider requirements
+	for _, overrides := range allOverrides {
+		controlplane.ApplyKubeletConfigOverrides(kubeletConfig, overrides)
+	}
+
 	// Encode kubelet configuration into inline content
 	var newFCI *extensionsv1alpha1.FileContentInline
 	if newFCI, err = m.kubeletConfigCodec.Encode(kubeletConfig, fci.Encoding); err != nil {
@@ -208,6 +233,56 @@ func (m *mutator) ensureKubeletConfigFileContent(ctx context.Context, fci *exten
 	return nil
 }
 
+// kubeletConfigOverrides returns the kubelet configuration overrides of the worker pool the given
+// OperatingSystemConfig has been created for, in the order they have to be applied. The overrides in
+// the provider config of the worker pool come first, so that the ones in the shoot annotation take
+// precedence.
+func (m *mutator) kubeletConfigOverrides(ctx context.Context, osc *extensionsv1alpha1.OperatingSystemConfig) ([]*controlplane.KubeletConfigOverrides, error) {
+	pool, ok := controlplane.WorkerPoolFromOperatingSystemConfig(osc)
+	if !ok {
+		return nil, nil
+	}
+
+	var allOverrides []*controlplane.KubeletConfigOverrides
+
+	// List the workers of the shoot and find the worker pool
+	workers := &extensionsv1alpha1.WorkerList{}
+	if err := m.client.List(ctx, client.InNamespace(osc.Namespace), workers); err != nil {
+		return nil, errors.Wrapf(err, "could not list workers in namespace '%s'", osc.Namespace)
+	}
+	for _, worker := range workers.Items {
+		for i := range worker.Spec.Pools {
+			if worker.Spec.Pools[i].Name != pool {
+				continue
+			}
+			overrides, err := controlplane.KubeletConfigOverridesFromWorkerPool(&worker.Spec.Pools[i])
+			if err != nil {
+				return nil, err
+			}
+			if overrides != nil {
+				allOverrides = append(allOverrides, overrides)
+			}
+		}
+	}
+
+	// Get cluster info
+	cluster, err := extensionscontroller.GetCluster(ctx, m.client, osc.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get cluster for namespace '%s'", osc.Namespace)
+	}
+	if cluster.Shoot != nil {
+		overrides, err := controlplane.KubeletConfigOverridesFromShoot(cluster.Shoot, pool)
+		if err != nil {
+			return nil, err
+		}
+		if overrides != nil {
+			allOverrides = append(allOverrides, overrides)
+		}
+	}
+
+	return allOverrides, nil
+}
+
 func (m *mutator) ensureKubernetesGeneralConfiguration(ctx context.Context, fci *extensionsv1alpha1.FileContentInline) error {
 	var data []byte
 	var err error
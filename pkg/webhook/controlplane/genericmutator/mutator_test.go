@@ -27,6 +27,7 @@ import (
 	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 
 	"github.com/coreos/go-systemd/unit"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
 	"github.com/gardener/gardener/pkg/operation/common"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
@@ -186,7 +188,26 @@ var _ = Describe("Mutator", func() {
 			Expect(err).To(Not(HaveOccurred()))
 		})
 
-		It("should ignore other deployments than kube-apiserver, kube-controller-manager, and kube-scheduler", func() {
+		It("should invoke ensurer.EnsureClusterAutoscalerDeployment with a cluster-autoscaler deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: gardencorev1alpha1.DeploymentNameClusterAutoscaler},
+				}
+			)
+
+			// Create mock ensurer
+			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
+			ensurer.EXPECT().EnsureClusterAutoscalerDeployment(context.TODO(), dep).Return(nil)
+
+			// Create mutator
+			mutator := NewMutator(ensurer, nil, nil, nil, logger)
+
+			// Call Mutate method and check the result
+			err := mutator.Mutate(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+
+		It("should ignore other deployments than kube-apiserver, kube-controller-manager, kube-scheduler, and cluster-autoscaler", func() {
 			var (
 				dep = &appsv1.Deployment{
 					ObjectMeta: metav1.ObjectMeta{Name: "test"},
@@ -330,8 +351,8 @@ var _ = Describe("Mutator", func() {
 			// Create mock ensurer
 			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
 			ensurer.EXPECT().EnsureKubeletServiceUnitOptions(context.TODO(), oldUnitOptions).Return(newUnitOptions, nil)
-			ensurer.EXPECT().EnsureKubeletConfiguration(context.TODO(), oldKubeletConfig).DoAndReturn(
-				func(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration) error {
+			ensurer.EXPECT().EnsureKubeletConfiguration(context.TODO(), oldKubeletConfig, osc.Namespace).DoAndReturn(
+				func(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, namespace string) error {
 					*kubeletConfig = *newKubeletConfig
 					return nil
 				},
@@ -374,6 +395,239 @@ var _ = Describe("Mutator", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkOperatingSystemConfig(osc)
 		})
+
+		It("should apply the kubelet configuration overrides of the worker pool with OperatingSystemConfig", func() {
+			var (
+				osc = &extensionsv1alpha1.OperatingSystemConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-config-pool-1-3c8a1-original",
+						Namespace: namespace,
+					},
+					Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+						Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+						Files: []extensionsv1alpha1.File{
+							{
+								Path: "/var/lib/kubelet/config/kubelet",
+								Content: extensionsv1alpha1.FileContent{
+									Inline: &extensionsv1alpha1.FileContentInline{
+										Data: oldKubeletConfigData,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				workers = &extensionsv1alpha1.WorkerList{
+					Items: []extensionsv1alpha1.Worker{
+						{
+							Spec: extensionsv1alpha1.WorkerSpec{
+								Pools: []extensionsv1alpha1.WorkerPool{
+									{
+										Name:           "pool-1",
+										ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kind":"WorkerConfig","kubelet":{"maxPods":50,"evictionHard":{"memory.available":"200Mi"}}}`)},
+									},
+									{
+										Name:           "pool-2",
+										ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kubelet":{"maxPods":250}}`)},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				shootCluster = &extensionscontroller.Cluster{
+					CloudProfile: cluster.CloudProfile,
+					Seed:         cluster.Seed,
+					Shoot: &gardenv1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								controlplane.AnnotationKubeletConfigOverrides: `{"pool-1":{"maxPods":100,"kubeReserved":{"cpu":"100m"}}}`,
+							},
+						},
+					},
+				}
+
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					MaxPods:      110,
+					EvictionHard: map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+				}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					MaxPods:      100,
+					EvictionHard: map[string]string{"memory.available": "200Mi", "nodefs.available": "10%"},
+					KubeReserved: map[string]string{"cpu": "100m", "memory": "1Gi"},
+				}
+			)
+
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.WorkerList{}).DoAndReturn(
+				func(_ context.Context, _ *client.ListOptions, list *extensionsv1alpha1.WorkerList) error {
+					*list = *workers
+					return nil
+				},
+			)
+			c.EXPECT().Get(context.TODO(), clusterKey, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(clusterObject(shootCluster)))
+
+			// Create mock ensurer
+			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
+			ensurer.EXPECT().EnsureKubeletConfiguration(context.TODO(), oldKubeletConfig, osc.Namespace).DoAndReturn(
+				func(ctx context.Context, kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, namespace string) error {
+					kubeletConfig.KubeReserved = map[string]string{"cpu": "80m", "memory": "1Gi"}
+					return nil
+				},
+			)
+			ensurer.EXPECT().ShouldProvisionKubeletCloudProviderConfig().Return(false)
+
+			// Create mock KubeletConfigCodec
+			kcc := mockcontrolplane.NewMockKubeletConfigCodec(ctrl)
+			kcc.EXPECT().Decode(&extensionsv1alpha1.FileContentInline{Data: oldKubeletConfigData}).Return(oldKubeletConfig, nil)
+			kcc.EXPECT().Encode(newKubeletConfig, "").Return(&extensionsv1alpha1.FileContentInline{Data: newKubeletConfigData}, nil)
+
+			// Create mutator
+			mutator := NewMutator(ensurer, nil, kcc, nil, logger)
+			err := mutator.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call Mutate method and check the result
+			err = mutator.Mutate(context.TODO(), osc)
+			Expect(err).To(Not(HaveOccurred()))
+			f := controlplane.FileWithPath(osc.Spec.Files, "/var/lib/kubelet/config/kubelet")
+			Expect(f.Content.Inline).To(Equal(&extensionsv1alpha1.FileContentInline{Data: newKubeletConfigData}))
+		})
+
+		It("should apply the kubelet configuration overrides of the worker pool of an OperatingSystemConfig created by Gardener", func() {
+			var (
+				osc = &extensionsv1alpha1.OperatingSystemConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "cloud-config-cpu-worker-3c8a1-original",
+						Namespace: namespace,
+					},
+					Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+						Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+						Files: []extensionsv1alpha1.File{
+							{
+								Path: "/var/lib/kubelet/config/kubelet",
+								Content: extensionsv1alpha1.FileContent{
+									Inline: &extensionsv1alpha1.FileContentInline{
+										Data: oldKubeletConfigData,
+									},
+								},
+							},
+						},
+					},
+				}
+
+				worker = &extensionsv1alpha1.Worker{
+					ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: namespace},
+					Spec: extensionsv1alpha1.WorkerSpec{
+						Pools: []extensionsv1alpha1.WorkerPool{
+							{
+								Name:           "cpu",
+								ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kubelet":{"maxPods":250}}`)},
+							},
+							{
+								Name:           "cpu-worker",
+								ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kubelet":{"maxPods":50}}`)},
+							},
+						},
+					},
+				}
+
+				clusterObj = clusterObject(&extensionscontroller.Cluster{
+					CloudProfile: cluster.CloudProfile,
+					Seed:         cluster.Seed,
+					Shoot: &gardenv1beta1.Shoot{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								controlplane.AnnotationKubeletConfigOverrides: `{"cpu-worker":{"kubeReserved":{"cpu":"100m"}}}`,
+							},
+						},
+					},
+				})
+
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{MaxPods: 110}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					MaxPods:      50,
+					KubeReserved: map[string]string{"cpu": "100m"},
+				}
+			)
+			clusterObj.Name = namespace
+
+			// Create fake client with the resources Gardener creates
+			s := runtime.NewScheme()
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+			c := fake.NewFakeClientWithScheme(s, worker, clusterObj)
+
+			// Create mock ensurer
+			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
+			ensurer.EXPECT().EnsureKubeletConfiguration(context.TODO(), oldKubeletConfig, osc.Namespace).Return(nil)
+			ensurer.EXPECT().ShouldProvisionKubeletCloudProviderConfig().Return(false)
+
+			// Create mock KubeletConfigCodec
+			kcc := mockcontrolplane.NewMockKubeletConfigCodec(ctrl)
+			kcc.EXPECT().Decode(&extensionsv1alpha1.FileContentInline{Data: oldKubeletConfigData}).Return(oldKubeletConfig, nil)
+			kcc.EXPECT().Encode(newKubeletConfig, "").Return(&extensionsv1alpha1.FileContentInline{Data: newKubeletConfigData}, nil)
+
+			// Create mutator
+			mutator := NewMutator(ensurer, nil, kcc, nil, logger)
+			err := mutator.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call Mutate method and check the result
+			err = mutator.Mutate(context.TODO(), osc)
+			Expect(err).To(Not(HaveOccurred()))
+			f := controlplane.FileWithPath(osc.Spec.Files, "/var/lib/kubelet/config/kubelet")
+			Expect(f.Content.Inline).To(Equal(&extensionsv1alpha1.FileContentInline{Data: newKubeletConfigData}))
+		})
+
+		It("should fail for an OperatingSystemConfig with invalid kubelet configuration overrides", func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "cloud-config-pool-1-3c8a1-original",
+					Namespace: namespace,
+				},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+					Files: []extensionsv1alpha1.File{
+						{
+							Path: "/var/lib/kubelet/config/kubelet",
+							Content: extensionsv1alpha1.FileContent{
+								Inline: &extensionsv1alpha1.FileContentInline{
+									Data: oldKubeletConfigData,
+								},
+							},
+						},
+					},
+				},
+			}
+
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().List(context.TODO(), gomock.Any(), &extensionsv1alpha1.WorkerList{}).DoAndReturn(
+				func(_ context.Context, _ *client.ListOptions, list *extensionsv1alpha1.WorkerList) error {
+					list.Items = []extensionsv1alpha1.Worker{{
+						Spec: extensionsv1alpha1.WorkerSpec{
+							Pools: []extensionsv1alpha1.WorkerPool{{
+								Name:           "pool-1",
+								ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kubelet":{"evictionHard":{"memory.free":"1Gi"}}}`)},
+							}},
+						},
+					}}
+					return nil
+				},
+			)
+
+			// Create mutator
+			mutator := NewMutator(nil, nil, nil, nil, logger)
+			err := mutator.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call Mutate method and check the result
+			err = mutator.Mutate(context.TODO(), osc)
+			Expect(err).To(MatchError(ContainSubstring("invalid kubelet config overrides of worker pool 'pool-1'")))
+		})
 	})
 })
 
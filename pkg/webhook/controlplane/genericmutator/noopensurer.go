@@ -48,6 +48,11 @@ func (e *NoopEnsurer) EnsureKubeSchedulerDeployment(context.Context, *appsv1.Dep
 	return nil
 }
 
+// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+func (e *NoopEnsurer) EnsureClusterAutoscalerDeployment(context.Context, *appsv1.Deployment) error {
+	return nil
+}
+
 // EnsureETCDStatefulSet ensures that the etcd stateful sets conform to the provider requirements.
 func (e *NoopEnsurer) EnsureETCDStatefulSet(context.Context, *appsv1.StatefulSet, *extensionscontroller.Cluster) error {
 	return nil
@@ -58,8 +63,9 @@ func (e *NoopEnsurer) EnsureKubeletServiceUnitOptions(_ context.Context, opts []
 	return opts, nil
 }
 
-// EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-func (e *NoopEnsurer) EnsureKubeletConfiguration(context.Context, *kubeletconfigv1beta1.KubeletConfiguration) error {
+// EnsureKubeletConfiguration ensures that the kubelet configuration of the shoot in the given namespace conforms to
+// the provider requirements.
+func (e *NoopEnsurer) EnsureKubeletConfiguration(context.Context, *kubeletconfigv1beta1.KubeletConfiguration, string) error {
 	return nil
 }
 
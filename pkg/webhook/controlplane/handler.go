@@ -16,6 +16,7 @@ package controlplane
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/go-logr/logr"
@@ -32,6 +33,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
 )
 
+// NewHandler creates a new admission handler for the given types, using the given mutator, and logger.
+// It is the handler of the controlplane webhooks and can be used by other mutating webhooks, too.
+func NewHandler(mgr manager.Manager, types []runtime.Object, mutator Mutator, logger logr.Logger) (admission.Handler, error) {
+	return newHandler(mgr, types, mutator, logger)
+}
+
 // newHandler creates a new handler for the given types, using the given mutator, and logger.
 func newHandler(mgr manager.Manager, types []runtime.Object, mutator Mutator, logger logr.Logger) (*handler, error) {
 	// Build a map of the given types keyed by their GVKs
@@ -52,6 +59,7 @@ type handler struct {
 	typesMap map[metav1.GroupVersionKind]runtime.Object
 	mutator  Mutator
 	decoder  types.Decoder
+	dryRun   bool
 	logger   logr.Logger
 }
 
@@ -61,6 +69,13 @@ func (h *handler) InjectDecoder(d types.Decoder) error {
 	return nil
 }
 
+// InjectDryRun implements extensionswebhook.DryRunInjector. In dry-run mode, the handler logs the patch that it
+// would apply, but always returns an allowing response without a patch.
+func (h *handler) InjectDryRun(dryRun bool) error {
+	h.dryRun = dryRun
+	return nil
+}
+
 // InjectClient injects the given client into the mutator.
 // TODO Replace this with the more generic InjectFunc when controller runtime supports it
 func (h *handler) InjectClient(client client.Client) error {
@@ -70,6 +85,14 @@ func (h *handler) InjectClient(client client.Client) error {
 	return nil
 }
 
+// InjectScheme injects the given scheme into the mutator.
+func (h *handler) InjectScheme(scheme *runtime.Scheme) error {
+	if _, err := inject.SchemeInto(scheme, h.mutator); err != nil {
+		return errors.Wrap(err, "could not inject the scheme into the mutator")
+	}
+	return nil
+}
+
 // Handle handles the given admission request.
 func (h *handler) Handle(ctx context.Context, req types.Request) types.Response {
 	ar := req.AdmissionRequest
@@ -91,22 +114,59 @@ func (h *handler) Handle(ctx context.Context, req types.Request) types.Response
 		return admission.ErrorResponse(http.StatusBadRequest, errors.Wrapf(err, "could not get accessor for %v", obj))
 	}
 
+	// Skip the mutation if the resource has opted out of it
+	if accessor.GetAnnotations()[AnnotationSkipMutation] == "true" {
+		h.logger.Info("Skipping mutation of resource", "kind", ar.Kind.String(), "namespace", accessor.GetNamespace(),
+			"name", accessor.GetName(), "operation", ar.Operation)
+		mutationsTotal.WithLabelValues(ar.Kind.Kind, mutationResultSkipped).Inc()
+		return admission.ValidationResponse(true, "")
+	}
+
 	// Mutate the resource
 	h.logger.Info("Mutating resource", "kind", ar.Kind.String(), "namespace", accessor.GetNamespace(),
-		"name", accessor.GetName(), "operation", ar.Operation)
+		"name", accessor.GetName(), "operation", ar.Operation, "dryRun", h.dryRun)
 	newObj := obj.DeepCopyObject()
 	err = h.mutator.Mutate(ctx, newObj)
 	if err != nil {
-		return admission.ErrorResponse(http.StatusInternalServerError,
-			errors.Wrapf(err, "could not mutate %s %s/%s", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName()))
+		return h.errorResponse(ar.Kind.Kind, accessor, errors.Wrapf(err, "could not mutate %s %s/%s", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName()))
+	}
+
+	// Return a validation response if the resource should not be changed
+	if equality.Semantic.DeepEqual(obj, newObj) {
+		mutationsTotal.WithLabelValues(ar.Kind.Kind, mutationResultUnchanged).Inc()
+		return admission.ValidationResponse(true, "")
 	}
 
 	// Return a patch response if the resource should be changed
-	if !equality.Semantic.DeepEqual(obj, newObj) {
-		return admission.PatchResponse(obj, newObj)
+	resp := admission.PatchResponse(obj, newObj)
+	if !resp.Response.Allowed {
+		return h.errorResponse(ar.Kind.Kind, accessor, errors.Errorf("could not create patch for %s %s/%s: %s", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName(), resp.Response.Result.Message))
+	}
+	if !h.dryRun {
+		mutationsTotal.WithLabelValues(ar.Kind.Kind, mutationResultMutated).Inc()
+		return resp
 	}
 
-	// Return a validation response if the resource should not be changed
+	// Log the patch and return a validation response in dry-run mode
+	patch, err := json.Marshal(resp.Patches)
+	if err != nil {
+		return h.errorResponse(ar.Kind.Kind, accessor, errors.Wrapf(err, "could not marshal patch for %s %s/%s", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName()))
+	}
+	h.logger.Info("Not mutating resource in dry-run mode", "kind", ar.Kind.String(), "namespace", accessor.GetNamespace(),
+		"name", accessor.GetName(), "operation", ar.Operation, "patch", string(patch))
+	mutationsTotal.WithLabelValues(ar.Kind.Kind, mutationResultDryRun).Inc()
+	return admission.ValidationResponse(true, "")
+}
+
+// errorResponse counts the given error of mutating a resource of the given kind and returns an error response. In
+// dry-run mode, the error is only logged and an allowing response is returned, so that the request is not rejected.
+func (h *handler) errorResponse(kind string, accessor metav1.Object, err error) types.Response {
+	mutationsTotal.WithLabelValues(kind, mutationResultError).Inc()
+	if !h.dryRun {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+
+	h.logger.Error(err, "Not rejecting resource in dry-run mode", "kind", kind, "namespace", accessor.GetNamespace(), "name", accessor.GetName())
 	return admission.ValidationResponse(true, "")
 }
 
@@ -27,6 +27,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -133,6 +134,51 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		It("should return an allowing response without patch if the resource was changed by mutator in dry-run mode", func() {
+			// Create mock mutator
+			mutator := mockcontrolplane.NewMockMutator(ctrl)
+			mutator.EXPECT().Mutate(context.TODO(), svc).DoAndReturn(func(ctx context.Context, obj runtime.Object) error {
+				accessor, _ := meta.Accessor(obj)
+				accessor.SetAnnotations(map[string]string{"foo": "bar"})
+				return nil
+			})
+
+			// Create handler
+			h, err := newHandler(mgr, objTypes, mutator, logger)
+			Expect(err).NotTo(HaveOccurred())
+			h.decoder = decoder
+			Expect(h.InjectDryRun(true)).To(Succeed())
+
+			// Call Handle and check response
+			resp := h.Handle(context.TODO(), req)
+			Expect(resp).To(Equal(types.Response{
+				Response: &admissionv1beta1.AdmissionResponse{
+					Allowed: true,
+				},
+			}))
+		})
+
+		It("should return an allowing response without calling the mutator if the resource opted out of mutation", func() {
+			svc.Annotations = map[string]string{AnnotationSkipMutation: "true"}
+			defer func() { svc.Annotations = nil }()
+
+			// Create mock mutator
+			mutator := mockcontrolplane.NewMockMutator(ctrl)
+
+			// Create handler
+			h, err := newHandler(mgr, objTypes, mutator, logger)
+			Expect(err).NotTo(HaveOccurred())
+			h.decoder = decoder
+
+			// Call Handle and check response
+			resp := h.Handle(context.TODO(), req)
+			Expect(resp).To(Equal(types.Response{
+				Response: &admissionv1beta1.AdmissionResponse{
+					Allowed: true,
+				},
+			}))
+		})
+
 		It("should return an error response if the mutator returned an error", func() {
 			// Create mock mutator
 			mutator := mockcontrolplane.NewMockMutator(ctrl)
@@ -155,6 +201,28 @@ var _ = Describe("Handler", func() {
 				},
 			}))
 		})
+
+		It("should count the error and return an allowing response if the mutator returned an error in dry-run mode", func() {
+			// Create mock mutator
+			mutator := mockcontrolplane.NewMockMutator(ctrl)
+			mutator.EXPECT().Mutate(context.TODO(), svc).Return(errors.New("test error"))
+
+			// Create handler
+			h, err := newHandler(mgr, objTypes, mutator, logger)
+			Expect(err).NotTo(HaveOccurred())
+			h.decoder = decoder
+			Expect(h.InjectDryRun(true)).To(Succeed())
+
+			// Call Handle and check response and metric
+			errorCount := mutationsCount("Service", mutationResultError)
+			resp := h.Handle(context.TODO(), req)
+			Expect(resp).To(Equal(types.Response{
+				Response: &admissionv1beta1.AdmissionResponse{
+					Allowed: true,
+				},
+			}))
+			Expect(mutationsCount("Service", mutationResultError)).To(Equal(errorCount + 1))
+		})
 	})
 })
 
@@ -167,3 +235,9 @@ func decoderDecode(result runtime.Object) interface{} {
 		return nil
 	}
 }
+
+func mutationsCount(kind, result string) float64 {
+	metric := &dto.Metric{}
+	Expect(mutationsTotal.WithLabelValues(kind, result).Write(metric)).To(Succeed())
+	return metric.GetCounter().GetValue()
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kmsPluginSocketVolumeName       = "kms-plugin-socket"
+	kmsEncryptionConfigVolumeName   = "kms-encryption-config"
+	kmsEncryptionConfigDir          = "/etc/kubernetes/kms"
+	kmsPluginContainerNamePrefix    = "kms-"
+	kubeAPIServerContainerName      = "kube-apiserver"
+	kubeAPIServerEncryptionConfFlag = "--encryption-provider-config="
+)
+
+// KMSPluginContainerFunc returns the provider-specific KMS plugin container with the given name for the key with
+// the given ID. The plugin must listen on the unix socket with the given path.
+type KMSPluginContainerFunc func(name, keyID, socketPath string) (*corev1.Container, error)
+
+// EnsureKMSPlugins ensures that the given kube-apiserver pod template encrypts secrets with the KMS keys contained
+// in the KMS encryption config secret in the given namespace, if it exists. It adds a KMS plugin container returned
+// by the given function for each key, removes the containers of keys that are no longer used, and configures
+// kube-apiserver to use the encryption configuration contained in the secret.
+func EnsureKMSPlugins(ctx context.Context, template *corev1.PodTemplateSpec, c client.Client, namespace string, pluginFunc KMSPluginContainerFunc) error {
+	// Get KMS encryption config secret from cluster
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: controlplane.KMSEncryptionConfigSecretName}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "could not get secret '%s/%s'", namespace, controlplane.KMSEncryptionConfigSecretName)
+	}
+
+	ps := &template.Spec
+
+	// Ensure a KMS plugin container for each key
+	names := make(map[string]bool)
+	for _, keyID := range controlplane.KMSKeysFromSecret(secret) {
+		if keyID == controlplane.KMSIdentityKey {
+			continue
+		}
+		name := controlplane.KMSPluginName(keyID)
+		pc, err := pluginFunc(name, keyID, controlplane.KMSPluginSocketPath(name))
+		if err != nil {
+			return errors.Wrapf(err, "could not get KMS plugin container for key '%s'", keyID)
+		}
+		pc.Name = name
+		pc.VolumeMounts = EnsureVolumeMountWithName(pc.VolumeMounts, kmsPluginSocketVolumeMount)
+		ps.Containers = EnsureContainerWithName(ps.Containers, *pc)
+		names[name] = true
+	}
+
+	// Remove the KMS plugin containers of keys that are no longer used
+	for i := len(ps.Containers) - 1; i >= 0; i-- {
+		if name := ps.Containers[i].Name; strings.HasPrefix(name, kmsPluginContainerNamePrefix) && !names[name] {
+			ps.Containers = EnsureNoContainerWithName(ps.Containers, name)
+		}
+	}
+
+	// Ensure kube-apiserver uses the encryption configuration and can reach the KMS plugins
+	if apiServer := ContainerWithName(ps.Containers, kubeAPIServerContainerName); apiServer != nil {
+		apiServer.Command = EnsureStringWithPrefix(apiServer.Command, kubeAPIServerEncryptionConfFlag,
+			path.Join(kmsEncryptionConfigDir, controlplane.KMSEncryptionConfigSecretKey))
+		apiServer.VolumeMounts = EnsureVolumeMountWithName(apiServer.VolumeMounts, kmsPluginSocketVolumeMount)
+		apiServer.VolumeMounts = EnsureVolumeMountWithName(apiServer.VolumeMounts, kmsEncryptionConfigVolumeMount)
+	}
+	ps.Volumes = EnsureVolumeWithName(ps.Volumes, kmsPluginSocketVolume)
+	ps.Volumes = EnsureVolumeWithName(ps.Volumes, kmsEncryptionConfigVolume)
+
+	// Roll kube-apiserver if the keys have changed. The actuator sets the same annotation to trigger the roll-out.
+	return EnsureSecretChecksumAnnotation(ctx, template, c, namespace, controlplane.KMSEncryptionConfigSecretName)
+}
+
+var (
+	kmsPluginSocketVolumeMount = corev1.VolumeMount{
+		Name:      kmsPluginSocketVolumeName,
+		MountPath: controlplane.KMSPluginSocketDir,
+	}
+	kmsEncryptionConfigVolumeMount = corev1.VolumeMount{
+		Name:      kmsEncryptionConfigVolumeName,
+		MountPath: kmsEncryptionConfigDir,
+		ReadOnly:  true,
+	}
+	kmsPluginSocketVolume = corev1.Volume{
+		Name: kmsPluginSocketVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+	kmsEncryptionConfigVolume = corev1.Volume{
+		Name: kmsEncryptionConfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: controlplane.KMSEncryptionConfigSecretName,
+				Items: []corev1.KeyToPath{
+					{Key: controlplane.KMSEncryptionConfigSecretKey, Path: controlplane.KMSEncryptionConfigSecretKey},
+				},
+			},
+		},
+	}
+)
@@ -0,0 +1,125 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("KMS", func() {
+	const (
+		namespace = "shoot--foo--bar"
+		oldKey    = "old-key"
+		newKey    = "new-key"
+	)
+
+	var (
+		ctrl *gomock.Controller
+		c    *mockclient.MockClient
+		ctx  = context.TODO()
+
+		secretKey  = client.ObjectKey{Namespace: namespace, Name: controlplane.KMSEncryptionConfigSecretName}
+		secretData = map[string][]byte{controlplane.KMSKeysSecretKey: []byte(newKey)}
+
+		pluginFunc = func(name, keyID, socketPath string) (*corev1.Container, error) {
+			return &corev1.Container{
+				Image:   "kms-plugin",
+				Command: []string{"kms-plugin", "--key=" + keyID, "--listen=" + socketPath},
+			}, nil
+		}
+		newTemplate = func(containers ...corev1.Container) *corev1.PodTemplateSpec {
+			return &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: append([]corev1.Container{{Name: "kube-apiserver"}}, containers...),
+				},
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#EnsureKMSPlugins", func() {
+		It("should do nothing if there is no KMS encryption config secret", func() {
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, controlplane.KMSEncryptionConfigSecretName))
+
+			template := newTemplate()
+			Expect(EnsureKMSPlugins(ctx, template, c, namespace, pluginFunc)).To(Succeed())
+			Expect(template).To(Equal(newTemplate()))
+		})
+
+		It("should add the KMS plugins and configure kube-apiserver", func() {
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, secret *corev1.Secret) error {
+					secret.Data = secretData
+					return nil
+				}).Times(2)
+
+			newName, oldName := controlplane.KMSPluginName(newKey), controlplane.KMSPluginName(oldKey)
+			template := newTemplate(corev1.Container{Name: oldName})
+			Expect(EnsureKMSPlugins(ctx, template, c, namespace, pluginFunc)).To(Succeed())
+
+			ps := template.Spec
+			Expect(ContainerWithName(ps.Containers, oldName)).To(BeNil())
+			Expect(ContainerWithName(ps.Containers, newName)).To(Equal(&corev1.Container{
+				Name:         newName,
+				Image:        "kms-plugin",
+				Command:      []string{"kms-plugin", "--key=" + newKey, "--listen=/var/run/kmsplugin/" + newName + ".sock"},
+				VolumeMounts: []corev1.VolumeMount{kmsPluginSocketVolumeMount},
+			}))
+			Expect(ContainerWithName(ps.Containers, "kube-apiserver")).To(Equal(&corev1.Container{
+				Name:         "kube-apiserver",
+				Command:      []string{"--encryption-provider-config=/etc/kubernetes/kms/encryption-configuration.yaml"},
+				VolumeMounts: []corev1.VolumeMount{kmsPluginSocketVolumeMount, kmsEncryptionConfigVolumeMount},
+			}))
+			Expect(ps.Volumes).To(Equal([]corev1.Volume{kmsPluginSocketVolume, kmsEncryptionConfigVolume}))
+			Expect(template.Annotations).To(HaveKeyWithValue(controlplane.KMSEncryptionConfigChecksumAnnotation, util.ComputeChecksum(secretData)))
+		})
+
+		It("should not add a KMS plugin for the identity provider", func() {
+			c.EXPECT().Get(ctx, secretKey, gomock.AssignableToTypeOf(&corev1.Secret{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, secret *corev1.Secret) error {
+					secret.Data = map[string][]byte{controlplane.KMSKeysSecretKey: []byte(controlplane.KMSIdentityKey + "\n" + newKey)}
+					return nil
+				}).Times(2)
+
+			template := newTemplate()
+			Expect(EnsureKMSPlugins(ctx, template, c, namespace, pluginFunc)).To(Succeed())
+
+			ps := template.Spec
+			Expect(ps.Containers).To(HaveLen(2))
+			Expect(ContainerWithName(ps.Containers, controlplane.KMSPluginName(newKey))).NotTo(BeNil())
+		})
+	})
+})
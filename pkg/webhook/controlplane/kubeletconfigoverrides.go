@@ -0,0 +1,201 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+// AnnotationKubeletConfigOverrides is the annotation on Shoots that contains KubeletConfigOverrides
+// per worker pool as JSON object with the names of the worker pools as keys.
+const AnnotationKubeletConfigOverrides = "controlplane.extensions.gardener.cloud/kubelet-config-overrides"
+
+// workerPoolOperatingSystemConfigName matches the names Gardener gives to the OperatingSystemConfigs
+// with the kubelet of a worker pool (`cloud-config-<pool>-<hash>-original`) and captures the name of
+// the worker pool. As the hash does not contain dashes, worker pool names with dashes are captured
+// completely.
+var workerPoolOperatingSystemConfigName = regexp.MustCompile(`^` + common.CloudConfigPrefix + `-(.+)-[0-9a-f]+-original$`)
+
+// KubeletConfigOverrides are kubelet configuration settings of a worker pool. They take precedence
+// over the settings of the kubelet configuration and of the provider ensurer. Map entries are
+// merged into the existing maps.
+type KubeletConfigOverrides struct {
+	// MaxPods is the maximum number of pods per node.
+	MaxPods *int32 `json:"maxPods,omitempty"`
+	// EvictionHard are the hard eviction thresholds per signal.
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// EvictionSoft are the soft eviction thresholds per signal.
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+	// EvictionSoftGracePeriod are the grace periods of the soft eviction thresholds per signal.
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+	// KubeReserved are the resources reserved for Kubernetes system components.
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+	// SystemReserved are the resources reserved for non-Kubernetes system components.
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+}
+
+// workerPoolProviderConfig is the part of the provider specific configuration of a worker pool
+// that contains the kubelet configuration overrides. Other fields are ignored.
+type workerPoolProviderConfig struct {
+	Kubelet *KubeletConfigOverrides `json:"kubelet,omitempty"`
+}
+
+var (
+	evictionSignals = []string{"memory.available", "nodefs.available", "nodefs.inodesFree", "imagefs.available", "imagefs.inodesFree", "pid.available"}
+	reservedNames   = []string{"cpu", "memory", "ephemeral-storage", "pid"}
+)
+
+// WorkerPoolFromOperatingSystemConfig returns the name of the worker pool the given
+// OperatingSystemConfig has been created for. Gardener does not label OperatingSystemConfigs with their
+// worker pool, hence it is derived from the name Gardener gives them. It returns false for
+// OperatingSystemConfigs that have not been created for the kubelet of a worker pool.
+func WorkerPoolFromOperatingSystemConfig(osc *extensionsv1alpha1.OperatingSystemConfig) (string, bool) {
+	match := workerPoolOperatingSystemConfigName.FindStringSubmatch(osc.Name)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// KubeletConfigOverridesFromWorkerPool returns the kubelet configuration overrides of the `kubelet`
+// field of the provider config of the given worker pool, or nil if it has none.
+func KubeletConfigOverridesFromWorkerPool(pool *extensionsv1alpha1.WorkerPool) (*KubeletConfigOverrides, error) {
+	if pool.ProviderConfig == nil || len(pool.ProviderConfig.Raw) == 0 {
+		return nil, nil
+	}
+
+	config := &workerPoolProviderConfig{}
+	if err := json.Unmarshal(pool.ProviderConfig.Raw, config); err != nil {
+		return nil, errors.Wrapf(err, "could not decode provider config of worker pool '%s'", pool.Name)
+	}
+	if config.Kubelet == nil {
+		return nil, nil
+	}
+
+	if errs := ValidateKubeletConfigOverrides(config.Kubelet, field.NewPath("providerConfig", "kubelet")); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid kubelet config overrides of worker pool '%s'", pool.Name)
+	}
+	return config.Kubelet, nil
+}
+
+// KubeletConfigOverridesFromShoot returns the kubelet configuration overrides of the given worker
+// pool from the AnnotationKubeletConfigOverrides annotation of the given shoot, or nil if it has none.
+func KubeletConfigOverridesFromShoot(shoot *gardenv1beta1.Shoot, pool string) (*KubeletConfigOverrides, error) {
+	data, ok := shoot.Annotations[AnnotationKubeletConfigOverrides]
+	if !ok {
+		return nil, nil
+	}
+
+	overrides := map[string]*KubeletConfigOverrides{}
+	if err := json.Unmarshal([]byte(data), &overrides); err != nil {
+		return nil, errors.Wrapf(err, "could not decode annotation '%s' of shoot '%s'", AnnotationKubeletConfigOverrides, shoot.Name)
+	}
+	if overrides[pool] == nil {
+		return nil, nil
+	}
+
+	if errs := ValidateKubeletConfigOverrides(overrides[pool], field.NewPath("metadata", "annotations").Key(AnnotationKubeletConfigOverrides).Key(pool)); len(errs) > 0 {
+		return nil, errors.Wrapf(errs.ToAggregate(), "invalid kubelet config overrides of worker pool '%s'", pool)
+	}
+	return overrides[pool], nil
+}
+
+// ValidateKubeletConfigOverrides validates the given kubelet configuration overrides.
+func ValidateKubeletConfigOverrides(overrides *KubeletConfigOverrides, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if overrides.MaxPods != nil && *overrides.MaxPods <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxPods"), *overrides.MaxPods, "must be greater than 0"))
+	}
+
+	for name, thresholds := range map[string]map[string]string{"evictionHard": overrides.EvictionHard, "evictionSoft": overrides.EvictionSoft} {
+		for signal, threshold := range thresholds {
+			idxPath := fldPath.Child(name).Key(signal)
+			if !containsString(evictionSignals, signal) {
+				allErrs = append(allErrs, field.NotSupported(idxPath, signal, evictionSignals))
+			} else if !isEvictionThreshold(threshold) {
+				allErrs = append(allErrs, field.Invalid(idxPath, threshold, "must be a quantity or a percentage"))
+			}
+		}
+	}
+	for signal := range overrides.EvictionSoftGracePeriod {
+		if !containsString(evictionSignals, signal) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("evictionSoftGracePeriod").Key(signal), signal, evictionSignals))
+		}
+	}
+
+	for fieldName, reserved := range map[string]map[string]string{"kubeReserved": overrides.KubeReserved, "systemReserved": overrides.SystemReserved} {
+		for name, quantity := range reserved {
+			idxPath := fldPath.Child(fieldName).Key(name)
+			if !containsString(reservedNames, name) {
+				allErrs = append(allErrs, field.NotSupported(idxPath, name, reservedNames))
+			} else if _, err := resource.ParseQuantity(quantity); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath, quantity, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ApplyKubeletConfigOverrides applies the given kubelet configuration overrides to the given kubelet
+// configuration.
+func ApplyKubeletConfigOverrides(kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, overrides *KubeletConfigOverrides) {
+	if overrides.MaxPods != nil {
+		kubeletConfig.MaxPods = *overrides.MaxPods
+	}
+
+	kubeletConfig.EvictionHard = mergeStringMaps(kubeletConfig.EvictionHard, overrides.EvictionHard)
+	kubeletConfig.EvictionSoft = mergeStringMaps(kubeletConfig.EvictionSoft, overrides.EvictionSoft)
+	kubeletConfig.EvictionSoftGracePeriod = mergeStringMaps(kubeletConfig.EvictionSoftGracePeriod, overrides.EvictionSoftGracePeriod)
+	kubeletConfig.KubeReserved = mergeStringMaps(kubeletConfig.KubeReserved, overrides.KubeReserved)
+	kubeletConfig.SystemReserved = mergeStringMaps(kubeletConfig.SystemReserved, overrides.SystemReserved)
+}
+
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(overrides))
+	}
+	for key, value := range overrides {
+		base[key] = value
+	}
+	return base
+}
+
+func isEvictionThreshold(threshold string) bool {
+	if strings.HasSuffix(threshold, "%") {
+		_, err := resource.ParseQuantity(strings.TrimSuffix(threshold, "%"))
+		return err == nil
+	}
+	_, err := resource.ParseQuantity(threshold)
+	return err == nil
+}
+
+func containsString(items []string, value string) bool {
+	return StringIndex(items, value) >= 0
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+)
+
+var _ = Describe("KubeletConfigOverrides", func() {
+	Describe("#WorkerPoolFromOperatingSystemConfig", func() {
+		DescribeTable("should derive the worker pool from the name Gardener gives the OperatingSystemConfig",
+			func(name, expectedPool string, expectedOK bool) {
+				osc := &extensionsv1alpha1.OperatingSystemConfig{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+				pool, ok := WorkerPoolFromOperatingSystemConfig(osc)
+				Expect(pool).To(Equal(expectedPool))
+				Expect(ok).To(Equal(expectedOK))
+			},
+			Entry("worker pool", "cloud-config-cpu-worker-3c8a1-original", "cpu-worker", true),
+			Entry("worker pool with hex name", "cloud-config-a-b-1f2e3-original", "a-b", true),
+			Entry("downloader", "cloud-config-cpu-worker-3c8a1-downloader", "", false),
+			Entry("other name", "test", "", false),
+		)
+	})
+
+	Describe("#KubeletConfigOverridesFromWorkerPool", func() {
+		It("should return the overrides of the kubelet field and ignore other fields", func() {
+			pool := &extensionsv1alpha1.WorkerPool{
+				Name:           "pool-1",
+				ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"aws.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkerConfig","kubelet":{"maxPods":50,"kubeReserved":{"cpu":"100m"}}}`)},
+			}
+
+			Expect(KubeletConfigOverridesFromWorkerPool(pool)).To(Equal(&KubeletConfigOverrides{
+				MaxPods:      util.Int32Ptr(50),
+				KubeReserved: map[string]string{"cpu": "100m"},
+			}))
+		})
+
+		It("should return nil without provider config or kubelet field", func() {
+			Expect(KubeletConfigOverridesFromWorkerPool(&extensionsv1alpha1.WorkerPool{Name: "pool-1"})).To(BeNil())
+			Expect(KubeletConfigOverridesFromWorkerPool(&extensionsv1alpha1.WorkerPool{
+				Name:           "pool-1",
+				ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kind":"WorkerConfig"}`)},
+			})).To(BeNil())
+		})
+
+		It("should fail for invalid overrides", func() {
+			_, err := KubeletConfigOverridesFromWorkerPool(&extensionsv1alpha1.WorkerPool{
+				Name:           "pool-1",
+				ProviderConfig: &runtime.RawExtension{Raw: []byte(`{"kubelet":{"maxPods":0}}`)},
+			})
+			Expect(err).To(MatchError(ContainSubstring("providerConfig.kubelet.maxPods")))
+		})
+	})
+
+	Describe("#KubeletConfigOverridesFromShoot", func() {
+		var shoot *gardenv1beta1.Shoot
+
+		BeforeEach(func() {
+			shoot = &gardenv1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bar",
+					Annotations: map[string]string{
+						AnnotationKubeletConfigOverrides: `{"pool-1":{"evictionHard":{"memory.available":"5%"}}}`,
+					},
+				},
+			}
+		})
+
+		It("should return the overrides of the given worker pool", func() {
+			Expect(KubeletConfigOverridesFromShoot(shoot, "pool-1")).To(Equal(&KubeletConfigOverrides{
+				EvictionHard: map[string]string{"memory.available": "5%"},
+			}))
+			Expect(KubeletConfigOverridesFromShoot(shoot, "pool-2")).To(BeNil())
+		})
+
+		It("should return nil without annotation", func() {
+			shoot.Annotations = nil
+			Expect(KubeletConfigOverridesFromShoot(shoot, "pool-1")).To(BeNil())
+		})
+
+		It("should fail for an undecodable annotation", func() {
+			shoot.Annotations[AnnotationKubeletConfigOverrides] = `["pool-1"]`
+			_, err := KubeletConfigOverridesFromShoot(shoot, "pool-1")
+			Expect(err).To(MatchError(ContainSubstring("could not decode annotation")))
+		})
+	})
+
+	Describe("#ValidateKubeletConfigOverrides", func() {
+		DescribeTable("should validate the overrides",
+			func(overrides *KubeletConfigOverrides, matcher OmegaMatcher) {
+				Expect(ValidateKubeletConfigOverrides(overrides, field.NewPath("kubelet"))).To(matcher)
+			},
+			Entry("empty overrides", &KubeletConfigOverrides{}, BeEmpty()),
+			Entry("valid overrides", &KubeletConfigOverrides{
+				MaxPods:                 util.Int32Ptr(64),
+				EvictionHard:            map[string]string{"memory.available": "100Mi", "nodefs.available": "5%"},
+				EvictionSoft:            map[string]string{"imagefs.inodesFree": "10%"},
+				EvictionSoftGracePeriod: map[string]string{"imagefs.inodesFree": "1m30s"},
+				KubeReserved:            map[string]string{"cpu": "80m", "memory": "1Gi"},
+				SystemReserved:          map[string]string{"ephemeral-storage": "1Gi", "pid": "1000"},
+			}, BeEmpty()),
+			Entry("negative max pods", &KubeletConfigOverrides{MaxPods: util.Int32Ptr(-1)}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("kubelet.maxPods"),
+				})),
+			)),
+			Entry("unsupported eviction signal", &KubeletConfigOverrides{EvictionHard: map[string]string{"memory.free": "1Gi"}}, ConsistOf(
+				gstruct.PointTo(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("kubelet.evictionHard[memory.free]"),
+				})),
+			)),
+			Entry("invalid eviction threshold", &KubeletConfigOverrides{EvictionSoft: map[string]string{"memory.available": "lots"}}, HaveLen(1)),
+			Entry("unsupported grace period signal", &KubeletConfigOverrides{EvictionSoftGracePeriod: map[string]string{"memory": "1m"}}, HaveLen(1)),
+			Entry("unsupported reserved resource", &KubeletConfigOverrides{KubeReserved: map[string]string{"gpu": "1"}}, HaveLen(1)),
+			Entry("invalid reserved quantity", &KubeletConfigOverrides{SystemReserved: map[string]string{"memory": "1 GB"}}, HaveLen(1)),
+		)
+	})
+
+	Describe("#ApplyKubeletConfigOverrides", func() {
+		It("should replace the max pods and merge the maps", func() {
+			kubeletConfig := &kubeletconfigv1beta1.KubeletConfiguration{
+				MaxPods:      110,
+				EvictionHard: map[string]string{"memory.available": "100Mi", "nodefs.available": "5%"},
+				KubeReserved: map[string]string{"cpu": "80m", "memory": "1Gi"},
+			}
+
+			ApplyKubeletConfigOverrides(kubeletConfig, &KubeletConfigOverrides{
+				MaxPods:        util.Int32Ptr(50),
+				EvictionHard:   map[string]string{"memory.available": "200Mi"},
+				SystemReserved: map[string]string{"memory": "500Mi"},
+			})
+
+			Expect(kubeletConfig).To(Equal(&kubeletconfigv1beta1.KubeletConfiguration{
+				MaxPods:        50,
+				EvictionHard:   map[string]string{"memory.available": "200Mi", "nodefs.available": "5%"},
+				KubeReserved:   map[string]string{"cpu": "80m", "memory": "1Gi"},
+				SystemReserved: map[string]string{"memory": "500Mi"},
+			}))
+		})
+
+		It("should be applicable to a kubelet configuration decoded by the codec", func() {
+			codec := NewKubeletConfigCodec(NewFileContentInlineCodec())
+			data := `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+evictionHard:
+  memory.available: 100Mi
+  nodefs.available: 5%
+kubeReserved:
+  cpu: 80m
+  memory: 1Gi
+maxPods: 110
+`
+
+			kubeletConfig, err := codec.Decode(&extensionsv1alpha1.FileContentInline{Data: data})
+			Expect(err).NotTo(HaveOccurred())
+
+			ApplyKubeletConfigOverrides(kubeletConfig, &KubeletConfigOverrides{
+				MaxPods:      util.Int32Ptr(50),
+				EvictionHard: map[string]string{"memory.available": "200Mi"},
+				KubeReserved: map[string]string{"cpu": "100m"},
+			})
+
+			fci, err := codec.Encode(kubeletConfig, "")
+			Expect(err).NotTo(HaveOccurred())
+			kubeletConfig, err = codec.Decode(fci)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(kubeletConfig.MaxPods).To(Equal(int32(50)))
+			Expect(kubeletConfig.EvictionHard).To(Equal(map[string]string{"memory.available": "200Mi", "nodefs.available": "5%"}))
+			Expect(kubeletConfig.KubeReserved).To(Equal(map[string]string{"cpu": "100m", "memory": "1Gi"}))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Results of the mutations of the controlplane webhooks.
+const (
+	mutationResultMutated   = "mutated"
+	mutationResultUnchanged = "unchanged"
+	mutationResultDryRun    = "dryrun"
+	mutationResultSkipped   = "skipped"
+	mutationResultError     = "error"
+)
+
+// mutationsTotal counts the requests handled by the controlplane webhooks by object kind and result.
+var mutationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "controlplane_webhook_mutations_total",
+		Help: "Total number of requests handled by the controlplane webhooks by object kind and result.",
+	},
+	[]string{"kind", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(mutationsTotal)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericmutator
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
+
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+// Ensurer ensures that various standard Kubernetes shoot system components conform to the provider requirements.
+// If they don't initially, they are mutated accordingly.
+type Ensurer interface {
+	// EnsureKubeProxyDaemonSet ensures that the kube-proxy daemon set conforms to the provider requirements.
+	EnsureKubeProxyDaemonSet(context.Context, *appsv1.DaemonSet) error
+	// EnsureCoreDNSDeployment ensures that the coredns deployment conforms to the provider requirements.
+	EnsureCoreDNSDeployment(context.Context, *appsv1.Deployment) error
+}
+
+// NewMutator creates a new shoot mutator.
+func NewMutator(ensurer Ensurer, logger logr.Logger) controlplane.Mutator {
+	return &mutator{
+		ensurer: ensurer,
+		logger:  logger.WithName("mutator"),
+	}
+}
+
+type mutator struct {
+	ensurer Ensurer
+	logger  logr.Logger
+}
+
+// InjectClient injects the given client into the ensurer.
+// TODO Replace this with the more generic InjectFunc when controller runtime supports it
+func (m *mutator) InjectClient(client client.Client) error {
+	if _, err := inject.ClientInto(client, m.ensurer); err != nil {
+		return errors.Wrap(err, "could not inject the client into the ensurer")
+	}
+	return nil
+}
+
+// Mutate validates and if needed mutates the given object.
+func (m *mutator) Mutate(ctx context.Context, obj runtime.Object) error {
+	switch x := obj.(type) {
+	case *appsv1.DaemonSet:
+		if x.Namespace != metav1.NamespaceSystem {
+			return nil
+		}
+		switch x.Name {
+		case common.KubeProxyDaemonSetName:
+			return m.ensurer.EnsureKubeProxyDaemonSet(ctx, x)
+		}
+	case *appsv1.Deployment:
+		if x.Namespace != metav1.NamespaceSystem {
+			return nil
+		}
+		switch x.Name {
+		case common.CoreDNSDeploymentName:
+			return m.ensurer.EnsureCoreDNSDeployment(ctx, x)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericmutator
+
+import (
+	"context"
+	"testing"
+
+	mockgenericmutator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/webhook/shoot/genericmutator"
+
+	"github.com/gardener/gardener/pkg/operation/common"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+func TestShoot(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Shoot Webhook Generic Mutator Suite")
+}
+
+var _ = Describe("Mutator", func() {
+	var (
+		ctrl   *gomock.Controller
+		logger = log.Log.WithName("test")
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Mutate", func() {
+		It("should invoke ensurer.EnsureKubeProxyDaemonSet with a kube-proxy daemon set", func() {
+			var (
+				ds = &appsv1.DaemonSet{
+					ObjectMeta: metav1.ObjectMeta{Name: common.KubeProxyDaemonSetName, Namespace: metav1.NamespaceSystem},
+				}
+			)
+
+			// Create mock ensurer
+			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
+			ensurer.EXPECT().EnsureKubeProxyDaemonSet(context.TODO(), ds).Return(nil)
+
+			// Create mutator
+			mutator := NewMutator(ensurer, logger)
+
+			// Call Mutate method and check the result
+			err := mutator.Mutate(context.TODO(), ds)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+
+		It("should invoke ensurer.EnsureCoreDNSDeployment with a coredns deployment", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: common.CoreDNSDeploymentName, Namespace: metav1.NamespaceSystem},
+				}
+			)
+
+			// Create mock ensurer
+			ensurer := mockgenericmutator.NewMockEnsurer(ctrl)
+			ensurer.EXPECT().EnsureCoreDNSDeployment(context.TODO(), dep).Return(nil)
+
+			// Create mutator
+			mutator := NewMutator(ensurer, logger)
+
+			// Call Mutate method and check the result
+			err := mutator.Mutate(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+
+		It("should ignore other deployments than coredns", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: metav1.NamespaceSystem},
+				}
+			)
+
+			// Create mutator
+			mutator := NewMutator(nil, logger)
+
+			// Call Mutate method and check the result
+			err := mutator.Mutate(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+
+		It("should ignore coredns deployments outside of the kube-system namespace", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: common.CoreDNSDeploymentName, Namespace: "default"},
+				}
+			)
+
+			// Create mutator
+			mutator := NewMutator(nil, logger)
+
+			// Call Mutate method and check the result
+			err := mutator.Mutate(context.TODO(), dep)
+			Expect(err).To(Not(HaveOccurred()))
+		})
+	})
+})
@@ -0,0 +1,34 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericmutator
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// NoopEnsurer provides no-op implementation of Ensurer. This can be anonymously composed by actual Ensurers for convenience.
+type NoopEnsurer struct{}
+
+// EnsureKubeProxyDaemonSet ensures that the kube-proxy daemon set conforms to the provider requirements.
+func (e *NoopEnsurer) EnsureKubeProxyDaemonSet(context.Context, *appsv1.DaemonSet) error {
+	return nil
+}
+
+// EnsureCoreDNSDeployment ensures that the coredns deployment conforms to the provider requirements.
+func (e *NoopEnsurer) EnsureCoreDNSDeployment(context.Context, *appsv1.Deployment) error {
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"context"
+
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// BuildWebhookConfig builds the MutatingWebhookConfiguration of the given shoot webhooks. It uses the CA bundle of
+// the MutatingWebhookConfiguration of the webhook server in the seed cluster, which is read with the given client.
+func BuildWebhookConfig(ctx context.Context, c client.Client, config *extensionswebhook.ShootWebhookConfig) (*admissionregistrationv1beta1.MutatingWebhookConfiguration, error) {
+	seedWebhookConfig := &admissionregistrationv1beta1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: config.SeedWebhookConfigName}, seedWebhookConfig); err != nil {
+		return nil, errors.Wrapf(err, "could not get mutating webhook configuration '%s'", config.SeedWebhookConfigName)
+	}
+
+	var caBundle []byte
+	for _, wh := range seedWebhookConfig.Webhooks {
+		if len(wh.ClientConfig.CABundle) > 0 {
+			caBundle = wh.ClientConfig.CABundle
+			break
+		}
+	}
+	if len(caBundle) == 0 {
+		return nil, errors.Errorf("mutating webhook configuration '%s' does not contain a CA bundle", config.SeedWebhookConfigName)
+	}
+
+	webhooks := make([]admissionregistrationv1beta1.Webhook, 0, len(config.Webhooks))
+	for _, wh := range config.Webhooks {
+		wh := *wh.DeepCopy()
+		wh.ClientConfig.CABundle = caBundle
+		webhooks = append(webhooks, wh)
+	}
+
+	return &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: config.Name},
+		Webhooks:   webhooks,
+	}, nil
+}
+
+// ReconcileWebhookConfig creates or updates the given MutatingWebhookConfiguration in the shoot cluster with the given
+// shoot client, and labels the kube-system namespace so that the shoot webhooks select it.
+func ReconcileWebhookConfig(ctx context.Context, shootClient client.Client, webhookConfig *admissionregistrationv1beta1.MutatingWebhookConfiguration) error {
+	if err := labelKubeSystemNamespace(ctx, shootClient); err != nil {
+		return err
+	}
+
+	webhooks, err := webhooksWithTimeout(webhookConfig.Webhooks)
+	if err != nil {
+		return errors.Wrapf(err, "could not convert webhooks of mutating webhook configuration '%s'", webhookConfig.Name)
+	}
+
+	// The webhook configuration is applied in unstructured form, as the vendored API does not contain the timeout yet
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(admissionregistrationv1beta1.SchemeGroupVersion.String())
+	obj.SetKind("MutatingWebhookConfiguration")
+	obj.SetName(webhookConfig.Name)
+	if _, err := controllerutil.CreateOrUpdate(ctx, shootClient, obj, func(existing runtime.Object) error {
+		existing.(*unstructured.Unstructured).Object["webhooks"] = webhooks
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "could not create or update mutating webhook configuration '%s' in shoot", webhookConfig.Name)
+	}
+	return nil
+}
+
+// DeleteWebhookConfig deletes the MutatingWebhookConfiguration with the given name from the shoot cluster with the
+// given shoot client.
+func DeleteWebhookConfig(ctx context.Context, shootClient client.Client, name string) error {
+	obj := &admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := shootClient.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "could not delete mutating webhook configuration '%s' in shoot", name)
+	}
+	return nil
+}
+
+// labelKubeSystemNamespace labels the kube-system namespace of the shoot cluster with its purpose, so that it is
+// selected by the namespace selector of the shoot webhooks.
+func labelKubeSystemNamespace(ctx context.Context, shootClient client.Client) error {
+	ns := &corev1.Namespace{}
+	if err := shootClient.Get(ctx, client.ObjectKey{Name: metav1.NamespaceSystem}, ns); err != nil {
+		return errors.Wrapf(err, "could not get namespace '%s' in shoot", metav1.NamespaceSystem)
+	}
+	if ns.Labels[extensionswebhook.PurposeLabel] == metav1.NamespaceSystem {
+		return nil
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[extensionswebhook.PurposeLabel] = metav1.NamespaceSystem
+	if err := shootClient.Update(ctx, ns); err != nil {
+		return errors.Wrapf(err, "could not label namespace '%s' in shoot", metav1.NamespaceSystem)
+	}
+	return nil
+}
+
+// webhooksWithTimeout converts the given webhooks to their unstructured form and sets the shoot webhook timeout.
+func webhooksWithTimeout(webhooks []admissionregistrationv1beta1.Webhook) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(webhooks))
+	for i := range webhooks {
+		wh, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&webhooks[i])
+		if err != nil {
+			return nil, err
+		}
+		wh["timeoutSeconds"] = int64(extensionswebhook.ShootWebhookTimeoutSeconds)
+		result = append(result, wh)
+	}
+	return result, nil
+}
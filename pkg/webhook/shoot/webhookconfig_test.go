@@ -0,0 +1,187 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"context"
+
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	seedWebhookConfigName  = "gardener-extension-provider-test"
+	shootWebhookConfigName = "gardener-extension-provider-test-shoot"
+)
+
+var _ = Describe("WebhookConfig", func() {
+	var (
+		ctrl *gomock.Controller
+		c    *mockclient.MockClient
+		ctx  = context.TODO()
+
+		caBundle = []byte("ca-bundle")
+		url      = "https://gardener-extension-provider-test.garden.svc/shoot"
+
+		config = &extensionswebhook.ShootWebhookConfig{
+			Name:                  shootWebhookConfigName,
+			SeedWebhookConfigName: seedWebhookConfigName,
+			Webhooks: []admissionregistrationv1beta1.Webhook{
+				{
+					Name:         "shoot.test.extensions.gardener.cloud",
+					ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{URL: &url},
+				},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#BuildWebhookConfig", func() {
+		It("should build the webhook configuration with the CA bundle of the seed webhook configuration", func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: seedWebhookConfigName}, gomock.AssignableToTypeOf(&admissionregistrationv1beta1.MutatingWebhookConfiguration{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, actual *admissionregistrationv1beta1.MutatingWebhookConfiguration) error {
+					actual.Webhooks = []admissionregistrationv1beta1.Webhook{
+						{Name: "controlplane.test.extensions.gardener.cloud", ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{CABundle: caBundle}},
+					}
+					return nil
+				})
+
+			webhookConfig, err := BuildWebhookConfig(ctx, c, config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhookConfig).To(Equal(&admissionregistrationv1beta1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: shootWebhookConfigName},
+				Webhooks: []admissionregistrationv1beta1.Webhook{
+					{
+						Name:         "shoot.test.extensions.gardener.cloud",
+						ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{URL: &url, CABundle: caBundle},
+					},
+				},
+			}))
+			Expect(config.Webhooks[0].ClientConfig.CABundle).To(BeNil())
+		})
+
+		It("should fail if the seed webhook configuration does not contain a CA bundle", func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: seedWebhookConfigName}, gomock.AssignableToTypeOf(&admissionregistrationv1beta1.MutatingWebhookConfiguration{})).
+				Return(nil)
+
+			_, err := BuildWebhookConfig(ctx, c, config)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the seed webhook configuration does not exist", func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: seedWebhookConfigName}, gomock.AssignableToTypeOf(&admissionregistrationv1beta1.MutatingWebhookConfiguration{})).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, seedWebhookConfigName))
+
+			_, err := BuildWebhookConfig(ctx, c, config)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ReconcileWebhookConfig", func() {
+		var (
+			webhookConfig *admissionregistrationv1beta1.MutatingWebhookConfiguration
+			expected      *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			webhookConfig = &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: shootWebhookConfigName},
+				Webhooks:   config.Webhooks,
+			}
+			expected = &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "admissionregistration.k8s.io/v1beta1",
+				"kind":       "MutatingWebhookConfiguration",
+				"metadata":   map[string]interface{}{"name": shootWebhookConfigName},
+				"webhooks": []interface{}{
+					map[string]interface{}{
+						"name":           "shoot.test.extensions.gardener.cloud",
+						"clientConfig":   map[string]interface{}{"url": url},
+						"timeoutSeconds": int64(extensionswebhook.ShootWebhookTimeoutSeconds),
+					},
+				},
+			}}
+		})
+
+		It("should label the kube-system namespace and create the webhook configuration with the timeout", func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: metav1.NamespaceSystem}, gomock.AssignableToTypeOf(&corev1.Namespace{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, actual *corev1.Namespace) error {
+					actual.Name = metav1.NamespaceSystem
+					return nil
+				})
+			c.EXPECT().Update(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   metav1.NamespaceSystem,
+				Labels: map[string]string{extensionswebhook.PurposeLabel: metav1.NamespaceSystem},
+			}}).Return(nil)
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: shootWebhookConfigName}, gomock.AssignableToTypeOf(&unstructured.Unstructured{})).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, shootWebhookConfigName))
+			c.EXPECT().Create(ctx, expected).Return(nil)
+
+			Expect(ReconcileWebhookConfig(ctx, c, webhookConfig)).To(Succeed())
+		})
+
+		It("should update the webhook configuration if it has been changed in the shoot", func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: metav1.NamespaceSystem}, gomock.AssignableToTypeOf(&corev1.Namespace{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, actual *corev1.Namespace) error {
+					actual.Name = metav1.NamespaceSystem
+					actual.Labels = map[string]string{extensionswebhook.PurposeLabel: metav1.NamespaceSystem}
+					return nil
+				})
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: shootWebhookConfigName}, gomock.AssignableToTypeOf(&unstructured.Unstructured{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, actual *unstructured.Unstructured) error {
+					actual.Object = expected.DeepCopy().Object
+					actual.Object["webhooks"] = []interface{}{}
+					return nil
+				})
+			c.EXPECT().Update(ctx, expected).Return(nil)
+
+			Expect(ReconcileWebhookConfig(ctx, c, webhookConfig)).To(Succeed())
+		})
+	})
+
+	Describe("#DeleteWebhookConfig", func() {
+		It("should delete the webhook configuration in the shoot", func() {
+			c.EXPECT().Delete(ctx, &admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: shootWebhookConfigName}}).
+				Return(nil)
+
+			Expect(DeleteWebhookConfig(ctx, c, shootWebhookConfigName)).To(Succeed())
+		})
+
+		It("should succeed if the webhook configuration does not exist", func() {
+			c.EXPECT().Delete(ctx, &admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: shootWebhookConfigName}}).
+				Return(apierrors.NewNotFound(schema.GroupResource{}, shootWebhookConfigName))
+
+			Expect(DeleteWebhookConfig(ctx, c, shootWebhookConfigName)).To(Succeed())
+		})
+	})
+})
@@ -15,6 +15,11 @@
 package webhook
 
 import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
 	"github.com/pkg/errors"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +45,14 @@ const (
 	// This provider can be different from both the Seed or the Shoot provider, see https://github.com/gardener/gardener/blob/master/docs/proposals/02-backupinfra.md.
 	// TODO Move this constant to gardener/gardener
 	BackupProviderLabel = "backup.gardener.cloud/provider"
+	// PurposeLabel is a label on namespaces in the shoot clusters that identifies their purpose. The kube-system
+	// namespace is labeled with `kube-system`, so that the shoot cluster webhooks can select it.
+	// TODO Move this constant to gardener/gardener
+	PurposeLabel = "gardener.cloud/purpose"
+
+	// ShootWebhookTimeoutSeconds is the timeout of the shoot cluster webhooks. It is short, so that requests in the
+	// shoot clusters are not delayed for long if the webhook server is not reachable, as errors are ignored anyway.
+	ShootWebhookTimeoutSeconds int32 = 5
 )
 
 // Kind is a type for webhook kinds.
@@ -53,8 +66,28 @@ const (
 	ShootKind Kind = "shoot"
 	// A backup webhook is applied only to those shoot namespaces that have the correct Backup provider label.
 	BackupKind Kind = "backup"
+	// A shoot cluster webhook is applied to the objects in the kube-system namespace of the shoot clusters. It is served
+	// by the webhook server in the seed cluster, but registered in the shoot clusters instead of the seed cluster.
+	ShootClusterKind Kind = "shootcluster"
 )
 
+// ShootWebhook is a webhook of kind ShootClusterKind. It is served by the webhook server, but not installed in the seed
+// cluster by the webhook server bootstrapping.
+type ShootWebhook struct {
+	*admission.Webhook
+}
+
+// ShootWebhookConfig is the configuration of the shoot webhooks that have to be registered in the shoot clusters.
+type ShootWebhookConfig struct {
+	// Name is the name of the MutatingWebhookConfiguration in the shoot clusters.
+	Name string
+	// SeedWebhookConfigName is the name of the MutatingWebhookConfiguration of the webhook server in the seed cluster.
+	// Its CA bundle is used to verify the webhook server certificate.
+	SeedWebhookConfigName string
+	// Webhooks are the shoot webhooks without CA bundle.
+	Webhooks []admissionregistrationv1beta1.Webhook
+}
+
 // FactoryAggregator aggregates various Factory functions.
 type FactoryAggregator []func(manager.Manager) (webhook.Webhook, error)
 
@@ -86,6 +119,48 @@ func (a *FactoryAggregator) Webhooks(mgr manager.Manager) ([]webhook.Webhook, er
 	return webhooks, nil
 }
 
+// DryRunInjector is implemented by admission handlers that support a dry-run mode, in which they compute their
+// mutations but do not apply them.
+type DryRunInjector interface {
+	InjectDryRun(dryRun bool) error
+}
+
+// DryRun wraps the given webhook factory function so that all handlers of the created webhook run in dry-run mode.
+// It fails if the created webhook has no handlers that support a dry-run mode.
+func DryRun(f func(manager.Manager) (webhook.Webhook, error)) func(manager.Manager) (webhook.Webhook, error) {
+	return func(mgr manager.Manager) (webhook.Webhook, error) {
+		wh, err := f(mgr)
+		if err != nil {
+			return nil, err
+		}
+
+		var admissionWebhook *admission.Webhook
+		switch w := wh.(type) {
+		case *admission.Webhook:
+			admissionWebhook = w
+		case *ShootWebhook:
+			admissionWebhook = w.Webhook
+		}
+
+		var injected bool
+		if admissionWebhook != nil {
+			for _, handler := range admissionWebhook.Handlers {
+				if injector, ok := handler.(DryRunInjector); ok {
+					if err := injector.InjectDryRun(true); err != nil {
+						return nil, errors.Wrapf(err, "could not inject dry-run mode into webhook %s", wh.GetName())
+					}
+					injected = true
+				}
+			}
+		}
+		if !injected {
+			return nil, errors.Errorf("webhook %s does not support a dry-run mode", wh.GetName())
+		}
+
+		return wh, nil
+	}
+}
+
 // ServerBuilder is a builder to build a webhook server.
 type ServerBuilder struct {
 	Name     string
@@ -104,24 +179,105 @@ func (s *ServerBuilder) Register(webhooks ...webhook.Webhook) {
 }
 
 // AddToManager creates and adds the webhook server to the manager if there are any webhooks.
-// If there are no webhooks, this is a no-op.
-func (s *ServerBuilder) AddToManager(mgr manager.Manager) error {
+// If there are no webhooks, this is a no-op. Shoot webhooks are served by the webhook server, but not installed
+// in the seed cluster. Instead, the configuration to register them in the shoot clusters is returned. It is nil
+// if there are no shoot webhooks.
+func (s *ServerBuilder) AddToManager(mgr manager.Manager) (*ShootWebhookConfig, error) {
 	if len(s.Webhooks) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	srv, err := webhook.NewServer(s.Name, mgr, s.Options)
 	if err != nil {
-		return errors.Wrapf(err, "could not create webhook server %s", s.Name)
+		return nil, errors.Wrapf(err, "could not create webhook server %s", s.Name)
 	}
 
-	if err := srv.Register(s.Webhooks...); err != nil {
-		return errors.Wrapf(err, "could not register webhooks in server %s", s.Name)
+	var (
+		seedWebhooks       []webhook.Webhook
+		shootWebhookConfig *ShootWebhookConfig
+	)
+	for _, wh := range s.Webhooks {
+		shootWebhook, ok := wh.(*ShootWebhook)
+		if !ok {
+			seedWebhooks = append(seedWebhooks, wh)
+			continue
+		}
+
+		if shootWebhookConfig == nil {
+			if shootWebhookConfig, err = s.newShootWebhookConfig(); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.registerShootWebhook(mgr, srv, shootWebhook, shootWebhookConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not register shoot webhook %s in server %s", wh.GetName(), s.Name)
+		}
+	}
+
+	if err := srv.Register(seedWebhooks...); err != nil {
+		return nil, errors.Wrapf(err, "could not register webhooks in server %s", s.Name)
+	}
+
+	return shootWebhookConfig, nil
+}
+
+// newShootWebhookConfig creates a new empty ShootWebhookConfig for the webhook server of this ServerBuilder.
+func (s *ServerBuilder) newShootWebhookConfig() (*ShootWebhookConfig, error) {
+	if s.Options.BootstrapOptions == nil || len(s.Options.BootstrapOptions.MutatingWebhookConfigName) == 0 {
+		return nil, errors.Errorf("webhook server %s has no mutating webhook config name", s.Name)
+	}
+
+	return &ShootWebhookConfig{
+		Name:                  s.Options.BootstrapOptions.MutatingWebhookConfigName + "-shoot",
+		SeedWebhookConfigName: s.Options.BootstrapOptions.MutatingWebhookConfigName,
+	}, nil
+}
+
+// registerShootWebhook serves the given shoot webhook with the given webhook server and adds it to the given
+// ShootWebhookConfig.
+func (s *ServerBuilder) registerShootWebhook(mgr manager.Manager, srv *webhook.Server, wh *ShootWebhook, config *ShootWebhookConfig) error {
+	if err := wh.Validate(); err != nil {
+		return err
 	}
 
+	// The webhook server only injects its dependencies into the webhooks that it installs
+	if err := mgr.SetFields(wh.Webhook); err != nil {
+		return err
+	}
+
+	clientConfig, err := s.shootClientConfig(wh.GetPath())
+	if err != nil {
+		return err
+	}
+
+	srv.Handle(wh.GetPath(), wh.Handler())
+	config.Webhooks = append(config.Webhooks, admissionregistrationv1beta1.Webhook{
+		Name:              wh.GetName(),
+		ClientConfig:      *clientConfig,
+		Rules:             wh.Rules,
+		FailurePolicy:     wh.FailurePolicy,
+		NamespaceSelector: wh.NamespaceSelector,
+	})
 	return nil
 }
 
+// shootClientConfig returns the client config with which the kube-apiservers of the shoot clusters call the webhook
+// with the given path. As they run in the seed cluster, they can reach the webhook server via its service.
+func (s *ServerBuilder) shootClientConfig(path string) (*admissionregistrationv1beta1.WebhookClientConfig, error) {
+	u := url.URL{Scheme: "https", Path: path}
+	switch {
+	case s.Options.BootstrapOptions != nil && s.Options.BootstrapOptions.Service != nil:
+		service := s.Options.BootstrapOptions.Service
+		u.Host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	case s.Options.BootstrapOptions != nil && s.Options.BootstrapOptions.Host != nil:
+		u.Host = net.JoinHostPort(*s.Options.BootstrapOptions.Host, strconv.Itoa(int(s.Options.Port)))
+	default:
+		return nil, errors.Errorf("webhook server %s has neither a service nor a host", s.Name)
+	}
+
+	urlString := u.String()
+	return &admissionregistrationv1beta1.WebhookClientConfig{URL: &urlString}, nil
+}
+
 // NewWebhook creates a new mutating webhook for create and update operations
 // with the given kind, provider, and name, applicable to objects of all given types,
 // executing the given handler, and bound to the given manager.
@@ -142,12 +298,18 @@ func NewWebhook(mgr manager.Manager, kind Kind, provider, name string, types []r
 		rules = append(rules, *rule)
 	}
 
+	// Shoot cluster webhooks must not prevent the shoot system components from being updated
+	failurePolicy := admissionregistrationv1beta1.Fail
+	if kind == ShootClusterKind {
+		failurePolicy = admissionregistrationv1beta1.Ignore
+	}
+
 	// Build webhook
 	return builder.NewWebhookBuilder().
 		Name(name + "." + provider + "." + NameSuffix).
 		Path("/" + name).
 		Mutating().
-		FailurePolicy(admissionregistrationv1beta1.Fail).
+		FailurePolicy(failurePolicy).
 		NamespaceSelector(namespaceSelector).
 		Rules(rules...).
 		Handlers(handler).
@@ -166,6 +328,12 @@ func buildSelector(kind Kind, provider string) (*metav1.LabelSelector, error) {
 		key = ShootProviderLabel
 	case BackupKind:
 		key = BackupProviderLabel
+	case ShootClusterKind:
+		// The webhook configuration is only registered in the shoot clusters of the provider, where it must only apply
+		// to the shoot system components in the kube-system namespace
+		return &metav1.LabelSelector{
+			MatchLabels: map[string]string{PurposeLabel: metav1.NamespaceSystem},
+		}, nil
 	default:
 		return nil, errors.Errorf("invalid webhook kind '%s'", kind)
 	}
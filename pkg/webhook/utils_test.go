@@ -31,6 +31,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
 )
@@ -135,9 +137,121 @@ var _ = Describe("Options", func() {
 				Handlers: []admission.Handler{handler},
 			}))
 		})
+
+		It("should create the correct ShootCluster webhook for daemon sets", func() {
+			// Create mock RESTMapper
+			mapper = mockmeta.NewMockRESTMapper(ctrl)
+			mapper.EXPECT().RESTMapping(schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, "v1").Return(&meta.RESTMapping{
+				Resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"},
+			}, nil)
+
+			// Create mock manager
+			mgr = mockmanager.NewMockManager(ctrl)
+			mgr.EXPECT().GetScheme().Return(scheme)
+			mgr.EXPECT().GetRESTMapper().Return(mapper)
+
+			webhook, err := NewWebhook(mgr, ShootClusterKind, provider, "shoot", []runtime.Object{&appsv1.DaemonSet{}}, handler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhook).To(Equal(&admission.Webhook{
+				Name: "shoot.aws.extensions.gardener.cloud",
+				Type: types.WebhookTypeMutating,
+				Path: "/shoot",
+				Rules: []admissionregistrationv1beta1.RuleWithOperations{
+					ruleWithOperations("apps", "v1", "daemonsets"),
+				},
+				FailurePolicy: failurePolicyTypePtr(admissionregistrationv1beta1.Ignore),
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{PurposeLabel: metav1.NamespaceSystem},
+				},
+				Handlers: []admission.Handler{handler},
+			}))
+		})
+	})
+
+	Describe("#DryRun", func() {
+		It("should inject the dry-run mode into the handlers of the webhook", func() {
+			dryRunHandler := &fakeDryRunHandler{Handler: handler}
+			factory := func(manager.Manager) (webhook.Webhook, error) {
+				return &admission.Webhook{Name: "controlplane", Handlers: []admission.Handler{dryRunHandler}}, nil
+			}
+
+			_, err := DryRun(factory)(mgr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dryRunHandler.dryRun).To(BeTrue())
+		})
+
+		It("should fail if no handler of the webhook supports a dry-run mode", func() {
+			factory := func(manager.Manager) (webhook.Webhook, error) {
+				return &admission.Webhook{Name: "controlplane", Handlers: []admission.Handler{handler}}, nil
+			}
+
+			_, err := DryRun(factory)(mgr)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ServerBuilder", func() {
+	Describe("#newShootWebhookConfig", func() {
+		It("should derive the shoot webhook config name from the mutating webhook config name", func() {
+			s := NewServerBuilder("webhooks", webhook.ServerOptions{
+				BootstrapOptions: &webhook.BootstrapOptions{MutatingWebhookConfigName: "gardener-extension-provider-aws"},
+			})
+
+			config, err := s.newShootWebhookConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config).To(Equal(&ShootWebhookConfig{
+				Name:                  "gardener-extension-provider-aws-shoot",
+				SeedWebhookConfigName: "gardener-extension-provider-aws",
+			}))
+		})
+
+		It("should fail if there is no mutating webhook config name", func() {
+			s := NewServerBuilder("webhooks", webhook.ServerOptions{})
+
+			_, err := s.newShootWebhookConfig()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#shootClientConfig", func() {
+		It("should return the URL of the service in service mode", func() {
+			s := NewServerBuilder("webhooks", webhook.ServerOptions{
+				Port: 7890,
+				BootstrapOptions: &webhook.BootstrapOptions{
+					Service: &webhook.Service{Name: "gardener-extension-provider-aws", Namespace: "garden"},
+				},
+			})
+
+			clientConfig, err := s.shootClientConfig("/shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*clientConfig.URL).To(Equal("https://gardener-extension-provider-aws.garden.svc/shoot"))
+		})
+
+		It("should return the URL of the host in host mode", func() {
+			host := "localhost"
+			s := NewServerBuilder("webhooks", webhook.ServerOptions{
+				Port:             7890,
+				BootstrapOptions: &webhook.BootstrapOptions{Host: &host},
+			})
+
+			clientConfig, err := s.shootClientConfig("/shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*clientConfig.URL).To(Equal("https://localhost:7890/shoot"))
+		})
 	})
 })
 
+type fakeDryRunHandler struct {
+	admission.Handler
+	dryRun bool
+}
+
+func (h *fakeDryRunHandler) InjectDryRun(dryRun bool) error {
+	h.dryRun = dryRun
+	return nil
+}
+
 func ruleWithOperations(apiGroup, apiVersion, resource string) admissionregistrationv1beta1.RuleWithOperations {
 	return admissionregistrationv1beta1.RuleWithOperations{
 		Operations: []admissionregistrationv1beta1.OperationType{